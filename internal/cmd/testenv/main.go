@@ -1,7 +1,25 @@
 package main
 
-import "github.com/rancher/opni-monitoring/pkg/test"
+import (
+	"flag"
+
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
 
 func main() {
-	test.StartStandaloneTestEnvironment()
+	var seed int64
+	var maxConcurrentStarts, maxQueuedStarts int
+	flag.Int64Var(&seed, "seed", 0, "if non-zero, generate cluster IDs deterministically using this seed")
+	flag.IntVar(&maxConcurrentStarts, "max-concurrent-starts", 4, "maximum number of agents which can be starting at the same time")
+	flag.IntVar(&maxQueuedStarts, "max-queued-starts", 32, "maximum number of agent start requests which can wait for a free slot")
+	flag.Parse()
+
+	opts := []test.StandaloneEnvironmentOption{
+		test.WithMaxConcurrentStarts(maxConcurrentStarts),
+		test.WithMaxQueuedStarts(maxQueuedStarts),
+	}
+	if seed != 0 {
+		opts = append(opts, test.WithIDGenerator(test.NewSeededIDGenerator(seed)))
+	}
+	test.StartStandaloneTestEnvironment(opts...)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -31,8 +32,12 @@ type Agent struct {
 	tenantID         string
 	identityProvider ident.Provider
 	keyringStore     storage.KeyringStore
-	gatewayClient    clients.GatewayHTTPClient
 	shutdownLock     sync.Mutex
+
+	gatewayClientMu sync.RWMutex
+	gatewayClient   clients.GatewayHTTPClient
+
+	diagnostics diagnosticsRecorder
 }
 
 type AgentOptions struct {
@@ -76,6 +81,17 @@ func New(ctx context.Context, conf *v1beta1.AgentConfig, opts ...AgentOption) (*
 		return c.SendStatus(fasthttp.StatusOK)
 	})
 
+	if conf.Spec.Profiling != nil && conf.Spec.Profiling.Enabled {
+		if conf.Spec.Profiling.AllowRemoteAccess {
+			lg.Warn("profiling endpoints are enabled and accessible from any address")
+		}
+		app.Use(pprofHandler(conf.Spec.Profiling))
+	}
+
+	if err := ValidateExternalLabels(conf.Spec.ExternalLabels); err != nil {
+		return nil, fmt.Errorf("configuration error: %w", err)
+	}
+
 	ip, err := ident.GetProvider(conf.Spec.IdentityProvider)
 	if err != nil {
 		return nil, fmt.Errorf("configuration error: %w", err)
@@ -93,6 +109,7 @@ func New(ctx context.Context, conf *v1beta1.AgentConfig, opts ...AgentOption) (*
 		identityProvider: ip,
 	}
 	agent.shutdownLock.Lock()
+	agent.diagnostics.event("agent starting with cluster ID %s", id)
 
 	var keyringStoreBroker storage.KeyringStoreBroker
 	switch agent.Storage.Type {
@@ -124,13 +141,14 @@ func New(ctx context.Context, conf *v1beta1.AgentConfig, opts ...AgentOption) (*
 	if conf.Spec.GatewayAddress == "" {
 		return nil, errors.New("gateway address not set")
 	}
-	agent.gatewayClient, err = clients.NewGatewayHTTPClient(
-		conf.Spec.GatewayAddress, ip, kr)
+	agent.gatewayClient, err = agent.newGatewayClient(conf.Spec.GatewayAddress, kr)
 	if err != nil {
 		return nil, fmt.Errorf("error configuring gateway client: %w", err)
 	}
 	go agent.streamRulesToGateway(ctx)
+	go agent.reportDiagnostics(ctx)
 
+	app.Get("/diagnostics", agent.handleDiagnostics)
 	app.Post("/api/agent/push", agent.handlePushRequest)
 	app.Use(default404Handler)
 
@@ -138,13 +156,22 @@ func New(ctx context.Context, conf *v1beta1.AgentConfig, opts ...AgentOption) (*
 }
 
 func (a *Agent) handlePushRequest(c *fiber.Ctx) error {
-	code, body, err := a.gatewayClient.Post(context.Background(), "/api/agent/push").
-		Body(c.Body()).
+	reqBody := c.Body()
+	if len(a.ExternalLabels) > 0 {
+		var err error
+		reqBody, err = ApplyExternalLabels(reqBody, a.ExternalLabels)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).SendString(err.Error())
+		}
+	}
+	code, body, err := a.gateway().Post(context.Background(), "/api/agent/push").
+		Body(reqBody).
 		Set(fiber.HeaderContentType, c.Get(fiber.HeaderContentType)).
-		Set(fiber.HeaderContentLength, c.Get(fiber.HeaderContentLength)).
+		Set(fiber.HeaderContentLength, strconv.Itoa(len(reqBody))).
 		Set(fiber.HeaderContentEncoding, c.Get(fiber.HeaderContentEncoding)).
 		Set("X-Prometheus-Remote-Write-Version", c.Get("X-Prometheus-Remote-Write-Version")).
 		Do()
+	a.diagnostics.gatewayRequest(err)
 	if err != nil {
 		a.logger.Error(err)
 		return err
@@ -162,6 +189,92 @@ func (a *Agent) Shutdown() error {
 	return a.app.Shutdown()
 }
 
+// SetGatewayAddress reconfigures a running agent to send requests to the
+// gateway at a new address, such as when the gateway has been moved. If a
+// bootstrapper is given, the agent first bootstraps with the new gateway and
+// replaces its stored keyring; otherwise the existing keyring is kept. The
+// new gateway's certificate is checked against the keyring's pinned public
+// keys before the address is changed. If anything fails, the agent keeps
+// using its current gateway.
+func (a *Agent) SetGatewayAddress(
+	ctx context.Context,
+	address string,
+	bootstrapper bootstrap.Bootstrapper,
+) error {
+	if err := a.setGatewayAddress(ctx, address, bootstrapper); err != nil {
+		a.diagnostics.error(fmt.Errorf("failed to change gateway address to %s: %w", address, err))
+		return err
+	}
+	a.diagnostics.event("gateway address changed to %s", address)
+	return nil
+}
+
+func (a *Agent) setGatewayAddress(
+	ctx context.Context,
+	address string,
+	bootstrapper bootstrap.Bootstrapper,
+) error {
+	if address == "" {
+		return errors.New("gateway address not set")
+	}
+	lg := a.logger.With("address", address)
+
+	var kr keyring.Keyring
+	var err error
+	if bootstrapper != nil {
+		lg.Info("bootstrapping with new gateway")
+		if kr, err = bootstrapper.Bootstrap(ctx, a.identityProvider); err != nil {
+			return fmt.Errorf("bootstrap failed: %w", err)
+		}
+	} else if kr, err = a.loadKeyring(ctx); err != nil {
+		return err
+	}
+
+	client, err := a.newGatewayClient(address, kr)
+	if err != nil {
+		return fmt.Errorf("error configuring gateway client: %w", err)
+	}
+	// Any response from the gateway means the TLS handshake succeeded, and
+	// the gateway's certificate matched one of the pinned keys.
+	if _, _, err := client.Get(ctx, "/healthz").Do(); err != nil {
+		return fmt.Errorf("error connecting to gateway: %w", err)
+	}
+
+	if bootstrapper != nil {
+		a.persistKeyring(ctx, kr)
+		if err := bootstrapper.Finalize(ctx); err != nil {
+			lg.With(zap.Error(err)).Error("error in post-bootstrap finalization")
+		}
+	}
+
+	a.gatewayClientMu.Lock()
+	a.gatewayClient = client
+	a.GatewayAddress = address
+	a.gatewayClientMu.Unlock()
+	lg.Info("gateway address updated")
+	return nil
+}
+
+func (a *Agent) gateway() clients.GatewayHTTPClient {
+	a.gatewayClientMu.RLock()
+	defer a.gatewayClientMu.RUnlock()
+	return a.gatewayClient
+}
+
+func (a *Agent) newGatewayClient(
+	address string,
+	kr keyring.Keyring,
+) (clients.GatewayHTTPClient, error) {
+	var clientOptions []clients.GatewayHTTPClientOption
+	if a.GatewaySPIFFEID != "" {
+		clientOptions = append(clientOptions, clients.WithGatewaySPIFFEID(a.GatewaySPIFFEID))
+	}
+	if a.TLSSessionCacheSize > 0 {
+		clientOptions = append(clientOptions, clients.WithTLSSessionCacheSize(a.TLSSessionCacheSize))
+	}
+	return clients.NewGatewayHTTPClient(address, a.identityProvider, kr, clientOptions...)
+}
+
 func (a *Agent) bootstrap(ctx context.Context) (keyring.Keyring, error) {
 	lg := a.logger
 
@@ -173,17 +286,8 @@ func (a *Agent) bootstrap(ctx context.Context) (keyring.Keyring, error) {
 			return nil, fmt.Errorf("bootstrap failed: %w", err)
 		}
 		lg.Info("bootstrap completed successfully")
-		for {
-			// Don't let this fail easily, otherwise we will lose the keyring forever.
-			// Keep retrying until it succeeds.
-			err = a.keyringStore.Put(ctx, newKeyring)
-			if err != nil {
-				lg.With(zap.Error(err)).Error("failed to persist keyring (retry in 1 second)")
-				time.Sleep(1 * time.Second)
-			} else {
-				break
-			}
-		}
+		a.diagnostics.event("initial bootstrap completed")
+		a.persistKeyring(ctx, newKeyring)
 	} else if err != nil {
 		return nil, fmt.Errorf("error loading keyring: %w", err)
 	} else {
@@ -193,12 +297,28 @@ func (a *Agent) bootstrap(ctx context.Context) (keyring.Keyring, error) {
 	lg.Info("running post-bootstrap finalization steps")
 	if err := a.bootstrapper.Finalize(ctx); err != nil {
 		lg.With(zap.Error(err)).Error("error in post-bootstrap finalization")
+		a.diagnostics.error(fmt.Errorf("error in post-bootstrap finalization: %w", err))
 	} else {
 		lg.Info("bootstrap completed successfully")
 	}
 	return a.loadKeyring(ctx)
 }
 
+func (a *Agent) persistKeyring(ctx context.Context, kr keyring.Keyring) {
+	for {
+		// Don't let this fail easily, otherwise we will lose the keyring forever.
+		// Keep retrying until it succeeds.
+		err := a.keyringStore.Put(ctx, kr)
+		if err != nil {
+			a.logger.With(zap.Error(err)).Error("failed to persist keyring (retry in 1 second)")
+			a.diagnostics.error(fmt.Errorf("failed to persist keyring: %w", err))
+			time.Sleep(1 * time.Second)
+		} else {
+			break
+		}
+	}
+}
+
 func (a *Agent) loadKeyring(ctx context.Context) (keyring.Keyring, error) {
 	lg := a.logger
 	lg.Info("loading keyring")
@@ -207,5 +327,6 @@ func (a *Agent) loadKeyring(ctx context.Context) (keyring.Keyring, error) {
 		return nil, fmt.Errorf("error loading keyring: %w", err)
 	}
 	lg.Info("keyring loaded successfully")
+	a.diagnostics.event("keyring loaded")
 	return kr, nil
 }
@@ -0,0 +1,259 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
+	"github.com/rancher/opni-monitoring/pkg/diagnostics"
+	"github.com/rancher/opni-monitoring/pkg/keyring"
+	"go.uber.org/zap"
+)
+
+const (
+	maxRecentEvents = 50
+	maxRecentErrors = 20
+)
+
+// diagnosticsRecorder keeps track of the agent's recent events, errors, and
+// gateway connection status.
+type diagnosticsRecorder struct {
+	mu         sync.Mutex
+	events     []diagnostics.Event
+	errors     []diagnostics.Event
+	connection diagnostics.ConnectionStatus
+
+	// fingerprint of the most recently uploaded bundle, and when it was
+	// uploaded
+	reportedFingerprint string
+	reportedAt          time.Time
+}
+
+func appendBounded(list []diagnostics.Event, event diagnostics.Event, limit int) []diagnostics.Event {
+	if len(list) >= limit {
+		list = append(list[:0], list[len(list)-limit+1:]...)
+	}
+	return append(list, event)
+}
+
+func (r *diagnosticsRecorder) event(format string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = appendBounded(r.events, diagnostics.Event{
+		Time:    time.Now(),
+		Message: fmt.Sprintf(format, args...),
+	}, maxRecentEvents)
+}
+
+func (r *diagnosticsRecorder) error(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = appendBounded(r.errors, diagnostics.Event{
+		Time:    time.Now(),
+		Message: err.Error(),
+	}, maxRecentErrors)
+}
+
+// gatewayRequest records the result of a request to the gateway. A request
+// which received any response is considered successful, since it means the
+// agent could connect to the gateway.
+func (r *diagnosticsRecorder) gatewayRequest(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	r.connection.TotalRequests++
+	if err != nil {
+		r.connection.Connected = false
+		r.connection.LastFailure = now
+		r.connection.LastError = err.Error()
+		r.connection.TotalFailures++
+		r.errors = appendBounded(r.errors, diagnostics.Event{
+			Time:    now,
+			Message: fmt.Sprintf("gateway request failed: %v", err),
+		}, maxRecentErrors)
+		return
+	}
+	r.connection.Connected = true
+	r.connection.LastSuccess = now
+}
+
+func (r *diagnosticsRecorder) snapshot() (events, errors []diagnostics.Event, connection diagnostics.ConnectionStatus) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	events = append([]diagnostics.Event{}, r.events...)
+	errors = append([]diagnostics.Event{}, r.errors...)
+	return events, errors, r.connection
+}
+
+// RedactConfig returns a copy of the agent configuration with secrets, such
+// as the bootstrap token and private key paths, replaced by
+// diagnostics.Redacted.
+func RedactConfig(spec v1beta1.AgentConfigSpec) v1beta1.AgentConfigSpec {
+	if spec.Bootstrap != nil {
+		bootstrap := *spec.Bootstrap
+		if bootstrap.Token != "" {
+			bootstrap.Token = diagnostics.Redacted
+		}
+		spec.Bootstrap = &bootstrap
+	}
+	if spec.Storage.Etcd != nil {
+		etcd := *spec.Storage.Etcd
+		if etcd.Certs != nil {
+			certs := *etcd.Certs
+			if certs.ClientKey != "" {
+				certs.ClientKey = diagnostics.Redacted
+			}
+			etcd.Certs = &certs
+		}
+		spec.Storage.Etcd = &etcd
+	}
+	return spec
+}
+
+// KeyringMetadata describes the keys in a keyring without revealing them.
+func KeyringMetadata(kr keyring.Keyring) []diagnostics.KeyMetadata {
+	metadata := []diagnostics.KeyMetadata{}
+	kr.ForEach(func(key interface{}) {
+		switch key := key.(type) {
+		case *keyring.SharedKeys:
+			sum := sha256.Sum256(key.ClientKey.Public().(ed25519.PublicKey))
+			metadata = append(metadata, diagnostics.KeyMetadata{
+				Type:         "sharedKeys",
+				Fingerprints: []string{hex.EncodeToString(sum[:])},
+			})
+		case *keyring.PKPKey:
+			pins := make([]string, 0, len(key.PinnedKeys))
+			for _, pin := range key.PinnedKeys {
+				pins = append(pins, pin.Encode())
+			}
+			metadata = append(metadata, diagnostics.KeyMetadata{
+				Type:         "pkp",
+				Fingerprints: pins,
+			})
+		default:
+			metadata = append(metadata, diagnostics.KeyMetadata{
+				Type: fmt.Sprintf("%T", key),
+			})
+		}
+	})
+	return metadata
+}
+
+// Diagnostics returns a diagnostics bundle describing the agent's current
+// state.
+func (a *Agent) Diagnostics(ctx context.Context) (*diagnostics.Bundle, error) {
+	events, errors, connection := a.diagnostics.snapshot()
+	a.gatewayClientMu.RLock()
+	connection.GatewayAddress = a.GatewayAddress
+	config := RedactConfig(a.AgentConfigSpec)
+	a.gatewayClientMu.RUnlock()
+
+	diag := &diagnostics.Bundle{
+		GeneratedAt: time.Now(),
+		ClusterID:   a.tenantID,
+		Config:      config,
+		Keyring:     []diagnostics.KeyMetadata{},
+		Connection:  connection,
+		Events:      events,
+		Errors:      errors,
+	}
+	kr, err := a.keyringStore.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error loading keyring: %w", err)
+	}
+	diag.Keyring = KeyringMetadata(kr)
+	return diag, nil
+}
+
+// handleDiagnostics serves the agent's diagnostics bundle. Requests which do
+// not originate from a loopback address are passed on to the next handler
+// as if the endpoint did not exist.
+func (a *Agent) handleDiagnostics(c *fiber.Ctx) error {
+	if !c.Context().RemoteIP().IsLoopback() {
+		return c.Next()
+	}
+	diag, err := a.Diagnostics(c.UserContext())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).SendString(err.Error())
+	}
+	return c.JSON(diag)
+}
+
+// ReportDiagnostics uploads the agent's diagnostics bundle to the gateway,
+// where it can be retrieved using the management API.
+func (a *Agent) ReportDiagnostics(ctx context.Context) error {
+	diag, err := a.Diagnostics(ctx)
+	if err != nil {
+		return err
+	}
+	return a.reportBundle(ctx, diag)
+}
+
+func (a *Agent) reportBundle(ctx context.Context, diag *diagnostics.Bundle) error {
+	data, err := json.Marshal(diag)
+	if err != nil {
+		return err
+	}
+	code, body, err := a.gateway().Post(ctx, diagnostics.AgentPath).
+		Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON).
+		Body(data).
+		Do()
+	a.diagnostics.gatewayRequest(err)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusOK {
+		return fmt.Errorf("gateway returned %d: %s", code, bytes.TrimSpace(body))
+	}
+	a.diagnostics.mu.Lock()
+	a.diagnostics.reportedFingerprint = diag.Fingerprint()
+	a.diagnostics.reportedAt = time.Now()
+	a.diagnostics.mu.Unlock()
+	return nil
+}
+
+// reportDiagnosticsIfChanged uploads the agent's diagnostics bundle to the
+// gateway if it has changed since it was last uploaded, or if the last
+// upload was more than diagnostics.RefreshInterval ago.
+func (a *Agent) reportDiagnosticsIfChanged(ctx context.Context) error {
+	diag, err := a.Diagnostics(ctx)
+	if err != nil {
+		return err
+	}
+	a.diagnostics.mu.Lock()
+	unchanged := diag.Fingerprint() == a.diagnostics.reportedFingerprint &&
+		time.Since(a.diagnostics.reportedAt) < diagnostics.RefreshInterval
+	a.diagnostics.mu.Unlock()
+	if unchanged {
+		return nil
+	}
+	return a.reportBundle(ctx, diag)
+}
+
+// reportDiagnostics periodically uploads the agent's diagnostics bundle to
+// the gateway when it changes, until the context is done.
+func (a *Agent) reportDiagnostics(ctx context.Context) {
+	ticker := time.NewTicker(diagnostics.CheckInterval)
+	defer ticker.Stop()
+	for {
+		if err := a.reportDiagnosticsIfChanged(ctx); err != nil && ctx.Err() == nil {
+			a.logger.With(
+				zap.Error(err),
+			).Debug("failed to report diagnostics")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package agent_test
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/agent"
+	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
+	"github.com/rancher/opni-monitoring/pkg/diagnostics"
+	"github.com/rancher/opni-monitoring/pkg/keyring"
+	"github.com/rancher/opni-monitoring/pkg/pkp"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Diagnostics", Label(test.Unit), func() {
+	When("redacting the agent config", func() {
+		spec := v1beta1.AgentConfigSpec{
+			GatewayAddress: "https://gateway:8080",
+			Storage: v1beta1.StorageSpec{
+				Type: v1beta1.StorageTypeEtcd,
+				Etcd: &v1beta1.EtcdStorageSpec{
+					Endpoints: []string{"etcd:2379"},
+					Certs: &v1beta1.MTLSSpec{
+						ClientCert: "/certs/tls.crt",
+						ClientKey:  "/certs/tls.key",
+					},
+				},
+			},
+			Bootstrap: &v1beta1.BootstrapSpec{
+				Token: "abcdef.0123456789abcdef0123456789abcdef0123456789abcdef0123456789ab",
+				Pins:  []string{"sha256:pin"},
+			},
+		}
+		It("should replace secrets", func() {
+			redacted := agent.RedactConfig(spec)
+			Expect(redacted.Bootstrap.Token).To(Equal(diagnostics.Redacted))
+			Expect(redacted.Storage.Etcd.Certs.ClientKey).To(Equal(diagnostics.Redacted))
+
+			data, err := json.Marshal(redacted)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).NotTo(ContainSubstring(spec.Bootstrap.Token))
+			Expect(string(data)).NotTo(ContainSubstring("/certs/tls.key"))
+		})
+		It("should keep everything else", func() {
+			redacted := agent.RedactConfig(spec)
+			Expect(redacted.GatewayAddress).To(Equal(spec.GatewayAddress))
+			Expect(redacted.Bootstrap.Pins).To(Equal(spec.Bootstrap.Pins))
+			Expect(redacted.Storage.Etcd.Endpoints).To(Equal(spec.Storage.Etcd.Endpoints))
+			Expect(redacted.Storage.Etcd.Certs.ClientCert).To(Equal("/certs/tls.crt"))
+		})
+		It("should not modify the original config", func() {
+			agent.RedactConfig(spec)
+			Expect(spec.Bootstrap.Token).NotTo(Equal(diagnostics.Redacted))
+			Expect(spec.Storage.Etcd.Certs.ClientKey).To(Equal("/certs/tls.key"))
+		})
+		It("should handle missing sections", func() {
+			Expect(agent.RedactConfig(v1beta1.AgentConfigSpec{})).To(Equal(v1beta1.AgentConfigSpec{}))
+		})
+	})
+	When("describing the keyring", func() {
+		It("should include metadata but no secrets", func() {
+			block, _ := pem.Decode(test.TestData("localhost.crt"))
+			cert, err := x509.ParseCertificate(block.Bytes)
+			Expect(err).NotTo(HaveOccurred())
+			pin := pkp.NewSha256(cert)
+			sharedKeys := keyring.NewSharedKeys(make([]byte, 64))
+			kr := keyring.New(sharedKeys, keyring.NewPKPKey([]*pkp.PublicKeyPin{pin}))
+
+			fingerprint := sha256.Sum256(sharedKeys.ClientKey.Public().(ed25519.PublicKey))
+
+			metadata := agent.KeyringMetadata(kr)
+			Expect(metadata).To(ConsistOf(
+				diagnostics.KeyMetadata{
+					Type:         "sharedKeys",
+					Fingerprints: []string{hex.EncodeToString(fingerprint[:])},
+				},
+				diagnostics.KeyMetadata{
+					Type:         "pkp",
+					Fingerprints: []string{pin.Encode()},
+				},
+			))
+
+			data, err := json.Marshal(metadata)
+			Expect(err).NotTo(HaveOccurred())
+			for _, secret := range [][]byte{
+				sharedKeys.ClientKey,
+				sharedKeys.ServerKey,
+			} {
+				Expect(string(data)).NotTo(ContainSubstring(base64.StdEncoding.EncodeToString(secret)))
+				Expect(string(data)).NotTo(ContainSubstring(hex.EncodeToString(secret)))
+			}
+		})
+	})
+})
@@ -0,0 +1,68 @@
+package agent
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cortexproject/cortex/pkg/cortexpb"
+	"github.com/golang/snappy"
+	"github.com/prometheus/common/model"
+)
+
+// ValidateExternalLabels checks that all external label names and values
+// are valid Prometheus label names and values.
+func ValidateExternalLabels(labels map[string]string) error {
+	for name, value := range labels {
+		if !model.LabelName(name).IsValid() {
+			return fmt.Errorf("invalid external label name %q", name)
+		}
+		if !model.LabelValue(value).IsValid() {
+			return fmt.Errorf("invalid value for external label %q", name)
+		}
+	}
+	return nil
+}
+
+// ApplyExternalLabels adds the given labels to every series in a
+// snappy-compressed remote-write request body, and returns the re-encoded
+// body. External labels take precedence: if a series already has a label
+// with the same name, its value is replaced. Labels in each series are kept
+// sorted by name, as required by the remote-write protocol.
+func ApplyExternalLabels(body []byte, externalLabels map[string]string) ([]byte, error) {
+	decoded, err := snappy.Decode(nil, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode request body: %w", err)
+	}
+	var req cortexpb.WriteRequest
+	if err := req.Unmarshal(decoded); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal write request: %w", err)
+	}
+	for _, ts := range req.Timeseries {
+		ts.Labels = mergeLabels(ts.Labels, externalLabels)
+	}
+	data, err := req.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal write request: %w", err)
+	}
+	return snappy.Encode(nil, data), nil
+}
+
+func mergeLabels(labels []cortexpb.LabelAdapter, externalLabels map[string]string) []cortexpb.LabelAdapter {
+	merged := make([]cortexpb.LabelAdapter, 0, len(labels)+len(externalLabels))
+	for _, l := range labels {
+		if _, ok := externalLabels[l.Name]; ok {
+			continue
+		}
+		merged = append(merged, l)
+	}
+	for name, value := range externalLabels {
+		merged = append(merged, cortexpb.LabelAdapter{
+			Name:  name,
+			Value: value,
+		})
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Name < merged[j].Name
+	})
+	return merged
+}
@@ -0,0 +1,98 @@
+package agent_test
+
+import (
+	"github.com/cortexproject/cortex/pkg/cortexpb"
+	"github.com/golang/snappy"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/agent"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+func series(labels ...string) cortexpb.PreallocTimeseries {
+	ts := &cortexpb.TimeSeries{
+		Samples: []cortexpb.Sample{
+			{Value: 1, TimestampMs: 1000},
+		},
+	}
+	for i := 0; i < len(labels); i += 2 {
+		ts.Labels = append(ts.Labels, cortexpb.LabelAdapter{
+			Name:  labels[i],
+			Value: labels[i+1],
+		})
+	}
+	return cortexpb.PreallocTimeseries{TimeSeries: ts}
+}
+
+func encode(timeseries ...cortexpb.PreallocTimeseries) []byte {
+	req := &cortexpb.WriteRequest{
+		Timeseries: timeseries,
+	}
+	data, err := req.Marshal()
+	Expect(err).NotTo(HaveOccurred())
+	return snappy.Encode(nil, data)
+}
+
+func decode(body []byte) *cortexpb.WriteRequest {
+	data, err := snappy.Decode(nil, body)
+	Expect(err).NotTo(HaveOccurred())
+	req := &cortexpb.WriteRequest{}
+	Expect(req.Unmarshal(data)).To(Succeed())
+	return req
+}
+
+func labelPairs(ts cortexpb.PreallocTimeseries) []string {
+	pairs := []string{}
+	for _, l := range ts.Labels {
+		pairs = append(pairs, l.Name+"="+l.Value)
+	}
+	return pairs
+}
+
+var _ = Describe("External Labels", Label(test.Unit), func() {
+	externalLabels := map[string]string{
+		"cluster": "prod-1",
+		"region":  "us-east-1",
+	}
+	It("should add external labels to every series", func() {
+		body, err := agent.ApplyExternalLabels(encode(
+			series("__name__", "up", "job", "a"),
+			series("__name__", "up", "job", "b"),
+		), externalLabels)
+		Expect(err).NotTo(HaveOccurred())
+
+		req := decode(body)
+		Expect(req.Timeseries).To(HaveLen(2))
+		Expect(labelPairs(req.Timeseries[0])).To(Equal([]string{
+			"__name__=up", "cluster=prod-1", "job=a", "region=us-east-1",
+		}))
+		Expect(labelPairs(req.Timeseries[1])).To(Equal([]string{
+			"__name__=up", "cluster=prod-1", "job=b", "region=us-east-1",
+		}))
+		Expect(req.Timeseries[0].Samples).To(Equal([]cortexpb.Sample{
+			{Value: 1, TimestampMs: 1000},
+		}))
+	})
+	It("should override conflicting source labels", func() {
+		body, err := agent.ApplyExternalLabels(encode(
+			series("__name__", "up", "cluster", "wrong", "region", "us-east-1"),
+		), externalLabels)
+		Expect(err).NotTo(HaveOccurred())
+
+		req := decode(body)
+		Expect(labelPairs(req.Timeseries[0])).To(Equal([]string{
+			"__name__=up", "cluster=prod-1", "region=us-east-1",
+		}))
+	})
+	It("should reject malformed request bodies", func() {
+		_, err := agent.ApplyExternalLabels([]byte("not snappy"), externalLabels)
+		Expect(err).To(HaveOccurred())
+	})
+	It("should validate external labels", func() {
+		Expect(agent.ValidateExternalLabels(externalLabels)).To(Succeed())
+		Expect(agent.ValidateExternalLabels(map[string]string{
+			"not-a-label": "foo",
+		})).NotTo(Succeed())
+	})
+})
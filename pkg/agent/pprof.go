@@ -0,0 +1,22 @@
+package agent
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/pprof"
+	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
+)
+
+// pprofHandler serves the net/http/pprof handlers under /debug/pprof/.
+// Unless remote access is allowed, requests which do not originate from a
+// loopback address are passed on to the next handler as if the endpoints
+// did not exist.
+func pprofHandler(spec *v1beta1.ProfilingSpec) fiber.Handler {
+	return pprof.New(pprof.Config{
+		Next: func(c *fiber.Ctx) bool {
+			if spec.AllowRemoteAccess {
+				return false
+			}
+			return !c.Context().RemoteIP().IsLoopback()
+		},
+	})
+}
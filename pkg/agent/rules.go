@@ -111,10 +111,11 @@ func (a *Agent) streamRulesToGateway(ctx context.Context) error {
 				for _, doc := range docs {
 					reqCtx, ca := context.WithTimeout(ctx, time.Second*2)
 					defer ca()
-					code, _, err := a.gatewayClient.Post(reqCtx, "/api/agent/sync_rules").
+					code, _, err := a.gateway().Post(reqCtx, "/api/agent/sync_rules").
 						Set("Content-Type", "application/yaml").
 						Body(doc).
 						Do()
+					a.diagnostics.gatewayRequest(err)
 					if err != nil || code != http.StatusAccepted {
 						// retry, unless another update is received from the channel
 						lg.With(
@@ -134,6 +135,7 @@ func (a *Agent) streamRulesToGateway(ctx context.Context) error {
 					}
 				}
 				lg.Infof("successfully sent %d alert rules to gateway", len(docs))
+				a.diagnostics.event("sent %d alert rules to gateway", len(docs))
 				break
 			}
 		}
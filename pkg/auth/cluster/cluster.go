@@ -107,25 +107,35 @@ func (m *ClusterMiddleware) Handle(c *fiber.Ctx) error {
 		return c.SendStatus(fiber.StatusUnauthorized)
 	}
 
-	kr, err := ks.Get(context.Background())
+	// Try the active keyring first. Previous versions, which have not yet
+	// been pruned after a key rotation, are only read if it does not match.
+	active, err := ks.Get(context.Background())
 	if err != nil {
 		lg.Debugf("unauthorized: error looking up keyring for cluster %s: %v", clusterID, err)
 		m.doFakeKeyringVerify(mac, clusterID, nonce, c.Body())
 		return c.SendStatus(fiber.StatusUnauthorized)
 	}
-
-	authorized := false
-	var sharedKeys *keyring.SharedKeys
-	if ok := kr.Try(func(shared *keyring.SharedKeys) {
-		if err := b2mac.Verify(mac, clusterID, nonce, c.Body(), shared.ClientKey); err == nil {
-			authorized = true
-			sharedKeys = shared
-		}
-	}); !ok {
-		lg.Errorf("unauthorized: invalid or corrupted keyring for cluster %s: %v", clusterID, err)
+	sharedKeys, ok := verifyKeyring(active, mac, clusterID, nonce, c.Body())
+	if !ok {
+		lg.Errorf("unauthorized: invalid or corrupted keyring for cluster %s", clusterID)
 		return c.Status(fiber.StatusInternalServerError).SendString("invalid or corrupted keyring")
 	}
-	if !authorized {
+	if sharedKeys == nil {
+		keyrings, err := ks.List(context.Background())
+		if err != nil {
+			lg.Debugf("unauthorized: error looking up previous keyrings for cluster %s: %v", clusterID, err)
+			return c.SendStatus(fiber.StatusUnauthorized)
+		}
+		// the keyring may have been rotated since the active keyring was
+		// read, so every version is tried
+		for i := 0; i < len(keyrings) && sharedKeys == nil; i++ {
+			if sharedKeys, ok = verifyKeyring(keyrings[i], mac, clusterID, nonce, c.Body()); !ok {
+				lg.Errorf("unauthorized: invalid or corrupted previous keyring for cluster %s", clusterID)
+				return c.Status(fiber.StatusInternalServerError).SendString("invalid or corrupted keyring")
+			}
+		}
+	}
+	if sharedKeys == nil {
 		lg.Debugf("unauthorized: invalid mac for cluster %s", clusterID)
 		return c.SendStatus(fiber.StatusUnauthorized)
 	}
@@ -135,6 +145,24 @@ func (m *ClusterMiddleware) Handle(c *fiber.Ctx) error {
 	return c.Next()
 }
 
+// verifyKeyring returns the shared keys in the keyring which were used to
+// sign the request, or nil if none were. ok is false if the keyring does not
+// contain any shared keys.
+func verifyKeyring(
+	kr keyring.Keyring,
+	mac []byte,
+	id []byte,
+	nonce uuid.UUID,
+	payload []byte,
+) (sharedKeys *keyring.SharedKeys, ok bool) {
+	ok = kr.Try(func(shared *keyring.SharedKeys) {
+		if sharedKeys == nil && b2mac.Verify(mac, id, nonce, payload, shared.ClientKey) == nil {
+			sharedKeys = shared
+		}
+	})
+	return
+}
+
 func AuthorizedKeys(c *fiber.Ctx) *keyring.SharedKeys {
 	return c.Locals(SharedKeysKey).(*keyring.SharedKeys)
 }
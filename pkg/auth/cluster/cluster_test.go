@@ -3,6 +3,7 @@ package cluster_test
 import (
 	"bytes"
 	"context"
+	"crypto/ed25519"
 	"crypto/rand"
 	"errors"
 	"io"
@@ -12,6 +13,7 @@ import (
 	"runtime/debug"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -20,6 +22,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gmeasure"
 	"github.com/rancher/opni-monitoring/pkg/auth/cluster"
+	"github.com/rancher/opni-monitoring/pkg/b2mac"
 	"github.com/rancher/opni-monitoring/pkg/core"
 	"github.com/rancher/opni-monitoring/pkg/keyring"
 	"github.com/rancher/opni-monitoring/pkg/storage"
@@ -29,6 +32,18 @@ import (
 	"github.com/valyala/fasthttp/fasthttputil"
 )
 
+// listCountingKeyringStore counts the number of times previous keyring
+// versions are listed.
+type listCountingKeyringStore struct {
+	storage.KeyringStore
+	lists int32
+}
+
+func (s *listCountingKeyringStore) List(ctx context.Context) ([]keyring.Keyring, error) {
+	atomic.AddInt32(&s.lists, 1)
+	return s.KeyringStore.List(ctx)
+}
+
 func bodyStr(body io.ReadCloser) string {
 	buf := new(bytes.Buffer)
 	buf.ReadFrom(body)
@@ -384,6 +399,55 @@ var _ = Describe("Cluster Auth", Ordered, test.EnableInCI[FlakeAttempts](5), Lab
 					Expect(resp.StatusCode).To(Equal(http.StatusOK))
 				})
 			})
+
+			When("the keyring has been rotated", func() {
+				var store *listCountingKeyringStore
+				var activeKeys *keyring.SharedKeys
+				BeforeEach(func() {
+					store = &listCountingKeyringStore{
+						KeyringStore: test.NewTestKeyringStore(ctrl, "", &core.Reference{
+							Id: "cluster-1",
+						}),
+					}
+					Expect(store.Put(context.Background(), keyring.New(keyring.NewSharedKeys(testSharedSecret)))).To(Succeed())
+					secret := make([]byte, 64)
+					_, err := rand.Read(secret)
+					Expect(err).NotTo(HaveOccurred())
+					activeKeys = keyring.NewSharedKeys(secret)
+					Expect(store.Rotate(context.Background(), keyring.New(activeKeys))).To(Succeed())
+					handler = func(_ context.Context, prefix string, ref *core.Reference) (storage.KeyringStore, error) {
+						if ref.Id == "cluster-1" {
+							return store, nil
+						}
+						return nil, errors.New("not found")
+					}
+				})
+				sendRequest := func(clientKey ed25519.PrivateKey) int {
+					header, err := b2mac.NewEncodedHeader([]byte("cluster-1"), []byte("payload"), clientKey)
+					Expect(err).NotTo(HaveOccurred())
+					req := newRequest(http.MethodPost, "/", strings.NewReader("payload"))
+					req.Header.Set("Authorization", header)
+					resp, err := client.Do(req)
+					Expect(err).NotTo(HaveOccurred())
+					resp.Body.Close()
+					return resp.StatusCode
+				}
+				It("should accept requests signed with the active keyring", func() {
+					Expect(sendRequest(activeKeys.ClientKey)).To(Equal(http.StatusOK))
+					Expect(atomic.LoadInt32(&store.lists)).To(BeZero())
+				})
+				It("should accept requests signed with the previous keyring", func() {
+					Expect(sendRequest(testClientKey)).To(Equal(http.StatusOK))
+					Expect(atomic.LoadInt32(&store.lists)).To(BeEquivalentTo(1))
+				})
+				When("the previous keyring is pruned", func() {
+					It("should only accept requests signed with the active keyring", func() {
+						Expect(store.Prune(context.Background())).To(Succeed())
+						Expect(sendRequest(activeKeys.ClientKey)).To(Equal(http.StatusOK))
+						Expect(sendRequest(testClientKey)).To(Equal(http.StatusUnauthorized))
+					})
+				})
+			})
 		})
 	})
 })
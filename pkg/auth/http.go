@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+const authorizedKey = "opni.auth.authorized"
+
+// HTTPMiddleware adapts a Middleware for use with net/http handlers. The
+// middleware is run against the request headers; if it calls Next, the
+// original request is passed to the wrapped handler unmodified. Otherwise,
+// the response written by the middleware is sent to the client.
+func HTTPMiddleware(mw Middleware) func(http.Handler) http.Handler {
+	app := fiber.New(fiber.Config{
+		DisableStartupMessage: true,
+	})
+	app.Use(mw.Handle, func(c *fiber.Ctx) error {
+		c.Context().SetUserValue(authorizedKey, true)
+		return nil
+	})
+	handler := app.Handler()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			req := fasthttp.AcquireRequest()
+			defer fasthttp.ReleaseRequest(req)
+			req.Header.SetMethod(r.Method)
+			req.SetRequestURI(r.URL.RequestURI())
+			req.SetHost(r.Host)
+			for k, values := range r.Header {
+				for _, v := range values {
+					req.Header.Add(k, v)
+				}
+			}
+			var remoteAddr net.Addr
+			if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+				remoteAddr = addr
+			}
+
+			fctx := &fasthttp.RequestCtx{}
+			fctx.Init(req, remoteAddr, nil)
+			handler(fctx)
+
+			if authorized, ok := fctx.UserValue(authorizedKey).(bool); ok && authorized {
+				next.ServeHTTP(rw, r)
+				return
+			}
+			fctx.Response.Header.VisitAll(func(key, value []byte) {
+				rw.Header().Add(string(key), string(value))
+			})
+			rw.WriteHeader(fctx.Response.StatusCode())
+			rw.Write(fctx.Response.Body())
+		})
+	}
+}
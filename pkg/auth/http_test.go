@@ -0,0 +1,74 @@
+package auth_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gofiber/fiber/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/auth"
+)
+
+type tokenMiddleware struct {
+	token string
+}
+
+func (tm *tokenMiddleware) Handle(c *fiber.Ctx) error {
+	if c.Get("Authorization") != "Bearer "+tm.token {
+		return c.Status(fiber.StatusUnauthorized).SendString("invalid token")
+	}
+	return c.Next()
+}
+
+var _ = Describe("HTTP Middleware", Ordered, func() {
+	var server *httptest.Server
+	BeforeAll(func() {
+		ok := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.Write([]byte(r.URL.Path))
+		})
+		mux := http.NewServeMux()
+		mux.Handle("/api/", auth.HTTPMiddleware(&tokenMiddleware{token: "api"})(ok))
+		mux.Handle("/web/", auth.HTTPMiddleware(&tokenMiddleware{token: "web"})(ok))
+		server = httptest.NewServer(mux)
+		DeferCleanup(server.Close)
+	})
+	do := func(path, token string) (int, string) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		Expect(err).NotTo(HaveOccurred())
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return resp.StatusCode, string(body)
+	}
+	It("should allow requests with credentials for the route group", func() {
+		code, body := do("/api/foo", "api")
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(Equal("/api/foo"))
+
+		code, body = do("/web/foo", "web")
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(Equal("/web/foo"))
+	})
+	It("should reject credentials for a different route group", func() {
+		code, body := do("/api/foo", "web")
+		Expect(code).To(Equal(http.StatusUnauthorized))
+		Expect(body).To(Equal("invalid token"))
+
+		code, _ = do("/web/foo", "api")
+		Expect(code).To(Equal(http.StatusUnauthorized))
+	})
+	It("should reject requests without credentials", func() {
+		code, _ := do("/api/foo", "")
+		Expect(code).To(Equal(http.StatusUnauthorized))
+		code, _ = do("/web/foo", "")
+		Expect(code).To(Equal(http.StatusUnauthorized))
+	})
+})
@@ -0,0 +1,82 @@
+package bootstrap
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BootstrapAction identifies the bootstrap endpoint a request was sent to.
+type BootstrapAction string
+
+const (
+	BootstrapActionJoin  BootstrapAction = "join"
+	BootstrapActionAuth  BootstrapAction = "auth"
+	BootstrapActionCheck BootstrapAction = "check"
+)
+
+// BootstrapOutcomeSuccess is the outcome of requests which succeeded. Failed
+// requests have the error code sent to the client as their outcome.
+const BootstrapOutcomeSuccess = "success"
+
+// BootstrapAuditEvent describes a single request to a bootstrap endpoint.
+type BootstrapAuditEvent struct {
+	Action BootstrapAction
+	// The ID requested by the client. Empty for join requests, and for
+	// requests rejected before the request body was read.
+	ClientID string
+	// Hex-encoded SHA-256 hash of the bootstrap token's ID, so that events
+	// can be correlated with tokens without revealing them. Empty for join
+	// requests, and for requests whose token signature could not be
+	// verified.
+	TokenIDHash string
+	RemoteAddr  string
+	Timestamp   time.Time
+	// BootstrapOutcomeSuccess, or the error code sent to the client.
+	Outcome    string
+	StatusCode int
+}
+
+// AuditLogger records bootstrap events, for example to keep an audit trail
+// of every attempt to register a cluster. RecordBootstrap is called after the
+// response has been written, and must not block for long, since it delays
+// the response from being sent.
+type AuditLogger interface {
+	RecordBootstrap(ctx context.Context, event BootstrapAuditEvent)
+}
+
+const (
+	localsClientID    = "bootstrap.clientID"
+	localsTokenIDHash = "bootstrap.tokenIDHash"
+	localsServerError = "bootstrap.serverError"
+)
+
+// hashTokenID returns the hex-encoded SHA-256 hash of a token ID.
+func hashTokenID(id string) string {
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:])
+}
+
+// recordBootstrap sends an audit event describing the completed request to
+// the configured audit logger, if any.
+func (h ServerConfig) recordBootstrap(c *fiber.Ctx, action BootstrapAction) {
+	if h.AuditLogger == nil {
+		return
+	}
+	event := BootstrapAuditEvent{
+		Action:     action,
+		RemoteAddr: c.IP(),
+		Timestamp:  time.Now(),
+		Outcome:    BootstrapOutcomeSuccess,
+		StatusCode: c.Response().StatusCode(),
+	}
+	event.ClientID, _ = c.Locals(localsClientID).(string)
+	event.TokenIDHash, _ = c.Locals(localsTokenIDHash).(string)
+	if serverErr, ok := c.Locals(localsServerError).(*ServerError); ok {
+		event.Outcome = string(serverErr.Code)
+	}
+	h.AuditLogger.RecordBootstrap(c.UserContext(), event)
+}
@@ -0,0 +1,116 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rancher/opni-monitoring/pkg/capabilities/wellknown"
+	"github.com/rancher/opni-monitoring/pkg/ecdh"
+	"github.com/rancher/opni-monitoring/pkg/ident"
+	"github.com/rancher/opni-monitoring/pkg/keyring"
+	"github.com/rancher/opni-monitoring/pkg/pkp"
+	"github.com/rancher/opni-monitoring/pkg/tokens"
+)
+
+var ErrNoClientID = errors.New("no client ID or identity provider configured")
+
+type ClientOptions struct {
+	capability    string
+	identProvider ident.Provider
+	curve         ecdh.Curve
+}
+
+type ClientOption func(*ClientOptions)
+
+func (o *ClientOptions) Apply(opts ...ClientOption) {
+	for _, op := range opts {
+		op(o)
+	}
+}
+
+// WithCapability sets the capability requested by the client. Defaults to
+// wellknown.CapabilityMetrics.
+func WithCapability(capability string) ClientOption {
+	return func(o *ClientOptions) {
+		o.capability = capability
+	}
+}
+
+// WithClientID sets the ID the client bootstraps as. Mutually exclusive with
+// WithIdentProvider.
+func WithClientID(id string) ClientOption {
+	return func(o *ClientOptions) {
+		o.identProvider = staticIdentProvider(id)
+	}
+}
+
+// WithIdentProvider sets the identity provider used to obtain the ID the
+// client bootstraps as. Mutually exclusive with WithClientID.
+func WithIdentProvider(provider ident.Provider) ClientOption {
+	return func(o *ClientOptions) {
+		o.identProvider = provider
+	}
+}
+
+// WithCurve sets the elliptic curve used for the ECDH key exchange, which
+// must match the server's curve. Defaults to ecdh.DefaultCurve.
+func WithCurve(curve ecdh.Curve) ClientOption {
+	return func(o *ClientOptions) {
+		o.curve = curve
+	}
+}
+
+type staticIdentProvider string
+
+func (p staticIdentProvider) UniqueIdentifier(context.Context) (string, error) {
+	return string(p), nil
+}
+
+// Client performs the client side of the bootstrap protocol served by
+// ServerConfig: it fetches the join response from the server, verifies the
+// token's signature using the server's certificate (which must match one of
+// the pinned public keys), sends an auth request, and derives a keyring from
+// the shared secret.
+//
+// Unlike ClientConfig, Client does not implement Bootstrapper, and does not
+// modify any agent configuration; it can be used by tools and tests which
+// need to register a cluster without running an agent.
+type Client struct {
+	ClientOptions
+	config ClientConfig
+}
+
+// NewClient creates a new bootstrap client for the server at the given
+// endpoint. The server's certificate must match one of the given pins. A
+// client ID must be configured using WithClientID or WithIdentProvider.
+func NewClient(
+	endpoint string,
+	token *tokens.Token,
+	pins []*pkp.PublicKeyPin,
+	opts ...ClientOption,
+) *Client {
+	options := ClientOptions{
+		capability: wellknown.CapabilityMetrics,
+	}
+	options.Apply(opts...)
+
+	return &Client{
+		ClientOptions: options,
+		config: ClientConfig{
+			Capability: options.capability,
+			Token:      token,
+			Pins:       pins,
+			Endpoint:   endpoint,
+			Curve:      options.curve,
+		},
+	}
+}
+
+// Bootstrap registers the client with the server, and returns a keyring
+// containing the keys shared with the server and the pinned public keys.
+func (c *Client) Bootstrap(ctx context.Context) (keyring.Keyring, error) {
+	if c.identProvider == nil {
+		return nil, ErrNoClientID
+	}
+	return c.config.Bootstrap(ctx, c.identProvider)
+}
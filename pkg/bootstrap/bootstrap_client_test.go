@@ -0,0 +1,164 @@
+package bootstrap_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/bootstrap"
+	"github.com/rancher/opni-monitoring/pkg/capabilities"
+	"github.com/rancher/opni-monitoring/pkg/capabilities/wellknown"
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/ecdh"
+	"github.com/rancher/opni-monitoring/pkg/keyring"
+	"github.com/rancher/opni-monitoring/pkg/pkp"
+	"github.com/rancher/opni-monitoring/pkg/storage"
+	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/pkg/tokens"
+)
+
+var _ = Describe("Bootstrap Client", Label(test.Unit, test.Slow), func() {
+	var endpoint string
+	var pins []*pkp.PublicKeyPin
+	var token *tokens.Token
+	var serverCurve ecdh.Curve
+	var tokenStore storage.TokenStore
+	var clusterStore storage.ClusterStore
+	var keyringStoreBroker storage.KeyringStoreBroker
+
+	BeforeEach(func() {
+		serverCurve = ""
+	})
+	JustBeforeEach(func() {
+		ctx, ca := context.WithCancel(context.Background())
+		DeferCleanup(ca)
+		tokenStore = test.NewTestTokenStore(ctx, ctrl)
+		clusterStore = test.NewTestClusterStore(ctrl)
+		keyringStoreBroker = test.NewTestKeyringStoreBroker(ctrl)
+		bt, err := tokenStore.CreateToken(ctx, time.Hour)
+		Expect(err).NotTo(HaveOccurred())
+		token, err = tokens.FromBootstrapToken(bt)
+		Expect(err).NotTo(HaveOccurred())
+
+		crt, err := tls.X509KeyPair(test.TestData("self_signed_leaf.crt"), test.TestData("self_signed_leaf.key"))
+		Expect(err).NotTo(HaveOccurred())
+		crt.Leaf, err = x509.ParseCertificate(crt.Certificate[0])
+		Expect(err).NotTo(HaveOccurred())
+		pins = []*pkp.PublicKeyPin{pkp.NewSha256(crt.Leaf)}
+
+		capBackendStore := capabilities.NewBackendStore(capabilities.ServerInstallerTemplateSpec{}, test.Log)
+		capBackendStore.Add(wellknown.CapabilityMetrics, test.NewTestCapabilityBackend(ctrl, &test.CapabilityInfo{
+			Name:       wellknown.CapabilityMetrics,
+			CanInstall: true,
+		}))
+		server := bootstrap.ServerConfig{
+			Certificate:         &crt,
+			TokenStore:          tokenStore,
+			ClusterStore:        clusterStore,
+			KeyringStoreBroker:  keyringStoreBroker,
+			CapabilityInstaller: capBackendStore,
+			Curve:               serverCurve,
+		}
+		app := fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		app.All("/bootstrap/*", server.Handle)
+		listener, err := net.Listen("tcp4", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		endpoint = "https://" + listener.Addr().String()
+		go app.Listener(tls.NewListener(listener, &tls.Config{
+			Certificates: []tls.Certificate{crt},
+		}))
+		DeferCleanup(func() {
+			Expect(app.Shutdown()).To(Succeed())
+		})
+	})
+
+	It("should register the client with the server", func() {
+		client := bootstrap.NewClient(endpoint, token, pins,
+			bootstrap.WithClientID("client-1"))
+		kr, err := client.Bootstrap(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		cluster, err := clusterStore.GetCluster(context.Background(), &core.Reference{Id: "client-1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.GetCapabilities()).To(ContainElement(
+			HaveField("Name", wellknown.CapabilityMetrics)))
+
+		ks, err := keyringStoreBroker.KeyringStore(context.Background(), "gateway", cluster.Reference())
+		Expect(err).NotTo(HaveOccurred())
+		serverKeyring, err := ks.Get(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		var clientKeys, serverKeys *keyring.SharedKeys
+		Expect(kr.Try(func(sk *keyring.SharedKeys) {
+			clientKeys = sk
+		})).To(BeTrue())
+		Expect(serverKeyring.Try(func(sk *keyring.SharedKeys) {
+			serverKeys = sk
+		})).To(BeTrue())
+		Expect(clientKeys).To(Equal(serverKeys))
+
+		Expect(kr.Try(func(pk *keyring.PKPKey) {
+			Expect(pk.PinnedKeys).To(Equal(pins))
+		})).To(BeTrue())
+	})
+	It("should use the identity provider to obtain the client ID", func() {
+		client := bootstrap.NewClient(endpoint, token, pins,
+			bootstrap.WithIdentProvider(test.NewTestIdentProvider(ctrl, "client-2")))
+		_, err := client.Bootstrap(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		_, err = clusterStore.GetCluster(context.Background(), &core.Reference{Id: "client-2"})
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("should fail if no client ID is configured", func() {
+		client := bootstrap.NewClient(endpoint, token, pins)
+		_, err := client.Bootstrap(context.Background())
+		Expect(err).To(MatchError(bootstrap.ErrNoClientID))
+	})
+	It("should fail if the server's certificate does not match the pins", func() {
+		crt, err := tls.X509KeyPair(test.TestData("localhost.crt"), test.TestData("localhost.key"))
+		Expect(err).NotTo(HaveOccurred())
+		leaf, err := x509.ParseCertificate(crt.Certificate[0])
+		Expect(err).NotTo(HaveOccurred())
+		client := bootstrap.NewClient(endpoint, token, []*pkp.PublicKeyPin{pkp.NewSha256(leaf)},
+			bootstrap.WithClientID("client-3"))
+		_, err = client.Bootstrap(context.Background())
+		Expect(err).To(HaveOccurred())
+		_, err = clusterStore.GetCluster(context.Background(), &core.Reference{Id: "client-3"})
+		Expect(errors.Is(err, storage.ErrNotFound)).To(BeTrue())
+	})
+	It("should fail if the token was not issued by the server", func() {
+		client := bootstrap.NewClient(endpoint, tokens.NewToken(), pins,
+			bootstrap.WithClientID("client-4"))
+		_, err := client.Bootstrap(context.Background())
+		Expect(err).To(MatchError(bootstrap.ErrNoValidSignature))
+	})
+	When("the server uses the p256 curve", func() {
+		BeforeEach(func() {
+			serverCurve = ecdh.CurveP256
+		})
+		It("should bootstrap if the client uses the same curve", func() {
+			client := bootstrap.NewClient(endpoint, token, pins,
+				bootstrap.WithClientID("client-5"),
+				bootstrap.WithCurve(ecdh.CurveP256))
+			_, err := client.Bootstrap(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("should fail if the client uses a different curve", func() {
+			client := bootstrap.NewClient(endpoint, token, pins,
+				bootstrap.WithClientID("client-6"))
+			_, err := client.Bootstrap(context.Background())
+			var serverErr *bootstrap.ServerError
+			Expect(errors.As(err, &serverErr)).To(BeTrue())
+			Expect(serverErr.Code).To(Equal(bootstrap.ErrorCodeUnsupportedCurve))
+		})
+	})
+})
@@ -31,6 +31,7 @@ var (
 	ErrBootstrapFailed    = errors.New("bootstrap failed")
 	ErrNoValidSignature   = errors.New("no valid signature found in response")
 	ErrNoToken            = errors.New("no bootstrap token provided")
+	ErrClusterIDConflict  = errors.New("cluster ID is already in use")
 )
 
 type ClientConfig struct {
@@ -40,6 +41,9 @@ type ClientConfig struct {
 	Endpoint     string
 	K8sConfig    *rest.Config
 	K8sNamespace string
+	// The elliptic curve used for the ECDH key exchange. Must match the
+	// server's curve. If unset, ecdh.DefaultCurve (X25519) is used.
+	Curve ecdh.Curve
 }
 
 func (c *ClientConfig) Bootstrap(
@@ -74,15 +78,30 @@ func (c *ClientConfig) Bootstrap(
 		Timeout: 10 * time.Second,
 	}
 
-	ekp := ecdh.NewEphemeralKeyPair()
 	id, err := ident.UniqueIdentifier(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to obtain unique identifier: %w", err)
 	}
+	version, err := NegotiateProtocolVersion(response.ProtocolVersions)
+	if err != nil {
+		return nil, err
+	}
+	if version.Supports(FeaturePreflightCheck) {
+		if err := c.bootstrapCheck(ctx, &client, id, completeJws); err != nil {
+			return nil, err
+		}
+	}
+
+	ekp, err := ecdh.NewEphemeralKeyPairForCurve(c.Curve)
+	if err != nil {
+		return nil, err
+	}
 	authReq, err := json.Marshal(BootstrapAuthRequest{
-		ClientID:     id,
-		ClientPubKey: ekp.PublicKey,
-		Capability:   c.Capability,
+		ClientID:        id,
+		ClientPubKey:    ekp.PublicKey,
+		Capability:      c.Capability,
+		ProtocolVersion: version,
+		Curve:           c.Curve,
 	})
 	if err != nil {
 		return nil, err
@@ -103,10 +122,10 @@ func (c *ClientConfig) Bootstrap(
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("%w: %s", ErrBootstrapFailed, resp.Status)
+		return nil, readServerError(resp)
 	}
-	defer resp.Body.Close()
 
 	var authResp BootstrapAuthResponse
 	if err := json.NewDecoder(resp.Body).Decode(&authResp); err != nil {
@@ -158,6 +177,53 @@ func (c *ClientConfig) bootstrapAuthURL() (*url.URL, error) {
 	return u, nil
 }
 
+func (c *ClientConfig) bootstrapCheckURL() (*url.URL, error) {
+	u, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	u.Scheme = "https"
+	u.Path = "bootstrap/check"
+	return u, nil
+}
+
+// bootstrapCheck asks the server whether the given ID can be used to
+// bootstrap, before any state is modified. If another cluster is already
+// using the ID, an error wrapping ErrClusterIDConflict is returned. If the
+// request cannot be sent, or ctx is canceled, that error is returned. Other
+// error responses from the server are ignored, since they will also be
+// reported by the auth request.
+func (c *ClientConfig) bootstrapCheck(ctx context.Context, client *http.Client, id string, completeJws []byte) error {
+	checkReq, err := json.Marshal(BootstrapCheckRequest{
+		ClientID:   id,
+		Capability: c.Capability,
+	})
+	if err != nil {
+		return err
+	}
+	url, err := c.bootstrapCheckURL()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url.String(), bytes.NewReader(checkReq))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Add("Authorization", "Bearer "+string(completeJws))
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusConflict {
+		serverErr := readServerError(resp)
+		return fmt.Errorf("%w: %q (%s). Another agent may be using the same identity; "+
+			"check that each agent's identity provider returns a unique ID", ErrClusterIDConflict, id, serverErr.Message)
+	}
+	return nil
+}
+
 func (c *ClientConfig) bootstrapJoin() (*BootstrapJoinResponse, *x509.Certificate, error) {
 	url, err := c.bootstrapJoinURL()
 	if err != nil {
@@ -179,7 +245,7 @@ func (c *ClientConfig) bootstrapJoin() (*BootstrapJoinResponse, *x509.Certificat
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != 200 {
-		return nil, nil, fmt.Errorf(resp.Status)
+		return nil, nil, readServerError(resp)
 	}
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
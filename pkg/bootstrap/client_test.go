@@ -7,6 +7,7 @@ import (
 	"crypto/x509"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -28,6 +29,7 @@ import (
 	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
 	"github.com/rancher/opni-monitoring/pkg/ecdh"
 	"github.com/rancher/opni-monitoring/pkg/ident"
+	"github.com/rancher/opni-monitoring/pkg/keyring"
 	"github.com/rancher/opni-monitoring/pkg/pkp"
 	"github.com/rancher/opni-monitoring/pkg/test"
 	"github.com/rancher/opni-monitoring/pkg/tokens"
@@ -69,10 +71,21 @@ var _ = Describe("Client", Ordered, Label(test.Unit, test.Slow), func() {
 				Signatures: map[string][]byte{
 					token.HexID(): data,
 				},
+				ProtocolVersions: bootstrap.SupportedProtocolVersions,
 			})
 			_, err = rw.Write(j)
 			Expect(err).NotTo(HaveOccurred())
 		})
+		checked := false
+		mux.HandleFunc("/bootstrap/check", func(rw http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.Header.Get("Authorization")).NotTo(BeEmpty())
+			req := bootstrap.BootstrapCheckRequest{}
+			Expect(json.NewDecoder(r.Body).Decode(&req)).To(Succeed())
+			Expect(req.ClientID).To(Equal("foo"))
+			checked = true
+			rw.WriteHeader(http.StatusOK)
+		})
 		mux.HandleFunc("/bootstrap/auth", func(rw http.ResponseWriter, r *http.Request) {
 			defer GinkgoRecover()
 			Expect(r.Header.Get("Authorization")).NotTo(BeEmpty())
@@ -85,6 +98,7 @@ var _ = Describe("Client", Ordered, Label(test.Unit, test.Slow), func() {
 			Expect(json.NewDecoder(r.Body).Decode(&req)).To(Succeed())
 			Expect(req.ClientID).To(Equal("foo"))
 			Expect(req.ClientPubKey).To(HaveLen(32))
+			Expect(req.ProtocolVersion).To(Equal(bootstrap.ProtocolV2))
 
 			ekp := ecdh.NewEphemeralKeyPair()
 			rw.WriteHeader(http.StatusOK)
@@ -109,6 +123,132 @@ var _ = Describe("Client", Ordered, Label(test.Unit, test.Slow), func() {
 
 		_, err := cc.Bootstrap(context.Background(), fooIdent)
 		Expect(err).NotTo(HaveOccurred())
+		Expect(checked).To(BeTrue())
+	})
+	When("the server does not advertise a protocol version", func() {
+		It("should bootstrap using the original protocol", func() {
+			mux := http.NewServeMux()
+
+			mux.HandleFunc("/bootstrap/join", func(rw http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				data, err := token.SignDetached(cert.PrivateKey)
+				Expect(err).NotTo(HaveOccurred())
+				rw.WriteHeader(http.StatusOK)
+				j, _ := json.Marshal(bootstrap.BootstrapJoinResponse{
+					Signatures: map[string][]byte{
+						token.HexID(): data,
+					},
+				})
+				_, err = rw.Write(j)
+				Expect(err).NotTo(HaveOccurred())
+			})
+			checked := false
+			mux.HandleFunc("/bootstrap/check", func(rw http.ResponseWriter, r *http.Request) {
+				checked = true
+				rw.WriteHeader(http.StatusOK)
+			})
+			mux.HandleFunc("/bootstrap/auth", func(rw http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				req := bootstrap.BootstrapAuthRequest{}
+				Expect(json.NewDecoder(r.Body).Decode(&req)).To(Succeed())
+				Expect(req.ProtocolVersion).To(Equal(bootstrap.ProtocolV1))
+
+				ekp := ecdh.NewEphemeralKeyPair()
+				rw.WriteHeader(http.StatusOK)
+				resp, _ := json.Marshal(bootstrap.BootstrapAuthResponse{
+					ServerPubKey: ekp.PublicKey,
+				})
+				_, err := rw.Write(resp)
+				Expect(err).NotTo(HaveOccurred())
+			})
+			server := httptest.NewUnstartedServer(mux)
+			server.TLS = &tls.Config{
+				Certificates: []tls.Certificate{*cert},
+			}
+			server.StartTLS()
+			defer server.Close()
+
+			cc := bootstrap.ClientConfig{
+				Token:    token,
+				Pins:     []*pkp.PublicKeyPin{pkp.NewSha256(server.Certificate())},
+				Endpoint: server.URL,
+			}
+
+			_, err := cc.Bootstrap(context.Background(), fooIdent)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checked).To(BeFalse())
+		})
+	})
+	When("the client is configured to use the p256 curve", func() {
+		It("should advertise the curve and derive keys using it", func() {
+			mux := http.NewServeMux()
+
+			mux.HandleFunc("/bootstrap/join", func(rw http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				data, err := token.SignDetached(cert.PrivateKey)
+				Expect(err).NotTo(HaveOccurred())
+				rw.WriteHeader(http.StatusOK)
+				j, _ := json.Marshal(bootstrap.BootstrapJoinResponse{
+					Signatures: map[string][]byte{
+						token.HexID(): data,
+					},
+					ProtocolVersions: bootstrap.SupportedProtocolVersions,
+				})
+				_, err = rw.Write(j)
+				Expect(err).NotTo(HaveOccurred())
+			})
+			mux.HandleFunc("/bootstrap/check", func(rw http.ResponseWriter, r *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+			})
+			serverKeys := make(chan *keyring.SharedKeys, 1)
+			mux.HandleFunc("/bootstrap/auth", func(rw http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				req := bootstrap.BootstrapAuthRequest{}
+				Expect(json.NewDecoder(r.Body).Decode(&req)).To(Succeed())
+				Expect(req.Curve).To(Equal(ecdh.CurveP256))
+				Expect(req.ClientPubKey).To(HaveLen(65))
+
+				ekp, err := ecdh.NewEphemeralKeyPairForCurve(ecdh.CurveP256)
+				Expect(err).NotTo(HaveOccurred())
+				secret, err := ecdh.DeriveSharedSecret(ekp, ecdh.PeerPublicKey{
+					PublicKey: req.ClientPubKey,
+					PeerType:  ecdh.PeerTypeClient,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				serverKeys <- keyring.NewSharedKeys(secret)
+
+				rw.WriteHeader(http.StatusOK)
+				resp, _ := json.Marshal(bootstrap.BootstrapAuthResponse{
+					ServerPubKey: ekp.PublicKey,
+				})
+				_, err = rw.Write(resp)
+				Expect(err).NotTo(HaveOccurred())
+			})
+			server := httptest.NewUnstartedServer(mux)
+			server.TLS = &tls.Config{
+				Certificates: []tls.Certificate{*cert},
+			}
+			server.StartTLS()
+			defer server.Close()
+
+			cc := bootstrap.ClientConfig{
+				Token:    token,
+				Pins:     []*pkp.PublicKeyPin{pkp.NewSha256(server.Certificate())},
+				Endpoint: server.URL,
+				Curve:    ecdh.CurveP256,
+			}
+
+			kr, err := cc.Bootstrap(context.Background(), fooIdent)
+			Expect(err).NotTo(HaveOccurred())
+			var clientKeys *keyring.SharedKeys
+			kr.Try(func(shared *keyring.SharedKeys) {
+				clientKeys = shared
+			})
+			Expect(clientKeys).NotTo(BeNil())
+			expected := <-serverKeys
+			Expect(clientKeys.ClientKey).To(Equal(expected.ClientKey))
+			Expect(clientKeys.ServerKey).To(Equal(expected.ServerKey))
+		})
 	})
 	When("the bootstrap process is complete", func() {
 		It("should erase bootstrap tokens from the config secret", func() {
@@ -369,6 +509,200 @@ var _ = Describe("Client", Ordered, Label(test.Unit, test.Slow), func() {
 				Expect(err).To(MatchError("bootstrap failed: 500 Internal Server Error"))
 			})
 		})
+		When("the server does not support any compatible protocol version", func() {
+			It("should error", func() {
+				mux := http.NewServeMux()
+
+				mux.HandleFunc("/bootstrap/join", func(rw http.ResponseWriter, r *http.Request) {
+					defer GinkgoRecover()
+					data, err := token.SignDetached(cert.PrivateKey)
+					Expect(err).NotTo(HaveOccurred())
+					rw.WriteHeader(http.StatusOK)
+					j, _ := json.Marshal(bootstrap.BootstrapJoinResponse{
+						Signatures: map[string][]byte{
+							token.HexID(): data,
+						},
+						ProtocolVersions: []bootstrap.ProtocolVersion{99},
+					})
+					_, err = rw.Write(j)
+					Expect(err).NotTo(HaveOccurred())
+				})
+				server := httptest.NewUnstartedServer(mux)
+				server.TLS = &tls.Config{
+					Certificates: []tls.Certificate{*cert},
+				}
+				server.StartTLS()
+				defer server.Close()
+
+				cc := bootstrap.ClientConfig{
+					Token:    token,
+					Pins:     []*pkp.PublicKeyPin{pkp.NewSha256(server.Certificate())},
+					Endpoint: server.URL,
+				}
+
+				_, err := cc.Bootstrap(context.Background(), fooIdent)
+				Expect(err).To(MatchError(bootstrap.ErrUnsupportedProtocolVersion))
+			})
+		})
+		When("the cluster ID is already in use", func() {
+			It("should error without sending the auth request", func() {
+				mux := http.NewServeMux()
+
+				mux.HandleFunc("/bootstrap/join", func(rw http.ResponseWriter, r *http.Request) {
+					defer GinkgoRecover()
+					data, err := token.SignDetached(cert.PrivateKey)
+					Expect(err).NotTo(HaveOccurred())
+					rw.WriteHeader(http.StatusOK)
+					j, _ := json.Marshal(bootstrap.BootstrapJoinResponse{
+						Signatures: map[string][]byte{
+							token.HexID(): data,
+						}, ProtocolVersions: bootstrap.SupportedProtocolVersions,
+					})
+					_, err = rw.Write(j)
+					Expect(err).NotTo(HaveOccurred())
+				})
+				mux.HandleFunc("/bootstrap/check", func(rw http.ResponseWriter, r *http.Request) {
+					rw.WriteHeader(http.StatusConflict)
+					rw.Write([]byte("A cluster with this ID already exists"))
+				})
+				authCalled := false
+				mux.HandleFunc("/bootstrap/auth", func(rw http.ResponseWriter, r *http.Request) {
+					authCalled = true
+					rw.WriteHeader(http.StatusInternalServerError)
+				})
+				server := httptest.NewUnstartedServer(mux)
+				server.TLS = &tls.Config{
+					Certificates: []tls.Certificate{*cert},
+				}
+				server.StartTLS()
+				defer server.Close()
+
+				cc := bootstrap.ClientConfig{
+					Token:    token,
+					Pins:     []*pkp.PublicKeyPin{pkp.NewSha256(server.Certificate())},
+					Endpoint: server.URL,
+				}
+
+				_, err := cc.Bootstrap(context.Background(), fooIdent)
+				Expect(err).To(MatchError(bootstrap.ErrClusterIDConflict))
+				Expect(err.Error()).To(ContainSubstring(`"foo"`))
+				Expect(authCalled).To(BeFalse())
+			})
+		})
+		When("the context is canceled during the check request", func() {
+			It("should return the context error without sending the auth request", func() {
+				mux := http.NewServeMux()
+				mux.HandleFunc("/bootstrap/join", func(rw http.ResponseWriter, r *http.Request) {
+					defer GinkgoRecover()
+					data, err := token.SignDetached(cert.PrivateKey)
+					Expect(err).NotTo(HaveOccurred())
+					rw.WriteHeader(http.StatusOK)
+					j, _ := json.Marshal(bootstrap.BootstrapJoinResponse{
+						Signatures: map[string][]byte{
+							token.HexID(): data,
+						}, ProtocolVersions: bootstrap.SupportedProtocolVersions,
+					})
+					_, err = rw.Write(j)
+					Expect(err).NotTo(HaveOccurred())
+				})
+				ctx, ca := context.WithCancel(context.Background())
+				defer ca()
+				mux.HandleFunc("/bootstrap/check", func(rw http.ResponseWriter, r *http.Request) {
+					// the request context is only canceled when the client
+					// disconnects if the body has been read
+					io.Copy(io.Discard, r.Body)
+					ca()
+					<-r.Context().Done()
+				})
+				authCalled := false
+				mux.HandleFunc("/bootstrap/auth", func(rw http.ResponseWriter, r *http.Request) {
+					authCalled = true
+					rw.WriteHeader(http.StatusInternalServerError)
+				})
+				server := httptest.NewUnstartedServer(mux)
+				server.TLS = &tls.Config{
+					Certificates: []tls.Certificate{*cert},
+				}
+				server.StartTLS()
+				defer server.Close()
+
+				cc := bootstrap.ClientConfig{
+					Token:    token,
+					Pins:     []*pkp.PublicKeyPin{pkp.NewSha256(server.Certificate())},
+					Endpoint: server.URL,
+				}
+
+				_, err := cc.Bootstrap(ctx, fooIdent)
+				Expect(err).To(MatchError(context.Canceled))
+				Expect(authCalled).To(BeFalse())
+			})
+		})
+		When("the server rejects the auth request", func() {
+			bootstrapWithAuthResponse := func(contentType string, status int, body []byte) error {
+				mux := http.NewServeMux()
+				mux.HandleFunc("/bootstrap/join", func(rw http.ResponseWriter, r *http.Request) {
+					defer GinkgoRecover()
+					data, err := token.SignDetached(cert.PrivateKey)
+					Expect(err).NotTo(HaveOccurred())
+					j, _ := json.Marshal(bootstrap.BootstrapJoinResponse{
+						Signatures: map[string][]byte{
+							token.HexID(): data,
+						},
+					})
+					rw.WriteHeader(http.StatusOK)
+					_, err = rw.Write(j)
+					Expect(err).NotTo(HaveOccurred())
+				})
+				mux.HandleFunc("/bootstrap/auth", func(rw http.ResponseWriter, r *http.Request) {
+					rw.Header().Set("Content-Type", contentType)
+					rw.WriteHeader(status)
+					rw.Write(body)
+				})
+				server := httptest.NewUnstartedServer(mux)
+				server.TLS = &tls.Config{
+					Certificates: []tls.Certificate{*cert},
+				}
+				server.StartTLS()
+				defer server.Close()
+
+				cc := bootstrap.ClientConfig{
+					Token:    token,
+					Pins:     []*pkp.PublicKeyPin{pkp.NewSha256(server.Certificate())},
+					Endpoint: server.URL,
+				}
+				_, err := cc.Bootstrap(context.Background(), fooIdent)
+				return err
+			}
+			It("should return the error code and message sent by the server", func() {
+				body, _ := json.Marshal(bootstrap.ErrorResponse{
+					Code:    bootstrap.ErrorCodeTokenExpired,
+					Message: "token expired",
+				})
+				err := bootstrapWithAuthResponse("application/json", http.StatusUnauthorized, body)
+				Expect(err).To(MatchError(bootstrap.ErrBootstrapFailed))
+				var serverErr *bootstrap.ServerError
+				Expect(errors.As(err, &serverErr)).To(BeTrue())
+				Expect(serverErr.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(serverErr.Code).To(Equal(bootstrap.ErrorCodeTokenExpired))
+				Expect(serverErr.Message).To(Equal("token expired"))
+				Expect(err.Error()).To(Equal("bootstrap failed: 401 Unauthorized (token_expired): token expired"))
+			})
+			It("should handle plain text errors from older servers", func() {
+				err := bootstrapWithAuthResponse("text/plain", http.StatusConflict,
+					[]byte("Capability is already installed on this cluster"))
+				Expect(err).To(MatchError(bootstrap.ErrBootstrapFailed))
+				var serverErr *bootstrap.ServerError
+				Expect(errors.As(err, &serverErr)).To(BeTrue())
+				Expect(serverErr.StatusCode).To(Equal(http.StatusConflict))
+				Expect(serverErr.Code).To(BeEmpty())
+				Expect(serverErr.Message).To(Equal("Capability is already installed on this cluster"))
+			})
+			It("should handle empty error responses", func() {
+				err := bootstrapWithAuthResponse("text/plain", http.StatusInternalServerError, nil)
+				Expect(err).To(MatchError(bootstrap.ErrBootstrapFailed))
+				Expect(err.Error()).To(Equal("bootstrap failed: 500 Internal Server Error"))
+			})
+		})
 		When("the server sends invalid response data", func() {
 			It("should error", func() {
 				mux := http.NewServeMux()
@@ -0,0 +1,31 @@
+package bootstrap
+
+import (
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/labels"
+)
+
+// NewCluster returns the cluster which is created when an agent with the
+// given ID bootstraps using the token and requests the given capability.
+// If computed labels cannot be evaluated, the error is returned along with
+// the cluster, which will contain only the token's labels.
+func NewCluster(
+	id string,
+	capability string,
+	token *core.BootstrapToken,
+	templates *labels.Templates,
+) (*core.Cluster, error) {
+	cluster := &core.Cluster{
+		Id: id,
+		Metadata: &core.ClusterMetadata{
+			Labels:              token.GetMetadata().GetLabels(),
+			AllowedCapabilities: token.GetAllowedCapabilities(),
+			Capabilities: []*core.ClusterCapability{
+				{
+					Name: capability,
+				},
+			},
+		},
+	}
+	return cluster, templates.Apply(cluster)
+}
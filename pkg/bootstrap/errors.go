@@ -0,0 +1,127 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ErrorCode is a stable, machine-readable identifier for the reason a
+// bootstrap request failed. Unlike the HTTP status code, which is shared by
+// several failure modes (for example, an expired token and an unknown token
+// both result in a 401), each error code identifies a single failure mode.
+type ErrorCode string
+
+const (
+	// The request body or headers are malformed or fail validation.
+	ErrorCodeInvalidRequest ErrorCode = "invalid_request"
+	// Bootstrapping is disabled on the server, and it will not accept
+	// bootstrap requests until it is reconfigured. Sent with a 503.
+	ErrorCodeBootstrapDisabled ErrorCode = "bootstrap_disabled"
+	// Bootstrapping is enabled, but the server currently has no bootstrap
+	// tokens. Agents can retry once a token has been created. Sent with a
+	// 405.
+	ErrorCodeNoActiveTokens ErrorCode = "no_active_tokens"
+	// The token is missing, was not signed by the server, or does not exist.
+	ErrorCodeInvalidToken ErrorCode = "invalid_token"
+	// The token's validity period has ended.
+	ErrorCodeTokenExpired ErrorCode = "token_expired"
+	// The token's validity period has not started yet.
+	ErrorCodeTokenNotYetValid ErrorCode = "token_not_yet_valid"
+	// The token has been used the maximum number of times.
+	ErrorCodeTokenUsageLimitReached ErrorCode = "token_usage_limit_reached"
+	// The requested capability is not allowed by the token or the cluster.
+	ErrorCodeCapabilityNotAllowed ErrorCode = "capability_not_allowed"
+	// The token has a shared identity which does not match the client's ID.
+	ErrorCodeSharedIdentityMismatch ErrorCode = "shared_identity_mismatch"
+	// The cluster with the requested ID already has the requested capability.
+	ErrorCodeCapabilityAlreadyInstalled ErrorCode = "capability_already_installed"
+	// A cluster with the requested ID already exists, and the token does not
+	// have permission to join it.
+	ErrorCodeClusterIDConflict ErrorCode = "cluster_id_conflict"
+	// The requested capability is not known to the server.
+	ErrorCodeUnknownCapability ErrorCode = "unknown_capability"
+	// The requested capability is known, but cannot currently be installed.
+	ErrorCodeCapabilityUnavailable ErrorCode = "capability_unavailable"
+	// The client requested an ECDH curve which the server does not use.
+	ErrorCodeUnsupportedCurve ErrorCode = "unsupported_curve"
+	// The server requires bootstrap requests to be sent over TLS, and the
+	// request was received over a plaintext connection.
+	ErrorCodeTLSRequired ErrorCode = "tls_required"
+	// The client has sent too many auth requests, and should try again later.
+	ErrorCodeRateLimited ErrorCode = "rate_limited"
+	// An unexpected error occurred on the server.
+	ErrorCodeInternal ErrorCode = "internal_error"
+)
+
+// ErrorResponse is the JSON body sent by the bootstrap server when a
+// request fails.
+type ErrorResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+// ServerError is an error response from the bootstrap server. Errors
+// returned by the bootstrap client for unsuccessful responses can be
+// retrieved using errors.As, and wrap ErrBootstrapFailed.
+//
+// Servers which predate error responses only send a status code and an
+// optional plain text message. In that case, Code is empty.
+type ServerError struct {
+	StatusCode int
+	ErrorResponse
+}
+
+func newServerError(statusCode int, code ErrorCode, message string) *ServerError {
+	return &ServerError{
+		StatusCode: statusCode,
+		ErrorResponse: ErrorResponse{
+			Code:    code,
+			Message: message,
+		},
+	}
+}
+
+func (e *ServerError) Error() string {
+	msg := fmt.Sprintf("%v: %d %s", ErrBootstrapFailed, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Code != "" {
+		msg += fmt.Sprintf(" (%s)", e.Code)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+func (e *ServerError) Unwrap() error {
+	return ErrBootstrapFailed
+}
+
+// sendError writes the error response to the client.
+func sendError(c *fiber.Ctx, err *ServerError) error {
+	c.Locals(localsServerError, err)
+	return c.Status(err.StatusCode).JSON(err.ErrorResponse)
+}
+
+// readServerError reads an unsuccessful response from the bootstrap server.
+func readServerError(resp *http.Response) *ServerError {
+	serverErr := &ServerError{
+		StatusCode: resp.StatusCode,
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil || len(body) == 0 {
+		return serverErr
+	}
+	if json.Unmarshal(body, &serverErr.ErrorResponse) == nil && serverErr.Code != "" {
+		return serverErr
+	}
+	// older servers send a plain text message, if any
+	serverErr.ErrorResponse = ErrorResponse{
+		Message: strings.TrimSpace(string(body)),
+	}
+	return serverErr
+}
@@ -0,0 +1,9 @@
+package bootstrap
+
+import "time"
+
+func SetSweepInterval(l *AuthRateLimiter, interval time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweepInterval = interval
+}
@@ -0,0 +1,79 @@
+package bootstrap
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+)
+
+// JoinResponseCache caches the signed bootstrap join response, so that
+// tokens are not re-signed each time an agent fetches the join data. The
+// cached response is keyed by a fingerprint of the token set and the signing
+// certificate, which is also served as the response's ETag. Creating or
+// revoking a token (or a token expiring) changes the fingerprint, which
+// invalidates the cached response.
+type JoinResponseCache struct {
+	maxAge time.Duration
+
+	mu   sync.Mutex
+	etag string
+	body []byte
+	// whether the cached response contains no signatures
+	empty bool
+}
+
+// NewJoinResponseCache creates a new join response cache. Join responses are
+// served with a Cache-Control max-age of maxAge, which should be short, since
+// agents and intermediaries may continue to use a cached response for this
+// long after a token has been created or revoked.
+func NewJoinResponseCache(maxAge time.Duration) *JoinResponseCache {
+	return &JoinResponseCache{
+		maxAge: maxAge,
+	}
+}
+
+// get returns the encoded join response and its ETag, signing the current
+// token set only if it has changed since the last call.
+func (jc *JoinResponseCache) get(
+	ctx context.Context,
+	h ServerConfig,
+) (body []byte, etag string, empty bool, err error) {
+	tokenList, err := h.TokenStore.ListTokens(ctx)
+	if err != nil {
+		return nil, "", false, err
+	}
+	ids := make([]string, 0, len(tokenList))
+	for _, token := range tokenList {
+		ids = append(ids, token.GetTokenID())
+	}
+	sort.Strings(ids)
+	hash := sha256.New()
+	if cert := h.primaryCertificate(); len(cert.Certificate) > 0 {
+		hash.Write(cert.Certificate[0])
+	}
+	for _, id := range ids {
+		hash.Write([]byte(id))
+		hash.Write([]byte{0})
+	}
+	etag = `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+
+	jc.mu.Lock()
+	defer jc.mu.Unlock()
+	if jc.etag == etag {
+		return jc.body, jc.etag, jc.empty, nil
+	}
+	resp, err := h.signJoinResponse(tokenList)
+	if err != nil {
+		return nil, "", false, err
+	}
+	body, err = json.Marshal(resp)
+	if err != nil {
+		return nil, "", false, err
+	}
+	jc.etag, jc.body, jc.empty = etag, body, len(resp.Signatures) == 0
+	return jc.body, jc.etag, jc.empty, nil
+}
@@ -0,0 +1,88 @@
+package bootstrap
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// DefaultRateLimiterSweepInterval is the minimum time between sweeps of
+// idle entries from an AuthRateLimiter.
+const DefaultRateLimiterSweepInterval = time.Minute
+
+// AuthRateLimiter limits the rate of bootstrap auth and check requests from
+// each remote IP, using a token bucket per IP. Buckets which have been idle
+// long enough to refill completely are evicted periodically, since they
+// behave the same as a new bucket. Sweeps are performed by Allow, so the limiter
+// does not need to be stopped.
+type AuthRateLimiter struct {
+	limit rate.Limit
+	burst int
+	// how long it takes an empty bucket to refill completely
+	refill        time.Duration
+	sweepInterval time.Duration
+
+	mu        sync.Mutex
+	buckets   map[string]*ipBucket
+	lastSweep time.Time
+}
+
+type ipBucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewAuthRateLimiter creates a new AuthRateLimiter which allows each remote
+// IP to send requestsPerSecond auth requests on average, and up to burst
+// requests at once. requestsPerSecond must be positive. Non-positive burst
+// values are treated as 1.
+func NewAuthRateLimiter(requestsPerSecond float64, burst int) *AuthRateLimiter {
+	if burst <= 0 {
+		burst = 1
+	}
+	return &AuthRateLimiter{
+		limit:         rate.Limit(requestsPerSecond),
+		burst:         burst,
+		refill:        time.Duration(float64(burst) / requestsPerSecond * float64(time.Second)),
+		sweepInterval: DefaultRateLimiterSweepInterval,
+		buckets:       map[string]*ipBucket{},
+		lastSweep:     time.Now(),
+	}
+}
+
+// Allow reports whether a request from the given IP can be handled now.
+func (l *AuthRateLimiter) Allow(ip string) bool {
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if now.Sub(l.lastSweep) >= l.sweepInterval {
+		l.sweep(now)
+	}
+	b, ok := l.buckets[ip]
+	if !ok {
+		b = &ipBucket{
+			limiter: rate.NewLimiter(l.limit, l.burst),
+		}
+		l.buckets[ip] = b
+	}
+	b.lastSeen = now
+	return b.limiter.AllowN(now, 1)
+}
+
+// sweep evicts buckets which have refilled completely.
+func (l *AuthRateLimiter) sweep(now time.Time) {
+	for ip, b := range l.buckets {
+		if now.Sub(b.lastSeen) >= l.refill {
+			delete(l.buckets, ip)
+		}
+	}
+	l.lastSweep = now
+}
+
+// Len returns the number of remote IPs currently tracked by the limiter.
+func (l *AuthRateLimiter) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
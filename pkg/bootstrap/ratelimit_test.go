@@ -0,0 +1,62 @@
+package bootstrap_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/bootstrap"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Auth Rate Limiter", Label(test.Unit), func() {
+	It("should reject requests beyond the burst", func() {
+		l := bootstrap.NewAuthRateLimiter(1, 3)
+		for i := 0; i < 3; i++ {
+			Expect(l.Allow("10.0.0.1")).To(BeTrue())
+		}
+		Expect(l.Allow("10.0.0.1")).To(BeFalse())
+	})
+	It("should limit each IP separately", func() {
+		l := bootstrap.NewAuthRateLimiter(1, 1)
+		Expect(l.Allow("10.0.0.1")).To(BeTrue())
+		Expect(l.Allow("10.0.0.1")).To(BeFalse())
+		Expect(l.Allow("10.0.0.2")).To(BeTrue())
+	})
+	It("should refill the bucket over time", func() {
+		l := bootstrap.NewAuthRateLimiter(20, 1)
+		Expect(l.Allow("10.0.0.1")).To(BeTrue())
+		Expect(l.Allow("10.0.0.1")).To(BeFalse())
+		Eventually(func() bool {
+			return l.Allow("10.0.0.1")
+		}, time.Second, 10*time.Millisecond).Should(BeTrue())
+	})
+	It("should treat a non-positive burst as 1", func() {
+		l := bootstrap.NewAuthRateLimiter(1, 0)
+		Expect(l.Allow("10.0.0.1")).To(BeTrue())
+		Expect(l.Allow("10.0.0.1")).To(BeFalse())
+	})
+	It("should evict idle entries once their bucket has refilled", func() {
+		l := bootstrap.NewAuthRateLimiter(20, 1)
+		bootstrap.SetSweepInterval(l, 10*time.Millisecond)
+		Expect(l.Allow("10.0.0.1")).To(BeTrue())
+		Expect(l.Allow("10.0.0.2")).To(BeTrue())
+		Expect(l.Len()).To(Equal(2))
+
+		time.Sleep(100 * time.Millisecond)
+		// the sweep is triggered by the next request
+		Expect(l.Allow("10.0.0.3")).To(BeTrue())
+		Expect(l.Len()).To(Equal(1))
+	})
+	It("should not evict entries which have not refilled", func() {
+		l := bootstrap.NewAuthRateLimiter(0.1, 1)
+		bootstrap.SetSweepInterval(l, 10*time.Millisecond)
+		Expect(l.Allow("10.0.0.1")).To(BeTrue())
+
+		time.Sleep(50 * time.Millisecond)
+		Expect(l.Allow("10.0.0.2")).To(BeTrue())
+		Expect(l.Len()).To(Equal(2))
+		Expect(l.Allow("10.0.0.1")).To(BeFalse())
+	})
+})
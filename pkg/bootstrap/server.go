@@ -6,7 +6,9 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/lestrrat-go/jwx/jwa"
@@ -15,86 +17,290 @@ import (
 	"github.com/rancher/opni-monitoring/pkg/core"
 	"github.com/rancher/opni-monitoring/pkg/ecdh"
 	"github.com/rancher/opni-monitoring/pkg/keyring"
+	"github.com/rancher/opni-monitoring/pkg/labels"
 	"github.com/rancher/opni-monitoring/pkg/storage"
 	"github.com/rancher/opni-monitoring/pkg/tokens"
 	"github.com/rancher/opni-monitoring/pkg/validation"
 )
 
+// DefaultMaxClockSkew is the clock skew allowed when checking a token's
+// validity period, if ServerConfig.MaxClockSkew is not set.
+const DefaultMaxClockSkew = 30 * time.Second
+
 type ServerConfig struct {
-	Certificate         *tls.Certificate
+	// The certificate whose private key is used to sign join responses and
+	// verify auth requests. Ignored if Certificates is set.
+	Certificate *tls.Certificate
+	// Certificates whose private keys can be used to verify auth requests.
+	// The first certificate is the primary certificate, which should be the
+	// gateway's current serving certificate, and is used to sign join
+	// responses. Agents which fetched a join response signed by one of the
+	// other certificates can still complete bootstrapping, for example when
+	// the serving certificate is rotated between the join and auth requests.
+	Certificates []*tls.Certificate
+	// The store containing bootstrap tokens. If nil, bootstrapping is
+	// disabled, and all bootstrap requests are rejected with a 503 and the
+	// bootstrap_disabled error code. This is distinct from the server having
+	// no tokens, in which case join requests are rejected with a 405 and the
+	// no_active_tokens error code.
 	TokenStore          storage.TokenStore
 	ClusterStore        storage.ClusterStore
 	KeyringStoreBroker  storage.KeyringStoreBroker
 	CapabilityInstaller capabilities.Installer
+	// The maximum allowed clock skew when checking a token's validity period.
+	// If unset, DefaultMaxClockSkew is used. A negative value disables
+	// clock skew tolerance.
+	MaxClockSkew time.Duration
+	// Templates for computed labels, which are applied to newly registered
+	// clusters. Computed labels replace any labels of the same name set by
+	// the bootstrap token.
+	LabelTemplates *labels.Templates
+	// If set, join responses are cached and served with an ETag and a
+	// Cache-Control max-age, so that agents and intermediaries can cache
+	// them. Requests with a matching If-None-Match header receive a 304.
+	JoinResponseCache *JoinResponseCache
+	// The elliptic curve used for the ECDH key exchange. Clients must
+	// request the same curve, or their auth requests are rejected. If unset,
+	// ecdh.DefaultCurve (X25519) is used. Set to ecdh.CurveP256 in
+	// environments which require FIPS-approved curves.
+	Curve ecdh.Curve
+	// If set, an audit event is recorded for every request to the join, auth,
+	// and check endpoints, describing its outcome.
+	AuditLogger AuditLogger
+	// If true, bootstrap requests which were not received over a TLS
+	// connection are rejected with a 403 and the tls_required error code.
+	// This applies to join requests as well: although the join response
+	// contains no secrets, agents rely on it being served over TLS so that
+	// they can verify the server's certificate against their pins before
+	// sending a token. TLS must be terminated by the server itself; requests
+	// forwarded by a TLS-terminating proxy over plaintext are rejected.
+	RequireTLSForBootstrap bool
+	// If set, auth and check requests are rate limited per remote IP, to
+	// slow down attempts to guess bootstrap tokens. Both share the same
+	// limit. Requests over the limit are rejected with a 429 before the
+	// token is verified.
+	AuthRateLimiter *AuthRateLimiter
+	// If set, the IDs requested by clients in auth and check requests must
+	// match this pattern, or the request is rejected with a 400 before any
+	// stores are accessed. Client IDs must always be valid resource IDs (see
+	// validation.ValidateID); this pattern can be used to restrict them
+	// further, for example to ClientIDPatternUUID.
+	ClientIDPattern *regexp.Regexp
+}
+
+// ClientIDPatternUUID matches client IDs which are UUIDs, such as the IDs
+// obtained from the kubernetes identity provider.
+var ClientIDPatternUUID = regexp.MustCompile(
+	`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// validateClientID checks that the client ID requested by a client matches
+// the configured client ID pattern, if any.
+func (h ServerConfig) validateClientID(id string) *ServerError {
+	if h.ClientIDPattern != nil && !h.ClientIDPattern.MatchString(id) {
+		return newServerError(fiber.StatusBadRequest, ErrorCodeInvalidRequest,
+			fmt.Sprintf("%v: client_id does not match the required pattern %s",
+				validation.ErrInvalidID, h.ClientIDPattern))
+	}
+	return nil
+}
+
+func (h ServerConfig) maxClockSkew() time.Duration {
+	switch {
+	case h.MaxClockSkew == 0:
+		return DefaultMaxClockSkew
+	case h.MaxClockSkew < 0:
+		return 0
+	default:
+		return h.MaxClockSkew
+	}
+}
+
+// tokenIsValidAt checks the token's validity period against the given time,
+// allowing for the configured clock skew in either direction.
+func (h ServerConfig) tokenIsValidAt(token *core.BootstrapToken, t time.Time) bool {
+	skew := h.maxClockSkew()
+	md := token.GetMetadata()
+	if nb := md.GetNotBefore(); nb != 0 && t.Add(skew).Before(time.Unix(nb, 0)) {
+		return false
+	}
+	return !h.tokenIsExpiredAt(token, t)
+}
+
+// tokenIsExpiredAt reports whether the token's validity period ended before
+// the given time, allowing for the configured clock skew. Tokens without an
+// expiration never expire.
+func (h ServerConfig) tokenIsExpiredAt(token *core.BootstrapToken, t time.Time) bool {
+	na := token.GetMetadata().GetNotAfter()
+	return na != 0 && t.Add(-h.maxClockSkew()).After(time.Unix(na, 0))
+}
+
+// signingCertificates returns the certificates which can be used to verify
+// auth requests, starting with the primary certificate.
+func (h ServerConfig) signingCertificates() []*tls.Certificate {
+	if len(h.Certificates) > 0 {
+		return h.Certificates
+	}
+	return []*tls.Certificate{h.Certificate}
+}
+
+// primaryCertificate returns the certificate used to sign join responses.
+func (h ServerConfig) primaryCertificate() *tls.Certificate {
+	return h.signingCertificates()[0]
 }
 
 func (h ServerConfig) bootstrapJoinResponse(
 	ctx context.Context,
 ) (BootstrapJoinResponse, error) {
-	signatures := map[string][]byte{}
 	tokenList, err := h.TokenStore.ListTokens(ctx)
 	if err != nil {
 		return BootstrapJoinResponse{}, err
 	}
+	return h.signJoinResponse(tokenList)
+}
+
+func (h ServerConfig) signJoinResponse(
+	tokenList []*core.BootstrapToken,
+) (BootstrapJoinResponse, error) {
+	signatures := map[string][]byte{}
+	privKey := h.primaryCertificate().PrivateKey
 	for _, token := range tokenList {
 		// Generate a JWS containing the signature of the detached secret token
 		rawToken, err := tokens.FromBootstrapToken(token)
 		if err != nil {
 			return BootstrapJoinResponse{}, err
 		}
-		sig, err := rawToken.SignDetached(h.Certificate.PrivateKey)
+		sig, err := rawToken.SignDetached(privKey)
 		if err != nil {
 			return BootstrapJoinResponse{}, fmt.Errorf("error signing token: %w", err)
 		}
 		signatures[rawToken.HexID()] = sig
 	}
 	return BootstrapJoinResponse{
-		Signatures: signatures,
+		Signatures:       signatures,
+		ProtocolVersions: SupportedProtocolVersions,
 	}, nil
 }
 
+type route struct {
+	methods []string
+	action  BootstrapAction
+	handler func(ServerConfig, *fiber.Ctx) error
+}
+
+var routes = map[string]route{
+	"/bootstrap/join": {
+		methods: []string{fiber.MethodGet, fiber.MethodPost},
+		action:  BootstrapActionJoin,
+		handler: ServerConfig.handleBootstrapJoin,
+	},
+	"/bootstrap/auth": {
+		methods: []string{fiber.MethodPost},
+		action:  BootstrapActionAuth,
+		handler: ServerConfig.handleBootstrapAuth,
+	},
+	"/bootstrap/check": {
+		methods: []string{fiber.MethodPost},
+		action:  BootstrapActionCheck,
+		handler: ServerConfig.handleBootstrapCheck,
+	},
+}
+
+// Handle serves all bootstrap routes. It should be mounted for all methods
+// (e.g. using app.All), since method validation is handled here. Requests
+// to unknown paths return 404, and requests to known paths using a method
+// the path does not support return 405.
 func (h ServerConfig) Handle(c *fiber.Ctx) error {
-	switch c.Path() {
-	case "/bootstrap/join":
-		return h.handleBootstrapJoin(c)
-	case "/bootstrap/auth":
-		return h.handleBootstrapAuth(c)
-	default:
+	r, ok := routes[c.Path()]
+	if !ok {
 		return c.SendStatus(fiber.StatusNotFound)
 	}
+	for _, method := range r.methods {
+		if c.Method() == method {
+			var err error
+			switch {
+			case h.TokenStore == nil:
+				err = sendError(c, errBootstrapDisabled)
+			case h.RequireTLSForBootstrap && !c.Context().IsTLS():
+				err = sendError(c, errTLSRequired)
+			default:
+				err = r.handler(h, c)
+			}
+			h.recordBootstrap(c, r.action)
+			return err
+		}
+	}
+	c.Set(fiber.HeaderAllow, strings.Join(r.methods, ", "))
+	return c.SendStatus(fiber.StatusMethodNotAllowed)
 }
 
 func (h ServerConfig) handleBootstrapJoin(c *fiber.Ctx) error {
 	authHeader := strings.TrimSpace(c.Get("Authorization"))
 	if authHeader == "" {
+		if h.JoinResponseCache != nil {
+			return h.handleCachedBootstrapJoin(c)
+		}
 		if resp, err := h.bootstrapJoinResponse(context.Background()); err != nil {
-			return c.SendStatus(fiber.StatusInternalServerError)
+			return sendError(c, errInternal)
 		} else {
 			if len(resp.Signatures) == 0 {
-				// No tokens - server is not accepting bootstrap requests
-				return c.SendStatus(fiber.StatusMethodNotAllowed)
+				// No active tokens - bootstrap is enabled, but no agents can join
+				// until a token is created
+				return sendError(c, errNoActiveTokens)
 			}
 			return c.Status(fiber.StatusOK).JSON(resp)
 		}
 	} else {
-		return c.SendStatus(fiber.StatusBadRequest)
+		return sendError(c, newServerError(fiber.StatusBadRequest, ErrorCodeInvalidRequest,
+			"join requests must not include an Authorization header"))
 	}
 }
 
-func (h ServerConfig) handleBootstrapAuth(c *fiber.Ctx) error {
+func (h ServerConfig) handleCachedBootstrapJoin(c *fiber.Ctx) error {
+	body, etag, empty, err := h.JoinResponseCache.get(context.Background(), h)
+	if err != nil {
+		return sendError(c, errInternal)
+	}
+	if empty {
+		// No active tokens - bootstrap is enabled, but no agents can join
+		// until a token is created
+		return sendError(c, errNoActiveTokens)
+	}
+	c.Set(fiber.HeaderETag, etag)
+	c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d",
+		int(h.JoinResponseCache.maxAge.Seconds())))
+	if c.Get(fiber.HeaderIfNoneMatch) == etag {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Status(fiber.StatusOK).Send(body)
+}
+
+// verifyBootstrapToken checks the signed token in the request's
+// Authorization header, and returns the corresponding stored token if it is
+// valid. If the token is not valid, the error response to send is returned.
+// Expired tokens are deleted from the token store.
+func (h ServerConfig) verifyBootstrapToken(c *fiber.Ctx) (*core.BootstrapToken, *ServerError) {
 	lg := c.Context().Logger()
 	authHeader := strings.TrimSpace(c.Get("Authorization"))
 	if strings.TrimSpace(authHeader) == "" {
-		return c.SendStatus(fiber.StatusUnauthorized)
+		return nil, errInvalidToken
 	}
 	// Authorization is given, check the authToken
 	// Remove "Bearer " from the header
 	bearerToken := strings.TrimSpace(strings.TrimPrefix(authHeader, "Bearer"))
-	// Verify the token
-	privKey := h.Certificate.PrivateKey.(crypto.Signer)
-	payload, err := jws.Verify([]byte(bearerToken), jwa.EdDSA, privKey.Public())
+	// Verify the token. It may have been signed using any of the signing
+	// certificates, if the primary certificate changed after the client
+	// fetched the join response.
+	var payload []byte
+	var err error
+	for _, cert := range h.signingCertificates() {
+		privKey := cert.PrivateKey.(crypto.Signer)
+		if payload, err = jws.Verify([]byte(bearerToken), jwa.EdDSA, privKey.Public()); err == nil {
+			break
+		}
+	}
 	if err != nil {
-		return c.SendStatus(fiber.StatusUnauthorized)
+		return nil, errInvalidToken
 	}
 
 	// The payload should contain the entire token encoded as JSON
@@ -102,61 +308,226 @@ func (h ServerConfig) handleBootstrapAuth(c *fiber.Ctx) error {
 	if err != nil {
 		panic("bug: jws.Verify returned a malformed token")
 	}
+	c.Locals(localsTokenIDHash, hashTokenID(token.HexID()))
 	bootstrapToken, err := h.TokenStore.GetToken(context.Background(), token.Reference())
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
-			return c.SendStatus(fiber.StatusUnauthorized)
+			return nil, errInvalidToken
+		}
+		lg.Printf("error checking if token exists: %v", err)
+		return nil, errInternal
+	}
+	now := time.Now()
+	if h.tokenIsExpiredAt(bootstrapToken, now) {
+		if err := h.TokenStore.DeleteToken(context.Background(), bootstrapToken.Reference()); err != nil &&
+			!errors.Is(err, storage.ErrNotFound) {
+			lg.Printf("error deleting expired token: %v", err)
 		}
-		lg.Printf("error checking if token exists: %v")
-		return c.SendStatus(fiber.StatusInternalServerError)
+		return nil, newServerError(fiber.StatusUnauthorized, ErrorCodeTokenExpired, "token expired")
+	}
+	if !h.tokenIsValidAt(bootstrapToken, now) {
+		return nil, newServerError(fiber.StatusUnauthorized, ErrorCodeTokenNotYetValid, "token is not yet valid")
+	}
+	if tokenUsagesExhausted(bootstrapToken) {
+		return nil, errTokenUsagesExhausted
+	}
+	return bootstrapToken, nil
+}
+
+var (
+	errInternal = newServerError(fiber.StatusInternalServerError,
+		ErrorCodeInternal, "internal server error")
+	errBootstrapDisabled = newServerError(fiber.StatusServiceUnavailable,
+		ErrorCodeBootstrapDisabled, "bootstrap is disabled on this server")
+	errNoActiveTokens = newServerError(fiber.StatusMethodNotAllowed,
+		ErrorCodeNoActiveTokens, "the server has no active bootstrap tokens")
+	errInvalidToken = newServerError(fiber.StatusUnauthorized,
+		ErrorCodeInvalidToken, "invalid bootstrap token")
+	errInvalidRequestBody = newServerError(fiber.StatusBadRequest,
+		ErrorCodeInvalidRequest, "Invalid request body")
+	errTokenUsagesExhausted = newServerError(fiber.StatusUnauthorized,
+		ErrorCodeTokenUsageLimitReached, "token usage limit reached")
+	errTLSRequired = newServerError(fiber.StatusForbidden,
+		ErrorCodeTLSRequired, "bootstrap requests must be sent over TLS")
+	errRateLimited = newServerError(fiber.StatusTooManyRequests,
+		ErrorCodeRateLimited, "too many bootstrap requests, try again later")
+)
+
+func curveMismatchError(requested, supported ecdh.Curve) *ServerError {
+	return newServerError(fiber.StatusBadRequest, ErrorCodeUnsupportedCurve,
+		fmt.Sprintf("Requested curve %s does not match the server's curve %s",
+			requested.OrDefault(), supported.OrDefault()))
+}
+
+func tokenUsagesExhausted(token *core.BootstrapToken) bool {
+	md := token.GetMetadata()
+	return md.GetMaxUsages() > 0 && md.GetUsageCount() >= md.GetMaxUsages()
+}
+
+// reserveTokenUsage atomically consumes one of the token's remaining usages.
+// If the token has no usages remaining, ok is false.
+func (h ServerConfig) reserveTokenUsage(token *core.BootstrapToken) (updated *core.BootstrapToken, ok bool, err error) {
+	var exhausted bool
+	updated, err = h.TokenStore.UpdateToken(context.Background(), token.Reference(),
+		storage.NewReserveUsageMutator(&exhausted))
+	if err != nil {
+		return nil, false, err
+	}
+	return updated, !exhausted, nil
+}
+
+// handleBootstrapCheck reports whether a client could bootstrap using the
+// requested ID, without modifying any state. If a cluster with the requested
+// ID already exists and the client would not be able to join it, the
+// response status is 409 (Conflict).
+func (h ServerConfig) handleBootstrapCheck(c *fiber.Ctx) error {
+	lg := c.Context().Logger()
+	if h.AuthRateLimiter != nil && !h.AuthRateLimiter.Allow(c.IP()) {
+		return sendError(c, errRateLimited)
+	}
+	bootstrapToken, verifyErr := h.verifyBootstrapToken(c)
+	if verifyErr != nil {
+		return sendError(c, verifyErr)
+	}
+	clientReq := BootstrapCheckRequest{}
+	if err := c.BodyParser(&clientReq); err != nil {
+		return sendError(c, errInvalidRequestBody)
+	}
+	c.Locals(localsClientID, clientReq.ClientID)
+	if err := validation.Validate(clientReq); err != nil {
+		return sendError(c, newServerError(fiber.StatusBadRequest, ErrorCodeInvalidRequest, err.Error()))
+	}
+	if err := h.validateClientID(clientReq.ClientID); err != nil {
+		return sendError(c, err)
+	}
+	if !capabilities.Allows(bootstrapToken, clientReq.Capability) {
+		return sendError(c, tokenCapabilityNotAllowedError(clientReq.Capability))
+	}
+	if shared := capabilities.SharedIdentityOf(bootstrapToken); shared != nil {
+		if shared.Id != clientReq.ClientID {
+			return sendError(c, sharedIdentityMismatchError(shared))
+		}
+		// agents using a shared identity token never conflict
+		return c.SendStatus(fiber.StatusOK)
+	}
+	existing := &core.Reference{
+		Id: clientReq.ClientID,
+	}
+	cluster, err := h.ClusterStore.GetCluster(context.Background(), existing)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return c.SendStatus(fiber.StatusOK)
+		}
+		lg.Printf("error checking if cluster exists: %v", err)
+		return sendError(c, errInternal)
+	}
+	if capabilities.Has(cluster, capabilities.Cluster(clientReq.Capability)) {
+		return sendError(c, newServerError(fiber.StatusConflict, ErrorCodeCapabilityAlreadyInstalled,
+			"A cluster with this ID already has the requested capability installed"))
+	}
+	if !capabilities.Has(bootstrapToken, capabilities.JoinExistingCluster.For(existing)) {
+		return sendError(c, newServerError(fiber.StatusConflict, ErrorCodeClusterIDConflict,
+			"A cluster with this ID already exists, and the token does not have permission to join it"))
+	}
+	if !capabilities.Allows(cluster, clientReq.Capability) {
+		return sendError(c, clusterCapabilityNotAllowedError(clientReq.Capability))
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h ServerConfig) handleBootstrapAuth(c *fiber.Ctx) error {
+	lg := c.Context().Logger()
+	if h.AuthRateLimiter != nil && !h.AuthRateLimiter.Allow(c.IP()) {
+		return sendError(c, errRateLimited)
+	}
+	bootstrapToken, verifyErr := h.verifyBootstrapToken(c)
+	if verifyErr != nil {
+		return sendError(c, verifyErr)
 	}
 
 	// Token is valid and not expired. Check the client's requested UUID
 	clientReq := BootstrapAuthRequest{}
 	if err := c.BodyParser(&clientReq); err != nil {
-		return c.Status(fiber.StatusBadRequest).SendString("Invalid request body")
+		return sendError(c, errInvalidRequestBody)
 	}
+	c.Locals(localsClientID, clientReq.ClientID)
 	if err := validation.Validate(clientReq); err != nil {
-		return c.Status(fiber.StatusBadRequest).SendString(err.Error())
+		return sendError(c, newServerError(fiber.StatusBadRequest, ErrorCodeInvalidRequest, err.Error()))
+	}
+	if err := h.validateClientID(clientReq.ClientID); err != nil {
+		return sendError(c, err)
+	}
+	if clientReq.Curve.OrDefault() != h.Curve.OrDefault() {
+		return sendError(c, curveMismatchError(clientReq.Curve, h.Curve))
+	}
+	if !capabilities.Allows(bootstrapToken, clientReq.Capability) {
+		return sendError(c, tokenCapabilityNotAllowedError(clientReq.Capability))
 	}
 
 	// If the cluster with the requested ID does not exist, it can be created
 	// normally. If it does exist, and the client advertises a capability that
 	// the cluster does not yet have, and the token has the capability to edit
 	// this cluster, the cluster will be updated with the new capability.
+	//
+	// Tokens with a shared identity can only be used to bootstrap the shared
+	// cluster, and are allowed to join it even if it already has the
+	// requested capability. In that case, the client's keys are appended to
+	// the cluster's keyring.
+	//
+	// If the token or the existing cluster has a capability allowlist, the
+	// requested capability must be in it.
 	existing := &core.Reference{
 		Id: clientReq.ClientID,
 	}
-	var shouldEditExisting bool
+	shared := capabilities.SharedIdentityOf(bootstrapToken)
+	if shared != nil {
+		if shared.Id != clientReq.ClientID {
+			return sendError(c, sharedIdentityMismatchError(shared))
+		}
+	}
+	var shouldEditExisting, shouldAppendKeys bool
 
 	if cluster, err := h.ClusterStore.GetCluster(context.Background(), existing); err != nil {
 		if !errors.Is(err, storage.ErrNotFound) {
 			lg.Printf("error checking if cluster exists: %v", err)
-			return c.SendStatus(fiber.StatusInternalServerError)
+			return sendError(c, errInternal)
 		}
 	} else {
 		if capabilities.Has(cluster, capabilities.Cluster(clientReq.Capability)) {
-			return c.Status(fiber.StatusConflict).
-				SendString("Capability is already installed on this cluster")
-		}
-
-		// the cluster capability is new, check if the token can edit it
-		if capabilities.Has(bootstrapToken, capabilities.JoinExistingCluster.For(existing)) {
+			if shared == nil {
+				return sendError(c, newServerError(fiber.StatusConflict, ErrorCodeCapabilityAlreadyInstalled,
+					"Capability is already installed on this cluster"))
+			}
+			shouldAppendKeys = true
+		} else if shared != nil ||
+			capabilities.Has(bootstrapToken, capabilities.JoinExistingCluster.For(existing)) {
+			// the cluster capability is new, and the token can edit the cluster
+			if !capabilities.Allows(cluster, clientReq.Capability) {
+				return sendError(c, clusterCapabilityNotAllowedError(clientReq.Capability))
+			}
 			shouldEditExisting = true
 		} else {
-			return c.Status(fiber.StatusUnauthorized).
-				SendString("Insufficient permissions for this cluster")
+			return sendError(c, newServerError(fiber.StatusUnauthorized, ErrorCodeClusterIDConflict,
+				"Insufficient permissions for this cluster"))
 		}
 	}
 
-	ekp := ecdh.NewEphemeralKeyPair()
+	ekp, err := ecdh.NewEphemeralKeyPairForCurve(h.Curve)
+	if err != nil {
+		lg.Printf("error generating keypair: %v", err)
+		return sendError(c, errInternal)
+	}
 	sharedSecret, err := ecdh.DeriveSharedSecret(ekp, ecdh.PeerPublicKey{
 		PublicKey: clientReq.ClientPubKey,
 		PeerType:  ecdh.PeerTypeClient,
 	})
 	if err != nil {
+		if errors.Is(err, ecdh.ErrInvalidPublicKey) {
+			return sendError(c, newServerError(fiber.StatusBadRequest, ErrorCodeInvalidRequest,
+				fmt.Sprintf("Invalid client public key for curve %s", h.Curve.OrDefault())))
+		}
 		lg.Printf("error computing shared secret: %v", err)
-		return c.SendStatus(fiber.StatusInternalServerError)
+		return sendError(c, errInternal)
 	}
 	kr := keyring.New(keyring.NewSharedKeys(sharedSecret))
 
@@ -164,34 +535,86 @@ func (h ServerConfig) handleBootstrapAuth(c *fiber.Ctx) error {
 	if err := h.CapabilityInstaller.CanInstall(clientReq.Capability); err != nil {
 		if errors.Is(err, capabilities.ErrUnknownCapability) {
 			lg.Printf("unknown capability: %s", clientReq.Capability)
-			return c.Status(fiber.StatusNotFound).
-				SendString(fmt.Sprintf("Unknown capability %s", clientReq.Capability))
+			return sendError(c, newServerError(fiber.StatusNotFound, ErrorCodeUnknownCapability,
+				fmt.Sprintf("Unknown capability %s", clientReq.Capability)))
 		}
 		lg.Printf("capability cannot be installed: %v", err)
-		return c.Status(fiber.StatusServiceUnavailable).
-			SendString(fmt.Sprintf("Capability cannot be installed: %v", err))
+		return sendError(c, newServerError(fiber.StatusServiceUnavailable, ErrorCodeCapabilityUnavailable,
+			fmt.Sprintf("Capability cannot be installed: %v", err)))
 	}
 
-	if shouldEditExisting {
-		if err := h.handleEdit(existing, clientReq.Capability, bootstrapToken, kr); err != nil {
+	// Tokens with a usage limit are consumed before any changes are made, so
+	// that concurrent requests cannot use the token more times than allowed.
+	// The usage is released again if bootstrapping fails. Other tokens have
+	// their usage counted once bootstrapping succeeds.
+	limited := bootstrapToken.GetMetadata().GetMaxUsages() > 0
+	release := func() {}
+	if limited {
+		updated, ok, err := h.reserveTokenUsage(bootstrapToken)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return sendError(c, errTokenUsagesExhausted)
+			}
+			lg.Printf("error reserving token usage: %v", err)
+			return sendError(c, errInternal)
+		}
+		if !ok {
+			return sendError(c, errTokenUsagesExhausted)
+		}
+		release = func() {
+			_, err := h.TokenStore.UpdateToken(context.Background(), updated.Reference(),
+				storage.NewDecrementUsageCountMutator())
+			if err != nil && !errors.Is(err, storage.ErrNotFound) {
+				lg.Printf("error releasing token usage: %v", err)
+			}
+		}
+		bootstrapToken = updated
+	}
+	countUsage := !limited
+
+	if shouldAppendKeys {
+		if err := h.handleAppend(existing, bootstrapToken, kr, countUsage); err != nil {
+			release()
+			lg.Printf("error appending to cluster keyring: %v", err)
+			return sendError(c, newServerError(fiber.StatusInternalServerError, ErrorCodeInternal, err.Error()))
+		}
+	} else if shouldEditExisting {
+		if err := h.handleEdit(existing, clientReq.Capability, bootstrapToken, kr, countUsage); err != nil {
+			release()
 			lg.Printf("error editing cluster capabilities: %v", err)
-			return c.Status(fiber.StatusInternalServerError).SendString(err.Error())
+			return sendError(c, newServerError(fiber.StatusInternalServerError, ErrorCodeInternal, err.Error()))
 		}
 	} else {
-		newCluster := &core.Cluster{
-			Id: clientReq.ClientID,
-			Metadata: &core.ClusterMetadata{
-				Labels: bootstrapToken.GetMetadata().GetLabels(),
-				Capabilities: []*core.ClusterCapability{
-					{
-						Name: clientReq.Capability,
-					},
-				},
-			},
+		newCluster, err := NewCluster(clientReq.ClientID, clientReq.Capability,
+			bootstrapToken, h.LabelTemplates)
+		if err != nil {
+			release()
+			lg.Printf("error computing cluster labels: %v", err)
+			return sendError(c, newServerError(fiber.StatusInternalServerError, ErrorCodeInternal,
+				fmt.Sprintf("Failed to compute cluster labels: %v", err)))
 		}
-		if err := h.handleCreate(newCluster, clientReq.Capability, bootstrapToken, kr); err != nil {
+		err = h.handleCreate(newCluster, clientReq.Capability, bootstrapToken, kr, countUsage)
+		if errors.Is(err, storage.ErrAlreadyExists) && shared != nil {
+			// Another agent using the shared identity created the cluster
+			// concurrently; join it instead.
+			err = h.handleEdit(existing, clientReq.Capability, bootstrapToken, kr, countUsage)
+		}
+		if err != nil {
+			release()
+			if errors.Is(err, storage.ErrAlreadyExists) {
+				return sendError(c, newServerError(fiber.StatusConflict, ErrorCodeClusterIDConflict,
+					"A cluster with this ID already exists"))
+			}
 			lg.Printf("error creating cluster: %v", err)
-			return c.Status(fiber.StatusInternalServerError).SendString(err.Error())
+			return sendError(c, newServerError(fiber.StatusInternalServerError, ErrorCodeInternal, err.Error()))
+		}
+	}
+
+	// Once its last usage has been consumed, the token is no longer needed
+	if limited && tokenUsagesExhausted(bootstrapToken) {
+		err := h.TokenStore.DeleteToken(context.Background(), bootstrapToken.Reference())
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			lg.Printf("error deleting exhausted token: %v", err)
 		}
 	}
 
@@ -205,27 +628,37 @@ func (h ServerConfig) handleCreate(
 	newCapability string,
 	token *core.BootstrapToken,
 	kr keyring.Keyring,
+	countUsage bool,
 ) error {
 	if err := h.ClusterStore.CreateCluster(context.Background(), newCluster); err != nil {
 		return fmt.Errorf("error creating cluster: %w", err)
 	}
+	mutators := []storage.MutatorFunc[*core.BootstrapToken]{
+		storage.NewAddCapabilityMutator[*core.BootstrapToken](&core.TokenCapability{
+			Type:      string(capabilities.JoinExistingCluster),
+			Reference: newCluster.Reference(),
+		}),
+	}
+	if countUsage {
+		mutators = append(mutators, storage.NewIncrementUsageCountMutator())
+	}
 	_, err := h.TokenStore.UpdateToken(context.Background(), token.Reference(),
-		storage.NewCompositeMutator(
-			storage.NewIncrementUsageCountMutator(),
-			storage.NewAddCapabilityMutator[*core.BootstrapToken](&core.TokenCapability{
-				Type:      string(capabilities.JoinExistingCluster),
-				Reference: newCluster.Reference(),
-			}),
-		),
-	)
+		storage.NewCompositeMutator(mutators...))
 	if err != nil {
-		return fmt.Errorf("error incrementing usage count: %w", err)
+		return fmt.Errorf("error updating token: %w", err)
 	}
 	krStore, err := h.KeyringStoreBroker.KeyringStore(context.Background(), "gateway", newCluster.Reference())
 	if err != nil {
 		return fmt.Errorf("error getting keyring store: %w", err)
 	}
-	if err := krStore.Put(context.Background(), kr); err != nil {
+	// Agents using a shared identity may append their keys to the keyring
+	// as soon as the cluster exists, so they must not be replaced.
+	if capabilities.SharedIdentityOf(token) != nil {
+		err = krStore.Append(context.Background(), kr)
+	} else {
+		err = krStore.Put(context.Background(), kr)
+	}
+	if err != nil {
 		return fmt.Errorf("error storing keyring: %w", err)
 	}
 	h.CapabilityInstaller.InstallCapabilities(newCluster.Reference(), newCapability)
@@ -237,29 +670,75 @@ func (h ServerConfig) handleEdit(
 	newCapability string,
 	token *core.BootstrapToken,
 	keyring keyring.Keyring,
+	countUsage bool,
 ) error {
-	_, err := h.TokenStore.UpdateToken(context.Background(), token.Reference(),
-		storage.NewIncrementUsageCountMutator())
-	if err != nil {
-		return fmt.Errorf("error incrementing usage count: %w", err)
+	if err := h.incrementUsageCount(token, countUsage); err != nil {
+		return err
 	}
-	_, err = h.ClusterStore.UpdateCluster(context.Background(), existingCluster,
+	_, err := h.ClusterStore.UpdateCluster(context.Background(), existingCluster,
 		storage.NewAddCapabilityMutator[*core.Cluster](capabilities.Cluster(newCapability)),
 	)
 	if err != nil {
 		return err
 	}
-	krStore, err := h.KeyringStoreBroker.KeyringStore(context.Background(), "gateway", existingCluster)
+	if err := h.appendKeyring(existingCluster, keyring); err != nil {
+		return err
+	}
+	h.CapabilityInstaller.InstallCapabilities(existingCluster, newCapability)
+	return nil
+}
+
+// handleAppend adds a client's keys to an existing cluster which already has
+// the requested capability. This is only allowed for shared identity tokens.
+func (h ServerConfig) handleAppend(
+	existingCluster *core.Reference,
+	token *core.BootstrapToken,
+	keyring keyring.Keyring,
+	countUsage bool,
+) error {
+	if err := h.incrementUsageCount(token, countUsage); err != nil {
+		return err
+	}
+	return h.appendKeyring(existingCluster, keyring)
+}
+
+func (h ServerConfig) incrementUsageCount(token *core.BootstrapToken, countUsage bool) error {
+	if !countUsage {
+		return nil
+	}
+	_, err := h.TokenStore.UpdateToken(context.Background(), token.Reference(),
+		storage.NewIncrementUsageCountMutator())
 	if err != nil {
-		return fmt.Errorf("error getting keyring store: %w", err)
+		return fmt.Errorf("error incrementing usage count: %w", err)
 	}
-	kr, err := krStore.Get(context.Background())
+	return nil
+}
+
+// appendKeyring merges the given keyring into the cluster's stored keyring.
+// Existing keys are kept, so previously bootstrapped clients remain valid.
+func (h ServerConfig) appendKeyring(ref *core.Reference, keyring keyring.Keyring) error {
+	krStore, err := h.KeyringStoreBroker.KeyringStore(context.Background(), "gateway", ref)
 	if err != nil {
-		return fmt.Errorf("error getting existing keyring: %w", err)
+		return fmt.Errorf("error getting keyring store: %w", err)
 	}
-	if err := krStore.Put(context.Background(), keyring.Merge(kr)); err != nil {
+	if err := krStore.Append(context.Background(), keyring); err != nil {
 		return fmt.Errorf("error storing keyring: %w", err)
 	}
-	h.CapabilityInstaller.InstallCapabilities(existingCluster, newCapability)
 	return nil
 }
+
+func tokenCapabilityNotAllowedError(capability string) *ServerError {
+	return newServerError(fiber.StatusForbidden, ErrorCodeCapabilityNotAllowed,
+		fmt.Sprintf("Capability %s is not allowed by this token", capability))
+}
+
+func clusterCapabilityNotAllowedError(capability string) *ServerError {
+	return newServerError(fiber.StatusForbidden, ErrorCodeCapabilityNotAllowed,
+		fmt.Sprintf("Capability %s is not allowed for this cluster", capability))
+}
+
+func sharedIdentityMismatchError(shared *core.Reference) *ServerError {
+	return newServerError(fiber.StatusForbidden, ErrorCodeSharedIdentityMismatch,
+		fmt.Sprintf("This token can only be used to bootstrap cluster %s; "+
+			"the agent's identity must match the shared identity", shared.Id))
+}
@@ -3,12 +3,20 @@ package bootstrap_test
 import (
 	"bytes"
 	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"regexp"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -22,17 +30,46 @@ import (
 	"github.com/rancher/opni-monitoring/pkg/capabilities"
 	"github.com/rancher/opni-monitoring/pkg/core"
 	"github.com/rancher/opni-monitoring/pkg/ecdh"
+	"github.com/rancher/opni-monitoring/pkg/keyring"
+	"github.com/rancher/opni-monitoring/pkg/labels"
 	"github.com/rancher/opni-monitoring/pkg/logger"
 	"github.com/rancher/opni-monitoring/pkg/storage"
 	"github.com/rancher/opni-monitoring/pkg/test"
 	"github.com/rancher/opni-monitoring/pkg/tokens"
 )
 
+// testAuditLogger records bootstrap audit events.
+type testAuditLogger struct {
+	mu     sync.Mutex
+	events []bootstrap.BootstrapAuditEvent
+}
+
+func (l *testAuditLogger) RecordBootstrap(_ context.Context, event bootstrap.BootstrapAuditEvent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+}
+
+func (l *testAuditLogger) Events() []bootstrap.BootstrapAuditEvent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]bootstrap.BootstrapAuditEvent{}, l.events...)
+}
+
 type testCapBackend struct {
 	Name       string
 	CanInstall bool
 }
 
+// decodeErrorResponse decodes the error response body sent by the bootstrap
+// server.
+func decodeErrorResponse(resp *http.Response) bootstrap.ErrorResponse {
+	ExpectWithOffset(1, resp.Header.Get("Content-Type")).To(HavePrefix("application/json"))
+	errResp := bootstrap.ErrorResponse{}
+	ExpectWithOffset(1, json.NewDecoder(resp.Body).Decode(&errResp)).To(Succeed())
+	return errResp
+}
+
 var _ = Describe("Server", Label(test.Unit, test.Slow), func() {
 	var token *core.BootstrapToken
 	var token2 *core.BootstrapToken
@@ -44,8 +81,32 @@ var _ = Describe("Server", Label(test.Unit, test.Slow), func() {
 	var mockClusterStore storage.ClusterStore
 	var mockKeyringStoreBroker storage.KeyringStoreBroker
 	var testCapBackends []*test.CapabilityInfo
+	var maxClockSkew time.Duration
+	var labelTemplates *labels.Templates
+	var joinCache *bootstrap.JoinResponseCache
+	var previousCerts []*tls.Certificate
+	var serverCurve ecdh.Curve
+	var auditLogger bootstrap.AuditLogger
+	var requireTLS bool
+	// if true, the server is served over TLS. Otherwise, requests are sent
+	// over a plaintext connection.
+	var serveTLS bool
+	var authRateLimiter *bootstrap.AuthRateLimiter
+	var bootstrapDisabled bool
+	var clientIDPattern *regexp.Regexp
 
 	BeforeEach(func() {
+		bootstrapDisabled = false
+		clientIDPattern = nil
+		authRateLimiter = nil
+		requireTLS = false
+		serveTLS = false
+		maxClockSkew = 0
+		serverCurve = ""
+		auditLogger = nil
+		labelTemplates = nil
+		joinCache = nil
+		previousCerts = nil
 		testCapBackends = append(testCapBackends, &test.CapabilityInfo{
 			Name:       "test",
 			CanInstall: true,
@@ -81,25 +142,50 @@ var _ = Describe("Server", Label(test.Unit, test.Slow), func() {
 			capBackendStore.Add(backend.Name, test.NewTestCapabilityBackend(ctrl, backend))
 		}
 		server := bootstrap.ServerConfig{
-			CapabilityInstaller: capBackendStore,
-			Certificate:         cert,
-			TokenStore:          mockTokenStore,
-			ClusterStore:        mockClusterStore,
-			KeyringStoreBroker:  mockKeyringStoreBroker,
+			CapabilityInstaller:    capBackendStore,
+			Certificate:            cert,
+			TokenStore:             mockTokenStore,
+			ClusterStore:           mockClusterStore,
+			KeyringStoreBroker:     mockKeyringStoreBroker,
+			MaxClockSkew:           maxClockSkew,
+			LabelTemplates:         labelTemplates,
+			JoinResponseCache:      joinCache,
+			Curve:                  serverCurve,
+			AuditLogger:            auditLogger,
+			RequireTLSForBootstrap: requireTLS,
+			AuthRateLimiter:        authRateLimiter,
+			ClientIDPattern:        clientIDPattern,
 		}
-		app.Post("/bootstrap/*", server.Handle)
+		if bootstrapDisabled {
+			server.TokenStore = nil
+		}
+		if len(previousCerts) > 0 {
+			server.Certificates = append([]*tls.Certificate{cert}, previousCerts...)
+		}
+		app.All("/bootstrap/*", server.Handle)
 		tlsConfig := &tls.Config{
 			Certificates: []tls.Certificate{crt},
 		}
 		app.Server().TLSConfig = tlsConfig
 		listener := fasthttputil.NewInmemoryListener()
 		*addr = "https://" + listener.Addr().String()
-		go app.Listener(listener)
+		if serveTLS {
+			go app.Listener(tls.NewListener(listener, tlsConfig))
+		} else {
+			go app.Listener(listener)
+		}
 		client = &http.Client{
 			Transport: &http.Transport{
 				TLSClientConfig: tlsConfig,
 				DialTLS: func(network, addr string) (net.Conn, error) {
-					return listener.Dial()
+					conn, err := listener.Dial()
+					if err != nil || !serveTLS {
+						return conn, err
+					}
+					tlsConn := tls.Client(conn, &tls.Config{
+						InsecureSkipVerify: true,
+					})
+					return tlsConn, tlsConn.Handshake()
 				},
 			},
 		}
@@ -117,6 +203,7 @@ var _ = Describe("Server", Label(test.Unit, test.Slow), func() {
 				resp, err := client.Do(req)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeInvalidRequest))
 			})
 		})
 		When("no Authorization header is given", func() {
@@ -131,6 +218,7 @@ var _ = Describe("Server", Label(test.Unit, test.Slow), func() {
 				joinResp := bootstrap.BootstrapJoinResponse{}
 				Expect(json.Unmarshal(body, &joinResp)).To(Succeed())
 				Expect(joinResp.Signatures).To(HaveLen(2))
+				Expect(joinResp.ProtocolVersions).To(Equal(bootstrap.SupportedProtocolVersions))
 
 				rawToken, err := tokens.FromBootstrapToken(token)
 				Expect(err).NotTo(HaveOccurred())
@@ -143,13 +231,110 @@ var _ = Describe("Server", Label(test.Unit, test.Slow), func() {
 				mockTokenStore.DeleteToken(context.Background(), token.Reference())
 				mockTokenStore.DeleteToken(context.Background(), token2.Reference())
 			})
-			It("should return http 409", func() {
+			It("should return http 405", func() {
 				req, err := http.NewRequest("POST", *addr+"/bootstrap/join", nil)
 				Expect(err).NotTo(HaveOccurred())
 				resp, err := client.Do(req)
 				Expect(err).NotTo(HaveOccurred())
 				defer resp.Body.Close()
 				Expect(resp.StatusCode).To(Equal(http.StatusMethodNotAllowed))
+				Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeNoActiveTokens))
+			})
+			When("join response caching is enabled", func() {
+				BeforeEach(func() {
+					joinCache = bootstrap.NewJoinResponseCache(30 * time.Second)
+				})
+				It("should return http 405", func() {
+					resp, err := client.Get(*addr + "/bootstrap/join")
+					Expect(err).NotTo(HaveOccurred())
+					defer resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusMethodNotAllowed))
+					Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeNoActiveTokens))
+				})
+			})
+		})
+		When("join response caching is enabled", func() {
+			BeforeEach(func() {
+				joinCache = bootstrap.NewJoinResponseCache(30 * time.Second)
+			})
+			join := func(etag string) (*http.Response, bootstrap.BootstrapJoinResponse) {
+				req, err := http.NewRequest("GET", *addr+"/bootstrap/join", nil)
+				Expect(err).NotTo(HaveOccurred())
+				if etag != "" {
+					req.Header.Set("If-None-Match", etag)
+				}
+				resp, err := client.Do(req)
+				Expect(err).NotTo(HaveOccurred())
+				defer resp.Body.Close()
+				joinResp := bootstrap.BootstrapJoinResponse{}
+				if resp.StatusCode == http.StatusOK {
+					body, _ := io.ReadAll(resp.Body)
+					Expect(json.Unmarshal(body, &joinResp)).To(Succeed())
+				}
+				return resp, joinResp
+			}
+			It("should return a cacheable join response", func() {
+				resp, joinResp := join("")
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+				Expect(resp.Header.Get("ETag")).NotTo(BeEmpty())
+				Expect(resp.Header.Get("Cache-Control")).To(Equal("public, max-age=30"))
+				Expect(joinResp.Signatures).To(HaveLen(2))
+				Expect(joinResp.ProtocolVersions).To(Equal(bootstrap.SupportedProtocolVersions))
+
+				rawToken, err := tokens.FromBootstrapToken(token)
+				Expect(err).NotTo(HaveOccurred())
+				sig, _ := rawToken.SignDetached(cert.PrivateKey)
+				Expect(joinResp.Signatures).To(HaveKeyWithValue(rawToken.HexID(), sig))
+			})
+			It("should keep the same ETag if the tokens have not changed", func() {
+				resp, joinResp := join("")
+				etag := resp.Header.Get("ETag")
+				for i := 0; i < 3; i++ {
+					resp, joinResp2 := join("")
+					Expect(resp.StatusCode).To(Equal(http.StatusOK))
+					Expect(resp.Header.Get("ETag")).To(Equal(etag))
+					Expect(joinResp2).To(Equal(joinResp))
+				}
+			})
+			It("should return http 304 if the ETag matches", func() {
+				resp, _ := join("")
+				etag := resp.Header.Get("ETag")
+				resp, _ = join(etag)
+				Expect(resp.StatusCode).To(Equal(http.StatusNotModified))
+				Expect(resp.Header.Get("ETag")).To(Equal(etag))
+
+				resp, _ = join(`"stale"`)
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			})
+			It("should change the ETag when a token is created", func() {
+				resp, _ := join("")
+				etag := resp.Header.Get("ETag")
+
+				token3, err := mockTokenStore.CreateToken(context.Background(), 1*time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+
+				resp, joinResp := join(etag)
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+				Expect(resp.Header.Get("ETag")).NotTo(Equal(etag))
+				Expect(joinResp.Signatures).To(HaveLen(3))
+				rawToken, err := tokens.FromBootstrapToken(token3)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(joinResp.Signatures).To(HaveKey(rawToken.HexID()))
+			})
+			It("should change the ETag when a token is revoked", func() {
+				resp, _ := join("")
+				etag := resp.Header.Get("ETag")
+
+				Expect(mockTokenStore.DeleteToken(context.Background(), token2.Reference())).To(Succeed())
+
+				resp, joinResp := join(etag)
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+				Expect(resp.Header.Get("ETag")).NotTo(Equal(etag))
+				Expect(joinResp.Signatures).To(HaveLen(1))
+
+				Expect(mockTokenStore.DeleteToken(context.Background(), token.Reference())).To(Succeed())
+				resp, _ = join("")
+				Expect(resp.StatusCode).To(Equal(http.StatusMethodNotAllowed))
 			})
 		})
 	})
@@ -161,6 +346,7 @@ var _ = Describe("Server", Label(test.Unit, test.Slow), func() {
 				resp, err := client.Do(req)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeInvalidToken))
 			})
 		})
 		When("an Authorization header is given", func() {
@@ -237,6 +423,32 @@ var _ = Describe("Server", Label(test.Unit, test.Slow), func() {
 						})))
 					})
 				})
+				When("the client requests an unsupported protocol version", func() {
+					It("should return http 400", func() {
+						rawToken, err := tokens.FromBootstrapToken(token)
+						Expect(err).NotTo(HaveOccurred())
+						jsonData, err := json.Marshal(rawToken)
+						Expect(err).NotTo(HaveOccurred())
+						sig, err := jws.Sign(jsonData, jwa.EdDSA, cert.PrivateKey)
+						Expect(err).NotTo(HaveOccurred())
+						req, err := http.NewRequest("POST", *addr+"/bootstrap/auth", nil)
+						Expect(err).NotTo(HaveOccurred())
+						req.Header.Add("Authorization", "Bearer "+string(sig))
+						ekp := ecdh.NewEphemeralKeyPair()
+						authReq := bootstrap.BootstrapAuthRequest{
+							Capability:      "test",
+							ClientID:        "foo",
+							ClientPubKey:    ekp.PublicKey,
+							ProtocolVersion: 99,
+						}
+						j, _ := json.Marshal(authReq)
+						req.Header.Set("Content-Type", "application/json")
+						req.Body = io.NopCloser(bytes.NewReader(j))
+						resp, err := client.Do(req)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
 			})
 			When("the token is invalid", func() {
 				It("should return http 401", func() {
@@ -246,9 +458,10 @@ var _ = Describe("Server", Label(test.Unit, test.Slow), func() {
 					resp, err := client.Do(req)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+					Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeInvalidToken))
 				})
 			})
-			When("the token is valid but expired", func() {
+			When("the token is valid but no longer exists", func() {
 				BeforeEach(func() {
 					mockTokenStore.DeleteToken(context.Background(), token.Reference())
 				})
@@ -273,6 +486,174 @@ var _ = Describe("Server", Label(test.Unit, test.Slow), func() {
 					resp, err := client.Do(req)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+					Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeInvalidToken))
+				})
+			})
+			When("label templates are configured", func() {
+				BeforeEach(func() {
+					var err error
+					labelTemplates, err = labels.ParseTemplates(map[string]string{
+						"tier": `{{ if eq (index .Labels "foo") "bar" }}critical{{ else }}standard{{ end }}`,
+					})
+					Expect(err).NotTo(HaveOccurred())
+				})
+				sendAuthRequest := func() *http.Response {
+					rawToken, err := tokens.FromBootstrapToken(token)
+					Expect(err).NotTo(HaveOccurred())
+					jsonData, err := json.Marshal(rawToken)
+					Expect(err).NotTo(HaveOccurred())
+					sig, err := jws.Sign(jsonData, jwa.EdDSA, cert.PrivateKey)
+					Expect(err).NotTo(HaveOccurred())
+					req, err := http.NewRequest("POST", *addr+"/bootstrap/auth", nil)
+					Expect(err).NotTo(HaveOccurred())
+					req.Header.Add("Authorization", "Bearer "+string(sig))
+					ekp := ecdh.NewEphemeralKeyPair()
+					authReq := bootstrap.BootstrapAuthRequest{
+						Capability:   "test",
+						ClientID:     "foo",
+						ClientPubKey: ekp.PublicKey,
+					}
+					j, _ := json.Marshal(authReq)
+					req.Header.Set("Content-Type", "application/json")
+					req.Body = io.NopCloser(bytes.NewReader(j))
+					resp, err := client.Do(req)
+					Expect(err).NotTo(HaveOccurred())
+					return resp
+				}
+				It("should compute labels for the new cluster", func() {
+					resp := sendAuthRequest()
+					Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+					cluster, err := mockClusterStore.GetCluster(context.Background(), &core.Reference{
+						Id: "foo",
+					})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(cluster.GetLabels()).To(Equal(map[string]string{
+						"foo":  "bar",
+						"tier": "critical",
+					}))
+				})
+				When("the labels cannot be computed", func() {
+					BeforeEach(func() {
+						var err error
+						labelTemplates, err = labels.ParseTemplates(map[string]string{
+							"tier": `{{ index .Labels "foo" }} tier`,
+						})
+						Expect(err).NotTo(HaveOccurred())
+					})
+					It("should fail without creating the cluster", func() {
+						resp := sendAuthRequest()
+						Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+						Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeInternal))
+
+						_, err := mockClusterStore.GetCluster(context.Background(), &core.Reference{
+							Id: "foo",
+						})
+						Expect(err).To(MatchError(storage.ErrNotFound))
+					})
+				})
+			})
+			When("the token is outside of its validity period", func() {
+				setValidity := func(notBefore, notAfter time.Time) {
+					_, err := mockTokenStore.UpdateToken(context.Background(), token.Reference(),
+						func(t *core.BootstrapToken) {
+							t.Metadata.NotBefore = notBefore.Unix()
+							t.Metadata.NotAfter = notAfter.Unix()
+						})
+					Expect(err).NotTo(HaveOccurred())
+				}
+				sendAuthRequest := func() *http.Response {
+					rawToken, err := tokens.FromBootstrapToken(token)
+					Expect(err).NotTo(HaveOccurred())
+					jsonData, err := json.Marshal(rawToken)
+					Expect(err).NotTo(HaveOccurred())
+					sig, err := jws.Sign(jsonData, jwa.EdDSA, cert.PrivateKey)
+					Expect(err).NotTo(HaveOccurred())
+					req, err := http.NewRequest("POST", *addr+"/bootstrap/auth", nil)
+					Expect(err).NotTo(HaveOccurred())
+					req.Header.Add("Authorization", "Bearer "+string(sig))
+					ekp := ecdh.NewEphemeralKeyPair()
+					authReq := bootstrap.BootstrapAuthRequest{
+						Capability:   "test",
+						ClientID:     "foo",
+						ClientPubKey: ekp.PublicKey,
+					}
+					j, _ := json.Marshal(authReq)
+					req.Header.Set("Content-Type", "application/json")
+					req.Body = io.NopCloser(bytes.NewReader(j))
+					resp, err := client.Do(req)
+					Expect(err).NotTo(HaveOccurred())
+					return resp
+				}
+				BeforeEach(func() {
+					maxClockSkew = 1 * time.Minute
+				})
+				When("the token expired within the allowed clock skew", func() {
+					It("should return http 200", func() {
+						setValidity(time.Now().Add(-1*time.Hour), time.Now().Add(-30*time.Second))
+						resp := sendAuthRequest()
+						Expect(resp.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+				When("the token expired beyond the allowed clock skew", func() {
+					It("should return http 401", func() {
+						setValidity(time.Now().Add(-1*time.Hour), time.Now().Add(-2*time.Minute))
+						resp := sendAuthRequest()
+						Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+						Expect(decodeErrorResponse(resp)).To(Equal(bootstrap.ErrorResponse{
+							Code:    bootstrap.ErrorCodeTokenExpired,
+							Message: "token expired",
+						}))
+					})
+					It("should delete the expired token", func() {
+						setValidity(time.Now().Add(-1*time.Hour), time.Now().Add(-2*time.Minute))
+						resp := sendAuthRequest()
+						Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+						_, err := mockTokenStore.GetToken(context.Background(), token.Reference())
+						Expect(err).To(MatchError(storage.ErrNotFound))
+					})
+				})
+				When("the token is about to expire", func() {
+					It("should return http 200", func() {
+						maxClockSkew = -1
+						setValidity(time.Now().Add(-1*time.Hour), time.Now().Add(2*time.Second))
+						resp := sendAuthRequest()
+						Expect(resp.StatusCode).To(Equal(http.StatusOK))
+						_, err := mockTokenStore.GetToken(context.Background(), token.Reference())
+						Expect(err).NotTo(HaveOccurred())
+					})
+				})
+				When("the token has no expiration", func() {
+					It("should return http 200", func() {
+						setValidity(time.Now().Add(-1*time.Hour), time.Unix(0, 0))
+						resp := sendAuthRequest()
+						Expect(resp.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+				When("the token is not yet valid, within the allowed clock skew", func() {
+					It("should return http 200", func() {
+						setValidity(time.Now().Add(30*time.Second), time.Now().Add(1*time.Hour))
+						resp := sendAuthRequest()
+						Expect(resp.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+				When("the token is not yet valid, beyond the allowed clock skew", func() {
+					It("should return http 401", func() {
+						setValidity(time.Now().Add(2*time.Minute), time.Now().Add(1*time.Hour))
+						resp := sendAuthRequest()
+						Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+						Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeTokenNotYetValid))
+					})
+				})
+				When("clock skew tolerance is disabled", func() {
+					BeforeEach(func() {
+						maxClockSkew = -1
+					})
+					It("should return http 401", func() {
+						setValidity(time.Now().Add(-1*time.Hour), time.Now().Add(-5*time.Second))
+						resp := sendAuthRequest()
+						Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+					})
 				})
 			})
 			When("joining with an additional capability", func() {
@@ -322,6 +703,7 @@ var _ = Describe("Server", Label(test.Unit, test.Slow), func() {
 						resp, err := client.Do(req)
 						Expect(err).NotTo(HaveOccurred())
 						Expect(resp.StatusCode).To(Equal(http.StatusConflict))
+						Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeCapabilityAlreadyInstalled))
 					})
 				})
 				When("the requested capability does not yet exist", func() {
@@ -352,6 +734,7 @@ var _ = Describe("Server", Label(test.Unit, test.Slow), func() {
 							resp, err := client.Do(req)
 							Expect(err).NotTo(HaveOccurred())
 							Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+							Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeUnknownCapability))
 						})
 					})
 					When("a backend for the capability exists", func() {
@@ -386,6 +769,7 @@ var _ = Describe("Server", Label(test.Unit, test.Slow), func() {
 							resp, err := client.Do(req)
 							Expect(err).NotTo(HaveOccurred())
 							Expect(resp.StatusCode).To(Equal(http.StatusServiceUnavailable))
+							Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeCapabilityUnavailable))
 						})
 					})
 					When("the token used does not have the correct join capability", func() {
@@ -412,10 +796,933 @@ var _ = Describe("Server", Label(test.Unit, test.Slow), func() {
 							resp, err := client.Do(req)
 							Expect(err).NotTo(HaveOccurred())
 							Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+							Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeClusterIDConflict))
+						})
+					})
+				})
+			})
+		})
+	})
+	When("using a token with allowed capabilities", func() {
+		var restrictedToken *core.BootstrapToken
+		BeforeEach(func() {
+			testCapBackends = append(testCapBackends,
+				&test.CapabilityInfo{
+					Name:       "test2",
+					CanInstall: true,
+				},
+				&test.CapabilityInfo{
+					Name:       "test3",
+					CanInstall: true,
+				},
+			)
+			var err error
+			restrictedToken, err = mockTokenStore.CreateToken(context.Background(), 1*time.Hour,
+				storage.WithAllowedCapabilities([]string{"test", "test2"}),
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		sendAuthRequest := func(t *core.BootstrapToken, capability string) int {
+			// use the latest version of the token, which may have been given
+			// permission to join clusters it has created
+			t, err := mockTokenStore.GetToken(context.Background(), t.Reference())
+			Expect(err).NotTo(HaveOccurred())
+			rawToken, err := tokens.FromBootstrapToken(t)
+			Expect(err).NotTo(HaveOccurred())
+			jsonData, err := json.Marshal(rawToken)
+			Expect(err).NotTo(HaveOccurred())
+			sig, err := jws.Sign(jsonData, jwa.EdDSA, cert.PrivateKey)
+			Expect(err).NotTo(HaveOccurred())
+			j, _ := json.Marshal(bootstrap.BootstrapAuthRequest{
+				Capability:   capability,
+				ClientID:     "foo",
+				ClientPubKey: ecdh.NewEphemeralKeyPair().PublicKey,
+			})
+			req, err := http.NewRequest("POST", *addr+"/bootstrap/auth", bytes.NewReader(j))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Add("Authorization", "Bearer "+string(sig))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+			return resp.StatusCode
+		}
+		It("should copy the allowlist to the new cluster", func() {
+			Expect(sendAuthRequest(restrictedToken, "test")).To(Equal(http.StatusOK))
+			cluster, err := mockClusterStore.GetCluster(context.Background(), &core.Reference{Id: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cluster.GetAllowedCapabilities()).To(Equal([]string{"test", "test2"}))
+		})
+		It("should not create clusters with a disallowed capability", func() {
+			Expect(sendAuthRequest(restrictedToken, "test3")).To(Equal(http.StatusForbidden))
+			_, err := mockClusterStore.GetCluster(context.Background(), &core.Reference{Id: "foo"})
+			Expect(err).To(MatchError(storage.ErrNotFound))
+		})
+		It("should allow installing an allowed capability", func() {
+			Expect(sendAuthRequest(restrictedToken, "test")).To(Equal(http.StatusOK))
+			Expect(sendAuthRequest(restrictedToken, "test2")).To(Equal(http.StatusOK))
+			cluster, err := mockClusterStore.GetCluster(context.Background(), &core.Reference{Id: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cluster.GetCapabilities()).To(ConsistOf(
+				BeEquivalentTo(&core.ClusterCapability{Name: "test"}),
+				BeEquivalentTo(&core.ClusterCapability{Name: "test2"}),
+			))
+		})
+		It("should allow any capability if the token has no allowlist", func() {
+			wildcardToken, err := mockTokenStore.CreateToken(context.Background(), 1*time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(wildcardToken.GetMetadata().GetAllowedCapabilities()).To(BeEmpty())
+			Expect(sendAuthRequest(wildcardToken, "test3")).To(Equal(http.StatusOK))
+			cluster, err := mockClusterStore.GetCluster(context.Background(), &core.Reference{Id: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cluster.GetAllowedCapabilities()).To(BeEmpty())
+			Expect(cluster.GetCapabilities()).To(ConsistOf(
+				BeEquivalentTo(&core.ClusterCapability{Name: "test3"}),
+			))
+		})
+		It("should deny installing a disallowed capability", func() {
+			Expect(sendAuthRequest(restrictedToken, "test")).To(Equal(http.StatusOK))
+			Expect(sendAuthRequest(restrictedToken, "test3")).To(Equal(http.StatusForbidden))
+
+			By("using an unrestricted token which can join the cluster")
+			joinToken, err := mockTokenStore.CreateToken(context.Background(), 1*time.Hour,
+				storage.WithCapabilities([]*core.TokenCapability{
+					capabilities.JoinExistingCluster.For(&core.Reference{Id: "foo"}),
+				}),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sendAuthRequest(joinToken, "test3")).To(Equal(http.StatusForbidden))
+
+			cluster, err := mockClusterStore.GetCluster(context.Background(), &core.Reference{Id: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cluster.GetCapabilities()).To(ConsistOf(
+				BeEquivalentTo(&core.ClusterCapability{Name: "test"}),
+			))
+		})
+	})
+	When("using a shared identity token", func() {
+		var sharedToken *core.BootstrapToken
+		BeforeEach(func() {
+			var err error
+			sharedToken, err = mockTokenStore.CreateToken(context.Background(), 1*time.Hour,
+				storage.WithCapabilities([]*core.TokenCapability{
+					capabilities.SharedIdentity.For(&core.Reference{Id: "shared"}),
+				}),
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		newRequest := func(path string, body any) *http.Request {
+			rawToken, err := tokens.FromBootstrapToken(sharedToken)
+			Expect(err).NotTo(HaveOccurred())
+			jsonData, err := json.Marshal(rawToken)
+			Expect(err).NotTo(HaveOccurred())
+			sig, err := jws.Sign(jsonData, jwa.EdDSA, cert.PrivateKey)
+			Expect(err).NotTo(HaveOccurred())
+			j, _ := json.Marshal(body)
+			req, err := http.NewRequest("POST", *addr+path, bytes.NewReader(j))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Add("Authorization", "Bearer "+string(sig))
+			req.Header.Set("Content-Type", "application/json")
+			// don't leave idle connections open, which would block the
+			// server from shutting down after concurrent requests
+			req.Close = true
+			return req
+		}
+		// bootstraps a client as the shared cluster, and returns the client's
+		// shared keys
+		bootstrapShared := func() *keyring.SharedKeys {
+			ekp := ecdh.NewEphemeralKeyPair()
+			resp, err := client.Do(newRequest("/bootstrap/auth", bootstrap.BootstrapAuthRequest{
+				Capability:   "test",
+				ClientID:     "shared",
+				ClientPubKey: ekp.PublicKey,
+			}))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			var authResp bootstrap.BootstrapAuthResponse
+			Expect(json.NewDecoder(resp.Body).Decode(&authResp)).To(Succeed())
+			secret, err := ecdh.DeriveSharedSecret(ekp, ecdh.PeerPublicKey{
+				PublicKey: authResp.ServerPubKey,
+				PeerType:  ecdh.PeerTypeServer,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return keyring.NewSharedKeys(secret)
+		}
+		storedClientKeys := func() [][]byte {
+			ks, err := mockKeyringStoreBroker.KeyringStore(context.Background(), "gateway", &core.Reference{
+				Id: "shared",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			kr, err := ks.Get(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			keys := [][]byte{}
+			kr.Try(func(shared *keyring.SharedKeys) {
+				keys = append(keys, []byte(shared.ClientKey))
+			})
+			return keys
+		}
+		It("should register all agents as the same cluster", func() {
+			clientKeys := make(chan []byte, 3)
+			for i := 0; i < 3; i++ {
+				go func() {
+					defer GinkgoRecover()
+					clientKeys <- bootstrapShared().ClientKey
+				}()
+			}
+			expected := [][]byte{}
+			for i := 0; i < 3; i++ {
+				expected = append(expected, <-clientKeys)
+			}
+
+			By("checking that a single cluster was created")
+			clusterList, err := mockClusterStore.ListClusters(context.Background(), &core.LabelSelector{}, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusterList.Items).To(HaveLen(1))
+			Expect(clusterList.Items[0].GetId()).To(Equal("shared"))
+			Expect(clusterList.Items[0].GetCapabilities()).To(ConsistOf(BeEquivalentTo(&core.ClusterCapability{
+				Name: "test",
+			})))
+
+			By("checking that every agent's keys are in the cluster's keyring")
+			Expect(storedClientKeys()).To(ConsistOf(expected))
+
+			t, err := mockTokenStore.GetToken(context.Background(), sharedToken.Reference())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.GetMetadata().GetUsageCount()).To(Equal(int64(3)))
+		})
+		It("should allow agents to join even if the cluster has the capability", func() {
+			first := bootstrapShared()
+			resp, err := client.Do(newRequest("/bootstrap/check", bootstrap.BootstrapCheckRequest{
+				Capability: "test",
+				ClientID:   "shared",
+			}))
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			second := bootstrapShared()
+			Expect(storedClientKeys()).To(ConsistOf([]byte(first.ClientKey), []byte(second.ClientKey)))
+		})
+		It("should reject agents with a different identity", func() {
+			ekp := ecdh.NewEphemeralKeyPair()
+			resp, err := client.Do(newRequest("/bootstrap/auth", bootstrap.BootstrapAuthRequest{
+				Capability:   "test",
+				ClientID:     "foo",
+				ClientPubKey: ekp.PublicKey,
+			}))
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+
+			resp, err = client.Do(newRequest("/bootstrap/check", bootstrap.BootstrapCheckRequest{
+				Capability: "test",
+				ClientID:   "foo",
+			}))
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+
+			_, err = mockClusterStore.GetCluster(context.Background(), &core.Reference{Id: "foo"})
+			Expect(err).To(MatchError(storage.ErrNotFound))
+		})
+	})
+	When("the token has a usage limit", func() {
+		const maxUsages = 3
+		var limitedToken *core.BootstrapToken
+		BeforeEach(func() {
+			var err error
+			limitedToken, err = mockTokenStore.CreateToken(context.Background(), 1*time.Hour,
+				storage.WithMaxUsages(maxUsages),
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		sendAuthRequest := func(clientID string) *http.Response {
+			rawToken, err := tokens.FromBootstrapToken(limitedToken)
+			Expect(err).NotTo(HaveOccurred())
+			jsonData, err := json.Marshal(rawToken)
+			Expect(err).NotTo(HaveOccurred())
+			sig, err := jws.Sign(jsonData, jwa.EdDSA, cert.PrivateKey)
+			Expect(err).NotTo(HaveOccurred())
+			ekp := ecdh.NewEphemeralKeyPair()
+			j, _ := json.Marshal(bootstrap.BootstrapAuthRequest{
+				Capability:   "test",
+				ClientID:     clientID,
+				ClientPubKey: ekp.PublicKey,
+			})
+			req, err := http.NewRequest("POST", *addr+"/bootstrap/auth", bytes.NewReader(j))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Add("Authorization", "Bearer "+string(sig))
+			req.Header.Set("Content-Type", "application/json")
+			req.Close = true
+			resp, err := client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			return resp
+		}
+		It("should only allow the token to be used up to the limit", func() {
+			for i := 0; i < maxUsages; i++ {
+				resp := sendAuthRequest(fmt.Sprintf("limited-%d", i))
+				resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			}
+			resp := sendAuthRequest("limited-extra")
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+			// the token is deleted once its last usage is consumed
+			Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeInvalidToken))
+
+			By("checking that the exhausted token was deleted")
+			_, err := mockTokenStore.GetToken(context.Background(), limitedToken.Reference())
+			Expect(err).To(MatchError(storage.ErrNotFound))
+		})
+		It("should reject tokens which have reached the limit", func() {
+			_, err := mockTokenStore.UpdateToken(context.Background(), limitedToken.Reference(),
+				func(t *core.BootstrapToken) {
+					t.Metadata.UsageCount = maxUsages
+				})
+			Expect(err).NotTo(HaveOccurred())
+			resp := sendAuthRequest("limited")
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+			Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeTokenUsageLimitReached))
+		})
+		It("should not count failed attempts", func() {
+			Expect(mockClusterStore.CreateCluster(context.Background(), &core.Cluster{
+				Id: "existing",
+				Metadata: &core.ClusterMetadata{
+					Capabilities: []*core.ClusterCapability{
+						{Name: "test"},
+					},
+				},
+			})).To(Succeed())
+			resp := sendAuthRequest("existing")
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusConflict))
+
+			t, err := mockTokenStore.GetToken(context.Background(), limitedToken.Reference())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.GetMetadata().GetUsageCount()).To(BeZero())
+		})
+		It("should not exceed the limit when used concurrently", func() {
+			const numRequests = 10
+			codes := make(chan int, numRequests)
+			var wg sync.WaitGroup
+			for i := 0; i < numRequests; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					resp := sendAuthRequest(fmt.Sprintf("concurrent-%d", i))
+					resp.Body.Close()
+					codes <- resp.StatusCode
+				}(i)
+			}
+			wg.Wait()
+			close(codes)
+			succeeded := 0
+			for code := range codes {
+				if code == http.StatusOK {
+					succeeded++
+				} else {
+					Expect(code).To(Equal(http.StatusUnauthorized))
+				}
+			}
+			Expect(succeeded).To(Equal(maxUsages))
+
+			clusterList, err := mockClusterStore.ListClusters(context.Background(), &core.LabelSelector{}, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusterList.Items).To(HaveLen(maxUsages))
+		})
+	})
+	When("sending a bootstrap check request", func() {
+		sendCheckRequest := func(t *core.BootstrapToken, checkReq bootstrap.BootstrapCheckRequest) *http.Response {
+			rawToken, err := tokens.FromBootstrapToken(t)
+			Expect(err).NotTo(HaveOccurred())
+			jsonData, err := json.Marshal(rawToken)
+			Expect(err).NotTo(HaveOccurred())
+			sig, err := jws.Sign(jsonData, jwa.EdDSA, cert.PrivateKey)
+			Expect(err).NotTo(HaveOccurred())
+			req, err := http.NewRequest("POST", *addr+"/bootstrap/check", nil)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Add("Authorization", "Bearer "+string(sig))
+			j, _ := json.Marshal(checkReq)
+			req.Header.Set("Content-Type", "application/json")
+			req.Body = io.NopCloser(bytes.NewReader(j))
+			resp, err := client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			return resp
+		}
+		When("an Authorization header is not given", func() {
+			It("should return http 401", func() {
+				req, err := http.NewRequest("POST", *addr+"/bootstrap/check", nil)
+				Expect(err).NotTo(HaveOccurred())
+				resp, err := client.Do(req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+		When("the request is invalid", func() {
+			It("should return http 400", func() {
+				resp := sendCheckRequest(token, bootstrap.BootstrapCheckRequest{
+					Capability: "test",
+				})
+				Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+		When("no cluster exists with the requested ID", func() {
+			It("should return http 200", func() {
+				resp := sendCheckRequest(token, bootstrap.BootstrapCheckRequest{
+					ClientID:   "foo",
+					Capability: "test",
+				})
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+				By("checking that the token was not consumed")
+				t, err := mockTokenStore.GetToken(context.Background(), token.Reference())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(t.GetMetadata().GetUsageCount()).To(BeZero())
+			})
+		})
+		When("a cluster already exists with the requested ID", func() {
+			BeforeEach(func() {
+				Expect(mockClusterStore.CreateCluster(context.Background(), &core.Cluster{
+					Id: "foo",
+					Metadata: &core.ClusterMetadata{
+						Capabilities: []*core.ClusterCapability{
+							{Name: "test"},
+						},
+					},
+				})).To(Succeed())
+			})
+			When("the cluster already has the requested capability", func() {
+				It("should return http 409", func() {
+					resp := sendCheckRequest(token, bootstrap.BootstrapCheckRequest{
+						ClientID:   "foo",
+						Capability: "test",
+					})
+					defer resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusConflict))
+					errResp := decodeErrorResponse(resp)
+					Expect(errResp.Code).To(Equal(bootstrap.ErrorCodeCapabilityAlreadyInstalled))
+					Expect(errResp.Message).To(ContainSubstring("already has the requested capability"))
+				})
+			})
+			When("the token does not have permission to join the cluster", func() {
+				It("should return http 409", func() {
+					resp := sendCheckRequest(token, bootstrap.BootstrapCheckRequest{
+						ClientID:   "foo",
+						Capability: "test2",
+					})
+					defer resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusConflict))
+					errResp := decodeErrorResponse(resp)
+					Expect(errResp.Code).To(Equal(bootstrap.ErrorCodeClusterIDConflict))
+					Expect(errResp.Message).To(ContainSubstring("does not have permission to join it"))
+				})
+			})
+			When("the token has permission to join the cluster", func() {
+				BeforeEach(func() {
+					_, err := mockTokenStore.UpdateToken(context.Background(), token.Reference(),
+						func(t *core.BootstrapToken) {
+							t.Metadata.Capabilities = append(t.Metadata.Capabilities, &core.TokenCapability{
+								Type:      string(capabilities.JoinExistingCluster),
+								Reference: &core.Reference{Id: "foo"},
+							})
 						})
+					Expect(err).NotTo(HaveOccurred())
+				})
+				It("should return http 200", func() {
+					resp := sendCheckRequest(token, bootstrap.BootstrapCheckRequest{
+						ClientID:   "foo",
+						Capability: "test2",
+					})
+					Expect(resp.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+		})
+	})
+	When("the signing certificate has been rotated", func() {
+		var previousCert *tls.Certificate
+		BeforeEach(func() {
+			crt, err := tls.X509KeyPair(test.TestData("localhost.crt"), test.TestData("localhost.key"))
+			Expect(err).NotTo(HaveOccurred())
+			previousCert = &crt
+			previousCerts = []*tls.Certificate{previousCert}
+		})
+		sendAuthRequest := func(key crypto.PrivateKey) *http.Response {
+			rawToken, err := tokens.FromBootstrapToken(token)
+			Expect(err).NotTo(HaveOccurred())
+			jsonData, err := json.Marshal(rawToken)
+			Expect(err).NotTo(HaveOccurred())
+			sig, err := jws.Sign(jsonData, jwa.EdDSA, key)
+			Expect(err).NotTo(HaveOccurred())
+			ekp := ecdh.NewEphemeralKeyPair()
+			j, _ := json.Marshal(bootstrap.BootstrapAuthRequest{
+				Capability:   "test",
+				ClientID:     "foo",
+				ClientPubKey: ekp.PublicKey,
+			})
+			req, err := http.NewRequest("POST", *addr+"/bootstrap/auth", bytes.NewReader(j))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Add("Authorization", "Bearer "+string(sig))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			return resp
+		}
+		It("should sign join responses using the primary certificate", func() {
+			resp, err := client.Post(*addr+"/bootstrap/join", "application/json", nil)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			joinResp := bootstrap.BootstrapJoinResponse{}
+			Expect(json.NewDecoder(resp.Body).Decode(&joinResp)).To(Succeed())
+
+			rawToken, err := tokens.FromBootstrapToken(token)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = rawToken.VerifyDetached(joinResp.Signatures[rawToken.HexID()], cert.Leaf.PublicKey)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("should accept auth requests signed using the primary certificate", func() {
+			resp := sendAuthRequest(cert.PrivateKey)
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+		It("should accept auth requests signed using a previous certificate", func() {
+			resp := sendAuthRequest(previousCert.PrivateKey)
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+		It("should reject auth requests signed using an unknown key", func() {
+			_, key, err := ed25519.GenerateKey(nil)
+			Expect(err).NotTo(HaveOccurred())
+			resp := sendAuthRequest(key)
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+			Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeInvalidToken))
+		})
+	})
+	When("auth requests are rate limited", func() {
+		BeforeEach(func() {
+			authRateLimiter = bootstrap.NewAuthRateLimiter(10, 2)
+		})
+		sendInvalidAuthRequest := func() *http.Response {
+			req, err := http.NewRequest("POST", *addr+"/bootstrap/auth", nil)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Add("Authorization", "Bearer invalid")
+			resp, err := client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			return resp
+		}
+		It("should reject requests beyond the burst before verifying the token", func() {
+			for i := 0; i < 2; i++ {
+				resp := sendInvalidAuthRequest()
+				resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+			}
+			resp := sendInvalidAuthRequest()
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+			Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeRateLimited))
+		})
+		It("should accept requests again once the bucket refills", func() {
+			for i := 0; i < 3; i++ {
+				resp := sendInvalidAuthRequest()
+				resp.Body.Close()
+			}
+			Eventually(func() int {
+				resp := sendInvalidAuthRequest()
+				resp.Body.Close()
+				return resp.StatusCode
+			}, time.Second, 50*time.Millisecond).Should(Equal(http.StatusUnauthorized))
+		})
+		It("should rate limit check requests", func() {
+			sendInvalidCheckRequest := func() *http.Response {
+				req, err := http.NewRequest("POST", *addr+"/bootstrap/check", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Add("Authorization", "Bearer invalid")
+				resp, err := client.Do(req)
+				Expect(err).NotTo(HaveOccurred())
+				return resp
+			}
+			for i := 0; i < 2; i++ {
+				resp := sendInvalidCheckRequest()
+				resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+			}
+			resp := sendInvalidCheckRequest()
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+			Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeRateLimited))
+
+			By("sharing the limit with auth requests")
+			resp = sendInvalidAuthRequest()
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+		})
+		It("should not rate limit join requests", func() {
+			for i := 0; i < 5; i++ {
+				resp, err := client.Post(*addr+"/bootstrap/join", "application/json", nil)
+				Expect(err).NotTo(HaveOccurred())
+				resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			}
+		})
+	})
+	When("TLS is required for bootstrap requests", func() {
+		BeforeEach(func() {
+			requireTLS = true
+		})
+		When("requests are sent over a plaintext connection", func() {
+			It("should reject join requests", func() {
+				resp, err := client.Post(*addr+"/bootstrap/join", "application/json", nil)
+				Expect(err).NotTo(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeTLSRequired))
+			})
+			It("should reject auth requests", func() {
+				resp, err := client.Post(*addr+"/bootstrap/auth", "application/json", nil)
+				Expect(err).NotTo(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeTLSRequired))
+			})
+		})
+		When("requests are sent over TLS", func() {
+			BeforeEach(func() {
+				serveTLS = true
+			})
+			It("should allow bootstrap requests", func() {
+				resp, err := client.Post(*addr+"/bootstrap/join", "application/json", nil)
+				Expect(err).NotTo(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			})
+		})
+	})
+	When("TLS is not required for bootstrap requests", func() {
+		It("should allow plaintext bootstrap requests", func() {
+			resp, err := client.Post(*addr+"/bootstrap/join", "application/json", nil)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+	})
+	When("an audit logger is configured", func() {
+		var recorder *testAuditLogger
+		BeforeEach(func() {
+			recorder = &testAuditLogger{}
+			auditLogger = recorder
+		})
+		sendAuthRequest := func(signingKey crypto.PrivateKey) *http.Response {
+			rawToken, err := tokens.FromBootstrapToken(token)
+			Expect(err).NotTo(HaveOccurred())
+			jsonData, err := json.Marshal(rawToken)
+			Expect(err).NotTo(HaveOccurred())
+			sig, err := jws.Sign(jsonData, jwa.EdDSA, signingKey)
+			Expect(err).NotTo(HaveOccurred())
+			j, _ := json.Marshal(bootstrap.BootstrapAuthRequest{
+				Capability:   "test",
+				ClientID:     "foo",
+				ClientPubKey: ecdh.NewEphemeralKeyPair().PublicKey,
+			})
+			req, err := http.NewRequest("POST", *addr+"/bootstrap/auth", bytes.NewReader(j))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Add("Authorization", "Bearer "+string(sig))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			return resp
+		}
+		tokenIDHash := func() string {
+			sum := sha256.Sum256([]byte(token.GetTokenID()))
+			return hex.EncodeToString(sum[:])
+		}
+		It("should record join requests", func() {
+			resp, err := client.Post(*addr+"/bootstrap/join", "application/json", nil)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			events := recorder.Events()
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Action).To(Equal(bootstrap.BootstrapActionJoin))
+			Expect(events[0].Outcome).To(Equal(bootstrap.BootstrapOutcomeSuccess))
+			Expect(events[0].StatusCode).To(Equal(http.StatusOK))
+			Expect(events[0].ClientID).To(BeEmpty())
+			Expect(events[0].TokenIDHash).To(BeEmpty())
+		})
+		It("should record successful auth requests", func() {
+			resp := sendAuthRequest(cert.PrivateKey)
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			events := recorder.Events()
+			Expect(events).To(HaveLen(1))
+			event := events[0]
+			Expect(event.Action).To(Equal(bootstrap.BootstrapActionAuth))
+			Expect(event.Outcome).To(Equal(bootstrap.BootstrapOutcomeSuccess))
+			Expect(event.StatusCode).To(Equal(http.StatusOK))
+			Expect(event.ClientID).To(Equal("foo"))
+			Expect(event.TokenIDHash).To(Equal(tokenIDHash()))
+			Expect(event.TokenIDHash).NotTo(ContainSubstring(token.GetTokenID()))
+			Expect(event.RemoteAddr).NotTo(BeEmpty())
+			Expect(event.Timestamp).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+		It("should record conflicting auth requests", func() {
+			resp := sendAuthRequest(cert.PrivateKey)
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			resp = sendAuthRequest(cert.PrivateKey)
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusConflict))
+
+			events := recorder.Events()
+			Expect(events).To(HaveLen(2))
+			Expect(events[1].Action).To(Equal(bootstrap.BootstrapActionAuth))
+			Expect(events[1].Outcome).To(Equal(string(bootstrap.ErrorCodeCapabilityAlreadyInstalled)))
+			Expect(events[1].StatusCode).To(Equal(http.StatusConflict))
+			Expect(events[1].ClientID).To(Equal("foo"))
+			Expect(events[1].TokenIDHash).To(Equal(tokenIDHash()))
+		})
+		It("should record unauthorized auth requests", func() {
+			_, key, err := ed25519.GenerateKey(nil)
+			Expect(err).NotTo(HaveOccurred())
+			resp := sendAuthRequest(key)
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+
+			events := recorder.Events()
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Action).To(Equal(bootstrap.BootstrapActionAuth))
+			Expect(events[0].Outcome).To(Equal(string(bootstrap.ErrorCodeInvalidToken)))
+			Expect(events[0].StatusCode).To(Equal(http.StatusUnauthorized))
+			Expect(events[0].TokenIDHash).To(BeEmpty())
+		})
+		It("should not record requests to unknown paths", func() {
+			resp, err := client.Post(*addr+"/bootstrap/foo", "application/json", nil)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+			Expect(recorder.Events()).To(BeEmpty())
+		})
+	})
+	Context("ECDH curve selection", func() {
+		sendAuthRequest := func(ekp ecdh.EphemeralKeyPair, requestedCurve ecdh.Curve) *http.Response {
+			rawToken, err := tokens.FromBootstrapToken(token)
+			Expect(err).NotTo(HaveOccurred())
+			jsonData, err := json.Marshal(rawToken)
+			Expect(err).NotTo(HaveOccurred())
+			sig, err := jws.Sign(jsonData, jwa.EdDSA, cert.PrivateKey)
+			Expect(err).NotTo(HaveOccurred())
+			j, _ := json.Marshal(bootstrap.BootstrapAuthRequest{
+				Capability:   "test",
+				ClientID:     "foo",
+				ClientPubKey: ekp.PublicKey,
+				Curve:        requestedCurve,
+			})
+			req, err := http.NewRequest("POST", *addr+"/bootstrap/auth", bytes.NewReader(j))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Add("Authorization", "Bearer "+string(sig))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			return resp
+		}
+		storedKeys := func() *keyring.SharedKeys {
+			ks, err := mockKeyringStoreBroker.KeyringStore(context.Background(), "gateway", &core.Reference{
+				Id: "foo",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			kr, err := ks.Get(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			var keys *keyring.SharedKeys
+			kr.Try(func(shared *keyring.SharedKeys) {
+				keys = shared
+			})
+			Expect(keys).NotTo(BeNil())
+			return keys
+		}
+		for _, curve := range []ecdh.Curve{ecdh.CurveX25519, ecdh.CurveP256} {
+			curve := curve
+			When(fmt.Sprintf("the server is configured to use %s", curve), func() {
+				BeforeEach(func() {
+					serverCurve = curve
+				})
+				It("should derive the same shared keys as the client", func() {
+					ekp, err := ecdh.NewEphemeralKeyPairForCurve(curve)
+					Expect(err).NotTo(HaveOccurred())
+					resp := sendAuthRequest(ekp, curve)
+					defer resp.Body.Close()
+					Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+					var authResp bootstrap.BootstrapAuthResponse
+					Expect(json.NewDecoder(resp.Body).Decode(&authResp)).To(Succeed())
+					secret, err := ecdh.DeriveSharedSecret(ekp, ecdh.PeerPublicKey{
+						PublicKey: authResp.ServerPubKey,
+						PeerType:  ecdh.PeerTypeServer,
 					})
+					Expect(err).NotTo(HaveOccurred())
+					clientKeys := keyring.NewSharedKeys(secret)
+
+					serverKeys := storedKeys()
+					Expect(serverKeys.ClientKey).To(Equal(clientKeys.ClientKey))
+					Expect(serverKeys.ServerKey).To(Equal(clientKeys.ServerKey))
 				})
 			})
+		}
+		When("the server is configured to use p256", func() {
+			BeforeEach(func() {
+				serverCurve = ecdh.CurveP256
+			})
+			It("should reject clients requesting the default curve", func() {
+				resp := sendAuthRequest(ecdh.NewEphemeralKeyPair(), "")
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+				errResp := decodeErrorResponse(resp)
+				Expect(errResp.Code).To(Equal(bootstrap.ErrorCodeUnsupportedCurve))
+				Expect(errResp.Message).To(ContainSubstring("x25519"))
+				Expect(errResp.Message).To(ContainSubstring("p256"))
+			})
+			It("should reject public keys which are not on the curve", func() {
+				resp := sendAuthRequest(ecdh.NewEphemeralKeyPair(), ecdh.CurveP256)
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeInvalidRequest))
+			})
+		})
+		When("the server uses the default curve", func() {
+			It("should reject clients requesting p256", func() {
+				ekp, err := ecdh.NewEphemeralKeyPairForCurve(ecdh.CurveP256)
+				Expect(err).NotTo(HaveOccurred())
+				resp := sendAuthRequest(ekp, ecdh.CurveP256)
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeUnsupportedCurve))
+			})
+			It("should reject unknown curves", func() {
+				resp := sendAuthRequest(ecdh.NewEphemeralKeyPair(), "p521")
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeInvalidRequest))
+			})
+		})
+	})
+	Context("client ID validation", func() {
+		const uuid = "5f3c1d2e-8a4b-4c6d-9e0f-123456789abc"
+		sendRequest := func(path string, clientID string) *http.Response {
+			rawToken, err := tokens.FromBootstrapToken(token)
+			Expect(err).NotTo(HaveOccurred())
+			jsonData, err := json.Marshal(rawToken)
+			Expect(err).NotTo(HaveOccurred())
+			sig, err := jws.Sign(jsonData, jwa.EdDSA, cert.PrivateKey)
+			Expect(err).NotTo(HaveOccurred())
+			var body any
+			if path == "/bootstrap/auth" {
+				body = bootstrap.BootstrapAuthRequest{
+					ClientID:     clientID,
+					ClientPubKey: ecdh.NewEphemeralKeyPair().PublicKey,
+					Capability:   "test",
+				}
+			} else {
+				body = bootstrap.BootstrapCheckRequest{
+					ClientID:   clientID,
+					Capability: "test",
+				}
+			}
+			j, _ := json.Marshal(body)
+			req, err := http.NewRequest("POST", *addr+path, bytes.NewReader(j))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Add("Authorization", "Bearer "+string(sig))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			return resp
+		}
+		expectRejected := func(path string, clientID string) {
+			resp := sendRequest(path, clientID)
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(decodeErrorResponse(resp).Code).To(Equal(bootstrap.ErrorCodeInvalidRequest))
+
+			clusters, err := mockClusterStore.ListClusters(context.Background(), &core.LabelSelector{}, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusters.Items).To(BeEmpty())
+			t, err := mockTokenStore.GetToken(context.Background(), token.Reference())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.GetMetadata().GetUsageCount()).To(BeZero())
+		}
+		When("no client ID pattern is configured", func() {
+			DescribeTable("should reject invalid IDs",
+				expectRejected,
+				Entry("empty ID, auth", "/bootstrap/auth", ""),
+				Entry("empty ID, check", "/bootstrap/check", ""),
+				Entry("ID with slashes, auth", "/bootstrap/auth", "foo/../bar"),
+				Entry("ID with slashes, check", "/bootstrap/check", "foo/bar"),
+				Entry("overly long ID", "/bootstrap/auth", strings.Repeat("a", 129)),
+			)
+			It("should accept UUIDs", func() {
+				resp := sendRequest("/bootstrap/auth", uuid)
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			})
+			It("should accept other valid IDs", func() {
+				resp := sendRequest("/bootstrap/auth", "foo")
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			})
+		})
+		When("client IDs must be UUIDs", func() {
+			BeforeEach(func() {
+				clientIDPattern = bootstrap.ClientIDPatternUUID
+			})
+			DescribeTable("should reject IDs which are not UUIDs",
+				expectRejected,
+				Entry("empty ID", "/bootstrap/auth", ""),
+				Entry("ID with slashes", "/bootstrap/auth", "5f3c1d2e/8a4b/4c6d/9e0f/123456789abc"),
+				Entry("non-UUID ID, auth", "/bootstrap/auth", "foo"),
+				Entry("non-UUID ID, check", "/bootstrap/check", "foo"),
+				Entry("UUID with a suffix", "/bootstrap/auth", uuid+"-foo"),
+			)
+			It("should accept UUIDs in auth requests", func() {
+				resp := sendRequest("/bootstrap/auth", uuid)
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+				_, err := mockClusterStore.GetCluster(context.Background(), &core.Reference{Id: uuid})
+				Expect(err).NotTo(HaveOccurred())
+			})
+			It("should accept UUIDs in check requests", func() {
+				resp := sendRequest("/bootstrap/check", uuid)
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			})
+		})
+	})
+	When("bootstrap is disabled", func() {
+		BeforeEach(func() {
+			bootstrapDisabled = true
+		})
+		DescribeTable("should return http 503",
+			func(method string, path string) {
+				req, err := http.NewRequest(method, *addr+path, nil)
+				Expect(err).NotTo(HaveOccurred())
+				resp, err := client.Do(req)
+				Expect(err).NotTo(HaveOccurred())
+				defer resp.Body.Close()
+				Expect(resp.StatusCode).To(Equal(http.StatusServiceUnavailable))
+				errResp := decodeErrorResponse(resp)
+				Expect(errResp.Code).To(Equal(bootstrap.ErrorCodeBootstrapDisabled))
+				Expect(errResp.Message).To(ContainSubstring("disabled"))
+			},
+			Entry(nil, http.MethodGet, "/bootstrap/join"),
+			Entry(nil, http.MethodPost, "/bootstrap/join"),
+			Entry(nil, http.MethodPost, "/bootstrap/auth"),
+			Entry(nil, http.MethodPost, "/bootstrap/check"),
+		)
+		It("should return http 404 for invalid paths", func() {
+			resp, err := client.Post(*addr+"/bootstrap/foo", "application/json", nil)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
 		})
 	})
 	When("sending a request to an invalid path", func() {
@@ -427,4 +1734,33 @@ var _ = Describe("Server", Label(test.Unit, test.Slow), func() {
 			Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
 		})
 	})
+	DescribeTable("sending requests with different methods",
+		func(method string, path string, expectedStatus int, expectedAllow string) {
+			req, err := http.NewRequest(method, *addr+path, nil)
+			Expect(err).NotTo(HaveOccurred())
+			resp, err := client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(expectedStatus))
+			Expect(resp.Header.Get("Allow")).To(Equal(expectedAllow))
+		},
+		Entry(nil, http.MethodGet, "/bootstrap/join", http.StatusOK, ""),
+		Entry(nil, http.MethodPost, "/bootstrap/join", http.StatusOK, ""),
+		Entry(nil, http.MethodPut, "/bootstrap/join", http.StatusMethodNotAllowed, "GET, POST"),
+		Entry(nil, http.MethodPatch, "/bootstrap/join", http.StatusMethodNotAllowed, "GET, POST"),
+		Entry(nil, http.MethodDelete, "/bootstrap/join", http.StatusMethodNotAllowed, "GET, POST"),
+		Entry(nil, http.MethodOptions, "/bootstrap/join", http.StatusMethodNotAllowed, "GET, POST"),
+		Entry(nil, http.MethodPost, "/bootstrap/auth", http.StatusUnauthorized, ""),
+		Entry(nil, http.MethodGet, "/bootstrap/auth", http.StatusMethodNotAllowed, "POST"),
+		Entry(nil, http.MethodPut, "/bootstrap/auth", http.StatusMethodNotAllowed, "POST"),
+		Entry(nil, http.MethodPatch, "/bootstrap/auth", http.StatusMethodNotAllowed, "POST"),
+		Entry(nil, http.MethodDelete, "/bootstrap/auth", http.StatusMethodNotAllowed, "POST"),
+		Entry(nil, http.MethodOptions, "/bootstrap/auth", http.StatusMethodNotAllowed, "POST"),
+		Entry(nil, http.MethodPost, "/bootstrap/check", http.StatusUnauthorized, ""),
+		Entry(nil, http.MethodGet, "/bootstrap/check", http.StatusMethodNotAllowed, "POST"),
+		Entry(nil, http.MethodPut, "/bootstrap/check", http.StatusMethodNotAllowed, "POST"),
+		Entry(nil, http.MethodGet, "/bootstrap/foo", http.StatusNotFound, ""),
+		Entry(nil, http.MethodPost, "/bootstrap/foo", http.StatusNotFound, ""),
+		Entry(nil, http.MethodDelete, "/bootstrap/foo", http.StatusNotFound, ""),
+	)
 })
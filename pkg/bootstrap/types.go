@@ -3,6 +3,7 @@ package bootstrap
 import (
 	"context"
 
+	"github.com/rancher/opni-monitoring/pkg/ecdh"
 	"github.com/rancher/opni-monitoring/pkg/ident"
 	"github.com/rancher/opni-monitoring/pkg/keyring"
 	"github.com/rancher/opni-monitoring/pkg/validation"
@@ -14,13 +15,25 @@ type Bootstrapper interface {
 }
 
 type BootstrapJoinResponse struct {
-	Signatures map[string][]byte `json:"signatures"`
+	Signatures       map[string][]byte `json:"signatures"`
+	ProtocolVersions []ProtocolVersion `json:"protocol_versions,omitempty"`
 }
 
 type BootstrapAuthRequest struct {
 	ClientID     string `json:"client_id"`
 	ClientPubKey []byte `json:"client_pub_key"`
 	Capability   string `json:"capability"`
+	// ProtocolVersion is the protocol version selected by the client. If
+	// unset, ProtocolV1 is assumed.
+	ProtocolVersion ProtocolVersion `json:"protocol_version,omitempty"`
+	// Curve is the ECDH curve of ClientPubKey. If unset, ecdh.DefaultCurve
+	// is assumed.
+	Curve ecdh.Curve `json:"curve,omitempty"`
+}
+
+type BootstrapCheckRequest struct {
+	ClientID   string `json:"client_id"`
+	Capability string `json:"capability"`
 }
 
 type BootstrapAuthResponse struct {
@@ -40,5 +53,24 @@ func (h BootstrapAuthRequest) Validate() error {
 	if h.Capability == "" {
 		return validation.Errorf("%w: %s", validation.ErrMissingRequiredField, "capability")
 	}
+	if h.ProtocolVersion != 0 && !h.ProtocolVersion.IsSupported() {
+		return validation.Errorf("%w: %s", validation.ErrInvalidValue, "protocol_version")
+	}
+	if !h.Curve.IsSupported() {
+		return validation.Errorf("%w: %s", validation.ErrInvalidValue, "curve")
+	}
+	return nil
+}
+
+func (h BootstrapCheckRequest) Validate() error {
+	if h.ClientID == "" {
+		return validation.Errorf("%w: %s", validation.ErrMissingRequiredField, "client_id")
+	}
+	if err := validation.ValidateID(h.ClientID); err != nil {
+		return validation.ErrInvalidID
+	}
+	if h.Capability == "" {
+		return validation.Errorf("%w: %s", validation.ErrMissingRequiredField, "capability")
+	}
 	return nil
 }
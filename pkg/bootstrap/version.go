@@ -0,0 +1,78 @@
+package bootstrap
+
+import (
+	"errors"
+	"sort"
+)
+
+// ProtocolVersion identifies a revision of the bootstrap protocol. The join
+// response advertises the versions supported by the server, and the client
+// sends the version it selected in the auth request.
+type ProtocolVersion int
+
+const (
+	// ProtocolV1 is the original bootstrap protocol. Clients and servers which
+	// do not advertise a protocol version are assumed to use it.
+	ProtocolV1 ProtocolVersion = 1
+	// ProtocolV2 adds the pre-flight cluster ID check.
+	ProtocolV2 ProtocolVersion = 2
+)
+
+// SupportedProtocolVersions lists the protocol versions supported by this
+// implementation, in ascending order.
+var SupportedProtocolVersions = []ProtocolVersion{
+	ProtocolV1,
+	ProtocolV2,
+}
+
+var ErrUnsupportedProtocolVersion = errors.New("unsupported bootstrap protocol version")
+
+// Feature is an optional part of the bootstrap protocol which is only
+// available starting at a specific protocol version.
+type Feature int
+
+const (
+	// FeaturePreflightCheck allows the client to check whether its cluster ID
+	// is available before sending the auth request.
+	FeaturePreflightCheck Feature = iota
+)
+
+var featureVersions = map[Feature]ProtocolVersion{
+	FeaturePreflightCheck: ProtocolV2,
+}
+
+// Supports returns true if the feature is available at this protocol version.
+func (v ProtocolVersion) Supports(f Feature) bool {
+	min, ok := featureVersions[f]
+	return ok && v >= min
+}
+
+// IsSupported returns true if this protocol version is supported by this
+// implementation.
+func (v ProtocolVersion) IsSupported() bool {
+	for _, sv := range SupportedProtocolVersions {
+		if v == sv {
+			return true
+		}
+	}
+	return false
+}
+
+// NegotiateProtocolVersion returns the highest protocol version supported by
+// both this implementation and the remote peer. If the remote peer does not
+// advertise any versions, it is assumed to only support ProtocolV1.
+func NegotiateProtocolVersion(remote []ProtocolVersion) (ProtocolVersion, error) {
+	if len(remote) == 0 {
+		remote = []ProtocolVersion{ProtocolV1}
+	}
+	sorted := append([]ProtocolVersion(nil), remote...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] > sorted[j]
+	})
+	for _, v := range sorted {
+		if v.IsSupported() {
+			return v, nil
+		}
+	}
+	return 0, ErrUnsupportedProtocolVersion
+}
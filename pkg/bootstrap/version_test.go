@@ -0,0 +1,33 @@
+package bootstrap_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/bootstrap"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Protocol Versions", Label(test.Unit), func() {
+	DescribeTable("negotiating a protocol version",
+		func(remote []bootstrap.ProtocolVersion, expected bootstrap.ProtocolVersion, expectedErr error) {
+			v, err := bootstrap.NegotiateProtocolVersion(remote)
+			if expectedErr != nil {
+				Expect(err).To(MatchError(expectedErr))
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v).To(Equal(expected))
+		},
+		Entry("remote advertises no versions", nil, bootstrap.ProtocolV1, nil),
+		Entry("remote only supports v1", []bootstrap.ProtocolVersion{1}, bootstrap.ProtocolV1, nil),
+		Entry("remote supports v1 and v2", []bootstrap.ProtocolVersion{1, 2}, bootstrap.ProtocolV2, nil),
+		Entry("remote supports a newer version", []bootstrap.ProtocolVersion{1, 2, 3}, bootstrap.ProtocolV2, nil),
+		Entry("remote versions are unordered", []bootstrap.ProtocolVersion{2, 3, 1}, bootstrap.ProtocolV2, nil),
+		Entry("no common versions", []bootstrap.ProtocolVersion{3}, bootstrap.ProtocolVersion(0), bootstrap.ErrUnsupportedProtocolVersion),
+	)
+	It("should gate features on the protocol version", func() {
+		Expect(bootstrap.ProtocolV1.Supports(bootstrap.FeaturePreflightCheck)).To(BeFalse())
+		Expect(bootstrap.ProtocolV2.Supports(bootstrap.FeaturePreflightCheck)).To(BeTrue())
+	})
+})
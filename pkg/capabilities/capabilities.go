@@ -23,6 +23,25 @@ func Has[T core.MetadataAccessor[U], U core.Capability[U]](
 	return false
 }
 
+type AllowlistAccessor interface {
+	GetAllowedCapabilities() []string
+}
+
+// Allows reports whether the given capability is in the object's capability
+// allowlist. An empty allowlist allows all capabilities.
+func Allows(accessor AllowlistAccessor, capability string) bool {
+	allowed := accessor.GetAllowedCapabilities()
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, name := range allowed {
+		if name == capability {
+			return true
+		}
+	}
+	return false
+}
+
 type Installer interface {
 	CanInstall(capabilities ...string) error
 	InstallCapabilities(target *core.Reference, capabilities ...string)
@@ -7,3 +7,14 @@ func Cluster(name string) *core.ClusterCapability {
 		Name: name,
 	}
 }
+
+// Paused returns true if the named capability is installed on the cluster
+// and has been paused.
+func Paused(cluster *core.Cluster, name string) bool {
+	for _, cap := range cluster.GetCapabilities() {
+		if cap.GetName() == name {
+			return cap.GetPaused()
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package capabilities
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/rancher/opni-monitoring/pkg/core"
 	"github.com/rancher/opni-monitoring/pkg/plugins/apis/capability"
@@ -17,6 +18,8 @@ type BackendStore interface {
 	Get(name string) (capability.Backend, error)
 	Add(name string, backend capability.Backend) error
 	List() []string
+	// Replace atomically replaces all backends in the store.
+	Replace(backends map[string]capability.Backend)
 	RenderInstaller(name string, spec UserInstallerTemplateSpec) (string, error)
 	CanInstall(capabilities ...string) error
 	InstallCapabilities(cluster *core.Reference, capabilities ...string)
@@ -25,6 +28,7 @@ type BackendStore interface {
 type backendStore struct {
 	serverSpec ServerInstallerTemplateSpec
 	backends   map[string]capability.Backend
+	backendsMu sync.RWMutex
 	logger     *zap.SugaredLogger
 }
 
@@ -37,6 +41,8 @@ func NewBackendStore(serverSpec ServerInstallerTemplateSpec, logger *zap.Sugared
 }
 
 func (s *backendStore) Get(name string) (capability.Backend, error) {
+	s.backendsMu.RLock()
+	defer s.backendsMu.RUnlock()
 	if backend, ok := s.backends[name]; !ok {
 		return nil, fmt.Errorf("%w: %s", ErrBackendNotFound, name)
 	} else {
@@ -45,6 +51,8 @@ func (s *backendStore) Get(name string) (capability.Backend, error) {
 }
 
 func (s *backendStore) Add(name string, backend capability.Backend) error {
+	s.backendsMu.Lock()
+	defer s.backendsMu.Unlock()
 	if _, ok := s.backends[name]; ok {
 		return fmt.Errorf("%w: %s", ErrBackendAlreadyExists, name)
 	}
@@ -53,6 +61,8 @@ func (s *backendStore) Add(name string, backend capability.Backend) error {
 }
 
 func (s *backendStore) List() []string {
+	s.backendsMu.RLock()
+	defer s.backendsMu.RUnlock()
 	capabilities := make([]string, 0, len(s.backends))
 	for capability := range s.backends {
 		capabilities = append(capabilities, capability)
@@ -60,6 +70,15 @@ func (s *backendStore) List() []string {
 	return capabilities
 }
 
+func (s *backendStore) Replace(backends map[string]capability.Backend) {
+	s.backendsMu.Lock()
+	defer s.backendsMu.Unlock()
+	s.backends = make(map[string]capability.Backend, len(backends))
+	for name, backend := range backends {
+		s.backends[name] = backend
+	}
+}
+
 func (s *backendStore) RenderInstaller(name string, spec UserInstallerTemplateSpec) (string, error) {
 	backend, err := s.Get(name)
 	if err != nil {
@@ -72,6 +91,8 @@ func (s *backendStore) RenderInstaller(name string, spec UserInstallerTemplateSp
 }
 
 func (s *backendStore) CanInstall(capabilities ...string) error {
+	s.backendsMu.RLock()
+	defer s.backendsMu.RUnlock()
 	for _, capability := range capabilities {
 		lg := s.logger.With(
 			"capability", capability,
@@ -99,6 +120,8 @@ func (s *backendStore) InstallCapabilities(
 	cluster *core.Reference,
 	capabilities ...string,
 ) {
+	s.backendsMu.RLock()
+	defer s.backendsMu.RUnlock()
 	lg := s.logger.With(
 		"cluster", cluster.GetId(),
 	)
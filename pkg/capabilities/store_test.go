@@ -6,6 +6,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/rancher/opni-monitoring/pkg/capabilities"
 	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/plugins/apis/capability"
 	"github.com/rancher/opni-monitoring/pkg/test"
 )
 
@@ -60,6 +61,28 @@ var _ = Describe("Store", Ordered, Label("unit"), func() {
 			Expect(store.Add("capability1", backend1)).To(MatchError(capabilities.ErrBackendAlreadyExists))
 		})
 	})
+	When("replacing items in the store", func() {
+		It("should replace all existing items", func() {
+			backend1 := test.NewTestCapabilityBackend(ctrl, &test.CapabilityInfo{
+				Name:              "capability1",
+				CanInstall:        true,
+				InstallerTemplate: "foo",
+			})
+			backend2 := test.NewTestCapabilityBackend(ctrl, &test.CapabilityInfo{
+				Name:              "capability2",
+				CanInstall:        true,
+				InstallerTemplate: "bar",
+			})
+			Expect(store.Add("capability1", backend1)).To(Succeed())
+			store.Replace(map[string]capability.Backend{
+				"capability2": backend2,
+			})
+			Expect(store.List()).To(ConsistOf("capability2"))
+			_, err := store.Get("capability1")
+			Expect(err).To(MatchError(capabilities.ErrBackendNotFound))
+			Expect(store.Get("capability2")).To(Equal(backend2))
+		})
+	})
 	When("getting items from the store", func() {
 		It("should return an error if the item does not exist", func() {
 			_, err := store.Get("capability1")
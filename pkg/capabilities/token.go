@@ -6,6 +6,10 @@ type TokenCapabilities string
 
 const (
 	JoinExistingCluster TokenCapabilities = "join_existing_cluster"
+	// SharedIdentity allows any number of agents to bootstrap as the
+	// referenced cluster. Instead of failing because the cluster already
+	// exists, each agent's session keys are appended to the cluster's keyring.
+	SharedIdentity TokenCapabilities = "shared_identity"
 )
 
 func (tc TokenCapabilities) For(ref *core.Reference) *core.TokenCapability {
@@ -14,3 +18,14 @@ func (tc TokenCapabilities) For(ref *core.Reference) *core.TokenCapability {
 		Reference: ref,
 	}
 }
+
+// SharedIdentityOf returns the cluster referenced by the token's
+// SharedIdentity capability, or nil if the token does not have one.
+func SharedIdentityOf(token *core.BootstrapToken) *core.Reference {
+	for _, tc := range token.GetCapabilities() {
+		if tc.GetType() == string(SharedIdentity) && tc.GetReference() != nil {
+			return tc.GetReference()
+		}
+	}
+	return nil
+}
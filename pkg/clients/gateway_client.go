@@ -3,6 +3,9 @@ package clients
 import (
 	"context"
 	"crypto/tls"
+	"net/http"
+	"strconv"
+	"time"
 
 	"emperror.dev/errors"
 
@@ -13,12 +16,24 @@ import (
 	"github.com/rancher/opni-monitoring/pkg/pkp"
 )
 
+const (
+	// Maximum number of times a request is retried after the gateway
+	// responds with 503 Service Unavailable and a Retry-After header, as it
+	// does while draining.
+	maxUnavailableRetries = 3
+	// Maximum time to wait before retrying such a request, regardless of
+	// the Retry-After header.
+	maxRetryAfter = 5 * time.Second
+)
+
 type RequestBuilder interface {
 	// Sets a request header
 	Set(key, value string) RequestBuilder
 	// Sets the request body
 	Body(body []byte) RequestBuilder
-	// Sends the request
+	// Sends the request. If the gateway responds with 503 Service
+	// Unavailable and a Retry-After header, such as while it is draining,
+	// the request is retried on a new connection after the given delay.
 	Do() (code int, body []byte, err error)
 
 	// Deprecated: use Do() instead
@@ -36,11 +51,47 @@ type GatewayHTTPClient interface {
 	Delete(ctx context.Context, path string) RequestBuilder
 }
 
+type GatewayHTTPClientOptions struct {
+	spiffeID         string
+	sessionCacheSize int
+}
+
+type GatewayHTTPClientOption func(*GatewayHTTPClientOptions)
+
+func (o *GatewayHTTPClientOptions) Apply(opts ...GatewayHTTPClientOption) {
+	for _, op := range opts {
+		op(o)
+	}
+}
+
+// WithGatewaySPIFFEID requires the gateway's serving certificate to contain
+// a URI SAN matching the given SPIFFE ID, in addition to matching one of the
+// pinned public keys in the keyring.
+func WithGatewaySPIFFEID(id string) GatewayHTTPClientOption {
+	return func(o *GatewayHTTPClientOptions) {
+		o.spiffeID = id
+	}
+}
+
+// WithTLSSessionCacheSize sets the number of TLS sessions cached by the
+// client, which allows new connections to the gateway to resume a previous
+// session instead of performing a full handshake. If unset or zero, a
+// default size is used.
+func WithTLSSessionCacheSize(size int) GatewayHTTPClientOption {
+	return func(o *GatewayHTTPClientOptions) {
+		o.sessionCacheSize = size
+	}
+}
+
 func NewGatewayHTTPClient(
 	address string,
 	ip ident.Provider,
 	kr keyring.Keyring,
+	opts ...GatewayHTTPClientOption,
 ) (GatewayHTTPClient, error) {
+	options := GatewayHTTPClientOptions{}
+	options.Apply(opts...)
+
 	if address[len(address)-1] == '/' {
 		address = address[:len(address)-1]
 	}
@@ -75,10 +126,15 @@ func NewGatewayHTTPClient(
 	if pkpKey == nil {
 		return nil, errors.New("keyring is missing PKP key")
 	}
-	tlsConfig, err := pkp.TLSConfig(pkpKey.PinnedKeys)
+	var tlsOptions []pkp.TLSConfigOption
+	if options.spiffeID != "" {
+		tlsOptions = append(tlsOptions, pkp.WithSPIFFEID(options.spiffeID))
+	}
+	tlsConfig, err := pkp.TLSConfig(pkpKey.PinnedKeys, tlsOptions...)
 	if err != nil {
 		return nil, err
 	}
+	tlsConfig.ClientSessionCache = tls.NewLRUClientSessionCache(options.sessionCacheSize)
 	return &gatewayClient{
 		address:    address,
 		id:         id,
@@ -104,6 +160,7 @@ func (gc *gatewayClient) requestPath(path string) string {
 func (gc *gatewayClient) Get(ctx context.Context, path string) RequestBuilder {
 	return &requestBuilder{
 		gatewayClient: gc,
+		ctx:           ctx,
 		req:           fiber.Get(gc.requestPath(path)).TLSConfig(gc.tlsConfig),
 	}
 }
@@ -111,6 +168,7 @@ func (gc *gatewayClient) Get(ctx context.Context, path string) RequestBuilder {
 func (gc *gatewayClient) Head(ctx context.Context, path string) RequestBuilder {
 	return &requestBuilder{
 		gatewayClient: gc,
+		ctx:           ctx,
 		req:           fiber.Head(gc.requestPath(path)).TLSConfig(gc.tlsConfig),
 	}
 }
@@ -118,6 +176,7 @@ func (gc *gatewayClient) Head(ctx context.Context, path string) RequestBuilder {
 func (gc *gatewayClient) Post(ctx context.Context, path string) RequestBuilder {
 	return &requestBuilder{
 		gatewayClient: gc,
+		ctx:           ctx,
 		req:           fiber.Post(gc.requestPath(path)).TLSConfig(gc.tlsConfig),
 	}
 }
@@ -125,6 +184,7 @@ func (gc *gatewayClient) Post(ctx context.Context, path string) RequestBuilder {
 func (gc *gatewayClient) Put(ctx context.Context, path string) RequestBuilder {
 	return &requestBuilder{
 		gatewayClient: gc,
+		ctx:           ctx,
 		req:           fiber.Put(gc.requestPath(path)).TLSConfig(gc.tlsConfig),
 	}
 }
@@ -132,6 +192,7 @@ func (gc *gatewayClient) Put(ctx context.Context, path string) RequestBuilder {
 func (gc *gatewayClient) Patch(ctx context.Context, path string) RequestBuilder {
 	return &requestBuilder{
 		gatewayClient: gc,
+		ctx:           ctx,
 		req:           fiber.Patch(gc.requestPath(path)).TLSConfig(gc.tlsConfig),
 	}
 }
@@ -139,12 +200,14 @@ func (gc *gatewayClient) Patch(ctx context.Context, path string) RequestBuilder
 func (gc *gatewayClient) Delete(ctx context.Context, path string) RequestBuilder {
 	return &requestBuilder{
 		gatewayClient: gc,
+		ctx:           ctx,
 		req:           fiber.Delete(gc.requestPath(path)).TLSConfig(gc.tlsConfig),
 	}
 }
 
 type requestBuilder struct {
 	gatewayClient *gatewayClient
+	ctx           context.Context
 	req           *fiber.Agent
 }
 
@@ -162,26 +225,73 @@ func (rb *requestBuilder) Body(body []byte) RequestBuilder {
 
 // Sends the request
 func (rb *requestBuilder) Do() (code int, body []byte, err error) {
+	// the agent is kept so that the request can be retried
+	rb.req.Reuse()
+	defer fiber.ReleaseAgent(rb.req)
+	resp := fiber.AcquireResponse()
+	defer fiber.ReleaseResponse(resp)
+	rb.req.SetResponse(resp)
+
+	if err := rb.req.Parse(); err != nil {
+		return 0, nil, err
+	}
+	for attempt := 0; ; attempt++ {
+		if err := rb.sign(); err != nil {
+			return 0, nil, err
+		}
+		code, body, errs := rb.req.Bytes()
+		if len(errs) > 0 {
+			return 0, nil, errors.Combine(errs...)
+		}
+		delay, ok := retryAfter(resp)
+		if code != http.StatusServiceUnavailable || !ok || attempt >= maxUnavailableRetries {
+			return code, body, nil
+		}
+		select {
+		case <-rb.ctx.Done():
+			return code, body, nil
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (rb *requestBuilder) sign() error {
 	nonce, mac, err := b2mac.New512([]byte(rb.gatewayClient.id),
 		rb.req.Request().Body(), rb.gatewayClient.sharedKeys.ClientKey)
 	if err != nil {
-		return 0, nil, err
+		return err
 	}
 	authHeader, err := b2mac.EncodeAuthHeader([]byte(rb.gatewayClient.id), nonce, mac)
 	if err != nil {
-		return 0, nil, err
+		return err
 	}
 	rb.req.Set("Authorization", authHeader)
+	return nil
+}
 
-	if err := rb.req.Parse(); err != nil {
-		return 0, nil, err
+// retryAfter returns the delay requested by the response's Retry-After
+// header, which can either be a number of seconds or an HTTP date, up to
+// maxRetryAfter.
+func retryAfter(resp *fiber.Response) (time.Duration, bool) {
+	value := string(resp.Header.Peek(fiber.HeaderRetryAfter))
+	if value == "" {
+		return 0, false
 	}
-
-	code, body, errs := rb.req.Bytes()
-	if len(errs) > 0 {
-		return 0, nil, errors.Combine(errs...)
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if t, err := http.ParseTime(value); err == nil {
+		delay = time.Until(t)
+	} else {
+		return 0, false
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > maxRetryAfter {
+		delay = maxRetryAfter
 	}
-	return code, body, nil
+	return delay, true
 }
 
 // Deprecated: use Do() instead
@@ -35,8 +35,7 @@ func LoadObjectsFromFile(path string) (meta.ObjectList, error) {
 		return nil, err
 	}
 	objects := []meta.Object{}
-	documents := bytes.Split(data, []byte("\n---\n"))
-	for i, document := range documents {
+	for i, document := range splitDocuments(data) {
 		lg := configLog.With(
 			"path", path,
 			"documentIndex", i,
@@ -54,6 +53,10 @@ func LoadObjectsFromFile(path string) (meta.ObjectList, error) {
 	return objects, nil
 }
 
+func splitDocuments(data []byte) [][]byte {
+	return bytes.Split(data, []byte("\n---\n"))
+}
+
 func LoadObject(document []byte) (meta.Object, error) {
 	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(document), 4096)
 	typeMeta := meta.TypeMeta{}
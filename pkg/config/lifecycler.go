@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/rancher/opni-monitoring/pkg/config/meta"
+	"github.com/rancher/opni-monitoring/pkg/util/eventbus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -11,23 +12,25 @@ import (
 type Lifecycler interface {
 	GetObjectList() (meta.ObjectList, error)
 	UpdateObjectList(objects meta.ObjectList) error
-	ReloadC() (chan struct{}, error)
+	// ReloadC returns a channel which will receive a value when the object
+	// list is updated. Each call returns a new channel.
+	ReloadC() (<-chan struct{}, error)
 }
 
 type lifecycler struct {
 	objects meta.ObjectList
-	reloadC chan struct{}
+	reload  *eventbus.Bus[struct{}]
 }
 
 func NewLifecycler(objects meta.ObjectList) *lifecycler {
 	return &lifecycler{
 		objects: objects,
-		reloadC: make(chan struct{}),
+		reload:  eventbus.New[struct{}](eventbus.WithBufferSize(1)),
 	}
 }
 
-func (l *lifecycler) ReloadC() (chan struct{}, error) {
-	return l.reloadC, nil
+func (l *lifecycler) ReloadC() (<-chan struct{}, error) {
+	return l.reload.Subscribe().C(), nil
 }
 
 func (l *lifecycler) GetObjectList() (meta.ObjectList, error) {
@@ -36,9 +39,7 @@ func (l *lifecycler) GetObjectList() (meta.ObjectList, error) {
 
 func (l *lifecycler) UpdateObjectList(objects meta.ObjectList) error {
 	l.objects = objects
-	select {
-	case l.reloadC <- struct{}{}:
-	default:
+	if l.reload.Publish(struct{}{}) == 0 {
 		return errors.New("no reload handler available")
 	}
 	return nil
@@ -55,7 +56,7 @@ func NewUnavailableLifecycler(objects meta.ObjectList) Lifecycler {
 	}
 }
 
-func (l *unavailableLifecycler) ReloadC() (chan struct{}, error) {
+func (l *unavailableLifecycler) ReloadC() (<-chan struct{}, error) {
 	return nil, status.Error(codes.Unavailable, "lifecycler not available")
 }
 
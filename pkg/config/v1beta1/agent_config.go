@@ -18,12 +18,34 @@ type AgentConfigSpec struct {
 	// The address of the gateway's public HTTP API. This should be of the format
 	// "https://host:port". The scheme must be "https".
 	GatewayAddress string `json:"gatewayAddress,omitempty"`
+	// If set, the gateway's serving certificate must contain a URI SAN
+	// matching this SPIFFE ID (e.g. "spiffe://example.org/gateway"). This is
+	// checked in addition to the pinned public keys obtained during bootstrap.
+	GatewaySPIFFEID string `json:"gatewaySPIFFEID,omitempty"`
+	// Number of TLS sessions to cache for resuming connections to the gateway.
+	// Defaults to 64.
+	TLSSessionCacheSize int `json:"tlsSessionCacheSize,omitempty"`
 	// The name of the identity provider to use. Defaults to "kubernetes".
 	IdentityProvider string `json:"identityProvider,omitempty"`
 	// Configuration for agent keyring storage.
 	Storage   StorageSpec    `json:"storage,omitempty"`
 	Bootstrap *BootstrapSpec `json:"bootstrap,omitempty"`
 	Rules     *RulesSpec     `json:"rules,omitempty"`
+	// Configuration for the agent's profiling endpoints. Disabled by default.
+	Profiling *ProfilingSpec `json:"profiling,omitempty"`
+	// Labels added to every series forwarded by the agent. If a series
+	// already has a label with the same name, its value is replaced by the
+	// external label's value.
+	ExternalLabels map[string]string `json:"externalLabels,omitempty"`
+}
+
+type ProfilingSpec struct {
+	// If true, the agent will serve the standard net/http/pprof handlers
+	// under /debug/pprof/ on its listen address.
+	Enabled bool `json:"enabled,omitempty"`
+	// By default, the profiling endpoints only respond to requests from
+	// localhost. If true, they will respond to requests from any address.
+	AllowRemoteAccess bool `json:"allowRemoteAccess,omitempty"`
 }
 
 type BootstrapSpec struct {
@@ -1,7 +1,16 @@
 package v1beta1
 
 import (
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/rancher/opni-monitoring/pkg/config/meta"
+	"github.com/rancher/opni-monitoring/pkg/labels"
+	"github.com/rancher/opni-monitoring/pkg/validation"
 )
 
 type GatewayConfig struct {
@@ -11,17 +20,87 @@ type GatewayConfig struct {
 }
 
 type GatewayConfigSpec struct {
-	ListenAddress  string         `json:"listenAddress,omitempty"`
-	Hostname       string         `json:"hostname,omitempty"`
-	MetricsPort    int            `json:"metricsPort,omitempty"`
-	Management     ManagementSpec `json:"management,omitempty"`
-	EnableMonitor  bool           `json:"enableMonitor,omitempty"`
-	TrustedProxies []string       `json:"trustedProxies,omitempty"`
-	Cortex         CortexSpec     `json:"cortex,omitempty"`
-	AuthProvider   string         `json:"authProvider,omitempty"`
-	Storage        StorageSpec    `json:"storage,omitempty"`
-	Certs          CertsSpec      `json:"certs,omitempty"`
-	Plugins        PluginsSpec    `json:"plugins,omitempty"`
+	ListenAddress      string                 `json:"listenAddress,omitempty"`
+	Hostname           string                 `json:"hostname,omitempty"`
+	MetricsPort        int                    `json:"metricsPort,omitempty"`
+	Management         ManagementSpec         `json:"management,omitempty"`
+	EnableMonitor      bool                   `json:"enableMonitor,omitempty"`
+	TrustedProxies     []string               `json:"trustedProxies,omitempty"`
+	Cortex             CortexSpec             `json:"cortex,omitempty"`
+	AuthProvider       string                 `json:"authProvider,omitempty"`
+	RouteAuthProviders RouteAuthProvidersSpec `json:"routeAuthProviders,omitempty"`
+	Storage            StorageSpec            `json:"storage,omitempty"`
+	Certs              CertsSpec              `json:"certs,omitempty"`
+	SessionTickets     SessionTicketsSpec     `json:"sessionTickets,omitempty"`
+	Plugins            PluginsSpec            `json:"plugins,omitempty"`
+	// Templates for computed cluster labels, keyed by label name. Computed
+	// labels are evaluated when a cluster is registered and when its labels
+	// are edited, and cannot be set directly.
+	LabelTemplates map[string]string `json:"labelTemplates,omitempty"`
+	// Limits on the number of concurrent requests to specific paths.
+	ConcurrencyLimits []ConcurrencyLimitSpec `json:"concurrencyLimits,omitempty"`
+	// If true, serve a discovery document at /.well-known/opni-monitoring
+	// describing how agents and other clients can connect to the gateway.
+	EnableDiscovery bool `json:"enableDiscovery,omitempty"`
+	// How long an idle connection to the gateway API, such as one held open
+	// by an agent between requests, is kept open before the gateway closes
+	// it, e.g. "10m". Agents transparently reconnect on their next request.
+	// If unset, idle connections are never closed.
+	IdleTimeout string `json:"idleTimeout,omitempty"`
+	// If set, bootstrap join responses are cached and served with an ETag
+	// and a Cache-Control max-age of this duration, e.g. "30s", so that
+	// agents and intermediaries can cache them. Agents may not be able to
+	// join using a newly created token until cached responses expire, so
+	// this should be kept short.
+	BootstrapJoinCacheMaxAge string `json:"bootstrapJoinCacheMaxAge,omitempty"`
+	// If true, bootstrap requests received over plaintext connections are
+	// rejected. This guards against misconfigurations which expose the
+	// bootstrap endpoints without TLS, such as a proxy forwarding to the
+	// gateway over plaintext.
+	RequireTLSForBootstrap bool `json:"requireTLSForBootstrap,omitempty"`
+	// If set, limits the average number of bootstrap auth and check
+	// requests per second accepted from each remote IP. Requests over the
+	// limit are rejected with 429 Too Many Requests.
+	BootstrapAuthRateLimit float64 `json:"bootstrapAuthRateLimit,omitempty"`
+	// The number of bootstrap auth and check requests each remote IP can
+	// send at once when BootstrapAuthRateLimit is set. Defaults to 1.
+	BootstrapAuthBurst int `json:"bootstrapAuthBurst,omitempty"`
+	// If set, the IDs requested by agents during bootstrap must match this
+	// regular expression, for example to require UUIDs. Requests with
+	// other IDs are rejected with 400 Bad Request.
+	BootstrapClientIDPattern string `json:"bootstrapClientIDPattern,omitempty"`
+}
+
+const (
+	// Authenticate agents using their keyring before counting requests
+	// towards a concurrency limit.
+	ConcurrencyLimitAuthCluster = "cluster"
+	// Count requests towards a concurrency limit without authenticating
+	// them.
+	ConcurrencyLimitAuthNone = "none"
+)
+
+type ConcurrencyLimitSpec struct {
+	// Path prefix to limit, e.g. "/prometheus/api/v1/query_range". All
+	// requests to this path or to paths below it share a single limit.
+	// Prefixes match on whole path segments, so "/api/v1/query" does not
+	// match "/api/v1/query_range". If a request matches multiple prefixes,
+	// the longest one is used.
+	Path string `json:"path,omitempty"`
+	// How requests to this path are authenticated before they are counted,
+	// so that unauthenticated clients cannot use up the available slots.
+	// Either the name of an auth provider, ConcurrencyLimitAuthCluster to
+	// authenticate agents using their keyring, or ConcurrencyLimitAuthNone
+	// to count requests without authenticating them, e.g. for routes which
+	// already authenticate requests themselves. If unset, the gateway's auth
+	// provider is used.
+	Auth string `json:"auth,omitempty"`
+	// Maximum number of requests to this path which can be handled at once.
+	MaxConcurrent int `json:"maxConcurrent,omitempty"`
+	// How long a request can wait for another request to complete before it
+	// is rejected with 429 Too Many Requests, e.g. "5s". If unset, requests
+	// over the limit are rejected immediately.
+	MaxWait string `json:"maxWait,omitempty"`
 }
 
 type ManagementSpec struct {
@@ -30,6 +109,20 @@ type ManagementSpec struct {
 	WebListenAddress  string `json:"webListenAddress,omitempty"`
 }
 
+// Auth providers to use for specific groups of routes, in addition to the
+// default auth provider used by the gateway API. Each field is the name of
+// an AuthProvider object. Route groups with no auth provider set are not
+// authenticated by an auth provider.
+type RouteAuthProvidersSpec struct {
+	// Auth provider for the management HTTP API.
+	ManagementAPI string `json:"managementAPI,omitempty"`
+	// Auth provider for the web UI and its management API proxy.
+	ManagementWeb string `json:"managementWeb,omitempty"`
+	// Auth provider for bootstrap routes. This is checked in addition to
+	// the bootstrap token.
+	Bootstrap string `json:"bootstrap,omitempty"`
+}
+
 type CortexSpec struct {
 	Distributor   DistributorSpec   `json:"distributor,omitempty"`
 	Ingester      IngesterSpec      `json:"ingester,omitempty"`
@@ -37,6 +130,12 @@ type CortexSpec struct {
 	Ruler         RulerSpec         `json:"ruler,omitempty"`
 	QueryFrontend QueryFrontendSpec `json:"queryFrontend,omitempty"`
 	Certs         MTLSSpec          `json:"certs,omitempty"`
+	// Path to a Cortex runtime configuration file. If set, per-cluster limits
+	// are merged into this file as tenant overrides, keeping any other
+	// settings it contains, and Cortex must be configured to load it
+	// (runtime_config.file). If unset, only ingestion rate limits are
+	// enforced, by the gateway.
+	RuntimeConfigFile string `json:"runtimeConfigFile,omitempty"`
 }
 
 type DistributorSpec struct {
@@ -74,6 +173,11 @@ type MTLSSpec struct {
 	ClientCert string `json:"clientCert,omitempty"`
 	// Path to the private key used for client-cert auth.
 	ClientKey string `json:"clientKey,omitempty"`
+	// Name of a key-value store in the gateway's storage backend containing
+	// the PEM encoded certificate and private key used for client-cert auth,
+	// under the keys "tls.crt" and "tls.key". If set, ClientCert and ClientKey
+	// are ignored, and the certificate is reloaded when it is rotated.
+	ClientCertStore string `json:"clientCertStore,omitempty"`
 }
 
 type CertsSpec struct {
@@ -91,9 +195,28 @@ type CertsSpec struct {
 	ServingKeyData *string `json:"servingKeyData,omitempty"`
 }
 
+// Configuration for TLS session resumption using session tickets. Session
+// tickets allow reconnecting agents to skip the full TLS handshake.
+type SessionTicketsSpec struct {
+	// Disables session tickets, requiring a full handshake for every new
+	// connection.
+	Disabled bool `json:"disabled,omitempty"`
+	// How often session ticket keys are rotated. Defaults to "1h".
+	RotationInterval string `json:"rotationInterval,omitempty"`
+	// Number of previous keys which can still be used to resume sessions
+	// after a rotation. Defaults to 2.
+	RetainedKeys *int `json:"retainedKeys,omitempty"`
+}
+
 type PluginsSpec struct {
 	// Directories to look for plugins in
 	Dirs []string `json:"dirs,omitempty"`
+	// Maximum number of concurrent calls the gateway will make to each
+	// plugin. If unset, the number of calls is not limited.
+	MaxConcurrentCalls int `json:"maxConcurrentCalls,omitempty"`
+	// Maximum duration of a single call to a plugin, e.g. "30s". If unset,
+	// calls do not time out.
+	CallTimeout string `json:"callTimeout,omitempty"`
 }
 
 func (s *GatewayConfigSpec) SetDefaults() {
@@ -109,6 +232,13 @@ func (s *GatewayConfigSpec) SetDefaults() {
 	if s.MetricsPort == 0 {
 		s.MetricsPort = 8086
 	}
+	if s.SessionTickets.RotationInterval == "" {
+		s.SessionTickets.RotationInterval = "1h"
+	}
+	if s.SessionTickets.RetainedKeys == nil {
+		retainedKeys := 2
+		s.SessionTickets.RetainedKeys = &retainedKeys
+	}
 	if s.Cortex.Distributor.HTTPAddress == "" {
 		s.Cortex.Distributor.HTTPAddress = "cortex-distributor:8080"
 		s.Cortex.Distributor.GRPCAddress = "cortex-distributor-headless:9095"
@@ -157,3 +287,123 @@ type CustomResourcesStorageSpec struct {
 	// Kubernetes namespace where custom resource objects will be stored.
 	Namespace string `json:"namespace,omitempty"`
 }
+
+// Validate checks the config for invalid or conflicting fields. Fields left
+// empty are not considered invalid if SetDefaults would populate them.
+// If any fields are invalid, the returned error is a FieldErrorList with
+// paths relative to the spec.
+func (s *GatewayConfigSpec) Validate() error {
+	errs := FieldErrorList{}
+	validateHostPort(&errs, "listenAddress", s.ListenAddress)
+	if s.MetricsPort < 0 || s.MetricsPort > 65535 {
+		errs.addf("metricsPort", validation.ErrInvalidValue, "%d is not a valid port", s.MetricsPort)
+	}
+	validateProtocolAddress(&errs, "management.grpcListenAddress", s.Management.GRPCListenAddress)
+	validateHostPort(&errs, "management.httpListenAddress", s.Management.HTTPListenAddress)
+	validateHostPort(&errs, "management.webListenAddress", s.Management.WebListenAddress)
+	for i, proxy := range s.TrustedProxies {
+		if net.ParseIP(proxy) != nil {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(proxy); err != nil {
+			errs.addf(fmt.Sprintf("trustedProxies[%d]", i), validation.ErrInvalidValue,
+				"%q is not a valid IP address or CIDR", proxy)
+		}
+	}
+
+	switch s.Storage.Type {
+	case "":
+		errs.add("storage.type", validation.ErrMissingRequiredField)
+	case StorageTypeEtcd:
+		if s.Storage.Etcd == nil || len(s.Storage.Etcd.Endpoints) == 0 {
+			errs.add("storage.etcd.endpoints", validation.ErrMissingRequiredField)
+		}
+	case StorageTypeCRDs:
+	default:
+		errs.addf("storage.type", validation.ErrInvalidValue, "unknown storage type %q", s.Storage.Type)
+	}
+
+	if interval := s.SessionTickets.RotationInterval; interval != "" {
+		if d, err := time.ParseDuration(interval); err != nil || d <= 0 {
+			errs.addf("sessionTickets.rotationInterval", validation.ErrInvalidValue, "%q is not a valid positive duration", interval)
+		}
+	}
+	if n := s.SessionTickets.RetainedKeys; n != nil && *n < 0 {
+		errs.addf("sessionTickets.retainedKeys", validation.ErrInvalidValue, "must not be negative")
+	}
+	if s.Plugins.MaxConcurrentCalls < 0 {
+		errs.addf("plugins.maxConcurrentCalls", validation.ErrInvalidValue, "must not be negative")
+	}
+	if timeout := s.Plugins.CallTimeout; timeout != "" {
+		if d, err := time.ParseDuration(timeout); err != nil || d <= 0 {
+			errs.addf("plugins.callTimeout", validation.ErrInvalidValue, "%q is not a valid positive duration", timeout)
+		}
+	}
+	if timeout := s.IdleTimeout; timeout != "" {
+		if d, err := time.ParseDuration(timeout); err != nil || d <= 0 {
+			errs.addf("idleTimeout", validation.ErrInvalidValue, "%q is not a valid positive duration", timeout)
+		}
+	}
+	if maxAge := s.BootstrapJoinCacheMaxAge; maxAge != "" {
+		if d, err := time.ParseDuration(maxAge); err != nil || d <= 0 {
+			errs.addf("bootstrapJoinCacheMaxAge", validation.ErrInvalidValue, "%q is not a valid positive duration", maxAge)
+		}
+	}
+	if s.BootstrapAuthRateLimit < 0 {
+		errs.addf("bootstrapAuthRateLimit", validation.ErrInvalidValue, "must not be negative")
+	}
+	if s.BootstrapAuthBurst < 0 {
+		errs.addf("bootstrapAuthBurst", validation.ErrInvalidValue, "must not be negative")
+	}
+	if s.BootstrapClientIDPattern != "" {
+		if _, err := regexp.Compile(s.BootstrapClientIDPattern); err != nil {
+			errs.addf("bootstrapClientIDPattern", validation.ErrInvalidValue, "%v", err)
+		}
+	}
+
+	seenLimitPaths := map[string]struct{}{}
+	for i, limit := range s.ConcurrencyLimits {
+		field := fmt.Sprintf("concurrencyLimits[%d]", i)
+		if limit.Path == "" {
+			errs.add(field+".path", validation.ErrMissingRequiredField)
+		} else if !strings.HasPrefix(limit.Path, "/") {
+			errs.addf(field+".path", validation.ErrInvalidValue, "%q must start with '/'", limit.Path)
+		} else if _, ok := seenLimitPaths[limit.Path]; ok {
+			errs.addf(field+".path", validation.ErrInvalidValue, "duplicate path %q", limit.Path)
+		}
+		seenLimitPaths[limit.Path] = struct{}{}
+		if limit.MaxConcurrent <= 0 {
+			errs.addf(field+".maxConcurrent", validation.ErrInvalidValue, "must be positive")
+		}
+		if limit.MaxWait != "" {
+			if d, err := time.ParseDuration(limit.MaxWait); err != nil || d < 0 {
+				errs.addf(field+".maxWait", validation.ErrInvalidValue, "%q is not a valid duration", limit.MaxWait)
+			}
+		}
+	}
+
+	templateKeys := make([]string, 0, len(s.LabelTemplates))
+	for key := range s.LabelTemplates {
+		templateKeys = append(templateKeys, key)
+	}
+	sort.Strings(templateKeys)
+	for _, key := range templateKeys {
+		if _, err := labels.ParseTemplates(map[string]string{key: s.LabelTemplates[key]}); err != nil {
+			errs.addf(fmt.Sprintf("labelTemplates[%s]", key), validation.ErrInvalidValue, "%v", err)
+		}
+	}
+
+	validateMutuallyExclusive(&errs, "certs.caCert", s.Certs.CACert, "certs.caCertData", s.Certs.CACertData)
+	validateMutuallyExclusive(&errs, "certs.servingCert", s.Certs.ServingCert, "certs.servingCertData", s.Certs.ServingCertData)
+	validateMutuallyExclusive(&errs, "certs.servingKey", s.Certs.ServingKey, "certs.servingKeyData", s.Certs.ServingKeyData)
+	return errs.orNil()
+}
+
+// Validate validates the spec. Paths in the returned FieldErrorList are
+// relative to the object, e.g. "spec.listenAddress".
+func (c *GatewayConfig) Validate() error {
+	if err := c.Spec.Validate(); err != nil {
+		return err.(FieldErrorList).WithPrefix("spec")
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package v1beta1
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/rancher/opni-monitoring/pkg/validation"
+)
+
+// FieldError describes a problem with a single field. Field is the json path
+// of the field relative to the object being validated, for example
+// "storage.etcd.endpoints".
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// FieldErrorList is returned by Validate methods when one or more fields
+// are invalid.
+type FieldErrorList []*FieldError
+
+func (l FieldErrorList) Error() string {
+	msgs := make([]string, len(l))
+	for i, e := range l {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// WithPrefix returns a copy of the list with each field path prefixed by
+// the given path.
+func (l FieldErrorList) WithPrefix(prefix string) FieldErrorList {
+	out := make(FieldErrorList, len(l))
+	for i, e := range l {
+		out[i] = &FieldError{
+			Field: prefix + "." + e.Field,
+			Err:   e.Err,
+		}
+	}
+	return out
+}
+
+func (l FieldErrorList) orNil() error {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
+func (l *FieldErrorList) add(field string, err error) {
+	*l = append(*l, &FieldError{
+		Field: field,
+		Err:   err,
+	})
+}
+
+func (l *FieldErrorList) addf(field string, base error, format string, args ...any) {
+	l.add(field, fmt.Errorf("%w: %s", base, fmt.Sprintf(format, args...)))
+}
+
+func validateHostPort(errs *FieldErrorList, field, addr string) {
+	if addr == "" {
+		return
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		errs.addf(field, validation.ErrInvalidValue, "%q is not a valid host:port address", addr)
+	}
+}
+
+func validateProtocolAddress(errs *FieldErrorList, field, addr string) {
+	if addr == "" {
+		return
+	}
+	u, err := url.Parse(addr)
+	if err != nil {
+		errs.addf(field, validation.ErrInvalidValue, "%q is not a valid address", addr)
+		return
+	}
+	switch u.Scheme {
+	case "tcp", "tcp4":
+		if u.Host == "" {
+			errs.addf(field, validation.ErrInvalidValue, "missing host in address %q", addr)
+		}
+	case "unix":
+		if u.Path == "" {
+			errs.addf(field, validation.ErrInvalidValue, "missing socket path in address %q", addr)
+		}
+	default:
+		errs.addf(field, validation.ErrInvalidValue, "unsupported protocol scheme in address %q", addr)
+	}
+}
+
+func validateMutuallyExclusive(errs *FieldErrorList, field1 string, v1 *string, field2 string, v2 *string) {
+	if v1 != nil && v2 != nil {
+		errs.addf(field1, validation.ErrInvalidValue, "mutually exclusive with %s", field2)
+	}
+}
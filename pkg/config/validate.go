@@ -0,0 +1,119 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
+	"github.com/rancher/opni-monitoring/pkg/validation"
+)
+
+var ErrNoObjects = errors.New("no config objects found")
+
+// ValidationProblem describes a single problem found in a config document.
+type ValidationProblem struct {
+	// Index of the document within the file.
+	DocumentIndex int
+	// Kind of the object, if it could be decoded.
+	Kind string
+	// Path of the offending field relative to the object, if known.
+	Field string
+	Err   error
+}
+
+func (p *ValidationProblem) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "document %d", p.DocumentIndex)
+	if p.Kind != "" {
+		fmt.Fprintf(&sb, " (%s)", p.Kind)
+	}
+	if p.Field != "" {
+		fmt.Fprintf(&sb, ": %s", p.Field)
+	}
+	fmt.Fprintf(&sb, ": %v", p.Err)
+	return sb.String()
+}
+
+func (p *ValidationProblem) Unwrap() error {
+	return p.Err
+}
+
+// ValidationReport is returned by Validate when a config file contains one
+// or more invalid documents.
+type ValidationReport struct {
+	Path     string
+	Problems []*ValidationProblem
+}
+
+func (r *ValidationReport) Error() string {
+	msgs := make([]string, len(r.Problems))
+	for i, p := range r.Problems {
+		msgs[i] = p.Error()
+	}
+	return fmt.Sprintf("%s: %s", r.Path, strings.Join(msgs, "; "))
+}
+
+// Validate loads all objects in the config file at the given path and
+// validates them, without starting any services. Unlike LoadObjectsFromFile,
+// documents which fail to decode are reported instead of skipped. If any
+// problems are found, the returned error is a *ValidationReport.
+func Validate(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	report := &ValidationReport{
+		Path: path,
+	}
+	numObjects := 0
+	for i, document := range splitDocuments(data) {
+		if len(strings.TrimSpace(string(document))) == 0 {
+			continue
+		}
+		numObjects++
+		object, err := LoadObject(document)
+		if err != nil {
+			report.Problems = append(report.Problems, &ValidationProblem{
+				DocumentIndex: i,
+				Err:           err,
+			})
+			continue
+		}
+		validator, ok := object.(validation.Validator)
+		if !ok {
+			continue
+		}
+		err = validator.Validate()
+		if err == nil {
+			continue
+		}
+		var fieldErrs v1beta1.FieldErrorList
+		if errors.As(err, &fieldErrs) {
+			for _, fe := range fieldErrs {
+				report.Problems = append(report.Problems, &ValidationProblem{
+					DocumentIndex: i,
+					Kind:          object.GetKind(),
+					Field:         fe.Field,
+					Err:           fe.Err,
+				})
+			}
+		} else {
+			report.Problems = append(report.Problems, &ValidationProblem{
+				DocumentIndex: i,
+				Kind:          object.GetKind(),
+				Err:           err,
+			})
+		}
+	}
+	if numObjects == 0 {
+		report.Problems = append(report.Problems, &ValidationProblem{
+			Err: ErrNoObjects,
+		})
+	}
+	if len(report.Problems) > 0 {
+		return report
+	}
+	return nil
+}
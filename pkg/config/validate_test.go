@@ -0,0 +1,89 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/config"
+	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/pkg/validation"
+)
+
+type expectedProblem struct {
+	index int
+	field string
+	err   error
+}
+
+func problemFields(report *config.ValidationReport) []string {
+	fields := []string{}
+	for _, p := range report.Problems {
+		fields = append(fields, p.Field)
+	}
+	return fields
+}
+
+var _ = Describe("Validate", Label(test.Unit), func() {
+	It("should accept a valid config", func() {
+		Expect(config.Validate("testdata/valid.yaml")).To(Succeed())
+	})
+	It("should return an error if the file does not exist", func() {
+		err := config.Validate("testdata/does-not-exist.yaml")
+		Expect(errors.Is(err, os.ErrNotExist)).To(BeTrue())
+	})
+	DescribeTable("invalid configs",
+		func(path string, expected []expectedProblem) {
+			err := config.Validate(path)
+			Expect(err).To(HaveOccurred())
+			report := &config.ValidationReport{}
+			Expect(errors.As(err, &report)).To(BeTrue())
+			Expect(report.Path).To(Equal(path))
+			Expect(report.Problems).To(HaveLen(len(expected)), "problems: %v", problemFields(report))
+			for i, e := range expected {
+				Expect(report.Problems[i].DocumentIndex).To(Equal(e.index))
+				Expect(report.Problems[i].Field).To(Equal(e.field))
+				Expect(report.Problems[i].Err).To(MatchError(e.err))
+				if e.field != "" {
+					Expect(err.Error()).To(ContainSubstring(e.field))
+				}
+			}
+		},
+		Entry("invalid fields", "testdata/invalid_fields.yaml", []expectedProblem{
+			{0, "spec.listenAddress", validation.ErrInvalidValue},
+			{0, "spec.metricsPort", validation.ErrInvalidValue},
+			{0, "spec.management.grpcListenAddress", validation.ErrInvalidValue},
+			{0, "spec.trustedProxies[1]", validation.ErrInvalidValue},
+			{0, "spec.storage.etcd.endpoints", validation.ErrMissingRequiredField},
+			{0, "spec.sessionTickets.rotationInterval", validation.ErrInvalidValue},
+			{0, "spec.plugins.maxConcurrentCalls", validation.ErrInvalidValue},
+			{0, "spec.plugins.callTimeout", validation.ErrInvalidValue},
+			{0, "spec.idleTimeout", validation.ErrInvalidValue},
+			{0, "spec.bootstrapJoinCacheMaxAge", validation.ErrInvalidValue},
+			{0, "spec.bootstrapAuthRateLimit", validation.ErrInvalidValue},
+			{0, "spec.bootstrapAuthBurst", validation.ErrInvalidValue},
+			{0, "spec.bootstrapClientIDPattern", validation.ErrInvalidValue},
+			{0, "spec.concurrencyLimits[0].maxConcurrent", validation.ErrInvalidValue},
+			{0, "spec.concurrencyLimits[0].maxWait", validation.ErrInvalidValue},
+			{0, "spec.concurrencyLimits[1].path", validation.ErrInvalidValue},
+			{0, "spec.labelTemplates[broken]", validation.ErrInvalidValue},
+			{0, "spec.certs.caCert", validation.ErrInvalidValue},
+		}),
+		Entry("invalid storage type", "testdata/invalid_storage_type.yaml", []expectedProblem{
+			{0, "spec.storage.type", validation.ErrInvalidValue},
+		}),
+		Entry("no objects", "testdata/empty.yaml", []expectedProblem{
+			{0, "", config.ErrNoObjects},
+		}),
+	)
+	It("should report documents that fail to decode", func() {
+		err := config.Validate("testdata/unknown_field.yaml")
+		report := &config.ValidationReport{}
+		Expect(errors.As(err, &report)).To(BeTrue())
+		Expect(report.Problems).To(HaveLen(1))
+		Expect(report.Problems[0].DocumentIndex).To(Equal(1))
+		Expect(report.Problems[0].Err.Error()).To(ContainSubstring("listenAdress"))
+	})
+})
@@ -13,6 +13,11 @@ const (
 	LabelSelectorOpNotIn        LabelSelectorOperator = "NotIn"
 	LabelSelectorOpExists       LabelSelectorOperator = "Exists"
 	LabelSelectorOpDoesNotExist LabelSelectorOperator = "DoesNotExist"
+	// Gt and Lt compare the label value to a single requirement value as
+	// integers. They do not match if the label is missing, or if either value
+	// is not an integer.
+	LabelSelectorOpGt LabelSelectorOperator = "Gt"
+	LabelSelectorOpLt LabelSelectorOperator = "Lt"
 
 	NameLabel = "opni.io/name"
 )
@@ -43,9 +48,18 @@ func (lsr *LabelSelectorRequirement) ExpressionString() string {
 	if lsr == nil {
 		return ""
 	}
+	if lsr.Negate {
+		return "¬(" + (&LabelSelectorRequirement{
+			Key:      lsr.Key,
+			Operator: lsr.Operator,
+			Values:   lsr.Values,
+		}).ExpressionString() + ")"
+	}
 	switch lsr.Operator {
 	case string(LabelSelectorOpExists), string(LabelSelectorOpDoesNotExist):
 		return keyWithOperatorSymbol(lsr.Key, lsr.Operator)
+	case string(LabelSelectorOpGt), string(LabelSelectorOpLt):
+		return keyWithOperatorSymbol(lsr.Key, lsr.Operator) + " " + strings.Join(lsr.Values, ",")
 	default:
 		return keyWithOperatorSymbol(lsr.Key, lsr.Operator) + " {" + strings.Join(lsr.Values, ",") + "}"
 	}
@@ -61,6 +75,10 @@ func keyWithOperatorSymbol(key string, operator string) string {
 		return "∃ " + key
 	case LabelSelectorOpDoesNotExist:
 		return "∄ " + key
+	case LabelSelectorOpGt:
+		return key + " >"
+	case LabelSelectorOpLt:
+		return key + " <"
 	default:
 		return key + " ?"
 	}
@@ -75,9 +93,18 @@ func (ls *LabelSelector) ToLabelSelector() *metav1.LabelSelector {
 		MatchExpressions: []metav1.LabelSelectorRequirement{},
 	}
 	for _, expr := range ls.MatchExpressions {
+		op := metav1.LabelSelectorOperator(expr.Operator)
+		if expr.Negate {
+			switch LabelSelectorOperator(expr.Operator) {
+			case LabelSelectorOpIn:
+				op = metav1.LabelSelectorOpNotIn
+			case LabelSelectorOpExists:
+				op = metav1.LabelSelectorOpDoesNotExist
+			}
+		}
 		s.MatchExpressions = append(s.MatchExpressions, metav1.LabelSelectorRequirement{
 			Key:      expr.Key,
-			Operator: metav1.LabelSelectorOperator(expr.Operator),
+			Operator: op,
 			Values:   expr.Values,
 		})
 	}
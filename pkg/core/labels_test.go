@@ -62,6 +62,91 @@ var _ = Describe("Labels", func() {
 				},
 			},
 		}).ExpressionString()).To(Equal("a ? {1,2}"))
+		Expect((&core.LabelSelector{
+			MatchExpressions: []*core.LabelSelectorRequirement{
+				{
+					Key:      "a",
+					Operator: string(core.LabelSelectorOpIn),
+					Values:   []string{"1", "2"},
+					Negate:   true,
+				},
+				{
+					Key:      "b",
+					Operator: string(core.LabelSelectorOpExists),
+					Negate:   true,
+				},
+			},
+		}).ExpressionString()).To(Equal("¬(a ∈ {1,2}) && ¬(∃ b)"))
 		Expect((*core.LabelSelectorRequirement)(nil).ExpressionString()).To(Equal(""))
 	})
+
+	It("should format label selectors as text", func() {
+		Expect(core.FormatLabelSelector(selector)).To(Equal("foo=bar && a In 1,2 && b Exists && c DoesNotExist && d NotIn 3,4"))
+		Expect(core.FormatLabelSelector(nil)).To(Equal(""))
+		Expect(core.FormatLabelSelector(&core.LabelSelector{
+			MatchLabels: map[string]string{
+				"z": "1",
+				"y": "2",
+				"x": "3",
+			},
+			MatchExpressions: []*core.LabelSelectorRequirement{
+				nil,
+				{
+					Key:      "a",
+					Operator: string(core.LabelSelectorOpExists),
+					Negate:   true,
+				},
+			},
+		})).To(Equal("x=3 && y=2 && z=1 && !a Exists"))
+	})
+
+	It("should format and parse numeric comparisons", func() {
+		ls := &core.LabelSelector{
+			MatchExpressions: []*core.LabelSelectorRequirement{
+				{
+					Key:      "replicas",
+					Operator: string(core.LabelSelectorOpGt),
+					Values:   []string{"3"},
+				},
+				{
+					Key:      "version",
+					Operator: string(core.LabelSelectorOpLt),
+					Values:   []string{"14"},
+				},
+			},
+		}
+		Expect(core.FormatLabelSelector(ls)).To(Equal("replicas Gt 3 && version Lt 14"))
+		Expect(ls.ExpressionString()).To(Equal("replicas > 3 && version < 14"))
+		parsed, err := core.ParseLabelSelector("replicas Gt 3 && version Lt 14")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(Equal(ls))
+	})
+
+	It("should parse label selectors from text", func() {
+		parsed, err := core.ParseLabelSelector("foo=bar&&a In 1,2 &&  b Exists && c DoesNotExist && d NotIn 3,4")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(Equal(selector))
+
+		parsed, err = core.ParseLabelSelector("  ")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed.IsEmpty()).To(BeTrue())
+
+		for _, invalid := range []string{
+			"foo=bar &&",
+			"&& foo=bar",
+			"foo=bar && foo=baz",
+			"=bar",
+			"foo",
+			"foo in bar",
+			"foo In",
+			"foo Exists bar",
+			"! In bar",
+			"foo In bar baz",
+			"foo Gt",
+			"foo Gt 1,2",
+		} {
+			_, err := core.ParseLabelSelector(invalid)
+			Expect(err).To(HaveOccurred(), invalid)
+		}
+	})
 })
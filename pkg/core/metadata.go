@@ -54,3 +54,33 @@ func (c *Cluster) SetLabels(labels map[string]string) {
 	}
 	c.Metadata.Labels = labels
 }
+
+func (c *Cluster) GetAnnotations() map[string]string {
+	return c.GetMetadata().GetAnnotations()
+}
+
+func (c *Cluster) SetAnnotations(annotations map[string]string) {
+	if c.Metadata == nil {
+		c.Metadata = &ClusterMetadata{}
+	}
+	c.Metadata.Annotations = annotations
+}
+
+func (t *BootstrapToken) GetAllowedCapabilities() []string {
+	return t.GetMetadata().GetAllowedCapabilities()
+}
+
+func (c *Cluster) GetAllowedCapabilities() []string {
+	return c.GetMetadata().GetAllowedCapabilities()
+}
+
+func (c *Cluster) GetLimits() *ClusterLimits {
+	return c.GetMetadata().GetLimits()
+}
+
+func (c *Cluster) SetLimits(limits *ClusterLimits) {
+	if c.Metadata == nil {
+		c.Metadata = &ClusterMetadata{}
+	}
+	c.Metadata.Limits = limits
+}
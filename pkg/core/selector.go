@@ -0,0 +1,126 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rancher/opni-monitoring/pkg/validation"
+)
+
+const selectorSeparator = "&&"
+
+// FormatLabelSelector returns the canonical text form of a label selector,
+// which can be parsed back into an equivalent selector using
+// ParseLabelSelector. Requirements are separated by "&&". Match labels are
+// written as "key=value", sorted by key, followed by match expressions in
+// their original order, written as "key Operator v1,v2" (or "key Operator"
+// for operators without values). Negated expressions are prefixed with '!'.
+// A nil or empty selector formats to an empty string.
+//
+// Keys and values cannot contain whitespace, and values cannot contain
+// commas or '&'; such selectors cannot be represented in text form.
+func FormatLabelSelector(ls *LabelSelector) string {
+	if ls == nil {
+		return ""
+	}
+	keys := make([]string, 0, len(ls.MatchLabels))
+	for k := range ls.MatchLabels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys)+len(ls.MatchExpressions))
+	for _, k := range keys {
+		parts = append(parts, k+"="+ls.MatchLabels[k])
+	}
+	for _, req := range ls.MatchExpressions {
+		if req == nil {
+			continue
+		}
+		var sb strings.Builder
+		if req.Negate {
+			sb.WriteString("!")
+		}
+		sb.WriteString(req.Key)
+		sb.WriteString(" ")
+		sb.WriteString(req.Operator)
+		if len(req.Values) > 0 {
+			sb.WriteString(" ")
+			sb.WriteString(strings.Join(req.Values, ","))
+		}
+		parts = append(parts, sb.String())
+	}
+	return strings.Join(parts, " "+selectorSeparator+" ")
+}
+
+// ParseLabelSelector parses a label selector from its text form. See
+// FormatLabelSelector for the syntax. Operators are case-sensitive. An empty string parses to an empty
+// selector. The parsed selector is not validated; use Validate to check
+// label names and operator usage.
+func ParseLabelSelector(expr string) (*LabelSelector, error) {
+	ls := &LabelSelector{}
+	if strings.TrimSpace(expr) == "" {
+		return ls, nil
+	}
+	for _, part := range strings.Split(expr, selectorSeparator) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, fmt.Errorf("%w: empty requirement in selector %q", validation.ErrInvalidValue, expr)
+		}
+		fields := strings.Fields(part)
+		if len(fields) == 1 && strings.Contains(part, "=") {
+			kv := strings.SplitN(part, "=", 2)
+			if kv[0] == "" {
+				return nil, fmt.Errorf("%w: missing key in %q", validation.ErrInvalidValue, part)
+			}
+			if ls.MatchLabels == nil {
+				ls.MatchLabels = map[string]string{}
+			}
+			if _, ok := ls.MatchLabels[kv[0]]; ok {
+				return nil, fmt.Errorf("%w: duplicate key %q", validation.ErrInvalidValue, kv[0])
+			}
+			ls.MatchLabels[kv[0]] = kv[1]
+			continue
+		}
+		req, err := parseRequirement(fields)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %s", validation.ErrInvalidValue, part, err.Error())
+		}
+		ls.MatchExpressions = append(ls.MatchExpressions, req)
+	}
+	return ls, nil
+}
+
+func parseRequirement(fields []string) (*LabelSelectorRequirement, error) {
+	if len(fields) < 2 || len(fields) > 3 {
+		return nil, fmt.Errorf("expected 'key operator [values]'")
+	}
+	req := &LabelSelectorRequirement{
+		Key:      strings.TrimPrefix(fields[0], "!"),
+		Operator: fields[1],
+		Negate:   strings.HasPrefix(fields[0], "!"),
+	}
+	if req.Key == "" {
+		return nil, fmt.Errorf("missing key")
+	}
+	switch LabelSelectorOperator(req.Operator) {
+	case LabelSelectorOpIn, LabelSelectorOpNotIn:
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("operator %s requires values", req.Operator)
+		}
+		req.Values = strings.Split(fields[2], ",")
+	case LabelSelectorOpExists, LabelSelectorOpDoesNotExist:
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("operator %s does not accept values", req.Operator)
+		}
+	case LabelSelectorOpGt, LabelSelectorOpLt:
+		if len(fields) != 3 || strings.Contains(fields[2], ",") {
+			return nil, fmt.Errorf("operator %s requires a single value", req.Operator)
+		}
+		req.Values = []string{fields[2]}
+	default:
+		return nil, fmt.Errorf("unknown operator %q", req.Operator)
+	}
+	return req, nil
+}
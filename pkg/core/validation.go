@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/rancher/opni-monitoring/pkg/validation"
 )
@@ -16,6 +17,9 @@ func (c *Cluster) Validate() error {
 	if err := validation.ValidateLabels(c.GetMetadata().GetLabels()); err != nil {
 		return err
 	}
+	if err := validation.ValidateAnnotations(c.GetMetadata().GetAnnotations()); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -44,10 +48,18 @@ func (r *LabelSelectorRequirement) Validate() error {
 		return err
 	}
 	switch LabelSelectorOperator(r.Operator) {
-	case LabelSelectorOpIn, LabelSelectorOpNotIn, LabelSelectorOpExists, LabelSelectorOpDoesNotExist:
+	case LabelSelectorOpIn, LabelSelectorOpNotIn, LabelSelectorOpExists, LabelSelectorOpDoesNotExist,
+		LabelSelectorOpGt, LabelSelectorOpLt:
 	default:
 		return fmt.Errorf("%w: unknown operator %q (values are case-sensitive)", validation.ErrInvalidValue, r.Operator)
 	}
+	if r.Negate {
+		switch LabelSelectorOperator(r.Operator) {
+		case LabelSelectorOpIn, LabelSelectorOpExists:
+		default:
+			return fmt.Errorf("%w: operator %q cannot be negated", validation.ErrInvalidValue, r.Operator)
+		}
+	}
 	for _, value := range r.Values {
 		if err := validation.ValidateLabelValue(value); err != nil {
 			return err
@@ -142,3 +154,25 @@ func (cc *ClusterCapability) Validate() error {
 	}
 	return nil
 }
+
+func (l *ClusterLimits) Validate() error {
+	if l.IngestionRate < 0 || math.IsNaN(l.IngestionRate) || math.IsInf(l.IngestionRate, 0) {
+		return fmt.Errorf("%w: %s", validation.ErrInvalidValue, "ingestionRate must be a non-negative number")
+	}
+	if l.IngestionBurstSize < 0 {
+		return fmt.Errorf("%w: %s", validation.ErrInvalidValue, "ingestionBurstSize cannot be negative")
+	}
+	if l.MaxSeries < 0 {
+		return fmt.Errorf("%w: %s", validation.ErrInvalidValue, "maxSeries cannot be negative")
+	}
+	if l.RetentionSeconds < 0 {
+		return fmt.Errorf("%w: %s", validation.ErrInvalidValue, "retentionSeconds cannot be negative")
+	}
+	if l.MaxSampleAgeSeconds < 0 {
+		return fmt.Errorf("%w: %s", validation.ErrInvalidValue, "maxSampleAgeSeconds cannot be negative")
+	}
+	if l.MaxSampleFutureSeconds < 0 {
+		return fmt.Errorf("%w: %s", validation.ErrInvalidValue, "maxSampleFutureSeconds cannot be negative")
+	}
+	return nil
+}
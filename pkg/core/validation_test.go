@@ -61,6 +61,39 @@ var _ = Describe("Validation", func() {
 			Operator: string(core.LabelSelectorOpExists),
 			Values:   []string{"bar"},
 		}, nil),
+		Entry(nil, &core.LabelSelectorRequirement{
+			Key:      "foo",
+			Operator: string(core.LabelSelectorOpIn),
+			Values:   []string{"bar"},
+			Negate:   true,
+		}, nil),
+		Entry(nil, &core.LabelSelectorRequirement{
+			Key:      "foo",
+			Operator: string(core.LabelSelectorOpExists),
+			Negate:   true,
+		}, nil),
+		Entry(nil, &core.LabelSelectorRequirement{
+			Key:      "foo",
+			Operator: string(core.LabelSelectorOpNotIn),
+			Values:   []string{"bar"},
+			Negate:   true,
+		}, validation.ErrInvalidValue),
+		Entry(nil, &core.LabelSelectorRequirement{
+			Key:      "replicas",
+			Operator: string(core.LabelSelectorOpGt),
+			Values:   []string{"3"},
+		}, nil),
+		Entry(nil, &core.LabelSelectorRequirement{
+			Key:      "replicas",
+			Operator: string(core.LabelSelectorOpLt),
+			Values:   []string{"3"},
+			Negate:   true,
+		}, validation.ErrInvalidValue),
+		Entry(nil, &core.LabelSelectorRequirement{
+			Key:      "foo",
+			Operator: string(core.LabelSelectorOpDoesNotExist),
+			Negate:   true,
+		}, validation.ErrInvalidValue),
 	)
 	DescribeTable("Role", validateEntry[*core.Role],
 		Entry(nil, &core.Role{}, validation.ErrMissingRequiredField),
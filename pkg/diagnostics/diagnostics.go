@@ -0,0 +1,97 @@
+// Package diagnostics contains the diagnostics bundle which agents upload to
+// the gateway, and the parameters which control how often it is uploaded.
+package diagnostics
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
+)
+
+const (
+	// AgentPath is the gateway path agents upload their diagnostics bundle
+	// to.
+	AgentPath = "/agent/diagnostics"
+	// CheckInterval is how often agents check whether their diagnostics
+	// bundle has changed since it was last uploaded.
+	CheckInterval = time.Minute
+	// RefreshInterval is how often agents upload their diagnostics bundle
+	// if it has not changed.
+	RefreshInterval = time.Hour
+	// MinReportInterval is the minimum time between diagnostics bundles
+	// accepted by the gateway from a single agent.
+	MinReportInterval = 30 * time.Second
+
+	// Value which replaces secrets in the configuration included in
+	// diagnostics bundles.
+	Redacted = "<redacted>"
+)
+
+// Bundle is a snapshot of an agent's state, intended to help diagnose
+// problems with the agent. It does not contain any secrets: the agent's
+// configuration is redacted, and only metadata about the keyring is
+// included.
+type Bundle struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+	ClusterID   string    `json:"clusterID"`
+	// The agent's configuration, with secrets replaced by Redacted.
+	Config     v1beta1.AgentConfigSpec `json:"config"`
+	Keyring    []KeyMetadata           `json:"keyring"`
+	Connection ConnectionStatus        `json:"connection"`
+	// Recent events, oldest first.
+	Events []Event `json:"events"`
+	// Recent errors, oldest first.
+	Errors []Event `json:"errors"`
+}
+
+// KeyMetadata describes a key in the agent's keyring without revealing it.
+type KeyMetadata struct {
+	// The key's type, e.g. "sharedKeys" or "pkp".
+	Type string `json:"type"`
+	// Fingerprints of the public parts of the key. For shared keys, this is
+	// the SHA-256 hash of the client's public key. For pinned public keys,
+	// these are the encoded pins.
+	Fingerprints []string `json:"fingerprints,omitempty"`
+}
+
+// ConnectionStatus describes the agent's most recent requests to the
+// gateway.
+type ConnectionStatus struct {
+	GatewayAddress string `json:"gatewayAddress"`
+	// Whether the most recent request to the gateway succeeded.
+	Connected     bool      `json:"connected"`
+	LastSuccess   time.Time `json:"lastSuccess,omitempty"`
+	LastFailure   time.Time `json:"lastFailure,omitempty"`
+	LastError     string    `json:"lastError,omitempty"`
+	TotalRequests uint64    `json:"totalRequests"`
+	TotalFailures uint64    `json:"totalFailures"`
+}
+
+type Event struct {
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+}
+
+// Fingerprint returns a hash of the bundle's contents, which can be compared
+// to determine whether anything of interest has changed between two bundles.
+// The time the bundle was generated and the request counters and timestamps
+// in the connection status, which change with every request the agent makes,
+// are not included.
+func (b *Bundle) Fingerprint() string {
+	stable := *b
+	stable.GeneratedAt = time.Time{}
+	stable.Connection.LastSuccess = time.Time{}
+	stable.Connection.LastFailure = time.Time{}
+	stable.Connection.TotalRequests = 0
+	stable.Connection.TotalFailures = 0
+	data, err := json.Marshal(stable)
+	if err != nil {
+		// the bundle only contains types which can always be marshaled
+		panic(err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -1,6 +1,7 @@
 package ecdh
 
 import (
+	"crypto/elliptic"
 	"crypto/rand"
 	"errors"
 	"fmt"
@@ -11,10 +12,47 @@ import (
 )
 
 var (
-	ErrInvalidPeerType = errors.New("invalid peer type")
+	ErrInvalidPeerType  = errors.New("invalid peer type")
+	ErrUnsupportedCurve = errors.New("unsupported curve")
+	ErrInvalidPublicKey = errors.New("invalid public key")
 )
 
+// Curve is the elliptic curve used for the key exchange.
+type Curve string
+
+const (
+	// X25519 is used by default, if no curve is specified.
+	CurveX25519 Curve = "x25519"
+	// NIST P-256, for environments which require FIPS-approved curves.
+	CurveP256 Curve = "p256"
+)
+
+// DefaultCurve is the curve used if none is specified.
+const DefaultCurve = CurveX25519
+
+// OrDefault returns the curve, or DefaultCurve if the curve is unset.
+func (c Curve) OrDefault() Curve {
+	if c == "" {
+		return DefaultCurve
+	}
+	return c
+}
+
+// IsSupported reports whether the curve is supported. An unset curve is
+// supported, and refers to DefaultCurve.
+func (c Curve) IsSupported() bool {
+	switch c.OrDefault() {
+	case CurveX25519, CurveP256:
+		return true
+	default:
+		return false
+	}
+}
+
 type EphemeralKeyPair struct {
+	// The curve the keys were generated for. If unset, DefaultCurve is
+	// assumed.
+	Curve      Curve
 	PrivateKey []byte
 	PublicKey  []byte
 }
@@ -33,17 +71,60 @@ type PeerPublicKey struct {
 
 // Creates a new x25519 keypair for use in ECDH key exchange.
 func NewEphemeralKeyPair() EphemeralKeyPair {
-	priv, err := io.ReadAll(io.LimitReader(rand.Reader, 32))
+	ekp, err := NewEphemeralKeyPairForCurve(CurveX25519)
 	if err != nil {
 		panic(err)
 	}
-	pub, err := curve25519.X25519(priv, curve25519.Basepoint)
-	if err != nil {
-		panic(err)
+	return ekp
+}
+
+// Creates a new keypair on the given curve for use in ECDH key exchange.
+// P-256 public keys are encoded in uncompressed form.
+func NewEphemeralKeyPairForCurve(curve Curve) (EphemeralKeyPair, error) {
+	switch curve = curve.OrDefault(); curve {
+	case CurveX25519:
+		priv, err := io.ReadAll(io.LimitReader(rand.Reader, 32))
+		if err != nil {
+			return EphemeralKeyPair{}, err
+		}
+		pub, err := curve25519.X25519(priv, curve25519.Basepoint)
+		if err != nil {
+			return EphemeralKeyPair{}, err
+		}
+		return EphemeralKeyPair{
+			Curve:      curve,
+			PrivateKey: priv,
+			PublicKey:  pub,
+		}, nil
+	case CurveP256:
+		priv, x, y, err := elliptic.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return EphemeralKeyPair{}, err
+		}
+		return EphemeralKeyPair{
+			Curve:      curve,
+			PrivateKey: priv,
+			PublicKey:  elliptic.Marshal(elliptic.P256(), x, y),
+		}, nil
+	default:
+		return EphemeralKeyPair{}, fmt.Errorf("%w: %s", ErrUnsupportedCurve, curve)
 	}
-	return EphemeralKeyPair{
-		PrivateKey: priv,
-		PublicKey:  pub,
+}
+
+// sharedPoint computes the raw ECDH shared secret on the keypair's curve.
+func sharedPoint(ours EphemeralKeyPair, theirs []byte) ([]byte, error) {
+	switch curve := ours.Curve.OrDefault(); curve {
+	case CurveX25519:
+		return curve25519.X25519(ours.PrivateKey, theirs)
+	case CurveP256:
+		x, y := elliptic.Unmarshal(elliptic.P256(), theirs)
+		if x == nil {
+			return nil, ErrInvalidPublicKey
+		}
+		qx, _ := elliptic.P256().ScalarMult(x, y, ours.PrivateKey)
+		return qx.FillBytes(make([]byte, 32)), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurve, curve)
 	}
 }
 
@@ -52,13 +133,15 @@ func NewEphemeralKeyPair() EphemeralKeyPair {
 //
 // The secret is computed using the following KDF (similar to libsodium):
 //  blake2b-512(q || client-pub || server-pub).
-// where q is the 32-byte x25519 shared secret.
+// where q is the 32-byte x25519 shared secret, or the x-coordinate of the
+// shared point when using P-256. The peer's public key must be on the same
+// curve as our keypair.
 //
 // The client and server's public keys must be ordered the same way on both
 // sides, so the peer's type (client or server) must be provided along with
 // the peer's public key.
 func DeriveSharedSecret(ours EphemeralKeyPair, theirs PeerPublicKey) ([]byte, error) {
-	q, err := curve25519.X25519(ours.PrivateKey, theirs.PublicKey)
+	q, err := sharedPoint(ours, theirs.PublicKey)
 	if err != nil {
 		return nil, err
 	}
@@ -66,6 +66,66 @@ var _ = Describe("ECDH", Label(test.Unit), func() {
 		Expect(kr1.ServerKey).To(Equal(kr2.ServerKey))
 	})
 
+	DescribeTable("should compute equal shared secrets using each curve",
+		func(curve ecdh.Curve, pubKeyLen int) {
+			ekpA, err := ecdh.NewEphemeralKeyPairForCurve(curve)
+			Expect(err).NotTo(HaveOccurred())
+			ekpB, err := ecdh.NewEphemeralKeyPairForCurve(curve)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ekpA.Curve).To(Equal(curve.OrDefault()))
+			Expect(ekpA.PublicKey).To(HaveLen(pubKeyLen))
+			Expect(ekpA.PrivateKey).NotTo(Equal(ekpB.PrivateKey))
+
+			secretA, err := ecdh.DeriveSharedSecret(ekpA, ecdh.PeerPublicKey{
+				PublicKey: ekpB.PublicKey,
+				PeerType:  ecdh.PeerTypeServer,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			secretB, err := ecdh.DeriveSharedSecret(ekpB, ecdh.PeerPublicKey{
+				PublicKey: ekpA.PublicKey,
+				PeerType:  ecdh.PeerTypeClient,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secretA).To(Equal(secretB))
+		},
+		Entry("x25519", ecdh.CurveX25519, 32),
+		Entry("p256", ecdh.CurveP256, 65),
+		Entry("default", ecdh.Curve(""), 32),
+	)
+
+	It("should reject unsupported curves", func() {
+		Expect(ecdh.Curve("").IsSupported()).To(BeTrue())
+		Expect(ecdh.Curve("p521").IsSupported()).To(BeFalse())
+		_, err := ecdh.NewEphemeralKeyPairForCurve("p521")
+		Expect(err).To(MatchError(ecdh.ErrUnsupportedCurve))
+		_, err = ecdh.DeriveSharedSecret(ecdh.EphemeralKeyPair{
+			Curve:      "p521",
+			PrivateKey: make([]byte, 32),
+		}, ecdh.PeerPublicKey{
+			PublicKey: make([]byte, 32),
+			PeerType:  ecdh.PeerTypeClient,
+		})
+		Expect(err).To(MatchError(ecdh.ErrUnsupportedCurve))
+	})
+
+	It("should reject peer keys from a different curve", func() {
+		ekpP256, err := ecdh.NewEphemeralKeyPairForCurve(ecdh.CurveP256)
+		Expect(err).NotTo(HaveOccurred())
+		ekpX25519 := ecdh.NewEphemeralKeyPair()
+
+		_, err = ecdh.DeriveSharedSecret(ekpP256, ecdh.PeerPublicKey{
+			PublicKey: ekpX25519.PublicKey,
+			PeerType:  ecdh.PeerTypeClient,
+		})
+		Expect(err).To(MatchError(ecdh.ErrInvalidPublicKey))
+
+		_, err = ecdh.DeriveSharedSecret(ekpX25519, ecdh.PeerPublicKey{
+			PublicKey: ekpP256.PublicKey,
+			PeerType:  ecdh.PeerTypeClient,
+		})
+		Expect(err).To(HaveOccurred())
+	})
+
 	It("should handle errors", func() {
 		ekpA := ecdh.NewEphemeralKeyPair()
 		ekpB := ecdh.NewEphemeralKeyPair()
@@ -3,11 +3,14 @@ package gateway
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"regexp"
 	"runtime/debug"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -15,9 +18,11 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/monitor"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rancher/opni-monitoring/pkg/auth"
+	"github.com/rancher/opni-monitoring/pkg/auth/cluster"
 	"github.com/rancher/opni-monitoring/pkg/bootstrap"
 	"github.com/rancher/opni-monitoring/pkg/capabilities"
 	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
+	"github.com/rancher/opni-monitoring/pkg/labels"
 	"github.com/rancher/opni-monitoring/pkg/logger"
 	"github.com/rancher/opni-monitoring/pkg/plugins/apis/apiextensions"
 	"github.com/rancher/opni-monitoring/pkg/plugins/meta"
@@ -37,6 +42,7 @@ var (
 	})
 	apiCollectors = []prometheus.Collector{
 		httpRequestsTotal,
+		httpRequestsRejectedTotal,
 	}
 )
 
@@ -46,8 +52,11 @@ type GatewayAPIServer struct {
 	conf           *v1beta1.GatewayConfigSpec
 	logger         *zap.SugaredLogger
 	tlsConfig      *tls.Config
+	servingCertsMu sync.RWMutex
+	servingCerts   []*tls.Certificate
 	wait           chan struct{}
 	metricsHandler *MetricsEndpointHandler
+	drainer        *Drainer
 
 	reservedPrefixRoutes []string
 }
@@ -55,6 +64,7 @@ type GatewayAPIServer struct {
 type APIServerOptions struct {
 	fiberMiddlewares []FiberMiddleware
 	authMiddleware   auth.NamedMiddleware
+	keyringStores    storage.KeyringStoreBroker
 	apiExtensions    []APIExtensionPlugin
 	metricsPlugins   []MetricsPlugin
 }
@@ -83,6 +93,14 @@ func WithAuthMiddleware(name string) APIServerOption {
 	}
 }
 
+// WithKeyringStoreBroker configures the keyring stores used to authenticate
+// agents, for concurrency limits using ConcurrencyLimitAuthCluster.
+func WithKeyringStoreBroker(broker storage.KeyringStoreBroker) APIServerOption {
+	return func(o *APIServerOptions) {
+		o.keyringStores = broker
+	}
+}
+
 func WithAPIExtensions(plugins []APIExtensionPlugin) APIServerOption {
 	return func(o *APIServerOptions) {
 		o.apiExtensions = plugins
@@ -110,6 +128,18 @@ func NewAPIServer(
 		lg.Fatal("auth middleware is required")
 	}
 
+	var idleTimeout time.Duration
+	if cfg.IdleTimeout != "" {
+		d, err := time.ParseDuration(cfg.IdleTimeout)
+		if err != nil {
+			lg.With(
+				zap.Error(err),
+			).Error("invalid idle timeout, idle connections will not be closed")
+		} else {
+			idleTimeout = d
+		}
+	}
+
 	app := fiber.New(fiber.Config{
 		StrictRouting:           false,
 		AppName:                 "Opni Gateway",
@@ -118,6 +148,7 @@ func NewAPIServer(
 		EnableTrustedProxyCheck: len(cfg.TrustedProxies) > 0,
 		TrustedProxies:          cfg.TrustedProxies,
 		DisableStartupMessage:   true,
+		IdleTimeout:             idleTimeout,
 	})
 
 	logger.ConfigureAppLogger(app, "gateway")
@@ -128,21 +159,36 @@ func NewAPIServer(
 			zap.Error(err),
 		).Fatal("failed to load serving cert bundle")
 	}
+	if err := configureSessionTickets(ctx, cfg.SessionTickets, tlsConfig, lg); err != nil {
+		lg.With(
+			zap.Error(err),
+		).Fatal("failed to configure session tickets")
+	}
 	srv := &GatewayAPIServer{
 		APIServerOptions: options,
 		app:              app,
 		conf:             cfg,
 		logger:           lg,
 		tlsConfig:        tlsConfig,
+		servingCerts:     []*tls.Certificate{&tlsConfig.Certificates[0]},
 		wait:             make(chan struct{}),
 		metricsHandler:   NewMetricsEndpointHandler(),
+		drainer:          NewDrainer(),
 		reservedPrefixRoutes: []string{
 			"/monitor",
 			"/healthz",
+			"/readyz",
 			"/bootstrap",
 			"/metrics",
+			"/.well-known",
+			TargetsPath,
 		},
 	}
+	// connections are served using the current serving certificate, which
+	// can be rotated
+	tlsConfig.GetConfigForClient = srv.getConfigForClient
+
+	app.Use(srv.drainer.Middleware)
 
 	for _, middleware := range options.fiberMiddlewares {
 		app.Use(middleware)
@@ -160,6 +206,16 @@ func NewAPIServer(
 		return c.Next()
 	})
 
+	if len(cfg.ConcurrencyLimits) > 0 {
+		limiter, err := NewConcurrencyLimiter(cfg.ConcurrencyLimits, srv.concurrencyLimitAuth)
+		if err != nil {
+			lg.With(
+				zap.Error(err),
+			).Fatal("failed to configure concurrency limits")
+		}
+		app.Use(limiter.Authenticate, limiter.Handle)
+	}
+
 	if cfg.EnableMonitor {
 		app.Get("/monitor", monitor.New())
 	}
@@ -167,6 +223,13 @@ func NewAPIServer(
 	app.All("/healthz", func(c *fiber.Ctx) error {
 		return c.SendStatus(http.StatusOK)
 	})
+	app.All("/readyz", srv.drainer.ReadinessHandler)
+
+	if cfg.EnableDiscovery {
+		app.Get(DiscoveryPath, func(c *fiber.Ctx) error {
+			return NewDiscoveryHandler(cfg, srv.TLSConfig())(c)
+		})
+	}
 
 	srv.metricsHandler.MustRegister(apiCollectors...)
 	for _, plugin := range options.metricsPlugins {
@@ -206,6 +269,32 @@ func NewAPIServer(
 	return srv
 }
 
+// concurrencyLimitAuth returns the handler which authenticates requests to
+// a path with a concurrency limit, given the limit's auth provider.
+func (s *GatewayAPIServer) concurrencyLimitAuth(provider string) (fiber.Handler, error) {
+	switch provider {
+	case "":
+		return s.authMiddleware.Handle, nil
+	case v1beta1.ConcurrencyLimitAuthNone:
+		return nil, nil
+	case v1beta1.ConcurrencyLimitAuthCluster:
+		if s.keyringStores == nil {
+			return nil, errors.New("cluster auth requires a storage backend")
+		}
+		mw, err := cluster.New(s.keyringStores, "X-Opni-Cluster-ID")
+		if err != nil {
+			return nil, err
+		}
+		return mw.Handle, nil
+	default:
+		mw, err := auth.GetMiddleware(provider)
+		if err != nil {
+			return nil, err
+		}
+		return mw.Handle, nil
+	}
+}
+
 func (s *GatewayAPIServer) ListenAndServe() error {
 	select {
 	case <-s.wait:
@@ -273,13 +362,72 @@ func (s *GatewayAPIServer) ConfigureBootstrapRoutes(
 ) {
 	limiterCfg := limiter.ConfigDefault
 	limiterCfg.Max = 60 // 60 requests per minute
-	s.app.Post("/bootstrap/*", limiter.New(limiterCfg), bootstrap.ServerConfig{
-		Certificate:         &s.tlsConfig.Certificates[0],
-		TokenStore:          storageBackend,
-		ClusterStore:        storageBackend,
-		KeyringStoreBroker:  storageBackend,
-		CapabilityInstaller: installer,
-	}.Handle)
+	handlers := []fiber.Handler{limiter.New(limiterCfg)}
+	if name := s.conf.RouteAuthProviders.Bootstrap; name != "" {
+		mw, err := auth.GetMiddleware(name)
+		if err != nil {
+			s.logger.With(
+				zap.Error(err),
+			).Fatal("failed to configure bootstrap auth provider")
+		}
+		handlers = append(handlers, mw.Handle)
+	}
+	labelTemplates, err := labels.ParseTemplates(s.conf.LabelTemplates)
+	if err != nil {
+		s.logger.With(
+			zap.Error(err),
+		).Fatal("failed to parse label templates")
+	}
+	var joinCache *bootstrap.JoinResponseCache
+	if s.conf.BootstrapJoinCacheMaxAge != "" {
+		maxAge, err := time.ParseDuration(s.conf.BootstrapJoinCacheMaxAge)
+		if err != nil {
+			s.logger.With(
+				zap.Error(err),
+			).Error("invalid bootstrap join cache max age, join responses will not be cached")
+		} else {
+			joinCache = bootstrap.NewJoinResponseCache(maxAge)
+		}
+	}
+	var authRateLimiter *bootstrap.AuthRateLimiter
+	if s.conf.BootstrapAuthRateLimit > 0 {
+		authRateLimiter = bootstrap.NewAuthRateLimiter(
+			s.conf.BootstrapAuthRateLimit, s.conf.BootstrapAuthBurst)
+	}
+	var clientIDPattern *regexp.Regexp
+	if s.conf.BootstrapClientIDPattern != "" {
+		clientIDPattern, err = regexp.Compile(s.conf.BootstrapClientIDPattern)
+		if err != nil {
+			s.logger.With(
+				zap.Error(err),
+			).Fatal("failed to parse bootstrap client ID pattern")
+		}
+	}
+	bootstrapServer := bootstrap.ServerConfig{
+		TokenStore:             storageBackend,
+		ClusterStore:           storageBackend,
+		KeyringStoreBroker:     storageBackend,
+		CapabilityInstaller:    installer,
+		LabelTemplates:         labelTemplates,
+		JoinResponseCache:      joinCache,
+		RequireTLSForBootstrap: s.conf.RequireTLSForBootstrap,
+		AuthRateLimiter:        authRateLimiter,
+		ClientIDPattern:        clientIDPattern,
+	}
+	handlers = append(handlers, func(c *fiber.Ctx) error {
+		// the serving certificate can be rotated at any time
+		srv := bootstrapServer
+		srv.Certificates = s.signingCertificates()
+		return srv.Handle(c)
+	})
+	s.app.All("/bootstrap/*", handlers...)
+}
+
+// ConfigureTargetRoutes serves the cluster inventory in the Prometheus
+// http_sd format at TargetsPath. The route requires authentication using the
+// gateway's auth middleware.
+func (s *GatewayAPIServer) ConfigureTargetRoutes(clusterStore storage.ClusterStore) {
+	s.app.Get(TargetsPath, s.authMiddleware.Handle, NewTargetsHandler(clusterStore))
 }
 
 func loadTLSConfig(cfg *v1beta1.GatewayConfigSpec) (*tls.Config, error) {
@@ -294,6 +442,36 @@ func loadTLSConfig(cfg *v1beta1.GatewayConfigSpec) (*tls.Config, error) {
 	}, nil
 }
 
+func configureSessionTickets(
+	ctx context.Context,
+	spec v1beta1.SessionTicketsSpec,
+	tlsConfig *tls.Config,
+	lg *zap.SugaredLogger,
+) error {
+	if spec.Disabled {
+		tlsConfig.SessionTicketsDisabled = true
+		return nil
+	}
+	interval, err := time.ParseDuration(spec.RotationInterval)
+	if err != nil {
+		return fmt.Errorf("invalid rotation interval: %w", err)
+	}
+	retainedKeys := 0
+	if spec.RetainedKeys != nil {
+		retainedKeys = *spec.RetainedKeys
+	}
+	rotator, err := util.NewSessionTicketKeyRotator(tlsConfig, retainedKeys)
+	if err != nil {
+		return err
+	}
+	go rotator.Run(ctx, interval, func(err error) {
+		lg.With(
+			zap.Error(err),
+		).Error("failed to rotate session ticket keys")
+	})
+	return nil
+}
+
 func default404Handler(c *fiber.Ctx) error {
 	return c.SendStatus(fiber.StatusNotFound)
 }
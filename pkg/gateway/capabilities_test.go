@@ -0,0 +1,86 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/rancher/opni-monitoring/pkg/gateway"
+	"github.com/rancher/opni-monitoring/pkg/plugins/apis/capability"
+	"github.com/rancher/opni-monitoring/pkg/plugins/meta"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+type infoBackendClient struct {
+	capability.BackendClient
+	name string
+	err  error
+}
+
+func (c *infoBackendClient) Info(context.Context, *emptypb.Empty, ...grpc.CallOption) (*capability.InfoResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &capability.InfoResponse{
+		CapabilityName: c.name,
+	}, nil
+}
+
+func capabilityPlugin(module string, client *infoBackendClient) gateway.CapabilityBackendPlugin {
+	return gateway.CapabilityBackendPlugin{
+		Metadata: meta.PluginMeta{
+			Module: module,
+		},
+		Typed: client,
+	}
+}
+
+var _ = Describe("Capability Backends", Label(test.Unit), func() {
+	It("should keep the previous backend of plugins which fail to respond", func() {
+		foo := &infoBackendClient{name: "foo"}
+		bar := &infoBackendClient{name: "bar"}
+		plugins := []gateway.CapabilityBackendPlugin{
+			capabilityPlugin("example.com/foo", foo),
+			capabilityPlugin("example.com/bar", bar),
+		}
+		backends, names, err := gateway.LoadCapabilityBackends(context.Background(), plugins, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backends).To(HaveKey("foo"))
+		Expect(backends).To(HaveKey("bar"))
+
+		bar.err = errors.New("transient error")
+		backends, names, err = gateway.LoadCapabilityBackends(context.Background(), plugins, names)
+		Expect(err).To(MatchError(ContainSubstring("transient error")))
+		Expect(backends).To(HaveKey("foo"))
+		Expect(backends).To(HaveKey("bar"))
+		Expect(names).To(HaveKeyWithValue("example.com/bar", "bar"))
+
+		bar.err = nil
+		_, _, err = gateway.LoadCapabilityBackends(context.Background(), plugins, names)
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("should skip plugins which fail to respond the first time they are loaded", func() {
+		plugins := []gateway.CapabilityBackendPlugin{
+			capabilityPlugin("example.com/foo", &infoBackendClient{err: errors.New("error")}),
+			capabilityPlugin("example.com/bar", &infoBackendClient{name: "bar"}),
+		}
+		backends, names, err := gateway.LoadCapabilityBackends(context.Background(), plugins, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(backends).To(HaveLen(1))
+		Expect(backends).To(HaveKey("bar"))
+		Expect(names).NotTo(HaveKey("example.com/foo"))
+	})
+	It("should report duplicate capabilities", func() {
+		plugins := []gateway.CapabilityBackendPlugin{
+			capabilityPlugin("example.com/foo", &infoBackendClient{name: "foo"}),
+			capabilityPlugin("example.com/foo2", &infoBackendClient{name: "foo"}),
+		}
+		backends, _, err := gateway.LoadCapabilityBackends(context.Background(), plugins, nil)
+		Expect(err).To(MatchError(ContainSubstring("example.com/foo2")))
+		Expect(backends).To(HaveLen(1))
+	})
+})
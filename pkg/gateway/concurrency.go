@@ -0,0 +1,146 @@
+package gateway
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
+)
+
+var httpRequestsRejectedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "opni",
+	Subsystem: "gateway",
+	Name:      "http_requests_rejected_total",
+	Help:      "Total number of HTTP requests rejected due to concurrency limits",
+}, []string{"path"})
+
+type pathLimiter struct {
+	prefix  string
+	sem     chan struct{}
+	maxWait time.Duration
+	// authenticates requests before they are counted, if set
+	auth fiber.Handler
+}
+
+// ConcurrencyLimiter limits the number of requests that can be handled
+// concurrently for each of the configured path prefixes.
+type ConcurrencyLimiter struct {
+	limiters []*pathLimiter
+}
+
+// NewConcurrencyLimiter returns a limiter for the given specs. Requests over
+// the limit wait up to the configured maximum wait time for a slot to become
+// available, and are otherwise rejected with 429 Too Many Requests. Requests
+// not matching any prefix are not limited. authHandler is called with each
+// spec's Auth field, and returns the handler used to authenticate requests
+// to that path, or nil if they should not be authenticated.
+func NewConcurrencyLimiter(
+	specs []v1beta1.ConcurrencyLimitSpec,
+	authHandler func(provider string) (fiber.Handler, error),
+) (*ConcurrencyLimiter, error) {
+	limiters := make([]*pathLimiter, 0, len(specs))
+	for _, spec := range specs {
+		if spec.MaxConcurrent <= 0 {
+			return nil, fmt.Errorf("invalid concurrency limit for %q: %d", spec.Path, spec.MaxConcurrent)
+		}
+		var maxWait time.Duration
+		if spec.MaxWait != "" {
+			var err error
+			maxWait, err = time.ParseDuration(spec.MaxWait)
+			if err != nil {
+				return nil, fmt.Errorf("invalid max wait for %q: %w", spec.Path, err)
+			}
+		}
+		auth, err := authHandler(spec.Auth)
+		if err != nil {
+			return nil, fmt.Errorf("invalid auth for %q: %w", spec.Path, err)
+		}
+		limiters = append(limiters, &pathLimiter{
+			prefix:  spec.Path,
+			sem:     make(chan struct{}, spec.MaxConcurrent),
+			maxWait: maxWait,
+			auth:    auth,
+		})
+	}
+	// check longer prefixes first so the most specific limit is used
+	sort.SliceStable(limiters, func(i, j int) bool {
+		return len(limiters[i].prefix) > len(limiters[j].prefix)
+	})
+	return &ConcurrencyLimiter{
+		limiters: limiters,
+	}, nil
+}
+
+// Authenticate is a middleware which authenticates requests that would be
+// limited using the auth handler for the matching path, and passes all other
+// requests through. It must be mounted directly before Handle, so that
+// unauthenticated requests are rejected without taking up a slot.
+func (cl *ConcurrencyLimiter) Authenticate(c *fiber.Ctx) error {
+	limiter := cl.find(c.Path())
+	if limiter == nil || limiter.auth == nil {
+		return c.Next()
+	}
+	return limiter.auth(c)
+}
+
+// Handle is a middleware which applies the concurrency limits.
+func (cl *ConcurrencyLimiter) Handle(c *fiber.Ctx) error {
+	limiter := cl.find(c.Path())
+	if limiter == nil {
+		return c.Next()
+	}
+	if !limiter.acquire(c) {
+		httpRequestsRejectedTotal.WithLabelValues(limiter.prefix).Inc()
+		return c.SendStatus(http.StatusTooManyRequests)
+	}
+	defer limiter.release()
+	return c.Next()
+}
+
+func (cl *ConcurrencyLimiter) find(path string) *pathLimiter {
+	for _, l := range cl.limiters {
+		if l.matches(path) {
+			return l
+		}
+	}
+	return nil
+}
+
+// matches reports whether path is the limiter's prefix or is below it, so
+// that e.g. "/api/v1/query" does not also match "/api/v1/query_range".
+func (l *pathLimiter) matches(path string) bool {
+	if path == l.prefix {
+		return true
+	}
+	return strings.HasPrefix(path, strings.TrimSuffix(l.prefix, "/")+"/")
+}
+
+func (l *pathLimiter) acquire(c *fiber.Ctx) bool {
+	select {
+	case l.sem <- struct{}{}:
+		return true
+	default:
+	}
+	if l.maxWait <= 0 {
+		return false
+	}
+	timer := time.NewTimer(l.maxWait)
+	defer timer.Stop()
+	select {
+	case l.sem <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-c.Context().Done():
+		return false
+	}
+}
+
+func (l *pathLimiter) release() {
+	<-l.sem
+}
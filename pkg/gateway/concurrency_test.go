@@ -0,0 +1,293 @@
+package gateway_test
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/auth/cluster"
+	"github.com/rancher/opni-monitoring/pkg/b2mac"
+	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/gateway"
+	"github.com/rancher/opni-monitoring/pkg/keyring"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Concurrency Limiter", Label(test.Unit), func() {
+	var app *fiber.App
+	var release chan struct{}
+	var inFlight int32
+
+	var agentKeys *keyring.SharedKeys
+	var authHandler func(provider string) (fiber.Handler, error)
+	BeforeEach(func() {
+		broker := test.NewTestKeyringStoreBroker(gomock.NewController(GinkgoT()))
+		secret := make([]byte, 64)
+		_, err := rand.Read(secret)
+		Expect(err).NotTo(HaveOccurred())
+		agentKeys = keyring.NewSharedKeys(secret)
+		ks, err := broker.KeyringStore(context.Background(), "gateway", &core.Reference{Id: "agent-1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ks.Put(context.Background(), keyring.New(agentKeys))).To(Succeed())
+		clusterAuth, err := cluster.New(broker, "X-Test-Cluster-ID")
+		Expect(err).NotTo(HaveOccurred())
+
+		authHandler = func(provider string) (fiber.Handler, error) {
+			switch provider {
+			case "":
+				return func(c *fiber.Ctx) error {
+					if c.Get("Authorization") != "test" {
+						return c.SendStatus(http.StatusUnauthorized)
+					}
+					return c.Next()
+				}, nil
+			case v1beta1.ConcurrencyLimitAuthCluster:
+				return clusterAuth.Handle, nil
+			case v1beta1.ConcurrencyLimitAuthNone:
+				return nil, nil
+			default:
+				return nil, errors.New("unknown auth provider")
+			}
+		}
+	})
+
+	newApp := func(specs ...v1beta1.ConcurrencyLimitSpec) {
+		limiter, err := gateway.NewConcurrencyLimiter(specs, authHandler)
+		Expect(err).NotTo(HaveOccurred())
+		release = make(chan struct{})
+		atomic.StoreInt32(&inFlight, 0)
+		app = fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		app.Use(limiter.Authenticate, limiter.Handle)
+		app.Get("/slow/*", func(c *fiber.Ctx) error {
+			atomic.AddInt32(&inFlight, 1)
+			<-release
+			return c.SendStatus(http.StatusOK)
+		})
+		app.Get("/*", func(c *fiber.Ctx) error {
+			return c.SendStatus(http.StatusOK)
+		})
+	}
+
+	getWithAuth := func(path string, authorization string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", authorization)
+		resp, err := app.Test(req, -1)
+		Expect(err).NotTo(HaveOccurred())
+		return resp.StatusCode
+	}
+
+	get := func(path string) int {
+		return getWithAuth(path, "test")
+	}
+
+	// starts n requests to path in the background, and waits until they are
+	// all being handled.
+	saturate := func(path string, n int) chan int {
+		codes := make(chan int, n)
+		for i := 0; i < n; i++ {
+			go func() {
+				defer GinkgoRecover()
+				codes <- get(path)
+			}()
+		}
+		Eventually(func() int32 {
+			return atomic.LoadInt32(&inFlight)
+		}).Should(BeEquivalentTo(n))
+		return codes
+	}
+
+	It("should reject requests over the limit with 429", func() {
+		newApp(v1beta1.ConcurrencyLimitSpec{
+			Path:          "/slow/query_range",
+			MaxConcurrent: 2,
+		})
+		codes := saturate("/slow/query_range", 2)
+
+		Expect(get("/slow/query_range")).To(Equal(http.StatusTooManyRequests))
+		Expect(get("/slow/query_range?query=up")).To(Equal(http.StatusTooManyRequests))
+
+		close(release)
+		Expect(<-codes).To(Equal(http.StatusOK))
+		Expect(<-codes).To(Equal(http.StatusOK))
+
+		Expect(get("/slow/query_range")).To(Equal(http.StatusOK))
+	})
+	It("should not affect other paths", func() {
+		newApp(v1beta1.ConcurrencyLimitSpec{
+			Path:          "/slow/query_range",
+			MaxConcurrent: 1,
+		})
+		codes := saturate("/slow/query_range", 1)
+		defer func() {
+			close(release)
+			Expect(<-codes).To(Equal(http.StatusOK))
+		}()
+
+		Expect(get("/slow/query_range")).To(Equal(http.StatusTooManyRequests))
+		Expect(get("/healthz")).To(Equal(http.StatusOK))
+		Expect(get("/api/v1/query")).To(Equal(http.StatusOK))
+	})
+	It("should limit each path independently", func() {
+		newApp(
+			v1beta1.ConcurrencyLimitSpec{
+				Path:          "/slow/query_range",
+				MaxConcurrent: 1,
+			},
+			v1beta1.ConcurrencyLimitSpec{
+				Path:          "/slow/query",
+				MaxConcurrent: 1,
+			},
+		)
+		codes := saturate("/slow/query_range", 1)
+		Expect(get("/slow/query_range")).To(Equal(http.StatusTooManyRequests))
+
+		// /slow/query_range matches the longer prefix, so /slow/query still
+		// has a free slot
+		go func() {
+			defer GinkgoRecover()
+			codes <- get("/slow/query")
+		}()
+		Eventually(func() int32 {
+			return atomic.LoadInt32(&inFlight)
+		}).Should(BeEquivalentTo(2))
+
+		close(release)
+		Expect(<-codes).To(Equal(http.StatusOK))
+		Expect(<-codes).To(Equal(http.StatusOK))
+	})
+	It("should match prefixes on path boundaries", func() {
+		newApp(v1beta1.ConcurrencyLimitSpec{
+			Path:          "/slow/query",
+			MaxConcurrent: 1,
+		})
+		codes := saturate("/slow/query", 1)
+		defer func() {
+			close(release)
+			Expect(<-codes).To(Equal(http.StatusOK))
+		}()
+
+		Expect(get("/slow/query")).To(Equal(http.StatusTooManyRequests))
+		Expect(get("/slow/query/foo")).To(Equal(http.StatusTooManyRequests))
+		Expect(get("/slowquery")).To(Equal(http.StatusOK))
+		Expect(get("/other/query_range")).To(Equal(http.StatusOK))
+	})
+	It("should reject unauthenticated requests without taking a slot", func() {
+		newApp(v1beta1.ConcurrencyLimitSpec{
+			Path:          "/slow/query_range",
+			MaxConcurrent: 1,
+		})
+		Expect(getWithAuth("/slow/query_range", "")).To(Equal(http.StatusUnauthorized))
+		Expect(getWithAuth("/slow/query_range", "wrong")).To(Equal(http.StatusUnauthorized))
+
+		codes := saturate("/slow/query_range", 1)
+		Expect(getWithAuth("/slow/query_range", "")).To(Equal(http.StatusUnauthorized))
+		Expect(get("/slow/query_range")).To(Equal(http.StatusTooManyRequests))
+
+		close(release)
+		Expect(<-codes).To(Equal(http.StatusOK))
+	})
+	It("should authenticate each path using its own auth provider", func() {
+		newApp(
+			v1beta1.ConcurrencyLimitSpec{
+				Path:          "/slow/push",
+				MaxConcurrent: 1,
+				Auth:          v1beta1.ConcurrencyLimitAuthCluster,
+			},
+			v1beta1.ConcurrencyLimitSpec{
+				Path:          "/other",
+				MaxConcurrent: 1,
+				Auth:          v1beta1.ConcurrencyLimitAuthNone,
+			},
+		)
+		agentAuth, err := b2mac.NewEncodedHeader([]byte("agent-1"), nil, agentKeys.ClientKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("rejecting requests which do not use the agent's keyring")
+		Expect(getWithAuth("/slow/push", "test")).To(Equal(http.StatusBadRequest))
+
+		By("limiting requests authenticated using the agent's keyring")
+		codes := make(chan int, 1)
+		go func() {
+			defer GinkgoRecover()
+			codes <- getWithAuth("/slow/push", agentAuth)
+		}()
+		Eventually(func() int32 {
+			return atomic.LoadInt32(&inFlight)
+		}).Should(BeEquivalentTo(1))
+		Expect(getWithAuth("/slow/push", agentAuth)).To(Equal(http.StatusTooManyRequests))
+
+		By("not authenticating requests to paths without auth")
+		Expect(getWithAuth("/other/query", "")).To(Equal(http.StatusOK))
+
+		close(release)
+		Expect(<-codes).To(Equal(http.StatusOK))
+	})
+	It("should not authenticate requests to other paths", func() {
+		newApp(v1beta1.ConcurrencyLimitSpec{
+			Path:          "/slow/query_range",
+			MaxConcurrent: 1,
+		})
+		Expect(getWithAuth("/healthz", "")).To(Equal(http.StatusOK))
+	})
+	It("should queue requests for up to the max wait time", func() {
+		newApp(v1beta1.ConcurrencyLimitSpec{
+			Path:          "/slow/query_range",
+			MaxConcurrent: 1,
+			MaxWait:       "5s",
+		})
+		codes := saturate("/slow/query_range", 1)
+
+		queued := make(chan int, 1)
+		go func() {
+			defer GinkgoRecover()
+			queued <- get("/slow/query_range")
+		}()
+		Consistently(queued, 100*time.Millisecond).ShouldNot(Receive())
+
+		close(release)
+		Expect(<-codes).To(Equal(http.StatusOK))
+		Eventually(queued).Should(Receive(Equal(http.StatusOK)))
+	})
+	It("should reject queued requests after the max wait time", func() {
+		newApp(v1beta1.ConcurrencyLimitSpec{
+			Path:          "/slow/query_range",
+			MaxConcurrent: 1,
+			MaxWait:       "100ms",
+		})
+		codes := saturate("/slow/query_range", 1)
+		defer func() {
+			close(release)
+			Expect(<-codes).To(Equal(http.StatusOK))
+		}()
+
+		start := time.Now()
+		Expect(get("/slow/query_range")).To(Equal(http.StatusTooManyRequests))
+		Expect(time.Since(start)).To(BeNumerically(">=", 100*time.Millisecond))
+	})
+	It("should reject invalid limits", func() {
+		_, err := gateway.NewConcurrencyLimiter([]v1beta1.ConcurrencyLimitSpec{
+			{Path: "/foo", MaxConcurrent: 0},
+		}, authHandler)
+		Expect(err).To(HaveOccurred())
+		_, err = gateway.NewConcurrencyLimiter([]v1beta1.ConcurrencyLimitSpec{
+			{Path: "/foo", MaxConcurrent: 1, MaxWait: "soon"},
+		}, authHandler)
+		Expect(err).To(HaveOccurred())
+		_, err = gateway.NewConcurrencyLimiter([]v1beta1.ConcurrencyLimitSpec{
+			{Path: "/foo", MaxConcurrent: 1, Auth: "does-not-exist"},
+		}, authHandler)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,125 @@
+package gateway
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rancher/opni-monitoring/pkg/auth/cluster"
+	"github.com/rancher/opni-monitoring/pkg/diagnostics"
+	"github.com/rancher/opni-monitoring/pkg/storage"
+	"go.uber.org/zap"
+)
+
+// Key-value store namespace containing agent diagnostics bundles.
+const diagnosticsNamespace = "diagnostics"
+
+// Maximum size of an uploaded diagnostics bundle.
+const maxDiagnosticsBundleSize = 1024 * 1024
+
+type diagnosticsReport struct {
+	time        time.Time
+	fingerprint string
+}
+
+type diagnosticsHandler struct {
+	store           *storage.DiagnosticsStore
+	logger          *zap.SugaredLogger
+	minInterval     time.Duration
+	refreshInterval time.Duration
+
+	mu sync.Mutex
+	// the most recently stored bundle for each cluster
+	reports map[string]diagnosticsReport
+}
+
+// NewDiagnosticsHandler returns a handler which stores diagnostics bundles
+// uploaded by agents. It must be mounted after the cluster auth middleware.
+//
+// Each agent may upload at most one bundle every
+// diagnostics.MinReportInterval; requests made more often are rejected with
+// 429 Too Many Requests. Bundles which are unchanged from the previous bundle
+// stored for the agent are accepted, but only stored once the previous bundle
+// is older than diagnostics.RefreshInterval.
+func NewDiagnosticsHandler(store *storage.DiagnosticsStore, lg *zap.SugaredLogger) fiber.Handler {
+	return newDiagnosticsHandler(store, lg, diagnostics.MinReportInterval, diagnostics.RefreshInterval)
+}
+
+func newDiagnosticsHandler(
+	store *storage.DiagnosticsStore,
+	lg *zap.SugaredLogger,
+	minInterval time.Duration,
+	refreshInterval time.Duration,
+) fiber.Handler {
+	h := &diagnosticsHandler{
+		store:           store,
+		logger:          lg,
+		minInterval:     minInterval,
+		refreshInterval: refreshInterval,
+		reports:         map[string]diagnosticsReport{},
+	}
+	return h.handle
+}
+
+func (h *diagnosticsHandler) handle(c *fiber.Ctx) error {
+	clusterID := cluster.AuthorizedID(c)
+	body := c.Body()
+	if len(body) > maxDiagnosticsBundleSize {
+		return c.Status(fiber.StatusRequestEntityTooLarge).
+			SendString("diagnostics bundle is too large")
+	}
+	var bundle diagnostics.Bundle
+	if err := json.Unmarshal(body, &bundle); err != nil {
+		return c.Status(fiber.StatusBadRequest).
+			SendString("diagnostics bundle must be a JSON object")
+	}
+	fingerprint := bundle.Fingerprint()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now()
+	last, ok := h.reports[clusterID]
+	if ok {
+		if elapsed := now.Sub(last.time); elapsed < h.minInterval {
+			retryAfter := math.Ceil((h.minInterval - elapsed).Seconds())
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(retryAfter)))
+			return c.Status(fiber.StatusTooManyRequests).
+				SendString("diagnostics bundles are being uploaded too often")
+		}
+		if last.fingerprint == fingerprint && now.Sub(last.time) < h.refreshInterval {
+			return c.SendStatus(fiber.StatusOK)
+		}
+	}
+	if err := h.store.Put(c.UserContext(), clusterID, body); err != nil {
+		h.logger.With(
+			zap.Error(err),
+			"id", clusterID,
+		).Error("failed to store diagnostics bundle")
+		return c.SendStatus(fiber.StatusInternalServerError)
+	}
+	h.reports[clusterID] = diagnosticsReport{
+		time:        now,
+		fingerprint: fingerprint,
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// ConfigureDiagnosticsRoutes accepts diagnostics bundles uploaded by agents
+// at diagnostics.AgentPath. Agents authenticate using their keyring.
+func (s *GatewayAPIServer) ConfigureDiagnosticsRoutes(
+	keyringStoreBroker storage.KeyringStoreBroker,
+	store *storage.DiagnosticsStore,
+) {
+	clusterMiddleware, err := cluster.New(keyringStoreBroker, "X-Opni-Cluster-ID")
+	if err != nil {
+		s.logger.With(
+			zap.Error(err),
+		).Error("failed to configure agent diagnostics routes")
+		return
+	}
+	s.app.Post(diagnostics.AgentPath, clusterMiddleware.Handle,
+		NewDiagnosticsHandler(store, s.logger))
+}
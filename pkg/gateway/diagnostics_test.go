@@ -0,0 +1,99 @@
+package gateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/auth/cluster"
+	"github.com/rancher/opni-monitoring/pkg/diagnostics"
+	"github.com/rancher/opni-monitoring/pkg/gateway"
+	"github.com/rancher/opni-monitoring/pkg/storage"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Diagnostics Handler", Label(test.Unit), func() {
+	var store *storage.DiagnosticsStore
+	var app *fiber.App
+	BeforeEach(func() {
+		store = storage.NewDiagnosticsStore(test.NewTestKeyValueStore(gomock.NewController(GinkgoT())))
+		app = fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		app.Post(diagnostics.AgentPath, func(c *fiber.Ctx) error {
+			c.Locals(cluster.ClusterIDKey, c.Get("X-Test-Cluster-Id"))
+			return c.Next()
+		}, gateway.NewDiagnosticsHandlerWithIntervals(store, 100*time.Millisecond, time.Hour))
+	})
+
+	upload := func(clusterID string, bundle *diagnostics.Bundle) int {
+		data, err := json.Marshal(bundle)
+		Expect(err).NotTo(HaveOccurred())
+		req := httptest.NewRequest(fiber.MethodPost, diagnostics.AgentPath, strings.NewReader(string(data)))
+		req.Header.Set("X-Test-Cluster-Id", clusterID)
+		resp, err := app.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		return resp.StatusCode
+	}
+	reportedAt := func(clusterID string) time.Time {
+		diag, err := store.Get(context.Background(), clusterID)
+		Expect(err).NotTo(HaveOccurred())
+		return diag.ReportedAt
+	}
+
+	It("should reject bundles uploaded too often by the same agent", func() {
+		Expect(upload("cluster-1", &diagnostics.Bundle{ClusterID: "cluster-1"})).To(Equal(fiber.StatusOK))
+		Expect(upload("cluster-1", &diagnostics.Bundle{ClusterID: "cluster-1"})).To(Equal(fiber.StatusTooManyRequests))
+		Expect(upload("cluster-2", &diagnostics.Bundle{ClusterID: "cluster-2"})).To(Equal(fiber.StatusOK))
+
+		time.Sleep(100 * time.Millisecond)
+		Expect(upload("cluster-1", &diagnostics.Bundle{
+			ClusterID: "cluster-1",
+			Errors: []diagnostics.Event{
+				{Message: "error"},
+			},
+		})).To(Equal(fiber.StatusOK))
+	})
+
+	It("should not store bundles which have not changed", func() {
+		Expect(upload("cluster-1", &diagnostics.Bundle{
+			GeneratedAt: time.Now(),
+			ClusterID:   "cluster-1",
+		})).To(Equal(fiber.StatusOK))
+		first := reportedAt("cluster-1")
+
+		time.Sleep(100 * time.Millisecond)
+		Expect(upload("cluster-1", &diagnostics.Bundle{
+			GeneratedAt: time.Now(),
+			ClusterID:   "cluster-1",
+			Connection: diagnostics.ConnectionStatus{
+				TotalRequests: 10,
+			},
+		})).To(Equal(fiber.StatusOK))
+		Expect(reportedAt("cluster-1")).To(Equal(first))
+
+		Expect(upload("cluster-1", &diagnostics.Bundle{
+			GeneratedAt: time.Now(),
+			ClusterID:   "cluster-1",
+			Connection: diagnostics.ConnectionStatus{
+				LastError: "connection refused",
+			},
+		})).To(Equal(fiber.StatusOK))
+		Expect(reportedAt("cluster-1")).To(BeTemporally(">", first))
+	})
+
+	It("should reject invalid bundles", func() {
+		req := httptest.NewRequest(fiber.MethodPost, diagnostics.AgentPath, strings.NewReader("[]"))
+		req.Header.Set("X-Test-Cluster-Id", "cluster-1")
+		resp, err := app.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(fiber.StatusBadRequest))
+	})
+})
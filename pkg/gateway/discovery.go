@@ -0,0 +1,79 @@
+package gateway
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rancher/opni-monitoring/pkg/bootstrap"
+	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
+	"github.com/rancher/opni-monitoring/pkg/pkp"
+)
+
+const DiscoveryPath = "/.well-known/opni-monitoring"
+
+// DiscoveryDocument describes how to connect to the gateway. It is served at
+// DiscoveryPath when discovery is enabled in the gateway config.
+type DiscoveryDocument struct {
+	// URL of the gateway's bootstrap API.
+	BootstrapURL string `json:"bootstrapURL"`
+	// Bootstrap protocol versions supported by the gateway.
+	ProtocolVersions []bootstrap.ProtocolVersion `json:"protocolVersions"`
+	// Names of the auth providers used by the gateway.
+	AuthProviders DiscoveryAuthProviders `json:"authProviders"`
+	// Public key pins of each certificate in the gateway's serving
+	// certificate chain, starting with the leaf certificate.
+	Pins []string `json:"pins"`
+}
+
+type DiscoveryAuthProviders struct {
+	Default       string `json:"default,omitempty"`
+	Bootstrap     string `json:"bootstrap,omitempty"`
+	ManagementAPI string `json:"managementAPI,omitempty"`
+	ManagementWeb string `json:"managementWeb,omitempty"`
+}
+
+// NewDiscoveryHandler returns a handler which serves the discovery document
+// for the given gateway config. The document is computed on each request,
+// so it always reflects the current config and serving certificate.
+func NewDiscoveryHandler(cfg *v1beta1.GatewayConfigSpec, tlsConfig *tls.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		doc, err := NewDiscoveryDocument(cfg, tlsConfig)
+		if err != nil {
+			return c.Status(http.StatusInternalServerError).SendString(err.Error())
+		}
+		return c.JSON(doc)
+	}
+}
+
+// NewDiscoveryDocument builds a discovery document from the given gateway
+// config and TLS config.
+func NewDiscoveryDocument(cfg *v1beta1.GatewayConfigSpec, tlsConfig *tls.Config) (*DiscoveryDocument, error) {
+	_, port, err := net.SplitHostPort(cfg.ListenAddress)
+	if err != nil {
+		return nil, err
+	}
+	doc := &DiscoveryDocument{
+		BootstrapURL:     "https://" + net.JoinHostPort(cfg.Hostname, port) + "/bootstrap",
+		ProtocolVersions: append([]bootstrap.ProtocolVersion{}, bootstrap.SupportedProtocolVersions...),
+		AuthProviders: DiscoveryAuthProviders{
+			Default:       cfg.AuthProvider,
+			Bootstrap:     cfg.RouteAuthProviders.Bootstrap,
+			ManagementAPI: cfg.RouteAuthProviders.ManagementAPI,
+			ManagementWeb: cfg.RouteAuthProviders.ManagementWeb,
+		},
+		Pins: []string{},
+	}
+	if tlsConfig != nil && len(tlsConfig.Certificates) > 0 {
+		for _, der := range tlsConfig.Certificates[0].Certificate {
+			cert, err := x509.ParseCertificate(der)
+			if err != nil {
+				return nil, err
+			}
+			doc.Pins = append(doc.Pins, pkp.NewSha256(cert).Encode())
+		}
+	}
+	return doc, nil
+}
@@ -0,0 +1,92 @@
+package gateway_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gofiber/fiber/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/bootstrap"
+	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
+	"github.com/rancher/opni-monitoring/pkg/gateway"
+	"github.com/rancher/opni-monitoring/pkg/pkp"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Discovery", Label(test.Unit), func() {
+	var app *fiber.App
+	var cfg *v1beta1.GatewayConfigSpec
+	var tlsConfig *tls.Config
+
+	BeforeEach(func() {
+		cert, err := tls.X509KeyPair(test.TestData("localhost.crt"), test.TestData("localhost.key"))
+		Expect(err).NotTo(HaveOccurred())
+		tlsConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+		}
+		cfg = &v1beta1.GatewayConfigSpec{
+			ListenAddress: ":8080",
+			Hostname:      "gateway.example.com",
+			AuthProvider:  "openid",
+			RouteAuthProviders: v1beta1.RouteAuthProvidersSpec{
+				Bootstrap: "noauth",
+			},
+		}
+		app = fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		app.Get(gateway.DiscoveryPath, gateway.NewDiscoveryHandler(cfg, tlsConfig))
+	})
+
+	getDocument := func() gateway.DiscoveryDocument {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, gateway.DiscoveryPath, nil))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Header.Get("Content-Type")).To(HavePrefix("application/json"))
+		var doc gateway.DiscoveryDocument
+		Expect(json.NewDecoder(resp.Body).Decode(&doc)).To(Succeed())
+		return doc
+	}
+
+	It("should serve the discovery document", func() {
+		leaf, err := x509.ParseCertificate(tlsConfig.Certificates[0].Certificate[0])
+		Expect(err).NotTo(HaveOccurred())
+
+		doc := getDocument()
+		Expect(doc.BootstrapURL).To(Equal("https://gateway.example.com:8080/bootstrap"))
+		Expect(doc.ProtocolVersions).To(Equal(bootstrap.SupportedProtocolVersions))
+		Expect(doc.AuthProviders).To(Equal(gateway.DiscoveryAuthProviders{
+			Default:   "openid",
+			Bootstrap: "noauth",
+		}))
+		Expect(doc.Pins).To(Equal([]string{pkp.NewSha256(leaf).Encode()}))
+	})
+
+	It("should reflect changes to the config", func() {
+		original := bootstrap.SupportedProtocolVersions
+		DeferCleanup(func() {
+			bootstrap.SupportedProtocolVersions = original
+		})
+		bootstrap.SupportedProtocolVersions = []bootstrap.ProtocolVersion{bootstrap.ProtocolV2}
+		cfg.Hostname = "other.example.com"
+		cfg.RouteAuthProviders.ManagementAPI = "openid"
+
+		doc := getDocument()
+		Expect(doc.BootstrapURL).To(Equal("https://other.example.com:8080/bootstrap"))
+		Expect(doc.ProtocolVersions).To(Equal([]bootstrap.ProtocolVersion{bootstrap.ProtocolV2}))
+		Expect(doc.AuthProviders.ManagementAPI).To(Equal("openid"))
+	})
+
+	It("should fail if the listen address is invalid", func() {
+		cfg.ListenAddress = "invalid"
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, gateway.DiscoveryPath, nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+	})
+})
@@ -0,0 +1,125 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Drainer tracks in-flight requests to the gateway API, and can be used to
+// stop accepting new requests before the gateway is shut down.
+type Drainer struct {
+	mu       sync.Mutex
+	draining bool
+	inFlight int
+	idle     chan struct{}
+}
+
+func NewDrainer() *Drainer {
+	return &Drainer{}
+}
+
+// Paths which are served normally while draining, so that liveness probes
+// keep passing and metrics can still be scraped. The readiness endpoint is
+// also served, and reports that the gateway is not ready.
+var drainExemptPaths = map[string]struct{}{
+	"/healthz": {},
+	"/readyz":  {},
+	"/metrics": {},
+}
+
+// Value of the Retry-After header sent with requests rejected while draining.
+const drainRetryAfterSeconds = "1"
+
+// Middleware counts in-flight requests. While draining, new requests are
+// rejected with 503 Service Unavailable and a Retry-After header, and
+// connections are closed once their current request completes. Agents retry
+// requests rejected this way on a new connection, which a load balancer will
+// send to a different gateway once the readiness endpoint reports that this
+// gateway is draining.
+func (d *Drainer) Middleware(c *fiber.Ctx) error {
+	if _, ok := drainExemptPaths[c.Path()]; ok {
+		return c.Next()
+	}
+	if !d.acquire() {
+		c.Set(fiber.HeaderConnection, "close")
+		c.Set(fiber.HeaderRetryAfter, drainRetryAfterSeconds)
+		return c.Status(http.StatusServiceUnavailable).SendString("gateway is draining")
+	}
+	defer d.release()
+	err := c.Next()
+	if d.Draining() {
+		c.Set(fiber.HeaderConnection, "close")
+	}
+	return err
+}
+
+// ReadinessHandler responds with 200 OK, or with 503 Service Unavailable once
+// the gateway is draining, so that load balancers stop sending new
+// connections to it.
+func (d *Drainer) ReadinessHandler(c *fiber.Ctx) error {
+	if d.Draining() {
+		return c.Status(http.StatusServiceUnavailable).SendString("gateway is draining")
+	}
+	return c.SendStatus(http.StatusOK)
+}
+
+// Drain stops the gateway from accepting new requests, then waits until all
+// in-flight requests have completed or the context is done. Draining cannot
+// be undone.
+func (d *Drainer) Drain(ctx context.Context) error {
+	d.mu.Lock()
+	d.draining = true
+	if d.inFlight == 0 {
+		d.mu.Unlock()
+		return nil
+	}
+	if d.idle == nil {
+		d.idle = make(chan struct{})
+	}
+	idle := d.idle
+	d.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Draining returns true if Drain has been called.
+func (d *Drainer) Draining() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.draining
+}
+
+// InFlightRequests returns the number of requests currently being handled.
+func (d *Drainer) InFlightRequests() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.inFlight
+}
+
+func (d *Drainer) acquire() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.draining {
+		return false
+	}
+	d.inFlight++
+	return true
+}
+
+func (d *Drainer) release() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.inFlight--
+	if d.inFlight == 0 && d.idle != nil {
+		close(d.idle)
+		d.idle = nil
+	}
+}
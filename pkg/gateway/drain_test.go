@@ -0,0 +1,285 @@
+package gateway_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/clients"
+	"github.com/rancher/opni-monitoring/pkg/gateway"
+	"github.com/rancher/opni-monitoring/pkg/keyring"
+	"github.com/rancher/opni-monitoring/pkg/pkp"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+type testGateway struct {
+	name     string
+	addr     string
+	drainer  *gateway.Drainer
+	rejected int32
+}
+
+func newTestGatewayApp(gw *testGateway, release <-chan struct{}) *fiber.App {
+	app := fiber.New(fiber.Config{
+		DisableStartupMessage: true,
+	})
+	app.Use(func(c *fiber.Ctx) error {
+		err := c.Next()
+		if c.Response().StatusCode() == http.StatusServiceUnavailable {
+			atomic.AddInt32(&gw.rejected, 1)
+		}
+		return err
+	})
+	app.Use(gw.drainer.Middleware)
+	app.Get("/healthz", func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+	app.Get("/readyz", gw.drainer.ReadinessHandler)
+	app.Get("/metrics", func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		<-release
+		return c.SendString(gw.name)
+	})
+	app.All("/*", func(c *fiber.Ctx) error {
+		return c.SendString(gw.name)
+	})
+	return app
+}
+
+func startTestGateway(name string, release <-chan struct{}) *testGateway {
+	gw := &testGateway{
+		name:    name,
+		drainer: gateway.NewDrainer(),
+	}
+	app := newTestGatewayApp(gw, release)
+	cert, err := tls.X509KeyPair(test.TestData("localhost.crt"), test.TestData("localhost.key"))
+	Expect(err).NotTo(HaveOccurred())
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	go app.Listener(tls.NewListener(listener, &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}))
+	DeferCleanup(app.Shutdown)
+	gw.addr = listener.Addr().String()
+	return gw
+}
+
+// testLoadBalancer forwards each new connection to the first gateway which
+// was ready when the load balancer last checked. Like a real load balancer,
+// it only notices that a gateway is draining when it next checks.
+type testLoadBalancer struct {
+	addr     string
+	gateways []*testGateway
+
+	mu    sync.Mutex
+	ready []*testGateway
+}
+
+func startTestLoadBalancer(gateways ...*testGateway) *testLoadBalancer {
+	lb := &testLoadBalancer{
+		gateways: gateways,
+	}
+	lb.Refresh()
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	DeferCleanup(listener.Close)
+	lb.addr = listener.Addr().String()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go lb.forward(conn)
+		}
+	}()
+	return lb
+}
+
+// Refresh checks the readiness of each gateway.
+func (lb *testLoadBalancer) Refresh() {
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: true,
+			},
+		},
+	}
+	defer client.CloseIdleConnections()
+	var ready []*testGateway
+	for _, gw := range lb.gateways {
+		resp, err := client.Get("https://" + gw.addr + "/readyz")
+		if err != nil {
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			ready = append(ready, gw)
+		}
+	}
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	lb.ready = ready
+}
+
+func (lb *testLoadBalancer) forward(conn net.Conn) {
+	defer conn.Close()
+	lb.mu.Lock()
+	if len(lb.ready) == 0 {
+		lb.mu.Unlock()
+		return
+	}
+	gw := lb.ready[0]
+	lb.mu.Unlock()
+	upstream, err := net.Dial("tcp4", gw.addr)
+	if err != nil {
+		return
+	}
+	defer upstream.Close()
+	go io.Copy(upstream, conn)
+	io.Copy(conn, upstream)
+}
+
+func newTestAgentClient(address string) clients.GatewayHTTPClient {
+	block, _ := pem.Decode(test.TestData("localhost.crt"))
+	cert, err := x509.ParseCertificate(block.Bytes)
+	Expect(err).NotTo(HaveOccurred())
+	kr := keyring.New(
+		keyring.NewSharedKeys(make([]byte, 64)),
+		keyring.NewPKPKey([]*pkp.PublicKeyPin{pkp.NewSha256(cert)}),
+	)
+	client, err := clients.NewGatewayHTTPClient("https://"+address,
+		test.NewTestIdentProvider(gomock.NewController(GinkgoT()), "agent"), kr)
+	Expect(err).NotTo(HaveOccurred())
+	return client
+}
+
+var _ = Describe("Drainer", Label(test.Unit), func() {
+	It("should count in-flight requests", func() {
+		drainer := gateway.NewDrainer()
+		Expect(drainer.InFlightRequests()).To(Equal(0))
+		Expect(drainer.Draining()).To(BeFalse())
+		Expect(drainer.Drain(context.Background())).To(Succeed())
+		Expect(drainer.Draining()).To(BeTrue())
+	})
+
+	It("should continue serving health and metrics routes while draining", func() {
+		gw := &testGateway{
+			name:    "a",
+			drainer: gateway.NewDrainer(),
+		}
+		app := newTestGatewayApp(gw, nil)
+		Expect(gw.drainer.Drain(context.Background())).To(Succeed())
+		get := func(path string) *http.Response {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+			Expect(err).NotTo(HaveOccurred())
+			return resp
+		}
+		Expect(get("/healthz").StatusCode).To(Equal(http.StatusOK))
+		Expect(get("/metrics").StatusCode).To(Equal(http.StatusOK))
+		Expect(get("/readyz").StatusCode).To(Equal(http.StatusServiceUnavailable))
+
+		resp := get("/push")
+		Expect(resp.StatusCode).To(Equal(http.StatusServiceUnavailable))
+		Expect(resp.Header.Get(fiber.HeaderRetryAfter)).NotTo(BeEmpty())
+		Expect(resp.Close).To(BeTrue())
+	})
+
+	It("should move agents to another gateway when draining", func() {
+		release := make(chan struct{})
+		a := startTestGateway("a", release)
+		b := startTestGateway("b", release)
+		lb := startTestLoadBalancer(a, b)
+		agent := newTestAgentClient(lb.addr)
+		send := func(path string) (int, string) {
+			code, body, err := agent.Get(context.Background(), path).Do()
+			Expect(err).NotTo(HaveOccurred())
+			return code, string(body)
+		}
+
+		code, body := send("/push")
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(Equal("a"))
+
+		slowDone := make(chan string)
+		go func() {
+			defer GinkgoRecover()
+			_, body := send("/slow")
+			slowDone <- body
+		}()
+		Eventually(a.drainer.InFlightRequests).Should(Equal(1))
+
+		drained := make(chan error)
+		go func() {
+			drained <- a.drainer.Drain(context.Background())
+		}()
+		Eventually(a.drainer.Draining).Should(BeTrue())
+
+		By("retrying requests rejected by the draining gateway")
+		type result struct {
+			code int
+			body string
+		}
+		pushDone := make(chan result)
+		go func() {
+			defer GinkgoRecover()
+			code, body := send("/push")
+			pushDone <- result{code, body}
+		}()
+		// the load balancer has not noticed yet, so the request is rejected
+		Eventually(func() int32 {
+			return atomic.LoadInt32(&a.rejected)
+		}).Should(BeEquivalentTo(1))
+		lb.Refresh()
+		Eventually(pushDone, 5*time.Second).Should(Receive(Equal(result{http.StatusOK, "b"})))
+
+		By("sending new requests to the other gateway")
+		for i := 0; i < 5; i++ {
+			_, body := send("/push")
+			Expect(body).To(Equal("b"))
+		}
+
+		By("waiting for in-flight requests to complete")
+		Consistently(drained, 100*time.Millisecond).ShouldNot(Receive())
+		close(release)
+		Eventually(slowDone).Should(Receive(Equal("a")))
+		Eventually(drained).Should(Receive(BeNil()))
+		Expect(a.drainer.InFlightRequests()).To(Equal(0))
+		Expect(b.drainer.Draining()).To(BeFalse())
+	})
+
+	It("should stop waiting when the context is done", func() {
+		release := make(chan struct{})
+		defer close(release)
+		gw := startTestGateway("a", release)
+		client := &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: true,
+				},
+			},
+		}
+		go client.Get("https://" + gw.addr + "/slow")
+		Eventually(gw.drainer.InFlightRequests).Should(Equal(1))
+
+		ctx, ca := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer ca()
+		Expect(gw.drainer.Drain(ctx)).To(MatchError(context.DeadlineExceeded))
+		Expect(gw.drainer.InFlightRequests()).To(Equal(1))
+	})
+})
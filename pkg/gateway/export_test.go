@@ -0,0 +1,27 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rancher/opni-monitoring/pkg/logger"
+	"github.com/rancher/opni-monitoring/pkg/plugins/apis/capability"
+	"github.com/rancher/opni-monitoring/pkg/storage"
+)
+
+func LoadCapabilityBackends(
+	ctx context.Context,
+	plugins []CapabilityBackendPlugin,
+	previous map[string]string,
+) (map[string]capability.Backend, map[string]string, error) {
+	return loadCapabilityBackends(ctx, plugins, previous, logger.New().Named("test"))
+}
+
+func NewDiagnosticsHandlerWithIntervals(
+	store *storage.DiagnosticsStore,
+	minInterval time.Duration,
+	refreshInterval time.Duration,
+) fiber.Handler {
+	return newDiagnosticsHandler(store, logger.New().Named("test"), minInterval, refreshInterval)
+}
@@ -3,8 +3,11 @@ package gateway
 import (
 	"context"
 	"crypto/tls"
+	"fmt"
 	"net"
+	"sync"
 
+	"emperror.dev/errors"
 	"github.com/gofiber/fiber/v2"
 	"github.com/hashicorp/go-plugin"
 	"github.com/prometheus/client_golang/prometheus"
@@ -39,6 +42,12 @@ type Gateway struct {
 
 	storageBackend  storage.Backend
 	capBackendStore capabilities.BackendStore
+	// the capability provided by each capability backend plugin, by module,
+	// as of the last time the backends were loaded
+	capBackendNames map[string]string
+	capBackendMu    sync.Mutex
+	auditLog        *storage.AuditLog
+	diagnostics     *storage.DiagnosticsStore
 }
 
 type GatewayOptions struct {
@@ -46,6 +55,7 @@ type GatewayOptions struct {
 	lifecycler        config.Lifecycler
 	systemPlugins     []plugins.ActivePlugin
 	capBackendPlugins []CapabilityBackendPlugin
+	storageBackend    storage.Backend
 }
 
 type GatewayOption func(*GatewayOptions)
@@ -82,6 +92,14 @@ func WithAPIServerOptions(opts ...APIServerOption) GatewayOption {
 	}
 }
 
+// WithStorageBackend configures the gateway to use the given storage
+// backend instead of the one configured in the gateway config.
+func WithStorageBackend(backend storage.Backend) GatewayOption {
+	return func(o *GatewayOptions) {
+		o.storageBackend = backend
+	}
+}
+
 func NewGateway(ctx context.Context, conf *config.GatewayConfig, opts ...GatewayOption) *Gateway {
 	options := GatewayOptions{
 		lifecycler: config.NewUnavailableLifecycler(meta.ObjectList{conf}),
@@ -92,11 +110,44 @@ func NewGateway(ctx context.Context, conf *config.GatewayConfig, opts ...Gateway
 
 	conf.Spec.SetDefaults()
 
-	storageBackend, err := machinery.ConfigureStorageBackend(ctx, &conf.Spec.Storage)
-	if err != nil {
+	storageMetrics := storage.NewMetrics()
+	storageBackend := options.storageBackend
+	var err error
+	if storageBackend == nil {
+		storageBackend, err = machinery.ConfigureStorageBackend(ctx, &conf.Spec.Storage)
+	}
+	if errors.Is(err, storage.ErrStorageUnavailable) {
+		lg.With(
+			zap.Error(err),
+		).Fatal("storage backend is unavailable; check that it is running and reachable from the gateway at the configured endpoints")
+	} else if err != nil {
 		lg.With(
 			zap.Error(err),
 		).Error("failed to configure storage backend")
+	} else {
+		storageBackend = storage.NewIndexedBackend(ctx, storage.NewMetricsBackend(storageBackend,
+			string(conf.Spec.Storage.Type), storageMetrics))
+	}
+
+	var auditLog *storage.AuditLog
+	if storageBackend != nil {
+		if store, err := storageBackend.KeyValueStore(auditLogNamespace); err != nil {
+			lg.With(
+				zap.Error(err),
+			).Error("failed to open audit log store")
+		} else {
+			auditLog = storage.NewAuditLog(store)
+		}
+	}
+	var diagnostics *storage.DiagnosticsStore
+	if storageBackend != nil {
+		if store, err := storageBackend.KeyValueStore(diagnosticsNamespace); err != nil {
+			lg.With(
+				zap.Error(err),
+			).Error("failed to open agent diagnostics store")
+		} else {
+			diagnostics = storage.NewDiagnosticsStore(store)
+		}
 	}
 
 	_, port, err := net.SplitHostPort(conf.Spec.ListenAddress)
@@ -110,25 +161,19 @@ func NewGateway(ctx context.Context, conf *config.GatewayConfig, opts ...Gateway
 		Address: "https://" + conf.Spec.Hostname + ":" + port,
 	}, lg)
 
-	for _, p := range options.capBackendPlugins {
-		info, err := p.Typed.Info(ctx, &emptypb.Empty{})
-		if err != nil {
-			lg.With(
-				zap.String("plugin", p.Metadata.Module),
-			).Error("failed to get capability info")
-			continue
-		}
-		backend := capabilities.NewBackend(p.Typed)
-		if err := capBackendStore.Add(info.CapabilityName, backend); err != nil {
-			lg.With(
-				zap.String("plugin", p.Metadata.Module),
-				zap.Error(err),
-			).Error("failed to add capability backend")
-		}
-	}
+	capBackends, capBackendNames, _ := loadCapabilityBackends(ctx, options.capBackendPlugins, nil, lg)
+	capBackendStore.Replace(capBackends)
 
-	apiServer := NewAPIServer(ctx, &conf.Spec, lg, options.apiServerOptions...)
+	apiServerOptions := append([]APIServerOption{
+		WithKeyringStoreBroker(storageBackend),
+	}, options.apiServerOptions...)
+	apiServer := NewAPIServer(ctx, &conf.Spec, lg, apiServerOptions...)
+	apiServer.metricsHandler.MustRegister(storageMetrics)
 	apiServer.ConfigureBootstrapRoutes(storageBackend, capBackendStore)
+	apiServer.ConfigureTargetRoutes(storageBackend)
+	if diagnostics != nil {
+		apiServer.ConfigureDiagnosticsRoutes(storageBackend, diagnostics)
+	}
 
 	g := &Gateway{
 		GatewayOptions:  options,
@@ -137,6 +182,9 @@ func NewGateway(ctx context.Context, conf *config.GatewayConfig, opts ...Gateway
 		logger:          lg,
 		storageBackend:  storageBackend,
 		capBackendStore: capBackendStore,
+		capBackendNames: capBackendNames,
+		auditLog:        auditLog,
+		diagnostics:     diagnostics,
 		apiServer:       apiServer,
 	}
 
@@ -149,6 +197,10 @@ func NewGateway(ctx context.Context, conf *config.GatewayConfig, opts ...Gateway
 	return g
 }
 
+// Key-value store namespace containing the audit log. System plugins use
+// their module paths as namespaces, so this cannot conflict with them.
+const auditLogNamespace = "audit"
+
 type keyValueStoreServer interface {
 	ServeKeyValueStore(store storage.KeyValueStore)
 }
@@ -216,7 +268,26 @@ func (g *Gateway) StorageBackend() storage.Backend {
 
 // Implements management.CoreDataSource
 func (g *Gateway) TLSConfig() *tls.Config {
-	return g.apiServer.tlsConfig
+	return g.apiServer.TLSConfig()
+}
+
+// RotateServingCertificate replaces the gateway's serving certificate
+// without restarting the gateway. See
+// GatewayAPIServer.RotateServingCertificate.
+func (g *Gateway) RotateServingCertificate(cert tls.Certificate) error {
+	return g.apiServer.RotateServingCertificate(cert)
+}
+
+// AuditLog returns the audit log kept in the gateway's storage backend, or
+// nil if the storage backend is unavailable.
+func (g *Gateway) AuditLog() *storage.AuditLog {
+	return g.auditLog
+}
+
+// DiagnosticsStore returns the store containing diagnostics bundles uploaded
+// by agents, or nil if the storage backend is unavailable.
+func (g *Gateway) DiagnosticsStore() *storage.DiagnosticsStore {
+	return g.diagnostics
 }
 
 // Implements management.CapabilitiesDataSource
@@ -224,6 +295,75 @@ func (g *Gateway) CapabilitiesStore() capabilities.BackendStore {
 	return g.capBackendStore
 }
 
+// Implements management.CapabilitiesDataSource
+func (g *Gateway) RefreshCapabilities(ctx context.Context) error {
+	g.capBackendMu.Lock()
+	defer g.capBackendMu.Unlock()
+	backends, names, err := loadCapabilityBackends(ctx, g.capBackendPlugins, g.capBackendNames, g.logger)
+	g.capBackendStore.Replace(backends)
+	g.capBackendNames = names
+	return err
+}
+
+// Implements management.DrainDataSource
+func (g *Gateway) Drain(ctx context.Context) error {
+	return g.apiServer.drainer.Drain(ctx)
+}
+
+// Implements management.DrainDataSource
+func (g *Gateway) InFlightRequests() int {
+	return g.apiServer.drainer.InFlightRequests()
+}
+
+// loadCapabilityBackends queries each capability backend plugin for the name
+// of the capability it provides, and returns the backends along with the name
+// of the capability provided by each plugin, keyed by module. If a plugin fails
+// to respond, the capability it provided according to previous is kept, so
+// that a transient error does not unregister a working backend. Plugins which
+// provide a capability already provided by an earlier plugin are skipped. The
+// errors for all plugins which failed to respond or were skipped are combined
+// and returned.
+func loadCapabilityBackends(
+	ctx context.Context,
+	plugins []CapabilityBackendPlugin,
+	previous map[string]string,
+	lg *zap.SugaredLogger,
+) (map[string]capability.Backend, map[string]string, error) {
+	backends := map[string]capability.Backend{}
+	names := map[string]string{}
+	var errs []error
+	for _, p := range plugins {
+		var name string
+		info, err := p.Typed.Info(ctx, &emptypb.Empty{})
+		if err != nil {
+			lg.With(
+				zap.String("plugin", p.Metadata.Module),
+				zap.Error(err),
+			).Error("failed to get capability info")
+			errs = append(errs, fmt.Errorf("plugin %s: %w", p.Metadata.Module, err))
+			prev, ok := previous[p.Metadata.Module]
+			if !ok {
+				continue
+			}
+			name = prev
+		} else {
+			name = info.CapabilityName
+		}
+		if _, ok := backends[name]; ok {
+			err := fmt.Errorf("%w: %s", capabilities.ErrBackendAlreadyExists, name)
+			lg.With(
+				zap.String("plugin", p.Metadata.Module),
+				zap.Error(err),
+			).Error("failed to add capability backend")
+			errs = append(errs, fmt.Errorf("plugin %s: %w", p.Metadata.Module, err))
+			continue
+		}
+		backends[name] = capabilities.NewBackend(p.Typed)
+		names[p.Metadata.Module] = name
+	}
+	return backends, names, errors.Combine(errs...)
+}
+
 func (g *Gateway) MustRegisterCollector(collector prometheus.Collector) {
 	g.apiServer.metricsHandler.MustRegister(collector)
 }
@@ -0,0 +1,115 @@
+package gateway_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/phayes/freeport"
+
+	"github.com/rancher/opni-monitoring/pkg/auth"
+	authtest "github.com/rancher/opni-monitoring/pkg/auth/test"
+	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
+	"github.com/rancher/opni-monitoring/pkg/gateway"
+	"github.com/rancher/opni-monitoring/pkg/logger"
+	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/pkg/util/waitctx"
+)
+
+var _ = Describe("Idle Timeout", Ordered, Label(test.Unit, test.Slow), func() {
+	var addr string
+	BeforeAll(func() {
+		Expect(auth.RegisterMiddleware("idle-test", &authtest.TestAuthMiddleware{
+			Strategy: authtest.AuthStrategyDenyAll,
+		})).To(Succeed())
+		ports, err := freeport.GetFreePorts(2)
+		Expect(err).NotTo(HaveOccurred())
+		addr = fmt.Sprintf("127.0.0.1:%d", ports[0])
+
+		caCertData := string(test.TestData("root_ca.crt"))
+		servingCertData := string(test.TestData("localhost.crt"))
+		servingKeyData := string(test.TestData("localhost.key"))
+		cfg := &v1beta1.GatewayConfigSpec{
+			ListenAddress: addr,
+			MetricsPort:   ports[1],
+			Certs: v1beta1.CertsSpec{
+				CACertData:      &caCertData,
+				ServingCertData: &servingCertData,
+				ServingKeyData:  &servingKeyData,
+			},
+			IdleTimeout: "500ms",
+		}
+		cfg.SetDefaults()
+		ctx, ca := context.WithCancel(waitctx.Background())
+		DeferCleanup(ca)
+		srv := gateway.NewAPIServer(ctx, cfg, logger.New().Named("gateway"),
+			gateway.WithAuthMiddleware("idle-test"),
+		)
+		go srv.ListenAndServe()
+		DeferCleanup(srv.Shutdown)
+		Eventually(func() error {
+			conn, err := tls.Dial("tcp4", addr, &tls.Config{InsecureSkipVerify: true})
+			if err == nil {
+				conn.Close()
+			}
+			return err
+		}).Should(Succeed())
+	})
+
+	It("should close connections which have been idle for too long", func() {
+		conn, err := tls.Dial("tcp4", addr, &tls.Config{InsecureSkipVerify: true})
+		Expect(err).NotTo(HaveOccurred())
+		defer conn.Close()
+		br := bufio.NewReader(conn)
+		for i := 0; i < 2; i++ {
+			req, _ := http.NewRequest(http.MethodGet, "https://"+addr+"/healthz", nil)
+			Expect(req.Write(conn)).To(Succeed())
+			resp, err := http.ReadResponse(br, req)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		}
+
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		start := time.Now()
+		_, err = br.ReadByte()
+		Expect(err).To(MatchError(io.EOF))
+		Expect(time.Since(start)).To(BeNumerically("~", 500*time.Millisecond, 400*time.Millisecond))
+	})
+
+	It("should allow idle clients to reconnect on their next request", func() {
+		client := &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		}
+		defer client.CloseIdleConnections()
+		// reports whether the request was sent on a reused connection
+		get := func() bool {
+			var reused bool
+			ctx := httptrace.WithClientTrace(context.Background(), &httptrace.ClientTrace{
+				GotConn: func(info httptrace.GotConnInfo) {
+					reused = info.Reused
+				},
+			})
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+addr+"/healthz", nil)
+			resp, err := client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			return reused
+		}
+		Expect(get()).To(BeFalse())
+		Expect(get()).To(BeTrue())
+		time.Sleep(1 * time.Second)
+		Expect(get()).To(BeFalse())
+		Expect(get()).To(BeTrue())
+	})
+})
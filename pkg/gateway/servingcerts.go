@@ -0,0 +1,64 @@
+package gateway
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+)
+
+// The number of previous serving certificates which can still be used to
+// verify bootstrap auth requests after the serving certificate is rotated.
+const retainedServingCerts = 1
+
+// TLSConfig returns a copy of the server's TLS config which uses the current
+// serving certificate.
+func (s *GatewayAPIServer) TLSConfig() *tls.Config {
+	s.servingCertsMu.RLock()
+	defer s.servingCertsMu.RUnlock()
+	tlsConfig := s.tlsConfig.Clone()
+	tlsConfig.Certificates = []tls.Certificate{*s.servingCerts[0]}
+	tlsConfig.GetConfigForClient = nil
+	return tlsConfig
+}
+
+// RotateServingCertificate replaces the server's serving certificate. New
+// connections are served using the new certificate, while existing
+// connections are not affected. Bootstrap auth requests signed using the
+// previous certificate are still accepted, so agents which fetched a join
+// response before the rotation can finish bootstrapping.
+func (s *GatewayAPIServer) RotateServingCertificate(cert tls.Certificate) error {
+	if len(cert.Certificate) == 0 {
+		return errors.New("certificate chain is empty")
+	}
+	if cert.Leaf == nil {
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			return fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		cert.Leaf = leaf
+	}
+	s.servingCertsMu.Lock()
+	defer s.servingCertsMu.Unlock()
+	certs := append([]*tls.Certificate{&cert}, s.servingCerts...)
+	if len(certs) > retainedServingCerts+1 {
+		certs = certs[:retainedServingCerts+1]
+	}
+	s.servingCerts = certs
+	s.logger.With(
+		"subject", cert.Leaf.Subject.String(),
+	).Info("serving certificate rotated")
+	return nil
+}
+
+// signingCertificates returns the current serving certificate, followed by
+// the retained previous certificates.
+func (s *GatewayAPIServer) signingCertificates() []*tls.Certificate {
+	s.servingCertsMu.RLock()
+	defer s.servingCertsMu.RUnlock()
+	return append([]*tls.Certificate{}, s.servingCerts...)
+}
+
+func (s *GatewayAPIServer) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	return s.TLSConfig(), nil
+}
@@ -0,0 +1,100 @@
+package gateway_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/phayes/freeport"
+
+	"github.com/rancher/opni-monitoring/pkg/auth"
+	authtest "github.com/rancher/opni-monitoring/pkg/auth/test"
+	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
+	"github.com/rancher/opni-monitoring/pkg/gateway"
+	"github.com/rancher/opni-monitoring/pkg/logger"
+	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/pkg/util/waitctx"
+)
+
+var _ = Describe("Serving Certificate Rotation", Ordered, Label(test.Unit, test.Slow), func() {
+	var addr string
+	var srv *gateway.GatewayAPIServer
+	var localhostLeaf, selfSignedLeaf *x509.Certificate
+	var selfSignedCert tls.Certificate
+
+	// returns the leaf certificate served by the gateway
+	servedLeaf := func(serverName string) *x509.Certificate {
+		conn, err := tls.Dial("tcp4", addr, &tls.Config{
+			InsecureSkipVerify: true,
+			ServerName:         serverName,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		defer conn.Close()
+		return conn.ConnectionState().PeerCertificates[0]
+	}
+
+	BeforeAll(func() {
+		Expect(auth.RegisterMiddleware("rotation-test", &authtest.TestAuthMiddleware{
+			Strategy: authtest.AuthStrategyDenyAll,
+		})).To(Succeed())
+		ports, err := freeport.GetFreePorts(2)
+		Expect(err).NotTo(HaveOccurred())
+		addr = fmt.Sprintf("127.0.0.1:%d", ports[0])
+
+		localhostCert, err := tls.X509KeyPair(test.TestData("localhost.crt"), test.TestData("localhost.key"))
+		Expect(err).NotTo(HaveOccurred())
+		localhostLeaf, err = x509.ParseCertificate(localhostCert.Certificate[0])
+		Expect(err).NotTo(HaveOccurred())
+		selfSignedCert, err = tls.X509KeyPair(test.TestData("self_signed_leaf.crt"), test.TestData("self_signed_leaf.key"))
+		Expect(err).NotTo(HaveOccurred())
+		selfSignedLeaf, err = x509.ParseCertificate(selfSignedCert.Certificate[0])
+		Expect(err).NotTo(HaveOccurred())
+
+		caCertData := string(test.TestData("root_ca.crt"))
+		servingCertData := string(test.TestData("localhost.crt"))
+		servingKeyData := string(test.TestData("localhost.key"))
+		cfg := &v1beta1.GatewayConfigSpec{
+			ListenAddress: addr,
+			MetricsPort:   ports[1],
+			Certs: v1beta1.CertsSpec{
+				CACertData:      &caCertData,
+				ServingCertData: &servingCertData,
+				ServingKeyData:  &servingKeyData,
+			},
+		}
+		cfg.SetDefaults()
+		ctx, ca := context.WithCancel(waitctx.Background())
+		DeferCleanup(ca)
+		srv = gateway.NewAPIServer(ctx, cfg, logger.New().Named("gateway"),
+			gateway.WithAuthMiddleware("rotation-test"),
+		)
+		go srv.ListenAndServe()
+		DeferCleanup(srv.Shutdown)
+		Eventually(func() error {
+			conn, err := tls.Dial("tcp4", addr, &tls.Config{InsecureSkipVerify: true})
+			if err == nil {
+				conn.Close()
+			}
+			return err
+		}).Should(Succeed())
+	})
+
+	It("should serve the configured certificate", func() {
+		Expect(servedLeaf("").Equal(localhostLeaf)).To(BeTrue())
+		Expect(servedLeaf("localhost").Equal(localhostLeaf)).To(BeTrue())
+		Expect(srv.TLSConfig().Certificates[0].Certificate[0]).To(Equal(localhostLeaf.Raw))
+	})
+	It("should reject an empty certificate", func() {
+		Expect(srv.RotateServingCertificate(tls.Certificate{})).NotTo(Succeed())
+		Expect(servedLeaf("").Equal(localhostLeaf)).To(BeTrue())
+	})
+	It("should serve the new certificate after a rotation", func() {
+		Expect(srv.RotateServingCertificate(selfSignedCert)).To(Succeed())
+		Expect(servedLeaf("").Equal(selfSignedLeaf)).To(BeTrue())
+		Expect(servedLeaf("localhost").Equal(selfSignedLeaf)).To(BeTrue())
+		Expect(srv.TLSConfig().Certificates[0].Certificate[0]).To(Equal(selfSignedLeaf.Raw))
+	})
+})
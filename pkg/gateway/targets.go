@@ -0,0 +1,98 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/storage"
+	"github.com/rancher/opni-monitoring/pkg/validation"
+)
+
+// TargetsPath is the path at which the cluster inventory is served in the
+// Prometheus http_sd format.
+const TargetsPath = "/targets"
+
+// Meta labels attached to each target. Cluster labels are added as
+// TargetLabelPrefix followed by the sanitized label name.
+const (
+	TargetClusterIDLabel    = "__meta_opni_cluster_id"
+	TargetCapabilitiesLabel = "__meta_opni_cluster_capabilities"
+	TargetLabelPrefix       = "__meta_opni_cluster_label_"
+)
+
+// TargetGroup is a single entry in a Prometheus http_sd response.
+// See https://prometheus.io/docs/prometheus/latest/http_sd/
+type TargetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// NewTargetsHandler returns a handler which serves the cluster inventory as a
+// list of http_sd target groups, one per cluster. The cluster ID is used as
+// the target, and the cluster's labels and capabilities are added as meta
+// labels, which can be used in relabeling rules. Clusters can be filtered by
+// passing a label selector (see core.ParseLabelSelector) in the "selector"
+// query parameter.
+func NewTargetsHandler(clusterStore storage.ClusterStore) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		selector, err := core.ParseLabelSelector(c.Query("selector"))
+		if err == nil {
+			err = validation.Validate(selector)
+		}
+		if err != nil {
+			return c.Status(http.StatusBadRequest).SendString(err.Error())
+		}
+		clusters, err := clusterStore.ListClusters(context.Background(), selector, core.MatchOptions_Default)
+		if err != nil {
+			return c.Status(http.StatusInternalServerError).SendString(err.Error())
+		}
+		groups := make([]TargetGroup, 0, len(clusters.GetItems()))
+		for _, cluster := range clusters.GetItems() {
+			groups = append(groups, NewTargetGroup(cluster))
+		}
+		sort.Slice(groups, func(i, j int) bool {
+			return groups[i].Targets[0] < groups[j].Targets[0]
+		})
+		return c.JSON(groups)
+	}
+}
+
+// NewTargetGroup builds the http_sd target group for a cluster.
+func NewTargetGroup(cluster *core.Cluster) TargetGroup {
+	labels := map[string]string{
+		TargetClusterIDLabel: cluster.GetId(),
+	}
+	for k, v := range cluster.GetLabels() {
+		labels[TargetLabelPrefix+sanitizeLabelName(k)] = v
+	}
+	if caps := cluster.GetCapabilities(); len(caps) > 0 {
+		names := make([]string, 0, len(caps))
+		for _, capability := range caps {
+			names = append(names, capability.GetName())
+		}
+		sort.Strings(names)
+		// Following the convention used by Prometheus service discovery,
+		// list values are surrounded by separators so that they can be
+		// matched with a regex like ".*,name,.*".
+		labels[TargetCapabilitiesLabel] = "," + strings.Join(names, ",") + ","
+	}
+	return TargetGroup{
+		Targets: []string{cluster.GetId()},
+		Labels:  labels,
+	}
+}
+
+// sanitizeLabelName replaces characters which are not allowed in Prometheus
+// label names with underscores.
+func sanitizeLabelName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,179 @@
+package gateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	authtest "github.com/rancher/opni-monitoring/pkg/auth/test"
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/gateway"
+	"github.com/rancher/opni-monitoring/pkg/storage"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Targets", Label(test.Unit), func() {
+	var app *fiber.App
+	var clusterStore storage.ClusterStore
+
+	BeforeEach(func() {
+		clusterStore = test.NewTestClusterStore(gomock.NewController(GinkgoT()))
+		for _, cluster := range []*core.Cluster{
+			{
+				Id: "cluster-1",
+				Metadata: &core.ClusterMetadata{
+					Labels: map[string]string{
+						"env":                 "prod",
+						"example.com/team-id": "a",
+					},
+					Capabilities: []*core.ClusterCapability{
+						{Name: "metrics"},
+						{Name: "logs"},
+					},
+				},
+			},
+			{
+				Id: "cluster-2",
+				Metadata: &core.ClusterMetadata{
+					Labels: map[string]string{
+						"env": "dev",
+					},
+				},
+			},
+			{
+				Id:       "cluster-3",
+				Metadata: &core.ClusterMetadata{},
+			},
+		} {
+			Expect(clusterStore.CreateCluster(context.Background(), cluster)).To(Succeed())
+		}
+		mw := &authtest.TestAuthMiddleware{
+			Strategy: authtest.AuthStrategyUserIDInAuthHeader,
+		}
+		app = fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		app.Get(gateway.TargetsPath, mw.Handle, gateway.NewTargetsHandler(clusterStore))
+	})
+
+	get := func(selector string) *http.Response {
+		path := gateway.TargetsPath
+		if selector != "" {
+			path += "?selector=" + url.QueryEscape(selector)
+		}
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "user")
+		resp, err := app.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		return resp
+	}
+	getTargets := func(selector string) []gateway.TargetGroup {
+		resp := get(selector)
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		var groups []gateway.TargetGroup
+		Expect(json.NewDecoder(resp.Body).Decode(&groups)).To(Succeed())
+		return groups
+	}
+	targetsOf := func(groups []gateway.TargetGroup) []string {
+		targets := []string{}
+		for _, g := range groups {
+			targets = append(targets, g.Targets...)
+		}
+		return targets
+	}
+
+	It("should serve the cluster inventory in the http_sd format", func() {
+		resp := get("")
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Header.Get("Content-Type")).To(HavePrefix("application/json"))
+
+		// The http_sd format is a list of objects, each containing a list of
+		// target strings and a map of label names to string values.
+		var groups []map[string]json.RawMessage
+		Expect(json.NewDecoder(resp.Body).Decode(&groups)).To(Succeed())
+		Expect(groups).To(HaveLen(3))
+		labelName := regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+		for _, group := range groups {
+			Expect(group).To(HaveLen(2))
+			var targets []string
+			Expect(json.Unmarshal(group["targets"], &targets)).To(Succeed())
+			Expect(targets).To(HaveLen(1))
+			var labels map[string]string
+			Expect(json.Unmarshal(group["labels"], &labels)).To(Succeed())
+			for name := range labels {
+				Expect(name).To(MatchRegexp(labelName.String()))
+			}
+		}
+	})
+
+	It("should include cluster labels and capabilities", func() {
+		groups := getTargets("")
+		Expect(groups).To(Equal([]gateway.TargetGroup{
+			{
+				Targets: []string{"cluster-1"},
+				Labels: map[string]string{
+					gateway.TargetClusterIDLabel:                      "cluster-1",
+					gateway.TargetCapabilitiesLabel:                   ",logs,metrics,",
+					gateway.TargetLabelPrefix + "env":                 "prod",
+					gateway.TargetLabelPrefix + "example_com_team_id": "a",
+				},
+			},
+			{
+				Targets: []string{"cluster-2"},
+				Labels: map[string]string{
+					gateway.TargetClusterIDLabel:      "cluster-2",
+					gateway.TargetLabelPrefix + "env": "dev",
+				},
+			},
+			{
+				Targets: []string{"cluster-3"},
+				Labels: map[string]string{
+					gateway.TargetClusterIDLabel: "cluster-3",
+				},
+			},
+		}))
+	})
+
+	It("should reflect changes to cluster labels", func() {
+		_, err := clusterStore.UpdateCluster(context.Background(), &core.Reference{Id: "cluster-3"},
+			func(c *core.Cluster) {
+				c.Metadata.Labels = map[string]string{"env": "prod"}
+			})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(targetsOf(getTargets("env=prod"))).To(ConsistOf("cluster-1", "cluster-3"))
+	})
+
+	DescribeTable("should filter clusters using the selector",
+		func(selector string, expected []string) {
+			Expect(targetsOf(getTargets(selector))).To(ConsistOf(expected))
+		},
+		Entry("match labels", "env=prod", []string{"cluster-1"}),
+		Entry("match expressions", "env In dev,prod", []string{"cluster-1", "cluster-2"}),
+		Entry("negated expressions", "!env Exists", []string{"cluster-3"}),
+		Entry("multiple requirements", "env Exists && example.com/team-id=a", []string{"cluster-1"}),
+		Entry("no matches", "env=staging", []string{}),
+	)
+
+	It("should reject invalid selectors", func() {
+		resp := get("env In")
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should require authentication", func() {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, gateway.TargetsPath, nil))
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+	})
+})
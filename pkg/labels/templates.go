@@ -0,0 +1,119 @@
+// Package labels implements computed cluster labels, which are derived from a
+// cluster's other labels using Go templates.
+package labels
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/validation"
+)
+
+// TemplateData is the data passed to each label template.
+type TemplateData struct {
+	// The cluster's ID.
+	ID string
+	// The cluster's labels, excluding computed labels.
+	Labels map[string]string
+}
+
+// Templates computes labels for clusters. Each template is keyed by the name
+// of the label it computes. Computed labels are read-only: any existing value
+// is replaced when the templates are applied.
+type Templates struct {
+	keys      []string
+	templates map[string]*template.Template
+}
+
+// ParseTemplates parses a set of label templates, keyed by label name.
+// Templates are executed with TemplateData, so for example a template can
+// reference another label's value using (index .Labels "env"). Templates
+// which fail to execute for a cluster with no labels are rejected.
+func ParseTemplates(specs map[string]string) (*Templates, error) {
+	t := &Templates{
+		templates: make(map[string]*template.Template, len(specs)),
+	}
+	for key, text := range specs {
+		if err := validation.ValidateLabelName(key); err != nil {
+			return nil, fmt.Errorf("%w: %q", err, key)
+		}
+		tmpl, err := template.New(key).Option("missingkey=zero").Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		// catch templates which can never execute, such as those referencing
+		// fields which TemplateData does not have
+		if err := tmpl.Execute(io.Discard, TemplateData{Labels: map[string]string{}}); err != nil {
+			return nil, err
+		}
+		t.keys = append(t.keys, key)
+		t.templates[key] = tmpl
+	}
+	sort.Strings(t.keys)
+	return t, nil
+}
+
+// Keys returns the names of the computed labels, in sorted order.
+func (t *Templates) Keys() []string {
+	if t == nil {
+		return nil
+	}
+	return t.keys
+}
+
+// IsComputed returns true if the named label is computed by a template.
+func (t *Templates) IsComputed(key string) bool {
+	if t == nil {
+		return false
+	}
+	_, ok := t.templates[key]
+	return ok
+}
+
+// Apply computes labels for the cluster and updates its metadata. Templates
+// which produce an empty string remove the label. If any template fails to
+// execute or produces an invalid label value, the cluster is not modified.
+// Applying a nil Templates is a no-op.
+func (t *Templates) Apply(cluster *core.Cluster) error {
+	if t == nil || len(t.keys) == 0 {
+		return nil
+	}
+	data := TemplateData{
+		ID:     cluster.GetId(),
+		Labels: map[string]string{},
+	}
+	for k, v := range cluster.GetLabels() {
+		if !t.IsComputed(k) {
+			data.Labels[k] = v
+		}
+	}
+	computed := make(map[string]string, len(t.keys))
+	for _, key := range t.keys {
+		var buf bytes.Buffer
+		if err := t.templates[key].Execute(&buf, data); err != nil {
+			return err
+		}
+		value := strings.TrimSpace(buf.String())
+		if value == "" {
+			continue
+		}
+		if err := validation.ValidateLabelValue(value); err != nil {
+			return fmt.Errorf("%w: %q (computed label %q)", err, value, key)
+		}
+		computed[key] = value
+	}
+
+	if cluster.Metadata == nil {
+		cluster.Metadata = &core.ClusterMetadata{}
+	}
+	for k, v := range computed {
+		data.Labels[k] = v
+	}
+	cluster.Metadata.Labels = data.Labels
+	return nil
+}
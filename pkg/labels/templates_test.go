@@ -0,0 +1,103 @@
+package labels_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/labels"
+	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/pkg/validation"
+)
+
+const tierTemplate = `{{ if eq (index .Labels "env") "prod" }}critical{{ else }}standard{{ end }}`
+
+var _ = Describe("Label Templates", Label(test.Unit), func() {
+	newCluster := func(labels map[string]string) *core.Cluster {
+		return &core.Cluster{
+			Id: "cluster-1",
+			Metadata: &core.ClusterMetadata{
+				Labels: labels,
+			},
+		}
+	}
+	var templates *labels.Templates
+	BeforeEach(func() {
+		var err error
+		templates, err = labels.ParseTemplates(map[string]string{
+			"tier":    tierTemplate,
+			"cluster": "{{ .ID }}",
+			"region":  `{{ index .Labels "zone" }}`,
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should derive labels from other labels", func() {
+		cluster := newCluster(map[string]string{"env": "prod"})
+		Expect(templates.Apply(cluster)).To(Succeed())
+		Expect(cluster.GetLabels()).To(Equal(map[string]string{
+			"env":     "prod",
+			"tier":    "critical",
+			"cluster": "cluster-1",
+		}))
+	})
+	It("should update computed labels when their source labels change", func() {
+		cluster := newCluster(map[string]string{"env": "prod"})
+		Expect(templates.Apply(cluster)).To(Succeed())
+		Expect(cluster.GetLabels()).To(HaveKeyWithValue("tier", "critical"))
+
+		cluster.Metadata.Labels["env"] = "dev"
+		Expect(templates.Apply(cluster)).To(Succeed())
+		Expect(cluster.GetLabels()).To(HaveKeyWithValue("tier", "standard"))
+	})
+	It("should replace existing values of computed labels", func() {
+		cluster := newCluster(map[string]string{"env": "dev", "tier": "critical"})
+		Expect(templates.Apply(cluster)).To(Succeed())
+		Expect(cluster.GetLabels()).To(HaveKeyWithValue("tier", "standard"))
+	})
+	It("should remove computed labels which evaluate to an empty string", func() {
+		cluster := newCluster(map[string]string{"zone": "us-east-1a"})
+		Expect(templates.Apply(cluster)).To(Succeed())
+		Expect(cluster.GetLabels()).To(HaveKeyWithValue("region", "us-east-1a"))
+
+		delete(cluster.Metadata.Labels, "zone")
+		Expect(templates.Apply(cluster)).To(Succeed())
+		Expect(cluster.GetLabels()).NotTo(HaveKey("region"))
+	})
+	It("should not modify the cluster if a computed label is invalid", func() {
+		cluster := newCluster(map[string]string{"env": "prod", "zone": "not valid!"})
+		err := templates.Apply(cluster)
+		Expect(err).To(MatchError(validation.ErrInvalidLabelValue))
+		Expect(cluster.GetLabels()).To(Equal(map[string]string{"env": "prod", "zone": "not valid!"}))
+	})
+	It("should report which labels are computed", func() {
+		Expect(templates.Keys()).To(Equal([]string{"cluster", "region", "tier"}))
+		Expect(templates.IsComputed("tier")).To(BeTrue())
+		Expect(templates.IsComputed("env")).To(BeFalse())
+	})
+	It("should do nothing if there are no templates", func() {
+		var nilTemplates *labels.Templates
+		cluster := newCluster(map[string]string{"env": "prod"})
+		Expect(nilTemplates.Apply(cluster)).To(Succeed())
+		Expect(cluster.GetLabels()).To(Equal(map[string]string{"env": "prod"}))
+	})
+	When("parsing invalid templates", func() {
+		It("should error", func() {
+			_, err := labels.ParseTemplates(map[string]string{
+				"tier": "{{ .Labels",
+			})
+			Expect(err).To(HaveOccurred())
+
+			_, err = labels.ParseTemplates(map[string]string{
+				"not a label": tierTemplate,
+			})
+			Expect(err).To(MatchError(validation.ErrInvalidLabelName))
+		})
+		It("should error if a template cannot be executed", func() {
+			_, err := labels.ParseTemplates(map[string]string{
+				"tier": "{{ .Annotations.tier }}",
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
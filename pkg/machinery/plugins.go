@@ -1,6 +1,8 @@
 package machinery
 
 import (
+	"time"
+
 	"github.com/hashicorp/go-plugin"
 	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
 	"github.com/rancher/opni-monitoring/pkg/plugins"
@@ -10,6 +12,19 @@ import (
 
 func LoadPlugins(loader *plugins.PluginLoader, conf v1beta1.PluginsSpec, reattach ...*plugin.ReattachConfig) int {
 	numLoaded := 0
+	limits := plugins.CallLimits{
+		MaxConcurrentCalls: conf.MaxConcurrentCalls,
+	}
+	if conf.CallTimeout != "" {
+		timeout, err := time.ParseDuration(conf.CallTimeout)
+		if err != nil {
+			loader.Logger.With(
+				zap.Error(err),
+			).Error("invalid plugin call timeout, calls will not time out")
+		} else {
+			limits.CallTimeout = timeout
+		}
+	}
 	for _, dir := range conf.Dirs {
 		pluginPaths, err := plugin.Discover("plugin_*", dir)
 		if err != nil {
@@ -24,6 +39,7 @@ func LoadPlugins(loader *plugins.PluginLoader, conf v1beta1.PluginsSpec, reattac
 				continue
 			}
 			cc := plugins.ClientConfig(md, plugins.ClientScheme, reattach...)
+			plugins.ApplyCallLimits(cc, limits)
 			loader.Load(md, cc)
 			numLoaded++
 		}
@@ -21,6 +21,9 @@ func ConfigureStorageBackend(ctx context.Context, cfg *v1beta1.StorageSpec) (sto
 			store := etcd.NewEtcdStore(ctx, cfg.Etcd,
 				etcd.WithPrefix("gateway"),
 			)
+			if err := store.CheckConnectivity(ctx, etcd.DefaultStartupCheckWindow); err != nil {
+				return nil, err
+			}
 			storageBackend.Use(store)
 		}
 	case v1beta1.StorageTypeCRDs:
@@ -0,0 +1,108 @@
+package management
+
+import (
+	"context"
+	"time"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/storage"
+	"github.com/rancher/opni-monitoring/pkg/validation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// AuditActorMetadataKey is the gRPC metadata key clients can use to
+	// identify themselves in the audit log. HTTP clients can set it using
+	// the Grpc-Metadata-Audit-Actor header.
+	AuditActorMetadataKey = "audit-actor"
+
+	defaultAuditEventsLimit = 100
+	maxAuditEventsLimit     = 1000
+)
+
+// WithAuditLog configures the server to record changes made through the
+// management API in the given audit log, and to serve ListAuditEvents.
+func WithAuditLog(log *storage.AuditLog) ManagementServerOption {
+	return func(o *ManagementServerOptions) {
+		o.auditLog = log
+	}
+}
+
+// auditDataSource wraps the storage backend of a CoreDataSource so that
+// changes made through it are recorded in the audit log.
+type auditDataSource struct {
+	CoreDataSource
+	log *storage.AuditLog
+}
+
+func (d *auditDataSource) StorageBackend() storage.Backend {
+	return storage.NewAuditBackend(d.CoreDataSource.StorageBackend(), d.log)
+}
+
+// auditActorInterceptor attributes changes made by a request to the client
+// which made it. Clients are identified by the audit-actor metadata key if
+// set, otherwise by their address.
+func auditActorInterceptor(
+	ctx context.Context,
+	req interface{},
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	return handler(storage.WithAuditActor(ctx, auditActor(ctx)), req)
+}
+
+func auditActor(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		// requests from the HTTP gateway are attributed to the HTTP client
+		for _, key := range []string{AuditActorMetadataKey, "x-forwarded-for"} {
+			if values := md.Get(key); len(values) > 0 && values[0] != "" {
+				return values[0]
+			}
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// ListAuditEvents returns audit events matching the request's filters,
+// oldest first.
+func (m *Server) ListAuditEvents(
+	ctx context.Context,
+	in *ListAuditEventsRequest,
+) (*ListAuditEventsResponse, error) {
+	if m.auditLog == nil {
+		return nil, status.Error(codes.Unavailable, "audit log not configured")
+	}
+	if err := validation.Validate(in); err != nil {
+		return nil, err
+	}
+	var start, end time.Time
+	if in.StartTime > 0 {
+		start = time.Unix(in.StartTime, 0)
+	}
+	if in.EndTime > 0 {
+		end = time.Unix(in.EndTime, 0)
+	}
+	limit := int(in.Limit)
+	if limit == 0 {
+		limit = defaultAuditEventsLimit
+	}
+	events, next, err := m.auditLog.List(ctx, func(e *core.AuditEvent) bool {
+		return (in.Actor == "" || e.Actor == in.Actor) &&
+			(in.ResourceType == "" || e.ResourceType == in.ResourceType) &&
+			(in.Action == "" || e.Action == in.Action)
+	}, start, end, in.PageToken, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &ListAuditEventsResponse{
+		Items:         events,
+		NextPageToken: next,
+	}, nil
+}
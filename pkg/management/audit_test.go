@@ -0,0 +1,161 @@
+package management_test
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/management"
+	"github.com/rancher/opni-monitoring/pkg/storage"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Audit Log", Ordered, Label(test.Unit, test.Slow), func() {
+	var tv *testVars
+	var token *core.BootstrapToken
+	BeforeAll(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		tv = &testVars{ctrl: ctrl}
+		auditLog := storage.NewAuditLog(test.NewTestKeyValueStore(ctrl))
+		setupManagementServer(&tv, management.WithAuditLog(auditLog))()
+	})
+	as := func(actor string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), management.AuditActorMetadataKey, actor)
+	}
+
+	It("should record changes made through the management API", func() {
+		var err error
+		token, err = tv.client.CreateBootstrapToken(as("alice"), &management.CreateBootstrapTokenRequest{
+			Ttl: durationpb.New(time.Hour),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tv.storageBackend.CreateCluster(context.Background(), &core.Cluster{Id: "cluster-1"})).To(Succeed())
+		_, err = tv.client.EditCluster(as("bob"), &management.EditClusterRequest{
+			Cluster: &core.Reference{Id: "cluster-1"},
+			Labels:  map[string]string{"foo": "bar"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = tv.client.CreateRole(as("bob"), &core.Role{Id: "role-1"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = tv.client.CreateRoleBinding(as("alice"), &core.RoleBinding{Id: "rb-1", RoleId: "role-1"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = tv.client.DeleteCluster(as("alice"), &core.Reference{Id: "cluster-1"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = tv.client.RevokeBootstrapToken(as("bob"), &core.Reference{Id: token.TokenID})
+		Expect(err).NotTo(HaveOccurred())
+
+		resp, err := tv.client.ListAuditEvents(context.Background(), &management.ListAuditEventsRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.NextPageToken).To(BeEmpty())
+		// changes made directly to the storage backend are not recorded
+		Expect(resp.Items).To(HaveLen(6))
+	})
+	It("should filter events by actor", func() {
+		resp, err := tv.client.ListAuditEvents(context.Background(), &management.ListAuditEventsRequest{
+			Actor: "alice",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Items).To(HaveLen(3))
+		Expect(resp.Items[0].ResourceType).To(Equal(storage.AuditResourceToken))
+		Expect(resp.Items[0].Action).To(Equal(storage.AuditActionCreate))
+		Expect(resp.Items[1].ResourceType).To(Equal(storage.AuditResourceRoleBinding))
+		Expect(resp.Items[2].ResourceType).To(Equal(storage.AuditResourceCluster))
+		Expect(resp.Items[2].Action).To(Equal(storage.AuditActionDelete))
+		for _, e := range resp.Items {
+			Expect(e.Actor).To(Equal("alice"))
+		}
+	})
+	It("should filter events by resource type and action", func() {
+		resp, err := tv.client.ListAuditEvents(context.Background(), &management.ListAuditEventsRequest{
+			ResourceType: storage.AuditResourceCluster,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Items).To(HaveLen(2))
+		Expect(resp.Items[0].Actor).To(Equal("bob"))
+		Expect(resp.Items[0].Action).To(Equal(storage.AuditActionUpdate))
+		Expect(resp.Items[0].ResourceID).To(Equal("cluster-1"))
+		Expect(resp.Items[1].Actor).To(Equal("alice"))
+		Expect(resp.Items[1].Action).To(Equal(storage.AuditActionDelete))
+
+		resp, err = tv.client.ListAuditEvents(context.Background(), &management.ListAuditEventsRequest{
+			ResourceType: storage.AuditResourceToken,
+			Action:       storage.AuditActionDelete,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Items).To(HaveLen(1))
+		Expect(resp.Items[0].Actor).To(Equal("bob"))
+		Expect(resp.Items[0].ResourceID).To(Equal(token.TokenID))
+	})
+	It("should filter events by time range", func() {
+		now := time.Now().Unix()
+		resp, err := tv.client.ListAuditEvents(context.Background(), &management.ListAuditEventsRequest{
+			StartTime: now - 60,
+			EndTime:   now + 60,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Items).To(HaveLen(6))
+		resp, err = tv.client.ListAuditEvents(context.Background(), &management.ListAuditEventsRequest{
+			StartTime: now + 60,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Items).To(BeEmpty())
+	})
+	It("should paginate events", func() {
+		var ids []string
+		req := &management.ListAuditEventsRequest{
+			Limit: 4,
+		}
+		for {
+			resp, err := tv.client.ListAuditEvents(context.Background(), req)
+			Expect(err).NotTo(HaveOccurred())
+			for _, e := range resp.Items {
+				ids = append(ids, e.Id)
+			}
+			if resp.NextPageToken == "" {
+				break
+			}
+			req.PageToken = resp.NextPageToken
+		}
+		Expect(ids).To(HaveLen(6))
+		Expect(ids).To(BeEquivalentTo(sortedCopy(ids)))
+	})
+	It("should not include secrets in events", func() {
+		resp, err := tv.client.ListAuditEvents(context.Background(), &management.ListAuditEventsRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		data, err := protojson.Marshal(resp)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).NotTo(ContainSubstring(token.Secret))
+	})
+	It("should report the audit log feature", func() {
+		info, err := tv.client.ServerInfo(context.Background(), &emptypb.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Features).To(ContainElement(management.FeatureAuditLog))
+	})
+})
+
+var _ = Describe("Audit Log (not configured)", Ordered, Label(test.Unit, test.Slow), func() {
+	var tv *testVars
+	BeforeAll(setupManagementServer(&tv))
+
+	It("should return Unavailable", func() {
+		_, err := tv.client.ListAuditEvents(context.Background(), &management.ListAuditEventsRequest{})
+		Expect(status.Code(err)).To(Equal(codes.Unavailable))
+	})
+})
+
+func sortedCopy(s []string) []string {
+	c := append([]string(nil), s...)
+	sort.Strings(c)
+	return c
+}
@@ -2,14 +2,20 @@ package management
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 
+	"github.com/rancher/opni-monitoring/pkg/pkp"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 type ManagementClientOptions struct {
 	listenAddr  string
 	dialOptions []grpc.DialOption
+	tlsConfig   *tls.Config
+	pins        []*pkp.PublicKeyPin
 }
 
 type ManagementClientOption func(*ManagementClientOptions)
@@ -32,15 +38,47 @@ func WithDialOptions(options ...grpc.DialOption) ManagementClientOption {
 	}
 }
 
+// WithTLSConfig configures the client to connect to the management server
+// using TLS with the given config. Cannot be used together with WithPins.
+func WithTLSConfig(tlsConfig *tls.Config) ManagementClientOption {
+	return func(o *ManagementClientOptions) {
+		o.tlsConfig = tlsConfig
+	}
+}
+
+// WithPins configures the client to connect to the management server using
+// TLS, and to verify the server's certificate chain using the given public
+// key pins. Cannot be used together with WithTLSConfig.
+func WithPins(pins []*pkp.PublicKeyPin) ManagementClientOption {
+	return func(o *ManagementClientOptions) {
+		o.pins = pins
+	}
+}
+
 func NewClient(ctx context.Context, opts ...ManagementClientOption) (ManagementClient, error) {
 	options := ManagementClientOptions{
 		listenAddr: DefaultManagementSocket(),
-		dialOptions: []grpc.DialOption{
-			grpc.WithTransportCredentials(insecure.NewCredentials()),
-		},
 	}
 	options.Apply(opts...)
-	cc, err := grpc.DialContext(ctx, options.listenAddr, options.dialOptions...)
+
+	creds := insecure.NewCredentials()
+	switch {
+	case options.tlsConfig != nil && len(options.pins) > 0:
+		return nil, errors.New("cannot use both a TLS config and public key pins")
+	case options.tlsConfig != nil:
+		creds = credentials.NewTLS(options.tlsConfig)
+	case len(options.pins) > 0:
+		tlsConfig, err := pkp.TLSConfig(options.pins)
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	}
+	dialOptions := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+	}, options.dialOptions...)
+
+	cc, err := grpc.DialContext(ctx, options.listenAddr, dialOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -2,12 +2,17 @@ package management
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/rancher/opni-monitoring/pkg/capabilities"
 	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/storage"
 	"github.com/rancher/opni-monitoring/pkg/validation"
+	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -110,10 +115,267 @@ func (m *Server) EditCluster(
 	if err := validation.Validate(in); err != nil {
 		return nil, err
 	}
+	// check that computed labels can be evaluated before updating the cluster
+	if err := m.labelTemplates.Apply(&core.Cluster{
+		Id: in.GetCluster().GetId(),
+		Metadata: &core.ClusterMetadata{
+			Labels: in.GetLabels(),
+		},
+	}); err != nil {
+		return nil, validation.Errorf("failed to compute cluster labels: %v", err)
+	}
 	return m.coreDataSource.StorageBackend().UpdateCluster(ctx, in.GetCluster(), func(cluster *core.Cluster) {
 		if cluster.Metadata == nil {
 			cluster.Metadata = &core.ClusterMetadata{}
 		}
 		cluster.Metadata.Labels = in.GetLabels()
+		m.labelTemplates.Apply(cluster)
+	})
+}
+
+// GetClusterAnnotations returns the annotations of the given cluster.
+func (m *Server) GetClusterAnnotations(
+	ctx context.Context,
+	ref *core.Reference,
+) (*ClusterAnnotations, error) {
+	if err := validation.Validate(ref); err != nil {
+		return nil, err
+	}
+	cluster, err := m.coreDataSource.StorageBackend().GetCluster(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return &ClusterAnnotations{
+		Annotations: cluster.GetAnnotations(),
+	}, nil
+}
+
+// SetClusterAnnotations replaces the annotations of the given cluster.
+// Annotations are free-form metadata and are never used for label selection.
+func (m *Server) SetClusterAnnotations(
+	ctx context.Context,
+	in *SetClusterAnnotationsRequest,
+) (*core.Cluster, error) {
+	if err := validation.Validate(in); err != nil {
+		return nil, err
+	}
+	return m.coreDataSource.StorageBackend().UpdateCluster(ctx, in.GetCluster(), func(cluster *core.Cluster) {
+		cluster.SetAnnotations(in.GetAnnotations())
 	})
 }
+
+// SetClusterLimits replaces the ingestion and retention limits of the given
+// cluster. Limits are enforced by the metrics capability backend.
+func (m *Server) SetClusterLimits(
+	ctx context.Context,
+	in *SetClusterLimitsRequest,
+) (*core.Cluster, error) {
+	if err := validation.Validate(in); err != nil {
+		return nil, err
+	}
+	return m.coreDataSource.StorageBackend().UpdateCluster(ctx, in.GetCluster(), func(cluster *core.Cluster) {
+		cluster.SetLimits(in.GetLimits())
+	})
+}
+
+// RenameCluster moves a cluster to a new ID. The cluster's labels,
+// annotations, capabilities, and keyring are copied to the new ID, computed
+// labels are re-evaluated, and the cluster and keyring with the old ID are
+// deleted. Bootstrap tokens which reference the old ID, allowing agents to
+// join the cluster, are updated to reference the new ID. The request is
+// rejected if a cluster with the new ID already exists.
+//
+// Renaming is not atomic. The storage backend does not support transactions
+// across clusters, tokens, and keyrings, so each is updated separately, and
+// if any step fails, the changes made so far are reverted. Renames made
+// through this server are serialized, but writes made concurrently through
+// other gateways, such as an agent bootstrapping with the old ID, may be lost,
+// and if the gateway stops part-way through a rename, clusters with both IDs
+// may remain.
+//
+// Data stored for the old ID outside of the gateway, such as metrics stored in
+// Cortex under the old tenant ID, is not migrated, and will no longer be
+// accessible through the cluster. Agents must also be reconfigured to use the
+// new ID.
+func (m *Server) RenameCluster(
+	ctx context.Context,
+	in *RenameClusterRequest,
+) (*core.Cluster, error) {
+	if err := validation.Validate(in); err != nil {
+		return nil, err
+	}
+	m.renameMu.Lock()
+	defer m.renameMu.Unlock()
+
+	backend := m.coreDataSource.StorageBackend()
+	oldRef := in.GetCluster()
+	newRef := &core.Reference{
+		Id: in.GetNewID(),
+	}
+	cluster, err := backend.GetCluster(ctx, oldRef)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := backend.GetCluster(ctx, newRef); err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "cluster %s already exists", newRef.Id)
+	} else if !errors.Is(err, storage.ErrNotFound) {
+		return nil, err
+	}
+
+	oldKrStore, err := backend.KeyringStore(ctx, "gateway", oldRef)
+	if err != nil {
+		return nil, err
+	}
+	keyrings, err := oldKrStore.List(ctx)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, err
+	}
+	tokens, err := backend.ListTokens(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	renamed := proto.Clone(cluster).(*core.Cluster)
+	renamed.Id = newRef.Id
+	if err := m.labelTemplates.Apply(renamed); err != nil {
+		return nil, validation.Errorf("failed to compute cluster labels: %v", err)
+	}
+
+	if err := backend.CreateCluster(ctx, renamed); err != nil {
+		return nil, err
+	}
+	var newKrStore storage.KeyringStore
+	var updatedTokens []*core.Reference
+	revert := func(err error) error {
+		lg := m.logger.With(
+			"cluster", oldRef.Id,
+			"newID", newRef.Id,
+		)
+		for _, ref := range updatedTokens {
+			_, rerr := backend.UpdateToken(ctx, ref, replaceClusterReferences(newRef.Id, oldRef.Id))
+			if rerr != nil {
+				lg.With(
+					"token", ref.Id,
+					zap.Error(rerr),
+				).Error("failed to revert token references during cluster rename")
+			}
+		}
+		if newKrStore != nil {
+			if rerr := newKrStore.Delete(ctx); rerr != nil {
+				lg.With(
+					zap.Error(rerr),
+				).Error("failed to delete copied keyrings during cluster rename")
+			}
+		}
+		if rerr := backend.DeleteCluster(ctx, newRef); rerr != nil {
+			lg.With(
+				zap.Error(rerr),
+			).Error("failed to revert cluster rename")
+		}
+		return err
+	}
+	if len(keyrings) > 0 {
+		krStore, err := backend.KeyringStore(ctx, "gateway", newRef)
+		if err != nil {
+			return nil, revert(err)
+		}
+		newKrStore = krStore
+		// Copy all keyring versions, oldest first, so that previous versions
+		// which are still valid during a rotation are kept.
+		if err := newKrStore.Put(ctx, keyrings[len(keyrings)-1]); err != nil {
+			return nil, revert(err)
+		}
+		for i := len(keyrings) - 2; i >= 0; i-- {
+			if err := newKrStore.Rotate(ctx, keyrings[i]); err != nil {
+				return nil, revert(err)
+			}
+		}
+	}
+	for _, token := range tokens {
+		if !referencesCluster(token, oldRef.Id) {
+			continue
+		}
+		_, err := backend.UpdateToken(ctx, token.Reference(), replaceClusterReferences(oldRef.Id, newRef.Id))
+		if err != nil {
+			return nil, revert(err)
+		}
+		updatedTokens = append(updatedTokens, token.Reference())
+	}
+	if err := backend.DeleteCluster(ctx, oldRef); err != nil {
+		return nil, revert(err)
+	}
+	if len(keyrings) > 0 {
+		// the rename has already succeeded, so a failure here only leaves
+		// unused keyrings behind
+		if err := oldKrStore.Delete(ctx); err != nil {
+			m.logger.With(
+				"cluster", oldRef.Id,
+				zap.Error(err),
+			).Warn("failed to delete keyrings for renamed cluster")
+		}
+	}
+	return renamed, nil
+}
+
+// referencesCluster reports whether any of the token's capabilities refer to
+// the cluster with the given ID.
+func referencesCluster(token *core.BootstrapToken, id string) bool {
+	for _, tc := range token.GetCapabilities() {
+		if tc.GetReference().GetId() == id {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceClusterReferences returns a mutator which changes token capabilities
+// referring to the cluster with oldID to refer to newID instead.
+func replaceClusterReferences(oldID, newID string) storage.TokenMutator {
+	return func(token *core.BootstrapToken) {
+		for _, tc := range token.GetCapabilities() {
+			if tc.GetReference().GetId() == oldID {
+				tc.Reference = &core.Reference{
+					Id: newID,
+				}
+			}
+		}
+	}
+}
+
+// SetCapabilityEnabled pauses or resumes a capability on all clusters matching
+// the given selector which have the capability installed. Paused capabilities
+// remain installed, but the gateway will reject data sent for them. Returns
+// the clusters which were modified.
+func (m *Server) SetCapabilityEnabled(
+	ctx context.Context,
+	in *SetCapabilityEnabledRequest,
+) (*core.ReferenceList, error) {
+	if err := validation.Validate(in); err != nil {
+		return nil, err
+	}
+	clusterList, err := m.coreDataSource.StorageBackend().ListClusters(ctx, in.MatchLabels, in.MatchOptions)
+	if err != nil {
+		return nil, err
+	}
+	updated := &core.ReferenceList{
+		Items: []*core.Reference{},
+	}
+	for _, cluster := range clusterList.Items {
+		if !capabilities.Has(cluster, capabilities.Cluster(in.Capability)) {
+			continue
+		}
+		_, err := m.coreDataSource.StorageBackend().UpdateCluster(ctx, cluster.Reference(),
+			func(c *core.Cluster) {
+				for _, capability := range c.GetCapabilities() {
+					if capability.Name == in.Capability {
+						capability.Paused = !in.Enabled
+					}
+				}
+			})
+		if err != nil {
+			return nil, err
+		}
+		updated.Items = append(updated.Items, cluster.Reference())
+	}
+	return updated, nil
+}
@@ -2,19 +2,25 @@ package management_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/rancher/opni-monitoring/pkg/capabilities"
+	"github.com/rancher/opni-monitoring/pkg/capabilities/wellknown"
 	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/keyring"
+	"github.com/rancher/opni-monitoring/pkg/labels"
 	"github.com/rancher/opni-monitoring/pkg/management"
 	"github.com/rancher/opni-monitoring/pkg/storage"
 	"github.com/rancher/opni-monitoring/pkg/test"
 	"github.com/rancher/opni-monitoring/pkg/validation"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 var _ = Describe("Clusters", Ordered, Label(test.Unit, test.Slow), func() {
@@ -237,3 +243,473 @@ var _ = Describe("Clusters", Ordered, Label(test.Unit, test.Slow), func() {
 		Expect(err.Error()).To(ContainSubstring(storage.ErrNotFound.Error()))
 	})
 })
+
+var _ = Describe("Pausing Capabilities", Ordered, Label(test.Unit, test.Slow), func() {
+	var tv *testVars
+	BeforeAll(setupManagementServer(&tv))
+
+	pausedClusters := func() []string {
+		clusters, err := tv.client.ListClusters(context.Background(), &management.ListClustersRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		ids := []string{}
+		for _, cluster := range clusters.Items {
+			if capabilities.Paused(cluster, wellknown.CapabilityMetrics) {
+				ids = append(ids, cluster.Id)
+			}
+		}
+		return ids
+	}
+	refIDs := func(list *core.ReferenceList) []string {
+		ids := []string{}
+		for _, ref := range list.Items {
+			ids = append(ids, ref.Id)
+		}
+		return ids
+	}
+
+	BeforeAll(func() {
+		for _, cluster := range []*core.Cluster{
+			{
+				Id: "prod-1",
+				Metadata: &core.ClusterMetadata{
+					Labels:       map[string]string{"env": "prod"},
+					Capabilities: []*core.ClusterCapability{capabilities.Cluster(wellknown.CapabilityMetrics)},
+				},
+			},
+			{
+				Id: "prod-2",
+				Metadata: &core.ClusterMetadata{
+					Labels:       map[string]string{"env": "prod"},
+					Capabilities: []*core.ClusterCapability{capabilities.Cluster(wellknown.CapabilityMetrics)},
+				},
+			},
+			{
+				Id: "prod-3",
+				Metadata: &core.ClusterMetadata{
+					Labels: map[string]string{"env": "prod"},
+				},
+			},
+			{
+				Id: "dev-1",
+				Metadata: &core.ClusterMetadata{
+					Labels:       map[string]string{"env": "dev"},
+					Capabilities: []*core.ClusterCapability{capabilities.Cluster(wellknown.CapabilityMetrics)},
+				},
+			},
+		} {
+			Expect(tv.storageBackend.CreateCluster(context.Background(), cluster)).To(Succeed())
+		}
+	})
+
+	It("should initially have no paused capabilities", func() {
+		Expect(pausedClusters()).To(BeEmpty())
+	})
+	It("should pause a capability on matching clusters", func() {
+		updated, err := tv.client.SetCapabilityEnabled(context.Background(), &management.SetCapabilityEnabledRequest{
+			Capability: wellknown.CapabilityMetrics,
+			Enabled:    false,
+			MatchLabels: &core.LabelSelector{
+				MatchLabels: map[string]string{"env": "prod"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(refIDs(updated)).To(ConsistOf("prod-1", "prod-2"))
+		Expect(pausedClusters()).To(ConsistOf("prod-1", "prod-2"))
+
+		By("checking that the capability was not installed on other clusters")
+		cluster, err := tv.client.GetCluster(context.Background(), &core.Reference{Id: "prod-3"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.GetCapabilities()).To(BeEmpty())
+	})
+	It("should resume a paused capability", func() {
+		updated, err := tv.client.SetCapabilityEnabled(context.Background(), &management.SetCapabilityEnabledRequest{
+			Capability: wellknown.CapabilityMetrics,
+			Enabled:    true,
+			MatchLabels: &core.LabelSelector{
+				MatchLabels: map[string]string{"env": "prod"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(refIDs(updated)).To(ConsistOf("prod-1", "prod-2"))
+		Expect(pausedClusters()).To(BeEmpty())
+	})
+	It("should pause a capability on all clusters if no selector is given", func() {
+		updated, err := tv.client.SetCapabilityEnabled(context.Background(), &management.SetCapabilityEnabledRequest{
+			Capability: wellknown.CapabilityMetrics,
+			Enabled:    false,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(refIDs(updated)).To(ConsistOf("prod-1", "prod-2", "dev-1"))
+		Expect(pausedClusters()).To(ConsistOf("prod-1", "prod-2", "dev-1"))
+	})
+	It("should handle validation errors", func() {
+		_, err := tv.client.SetCapabilityEnabled(context.Background(), &management.SetCapabilityEnabledRequest{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(validation.ErrMissingRequiredField.Error()))
+	})
+})
+
+var _ = Describe("Computed Labels", Ordered, Label(test.Unit, test.Slow), func() {
+	var tv *testVars
+	BeforeAll(func() {
+		templates, err := labels.ParseTemplates(map[string]string{
+			"tier": `{{ if eq (index .Labels "env") "prod" }}critical{{ else }}standard{{ end }}`,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		setupManagementServer(&tv, management.WithLabelTemplates(templates))()
+		Expect(tv.storageBackend.CreateCluster(context.Background(), &core.Cluster{
+			Id: "cluster-1",
+			Metadata: &core.ClusterMetadata{
+				Labels: map[string]string{"env": "dev"},
+			},
+		})).To(Succeed())
+	})
+
+	It("should compute labels when cluster labels are edited", func() {
+		cluster, err := tv.client.EditCluster(context.Background(), &management.EditClusterRequest{
+			Cluster: &core.Reference{Id: "cluster-1"},
+			Labels:  map[string]string{"env": "prod"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.GetLabels()).To(HaveKeyWithValue("tier", "critical"))
+
+		cluster, err = tv.client.EditCluster(context.Background(), &management.EditClusterRequest{
+			Cluster: &core.Reference{Id: "cluster-1"},
+			Labels:  map[string]string{"env": "dev"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.GetLabels()).To(HaveKeyWithValue("tier", "standard"))
+	})
+	It("should not allow computed labels to be set directly", func() {
+		cluster, err := tv.client.EditCluster(context.Background(), &management.EditClusterRequest{
+			Cluster: &core.Reference{Id: "cluster-1"},
+			Labels:  map[string]string{"env": "dev", "tier": "critical"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.GetLabels()).To(HaveKeyWithValue("tier", "standard"))
+	})
+})
+
+var _ = Describe("Cluster Annotations", Ordered, Label(test.Unit, test.Slow), func() {
+	var tv *testVars
+	BeforeAll(func() {
+		setupManagementServer(&tv)()
+		Expect(tv.storageBackend.CreateCluster(context.Background(), &core.Cluster{
+			Id: "cluster-1",
+			Metadata: &core.ClusterMetadata{
+				Labels: map[string]string{"env": "dev"},
+			},
+		})).To(Succeed())
+	})
+
+	annotations := map[string]string{
+		"owner":       "someone@example.com",
+		"description": "Development cluster, do not use for production workloads",
+		"ticket":      "https://example.com/tickets/1234",
+	}
+
+	It("should initially have no annotations", func() {
+		resp, err := tv.client.GetClusterAnnotations(context.Background(), &core.Reference{
+			Id: "cluster-1",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Annotations).To(BeEmpty())
+	})
+	It("should set and get annotations", func() {
+		cluster, err := tv.client.SetClusterAnnotations(context.Background(), &management.SetClusterAnnotationsRequest{
+			Cluster:     &core.Reference{Id: "cluster-1"},
+			Annotations: annotations,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.GetAnnotations()).To(Equal(annotations))
+		Expect(cluster.GetLabels()).To(Equal(map[string]string{"env": "dev"}))
+
+		resp, err := tv.client.GetClusterAnnotations(context.Background(), &core.Reference{
+			Id: "cluster-1",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Annotations).To(Equal(annotations))
+	})
+	It("should preserve annotations when labels are edited", func() {
+		cluster, err := tv.client.EditCluster(context.Background(), &management.EditClusterRequest{
+			Cluster: &core.Reference{Id: "cluster-1"},
+			Labels:  map[string]string{"env": "prod"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.GetAnnotations()).To(Equal(annotations))
+	})
+	It("should not use annotations for label selection", func() {
+		list, err := tv.client.ListClusters(context.Background(), &management.ListClustersRequest{
+			MatchLabels: &core.LabelSelector{
+				MatchLabels: map[string]string{"env": "prod"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.Items).To(HaveLen(1))
+
+		list, err = tv.client.ListClusters(context.Background(), &management.ListClustersRequest{
+			MatchLabels: &core.LabelSelector{
+				MatchExpressions: []*core.LabelSelectorRequirement{
+					{
+						Key:      "owner",
+						Operator: string(core.LabelSelectorOpExists),
+					},
+				},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.Items).To(BeEmpty())
+
+		list, err = tv.client.ListClusters(context.Background(), &management.ListClustersRequest{
+			MatchLabels: &core.LabelSelector{
+				MatchExpressions: []*core.LabelSelectorRequirement{
+					{
+						Key:      "owner",
+						Operator: string(core.LabelSelectorOpDoesNotExist),
+					},
+				},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.Items).To(HaveLen(1))
+	})
+	It("should clear annotations", func() {
+		cluster, err := tv.client.SetClusterAnnotations(context.Background(), &management.SetClusterAnnotationsRequest{
+			Cluster: &core.Reference{Id: "cluster-1"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.GetAnnotations()).To(BeEmpty())
+	})
+	It("should reject invalid annotation keys", func() {
+		_, err := tv.client.SetClusterAnnotations(context.Background(), &management.SetClusterAnnotationsRequest{
+			Cluster:     &core.Reference{Id: "cluster-1"},
+			Annotations: map[string]string{"not a key": "foo"},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(validation.ErrInvalidLabelName.Error()))
+	})
+})
+
+var _ = Describe("Cluster Limits", Ordered, Label(test.Unit, test.Slow), func() {
+	var tv *testVars
+	BeforeAll(func() {
+		setupManagementServer(&tv)()
+		Expect(tv.storageBackend.CreateCluster(context.Background(), &core.Cluster{
+			Id: "cluster-1",
+			Metadata: &core.ClusterMetadata{
+				Labels: map[string]string{"env": "dev"},
+			},
+		})).To(Succeed())
+	})
+
+	limits := &core.ClusterLimits{
+		IngestionRate:      1000,
+		IngestionBurstSize: 5000,
+		MaxSeries:          10000,
+		RetentionSeconds:   7 * 24 * 60 * 60,
+	}
+
+	It("should initially have no limits", func() {
+		cluster, err := tv.client.GetCluster(context.Background(), &core.Reference{
+			Id: "cluster-1",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.GetLimits()).To(BeNil())
+	})
+	It("should set limits", func() {
+		cluster, err := tv.client.SetClusterLimits(context.Background(), &management.SetClusterLimitsRequest{
+			Cluster: &core.Reference{Id: "cluster-1"},
+			Limits:  limits,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proto.Equal(cluster.GetLimits(), limits)).To(BeTrue())
+		Expect(cluster.GetLabels()).To(Equal(map[string]string{"env": "dev"}))
+
+		cluster, err = tv.client.GetCluster(context.Background(), &core.Reference{
+			Id: "cluster-1",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proto.Equal(cluster.GetLimits(), limits)).To(BeTrue())
+	})
+	It("should preserve limits when labels are edited", func() {
+		cluster, err := tv.client.EditCluster(context.Background(), &management.EditClusterRequest{
+			Cluster: &core.Reference{Id: "cluster-1"},
+			Labels:  map[string]string{"env": "prod"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proto.Equal(cluster.GetLimits(), limits)).To(BeTrue())
+	})
+	It("should remove limits", func() {
+		cluster, err := tv.client.SetClusterLimits(context.Background(), &management.SetClusterLimitsRequest{
+			Cluster: &core.Reference{Id: "cluster-1"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.GetLimits()).To(BeNil())
+	})
+	It("should reject invalid limits", func() {
+		_, err := tv.client.SetClusterLimits(context.Background(), &management.SetClusterLimitsRequest{
+			Cluster: &core.Reference{Id: "cluster-1"},
+			Limits: &core.ClusterLimits{
+				IngestionRate: -1,
+			},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(validation.ErrInvalidValue.Error()))
+	})
+	It("should return an error if the cluster does not exist", func() {
+		_, err := tv.client.SetClusterLimits(context.Background(), &management.SetClusterLimitsRequest{
+			Cluster: &core.Reference{Id: "does-not-exist"},
+			Limits:  limits,
+		})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+type failDeleteClusterBackend struct {
+	storage.Backend
+	id string
+}
+
+func (b failDeleteClusterBackend) DeleteCluster(ctx context.Context, ref *core.Reference) error {
+	if ref.GetId() == b.id {
+		return errors.New("delete failed")
+	}
+	return b.Backend.DeleteCluster(ctx, ref)
+}
+
+var _ = Describe("Cluster Rename", Ordered, Label(test.Unit, test.Slow), func() {
+	var tv *testVars
+	var token *core.BootstrapToken
+	tokenReferences := func() []string {
+		t, err := tv.storageBackend.GetToken(context.Background(), token.Reference())
+		Expect(err).NotTo(HaveOccurred())
+		var ids []string
+		for _, tc := range t.GetCapabilities() {
+			ids = append(ids, tc.GetReference().GetId())
+		}
+		return ids
+	}
+	BeforeAll(func() {
+		templates, err := labels.ParseTemplates(map[string]string{
+			"cluster": "{{ .ID }}",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		setupManagementServer(&tv, management.WithLabelTemplates(templates))()
+		for _, id := range []string{"old-id", "other"} {
+			Expect(tv.storageBackend.CreateCluster(context.Background(), &core.Cluster{
+				Id: id,
+				Metadata: &core.ClusterMetadata{
+					Labels: map[string]string{
+						"env":     "dev",
+						"cluster": id,
+					},
+					Annotations: map[string]string{"owner": "someone@example.com"},
+					Capabilities: []*core.ClusterCapability{
+						capabilities.Cluster(wellknown.CapabilityMetrics),
+					},
+				},
+			})).To(Succeed())
+		}
+		ks, err := tv.storageBackend.KeyringStore(context.Background(), "gateway", &core.Reference{Id: "old-id"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ks.Put(context.Background(), keyring.New(keyring.NewSharedKeys(make([]byte, 64))))).To(Succeed())
+		token, err = tv.storageBackend.CreateToken(context.Background(), time.Hour,
+			storage.WithCapabilities([]*core.TokenCapability{
+				capabilities.JoinExistingCluster.For(&core.Reference{Id: "old-id"}),
+				capabilities.JoinExistingCluster.For(&core.Reference{Id: "other"}),
+			}))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject renaming to an existing ID", func() {
+		_, err := tv.client.RenameCluster(context.Background(), &management.RenameClusterRequest{
+			Cluster: &core.Reference{Id: "old-id"},
+			NewID:   "other",
+		})
+		Expect(status.Code(err)).To(Equal(codes.AlreadyExists))
+
+		_, err = tv.client.GetCluster(context.Background(), &core.Reference{Id: "old-id"})
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("should reject renaming a cluster which does not exist", func() {
+		_, err := tv.client.RenameCluster(context.Background(), &management.RenameClusterRequest{
+			Cluster: &core.Reference{Id: "missing"},
+			NewID:   "new-id",
+		})
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+	})
+	It("should reject renaming a cluster to its own ID", func() {
+		_, err := tv.client.RenameCluster(context.Background(), &management.RenameClusterRequest{
+			Cluster: &core.Reference{Id: "old-id"},
+			NewID:   "old-id",
+		})
+		Expect(err).To(HaveOccurred())
+	})
+	It("should revert all changes if the rename fails", func() {
+		cds := tv.coreDataSource.(*testCoreDataSource)
+		cds.storageBackend = failDeleteClusterBackend{
+			Backend: tv.storageBackend,
+			id:      "old-id",
+		}
+		defer func() {
+			cds.storageBackend = tv.storageBackend
+		}()
+		_, err := tv.client.RenameCluster(context.Background(), &management.RenameClusterRequest{
+			Cluster: &core.Reference{Id: "old-id"},
+			NewID:   "new-id",
+		})
+		Expect(err).To(HaveOccurred())
+
+		_, err = tv.client.GetCluster(context.Background(), &core.Reference{Id: "old-id"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = tv.client.GetCluster(context.Background(), &core.Reference{Id: "new-id"})
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+		By("checking that the copied keyrings were deleted")
+		ks, err := tv.storageBackend.KeyringStore(context.Background(), "gateway", &core.Reference{Id: "new-id"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = ks.List(context.Background())
+		Expect(err).To(MatchError(storage.ErrNotFound))
+
+		By("checking that token references were restored")
+		Expect(tokenReferences()).To(Equal([]string{"old-id", "other"}))
+	})
+	It("should rename the cluster", func() {
+		cluster, err := tv.client.RenameCluster(context.Background(), &management.RenameClusterRequest{
+			Cluster: &core.Reference{Id: "old-id"},
+			NewID:   "new-id",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.GetId()).To(Equal("new-id"))
+
+		By("checking that the old cluster was removed")
+		_, err = tv.client.GetCluster(context.Background(), &core.Reference{Id: "old-id"})
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+		By("checking that the cluster metadata was copied")
+		cluster, err = tv.client.GetCluster(context.Background(), &core.Reference{Id: "new-id"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.GetLabels()).To(Equal(map[string]string{
+			"env":     "dev",
+			"cluster": "new-id",
+		}))
+		Expect(cluster.GetAnnotations()).To(Equal(map[string]string{"owner": "someone@example.com"}))
+		Expect(capabilities.Has(cluster, capabilities.Cluster(wellknown.CapabilityMetrics))).To(BeTrue())
+
+		By("checking that the keyring was copied")
+		ks, err := tv.storageBackend.KeyringStore(context.Background(), "gateway", &core.Reference{Id: "new-id"})
+		Expect(err).NotTo(HaveOccurred())
+		kr, err := ks.Get(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kr.Try(func(*keyring.SharedKeys) {})).To(BeTrue())
+
+		By("checking that the old keyring was deleted")
+		ks, err = tv.storageBackend.KeyringStore(context.Background(), "gateway", &core.Reference{Id: "old-id"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = ks.List(context.Background())
+		Expect(err).To(MatchError(storage.ErrNotFound))
+
+		By("checking that token references were updated")
+		Expect(tokenReferences()).To(Equal([]string{"new-id", "other"}))
+	})
+})
@@ -0,0 +1,49 @@
+package management
+
+import (
+	"context"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/storage"
+	"github.com/rancher/opni-monitoring/pkg/validation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// WithDiagnosticsStore configures the server to serve GetClusterDiagnostics
+// using the diagnostics bundles uploaded by agents to the given store.
+func WithDiagnosticsStore(store *storage.DiagnosticsStore) ManagementServerOption {
+	return func(o *ManagementServerOptions) {
+		o.diagnosticsStore = store
+	}
+}
+
+// GetClusterDiagnostics returns the most recent diagnostics bundle uploaded
+// by the cluster's agent. Agents upload a new bundle when their state changes,
+// and otherwise every diagnostics.RefreshInterval; check reportedAt to
+// determine when it was received.
+func (m *Server) GetClusterDiagnostics(
+	ctx context.Context,
+	ref *core.Reference,
+) (*ClusterDiagnostics, error) {
+	if m.diagnosticsStore == nil {
+		return nil, status.Error(codes.Unavailable, "diagnostics not configured")
+	}
+	if err := validation.Validate(ref); err != nil {
+		return nil, err
+	}
+	diag, err := m.diagnosticsStore.Get(ctx, ref.Id)
+	if err != nil {
+		return nil, err
+	}
+	bundle := &structpb.Struct{}
+	if err := protojson.Unmarshal(diag.Bundle, bundle); err != nil {
+		return nil, status.Errorf(codes.Internal, "stored diagnostics bundle is invalid: %v", err)
+	}
+	return &ClusterDiagnostics{
+		ReportedAt: diag.ReportedAt.Unix(),
+		Bundle:     bundle,
+	}, nil
+}
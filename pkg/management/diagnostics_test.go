@@ -0,0 +1,64 @@
+package management_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/management"
+	"github.com/rancher/opni-monitoring/pkg/storage"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Cluster Diagnostics", Ordered, Label(test.Unit, test.Slow), func() {
+	var tv *testVars
+	var store *storage.DiagnosticsStore
+	BeforeAll(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		tv = &testVars{ctrl: ctrl}
+		store = storage.NewDiagnosticsStore(test.NewTestKeyValueStore(ctrl))
+		setupManagementServer(&tv, management.WithDiagnosticsStore(store))()
+	})
+	It("should return NotFound if the agent has not reported diagnostics", func() {
+		_, err := tv.client.GetClusterDiagnostics(context.Background(), &core.Reference{
+			Id: "cluster-1",
+		})
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+	})
+	It("should return the most recent diagnostics bundle", func() {
+		Expect(store.Put(context.Background(), "cluster-1",
+			[]byte(`{"clusterID":"cluster-1","connection":{"connected":false}}`))).To(Succeed())
+		Expect(store.Put(context.Background(), "cluster-1",
+			[]byte(`{"clusterID":"cluster-1","connection":{"connected":true}}`))).To(Succeed())
+
+		diag, err := tv.client.GetClusterDiagnostics(context.Background(), &core.Reference{
+			Id: "cluster-1",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(time.Unix(diag.ReportedAt, 0)).To(BeTemporally("~", time.Now(), 5*time.Second))
+		bundle := diag.Bundle.AsMap()
+		Expect(bundle).To(HaveKeyWithValue("clusterID", "cluster-1"))
+		Expect(bundle).To(HaveKeyWithValue("connection", HaveKeyWithValue("connected", true)))
+	})
+	It("should validate the cluster reference", func() {
+		_, err := tv.client.GetClusterDiagnostics(context.Background(), &core.Reference{})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Cluster Diagnostics (not configured)", Ordered, Label(test.Unit, test.Slow), func() {
+	var tv *testVars
+	BeforeAll(setupManagementServer(&tv))
+	It("should return Unavailable", func() {
+		_, err := tv.client.GetClusterDiagnostics(context.Background(), &core.Reference{
+			Id: "cluster-1",
+		})
+		Expect(status.Code(err)).To(Equal(codes.Unavailable))
+	})
+})
@@ -0,0 +1,48 @@
+package management
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rancher/opni-monitoring/pkg/validation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DrainGateway stops the gateway from accepting new requests and waits for
+// in-flight requests to complete, or until the timeout elapses. While
+// draining, the gateway's readiness endpoint (/readyz) fails, and requests
+// are rejected with a Retry-After header, which agents connected through a
+// load balancer act on by retrying on a new connection to a different
+// gateway. The health and metrics endpoints are still served. The gateway
+// remains drained until it is restarted.
+func (m *Server) DrainGateway(
+	ctx context.Context,
+	in *DrainGatewayRequest,
+) (*DrainGatewayResponse, error) {
+	if err := validation.Validate(in); err != nil {
+		return nil, err
+	}
+	if m.drainDataSource == nil {
+		return nil, status.Error(codes.Unavailable, "drain data source not configured")
+	}
+	if in.Timeout != nil {
+		var ca context.CancelFunc
+		ctx, ca = context.WithTimeout(ctx, in.Timeout.AsDuration())
+		defer ca()
+	}
+	m.logger.Info("draining gateway")
+	err := m.drainDataSource.Drain(ctx)
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return nil, status.FromContextError(err).Err()
+	}
+	resp := &DrainGatewayResponse{
+		Drained:          err == nil,
+		InFlightRequests: int64(m.drainDataSource.InFlightRequests()),
+	}
+	m.logger.With(
+		"drained", resp.Drained,
+		"inFlightRequests", resp.InFlightRequests,
+	).Info("finished draining gateway")
+	return resp, nil
+}
@@ -0,0 +1,84 @@
+package management_test
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/rancher/opni-monitoring/pkg/management"
+	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/pkg/validation"
+)
+
+type testDrainDataSource struct {
+	inFlight int32
+	draining int32
+	idle     chan struct{}
+}
+
+func (t *testDrainDataSource) Drain(ctx context.Context) error {
+	atomic.StoreInt32(&t.draining, 1)
+	select {
+	case <-t.idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *testDrainDataSource) InFlightRequests() int {
+	return int(atomic.LoadInt32(&t.inFlight))
+}
+
+var _ = Describe("Draining the Gateway", Ordered, Label(test.Unit, test.Slow), func() {
+	var tv *testVars
+	drain := &testDrainDataSource{
+		inFlight: 2,
+		idle:     make(chan struct{}),
+	}
+	BeforeAll(setupManagementServer(&tv, management.WithDrainDataSource(drain)))
+
+	It("should report in-flight requests if the timeout elapses", func() {
+		resp, err := tv.client.DrainGateway(context.Background(), &management.DrainGatewayRequest{
+			Timeout: durationpb.New(50 * time.Millisecond),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Drained).To(BeFalse())
+		Expect(resp.InFlightRequests).To(BeEquivalentTo(2))
+		Expect(atomic.LoadInt32(&drain.draining)).To(BeEquivalentTo(1))
+	})
+	It("should report when the gateway is drained", func() {
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			atomic.StoreInt32(&drain.inFlight, 0)
+			close(drain.idle)
+		}()
+		resp, err := tv.client.DrainGateway(context.Background(), &management.DrainGatewayRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Drained).To(BeTrue())
+		Expect(resp.InFlightRequests).To(BeEquivalentTo(0))
+	})
+	It("should reject invalid timeouts", func() {
+		_, err := tv.client.DrainGateway(context.Background(), &management.DrainGatewayRequest{
+			Timeout: durationpb.New(-1 * time.Second),
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(validation.ErrInvalidValue.Error()))
+	})
+})
+
+var _ = Describe("Draining the Gateway without a data source", Ordered, Label(test.Unit, test.Slow), func() {
+	var tv *testVars
+	BeforeAll(setupManagementServer(&tv))
+
+	It("should return Unavailable", func() {
+		_, err := tv.client.DrainGateway(context.Background(), &management.DrainGatewayRequest{})
+		Expect(status.Code(err)).To(Equal(codes.Unavailable))
+	})
+})
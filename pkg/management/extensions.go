@@ -2,6 +2,7 @@ package management
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -36,9 +37,10 @@ func (m *Server) APIExtensions(context.Context, *emptypb.Empty) (*APIExtensionIn
 }
 
 type UnknownStreamMetadata struct {
-	Conn       *grpc.ClientConn
-	InputType  *desc.MessageDescriptor
-	OutputType *desc.MessageDescriptor
+	Conn          *grpc.ClientConn
+	InputType     *desc.MessageDescriptor
+	OutputType    *desc.MessageDescriptor
+	ServerStreams bool
 }
 
 type StreamDirector func(ctx context.Context, fullMethodName string) (context.Context, *UnknownStreamMetadata, error)
@@ -81,10 +83,17 @@ func (m *Server) configureApiExtensionDirector(ctx context.Context) StreamDirect
 				"name", fullName,
 			).Info("loading method")
 
+			if mtd.IsClientStreaming() {
+				lg.With(
+					"name", fullName,
+				).Warn("client-streaming methods are not supported, skipping")
+				continue
+			}
 			methodTable[fullName] = &UnknownStreamMetadata{
-				Conn:       plugin.Client,
-				InputType:  mtd.GetInputType(),
-				OutputType: mtd.GetOutputType(),
+				Conn:          plugin.Client,
+				InputType:     mtd.GetInputType(),
+				OutputType:    mtd.GetOutputType(),
+				ServerStreams: mtd.IsServerStreaming(),
 			}
 		}
 		httpRules := loadHttpRuleDescriptors(svcDesc)
@@ -272,6 +281,9 @@ func unknownServiceHandler(director StreamDirector) grpc.StreamHandler {
 		if err := stream.RecvMsg(request); err != nil {
 			return err
 		}
+		if meta.ServerStreams {
+			return forwardServerStream(outgoingCtx, fullMethodName, meta, request, stream)
+		}
 		reply := dynamic.NewMessage(meta.OutputType)
 		err = meta.Conn.Invoke(outgoingCtx, fullMethodName, request, reply)
 		if err != nil {
@@ -280,3 +292,38 @@ func unknownServiceHandler(director StreamDirector) grpc.StreamHandler {
 		return stream.SendMsg(reply)
 	}
 }
+
+func forwardServerStream(
+	ctx context.Context,
+	fullMethodName string,
+	meta *UnknownStreamMetadata,
+	request *dynamic.Message,
+	stream grpc.ServerStream,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	clientStream, err := meta.Conn.NewStream(ctx, &grpc.StreamDesc{
+		ServerStreams: true,
+	}, fullMethodName)
+	if err != nil {
+		return err
+	}
+	if err := clientStream.SendMsg(request); err != nil {
+		return err
+	}
+	if err := clientStream.CloseSend(); err != nil {
+		return err
+	}
+	for {
+		reply := dynamic.NewMessage(meta.OutputType)
+		if err := clientStream.RecvMsg(reply); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err := stream.SendMsg(reply); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package management_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/rancher/opni-monitoring/pkg/auth"
+	authtest "github.com/rancher/opni-monitoring/pkg/auth/test"
+	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
+	"github.com/rancher/opni-monitoring/pkg/management"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("HTTP Auth", Ordered, Label(test.Unit, test.Slow), func() {
+	var tv *testVars
+	BeforeAll(func() {
+		Expect(auth.RegisterMiddleware("management-http-test", &authtest.TestAuthMiddleware{
+			Strategy: authtest.AuthStrategyUserIDInAuthHeader,
+		})).To(Succeed())
+		DeferCleanup(auth.ResetMiddlewares)
+		setupManagementServer(&tv, management.WithHTTPAuthMiddleware("management-http-test"))()
+	})
+
+	get := func(authHeader string) int {
+		req, err := http.NewRequest(http.MethodGet, tv.httpEndpoint+"/management/certs", nil)
+		Expect(err).NotTo(HaveOccurred())
+		if authHeader != "" {
+			req.Header.Set("Authorization", authHeader)
+		}
+		var code int
+		Eventually(func() error {
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+			code = resp.StatusCode
+			return nil
+		}).Should(Succeed())
+		return code
+	}
+
+	It("should reject unauthenticated HTTP requests", func() {
+		Expect(get("")).To(Equal(http.StatusUnauthorized))
+	})
+	It("should allow authenticated HTTP requests", func() {
+		Expect(get("user1")).To(Equal(http.StatusOK))
+	})
+	It("should not affect the gRPC API", func() {
+		_, err := tv.client.CertsInfo(context.Background(), &emptypb.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("should fail to start if the middleware does not exist", func() {
+		server := management.NewServer(context.Background(), &v1beta1.ManagementSpec{}, tv.coreDataSource,
+			management.WithHTTPAuthMiddleware("does-not-exist"))
+		Expect(server.ListenAndServe()).To(MatchError(auth.ErrMiddlewareNotFound))
+	})
+})
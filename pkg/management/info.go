@@ -0,0 +1,75 @@
+package management
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/rancher/opni-monitoring/pkg/bootstrap"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Optional features which can be reported by ServerInfo.
+const (
+	FeatureCapabilities  = "capabilities"
+	FeatureDrain         = "drain"
+	FeatureServingTLS    = "tls"
+	FeatureHTTPAuth      = "http-auth"
+	FeatureAPIExtensions = "api-extensions"
+	FeatureAuditLog      = "audit-log"
+)
+
+// ServerInfo returns the gateway's version and build information, the
+// bootstrap protocol versions it supports, and the optional features enabled
+// on this server. Clients can use this to check compatibility with the
+// gateway before using it.
+func (m *Server) ServerInfo(
+	ctx context.Context,
+	_ *emptypb.Empty,
+) (*ServerInfoResponse, error) {
+	resp := &ServerInfoResponse{}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		resp.Version = info.Main.Version
+		resp.GoVersion = info.GoVersion
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				resp.Revision = setting.Value
+			case "vcs.time":
+				resp.BuildTime = setting.Value
+			}
+		}
+	}
+	for _, v := range bootstrap.SupportedProtocolVersions {
+		resp.ProtocolVersions = append(resp.ProtocolVersions, int32(v))
+	}
+	if m.capabilitiesDataSource != nil {
+		resp.Features = append(resp.Features, FeatureCapabilities)
+	}
+	if m.drainDataSource != nil {
+		resp.Features = append(resp.Features, FeatureDrain)
+	}
+	if m.servingTLS {
+		resp.Features = append(resp.Features, FeatureServingTLS)
+	}
+	if m.httpAuthMiddleware != nil {
+		resp.Features = append(resp.Features, FeatureHTTPAuth)
+	}
+	if len(m.apiExtPlugins) > 0 {
+		resp.Features = append(resp.Features, FeatureAPIExtensions)
+	}
+	if m.auditLog != nil {
+		resp.Features = append(resp.Features, FeatureAuditLog)
+	}
+	return resp, nil
+}
+
+// SupportsProtocolVersion returns true if the server supports the given
+// bootstrap protocol version.
+func (r *ServerInfoResponse) SupportsProtocolVersion(v bootstrap.ProtocolVersion) bool {
+	for _, sv := range r.GetProtocolVersions() {
+		if bootstrap.ProtocolVersion(sv) == v {
+			return true
+		}
+	}
+	return false
+}
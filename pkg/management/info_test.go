@@ -0,0 +1,53 @@
+package management_test
+
+import (
+	"context"
+	"runtime/debug"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/rancher/opni-monitoring/pkg/bootstrap"
+	"github.com/rancher/opni-monitoring/pkg/management"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Server Info", Ordered, Label(test.Unit, test.Slow), func() {
+	var tv *testVars
+	BeforeAll(setupManagementServer(&tv, management.WithDrainDataSource(&testDrainDataSource{})))
+
+	It("should return the compiled-in build info", func() {
+		info, err := tv.client.ServerInfo(context.Background(), &emptypb.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+
+		buildInfo, ok := debug.ReadBuildInfo()
+		Expect(ok).To(BeTrue())
+		Expect(info.Version).NotTo(BeEmpty())
+		Expect(info.Version).To(Equal(buildInfo.Main.Version))
+		Expect(info.GoVersion).NotTo(BeEmpty())
+		Expect(info.GoVersion).To(Equal(buildInfo.GoVersion))
+		for _, setting := range buildInfo.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				Expect(info.Revision).To(Equal(setting.Value))
+			case "vcs.time":
+				Expect(info.BuildTime).To(Equal(setting.Value))
+			}
+		}
+	})
+	It("should return the supported protocol versions", func() {
+		info, err := tv.client.ServerInfo(context.Background(), &emptypb.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.ProtocolVersions).To(HaveLen(len(bootstrap.SupportedProtocolVersions)))
+		for _, v := range bootstrap.SupportedProtocolVersions {
+			Expect(info.SupportsProtocolVersion(v)).To(BeTrue())
+		}
+		Expect(info.SupportsProtocolVersion(bootstrap.ProtocolVersion(0))).To(BeFalse())
+	})
+	It("should return the enabled features", func() {
+		info, err := tv.client.ServerInfo(context.Background(), &emptypb.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Features).To(ConsistOf(management.FeatureDrain))
+	})
+})
@@ -25,6 +25,7 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ManagementClient interface {
 	CreateBootstrapToken(ctx context.Context, in *CreateBootstrapTokenRequest, opts ...grpc.CallOption) (*core.BootstrapToken, error)
+	PreviewTokenEffect(ctx context.Context, in *PreviewTokenEffectRequest, opts ...grpc.CallOption) (*core.Cluster, error)
 	RevokeBootstrapToken(ctx context.Context, in *core.Reference, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ListBootstrapTokens(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*core.BootstrapTokenList, error)
 	GetBootstrapToken(ctx context.Context, in *core.Reference, opts ...grpc.CallOption) (*core.BootstrapToken, error)
@@ -33,7 +34,13 @@ type ManagementClient interface {
 	DeleteCluster(ctx context.Context, in *core.Reference, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	CertsInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CertsInfoResponse, error)
 	GetCluster(ctx context.Context, in *core.Reference, opts ...grpc.CallOption) (*core.Cluster, error)
+	ListRolesForCluster(ctx context.Context, in *core.Reference, opts ...grpc.CallOption) (*core.RoleList, error)
 	EditCluster(ctx context.Context, in *EditClusterRequest, opts ...grpc.CallOption) (*core.Cluster, error)
+	SetClusterLimits(ctx context.Context, in *SetClusterLimitsRequest, opts ...grpc.CallOption) (*core.Cluster, error)
+	GetClusterAnnotations(ctx context.Context, in *core.Reference, opts ...grpc.CallOption) (*ClusterAnnotations, error)
+	GetClusterDiagnostics(ctx context.Context, in *core.Reference, opts ...grpc.CallOption) (*ClusterDiagnostics, error)
+	SetClusterAnnotations(ctx context.Context, in *SetClusterAnnotationsRequest, opts ...grpc.CallOption) (*core.Cluster, error)
+	RenameCluster(ctx context.Context, in *RenameClusterRequest, opts ...grpc.CallOption) (*core.Cluster, error)
 	CreateRole(ctx context.Context, in *core.Role, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	DeleteRole(ctx context.Context, in *core.Reference, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetRole(ctx context.Context, in *core.Reference, opts ...grpc.CallOption) (*core.Role, error)
@@ -48,6 +55,11 @@ type ManagementClient interface {
 	UpdateConfig(ctx context.Context, in *UpdateConfigRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ListCapabilities(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CapabilityList, error)
 	CapabilityInstaller(ctx context.Context, in *CapabilityInstallerRequest, opts ...grpc.CallOption) (*CapabilityInstallerResponse, error)
+	RefreshCapabilities(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CapabilityList, error)
+	SetCapabilityEnabled(ctx context.Context, in *SetCapabilityEnabledRequest, opts ...grpc.CallOption) (*core.ReferenceList, error)
+	DrainGateway(ctx context.Context, in *DrainGatewayRequest, opts ...grpc.CallOption) (*DrainGatewayResponse, error)
+	ServerInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ServerInfoResponse, error)
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
 }
 
 type managementClient struct {
@@ -67,6 +79,15 @@ func (c *managementClient) CreateBootstrapToken(ctx context.Context, in *CreateB
 	return out, nil
 }
 
+func (c *managementClient) PreviewTokenEffect(ctx context.Context, in *PreviewTokenEffectRequest, opts ...grpc.CallOption) (*core.Cluster, error) {
+	out := new(core.Cluster)
+	err := c.cc.Invoke(ctx, "/management.Management/PreviewTokenEffect", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *managementClient) RevokeBootstrapToken(ctx context.Context, in *core.Reference, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/management.Management/RevokeBootstrapToken", in, out, opts...)
@@ -162,6 +183,15 @@ func (c *managementClient) GetCluster(ctx context.Context, in *core.Reference, o
 	return out, nil
 }
 
+func (c *managementClient) ListRolesForCluster(ctx context.Context, in *core.Reference, opts ...grpc.CallOption) (*core.RoleList, error) {
+	out := new(core.RoleList)
+	err := c.cc.Invoke(ctx, "/management.Management/ListRolesForCluster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *managementClient) EditCluster(ctx context.Context, in *EditClusterRequest, opts ...grpc.CallOption) (*core.Cluster, error) {
 	out := new(core.Cluster)
 	err := c.cc.Invoke(ctx, "/management.Management/EditCluster", in, out, opts...)
@@ -171,6 +201,51 @@ func (c *managementClient) EditCluster(ctx context.Context, in *EditClusterReque
 	return out, nil
 }
 
+func (c *managementClient) SetClusterLimits(ctx context.Context, in *SetClusterLimitsRequest, opts ...grpc.CallOption) (*core.Cluster, error) {
+	out := new(core.Cluster)
+	err := c.cc.Invoke(ctx, "/management.Management/SetClusterLimits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) GetClusterAnnotations(ctx context.Context, in *core.Reference, opts ...grpc.CallOption) (*ClusterAnnotations, error) {
+	out := new(ClusterAnnotations)
+	err := c.cc.Invoke(ctx, "/management.Management/GetClusterAnnotations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) GetClusterDiagnostics(ctx context.Context, in *core.Reference, opts ...grpc.CallOption) (*ClusterDiagnostics, error) {
+	out := new(ClusterDiagnostics)
+	err := c.cc.Invoke(ctx, "/management.Management/GetClusterDiagnostics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) SetClusterAnnotations(ctx context.Context, in *SetClusterAnnotationsRequest, opts ...grpc.CallOption) (*core.Cluster, error) {
+	out := new(core.Cluster)
+	err := c.cc.Invoke(ctx, "/management.Management/SetClusterAnnotations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) RenameCluster(ctx context.Context, in *RenameClusterRequest, opts ...grpc.CallOption) (*core.Cluster, error) {
+	out := new(core.Cluster)
+	err := c.cc.Invoke(ctx, "/management.Management/RenameCluster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *managementClient) CreateRole(ctx context.Context, in *core.Role, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/management.Management/CreateRole", in, out, opts...)
@@ -297,11 +372,57 @@ func (c *managementClient) CapabilityInstaller(ctx context.Context, in *Capabili
 	return out, nil
 }
 
+func (c *managementClient) RefreshCapabilities(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CapabilityList, error) {
+	out := new(CapabilityList)
+	err := c.cc.Invoke(ctx, "/management.Management/RefreshCapabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) SetCapabilityEnabled(ctx context.Context, in *SetCapabilityEnabledRequest, opts ...grpc.CallOption) (*core.ReferenceList, error) {
+	out := new(core.ReferenceList)
+	err := c.cc.Invoke(ctx, "/management.Management/SetCapabilityEnabled", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) DrainGateway(ctx context.Context, in *DrainGatewayRequest, opts ...grpc.CallOption) (*DrainGatewayResponse, error) {
+	out := new(DrainGatewayResponse)
+	err := c.cc.Invoke(ctx, "/management.Management/DrainGateway", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) ServerInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ServerInfoResponse, error) {
+	out := new(ServerInfoResponse)
+	err := c.cc.Invoke(ctx, "/management.Management/ServerInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managementClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, "/management.Management/ListAuditEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ManagementServer is the server API for Management service.
 // All implementations must embed UnimplementedManagementServer
 // for forward compatibility
 type ManagementServer interface {
 	CreateBootstrapToken(context.Context, *CreateBootstrapTokenRequest) (*core.BootstrapToken, error)
+	PreviewTokenEffect(context.Context, *PreviewTokenEffectRequest) (*core.Cluster, error)
 	RevokeBootstrapToken(context.Context, *core.Reference) (*emptypb.Empty, error)
 	ListBootstrapTokens(context.Context, *emptypb.Empty) (*core.BootstrapTokenList, error)
 	GetBootstrapToken(context.Context, *core.Reference) (*core.BootstrapToken, error)
@@ -310,7 +431,13 @@ type ManagementServer interface {
 	DeleteCluster(context.Context, *core.Reference) (*emptypb.Empty, error)
 	CertsInfo(context.Context, *emptypb.Empty) (*CertsInfoResponse, error)
 	GetCluster(context.Context, *core.Reference) (*core.Cluster, error)
+	ListRolesForCluster(context.Context, *core.Reference) (*core.RoleList, error)
 	EditCluster(context.Context, *EditClusterRequest) (*core.Cluster, error)
+	SetClusterLimits(context.Context, *SetClusterLimitsRequest) (*core.Cluster, error)
+	GetClusterAnnotations(context.Context, *core.Reference) (*ClusterAnnotations, error)
+	GetClusterDiagnostics(context.Context, *core.Reference) (*ClusterDiagnostics, error)
+	SetClusterAnnotations(context.Context, *SetClusterAnnotationsRequest) (*core.Cluster, error)
+	RenameCluster(context.Context, *RenameClusterRequest) (*core.Cluster, error)
 	CreateRole(context.Context, *core.Role) (*emptypb.Empty, error)
 	DeleteRole(context.Context, *core.Reference) (*emptypb.Empty, error)
 	GetRole(context.Context, *core.Reference) (*core.Role, error)
@@ -325,6 +452,11 @@ type ManagementServer interface {
 	UpdateConfig(context.Context, *UpdateConfigRequest) (*emptypb.Empty, error)
 	ListCapabilities(context.Context, *emptypb.Empty) (*CapabilityList, error)
 	CapabilityInstaller(context.Context, *CapabilityInstallerRequest) (*CapabilityInstallerResponse, error)
+	RefreshCapabilities(context.Context, *emptypb.Empty) (*CapabilityList, error)
+	SetCapabilityEnabled(context.Context, *SetCapabilityEnabledRequest) (*core.ReferenceList, error)
+	DrainGateway(context.Context, *DrainGatewayRequest) (*DrainGatewayResponse, error)
+	ServerInfo(context.Context, *emptypb.Empty) (*ServerInfoResponse, error)
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
 	mustEmbedUnimplementedManagementServer()
 }
 
@@ -335,6 +467,9 @@ type UnimplementedManagementServer struct {
 func (UnimplementedManagementServer) CreateBootstrapToken(context.Context, *CreateBootstrapTokenRequest) (*core.BootstrapToken, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateBootstrapToken not implemented")
 }
+func (UnimplementedManagementServer) PreviewTokenEffect(context.Context, *PreviewTokenEffectRequest) (*core.Cluster, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewTokenEffect not implemented")
+}
 func (UnimplementedManagementServer) RevokeBootstrapToken(context.Context, *core.Reference) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RevokeBootstrapToken not implemented")
 }
@@ -359,9 +494,27 @@ func (UnimplementedManagementServer) CertsInfo(context.Context, *emptypb.Empty)
 func (UnimplementedManagementServer) GetCluster(context.Context, *core.Reference) (*core.Cluster, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCluster not implemented")
 }
+func (UnimplementedManagementServer) ListRolesForCluster(context.Context, *core.Reference) (*core.RoleList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRolesForCluster not implemented")
+}
 func (UnimplementedManagementServer) EditCluster(context.Context, *EditClusterRequest) (*core.Cluster, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EditCluster not implemented")
 }
+func (UnimplementedManagementServer) SetClusterLimits(context.Context, *SetClusterLimitsRequest) (*core.Cluster, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetClusterLimits not implemented")
+}
+func (UnimplementedManagementServer) GetClusterAnnotations(context.Context, *core.Reference) (*ClusterAnnotations, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClusterAnnotations not implemented")
+}
+func (UnimplementedManagementServer) GetClusterDiagnostics(context.Context, *core.Reference) (*ClusterDiagnostics, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClusterDiagnostics not implemented")
+}
+func (UnimplementedManagementServer) SetClusterAnnotations(context.Context, *SetClusterAnnotationsRequest) (*core.Cluster, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetClusterAnnotations not implemented")
+}
+func (UnimplementedManagementServer) RenameCluster(context.Context, *RenameClusterRequest) (*core.Cluster, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameCluster not implemented")
+}
 func (UnimplementedManagementServer) CreateRole(context.Context, *core.Role) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateRole not implemented")
 }
@@ -404,6 +557,21 @@ func (UnimplementedManagementServer) ListCapabilities(context.Context, *emptypb.
 func (UnimplementedManagementServer) CapabilityInstaller(context.Context, *CapabilityInstallerRequest) (*CapabilityInstallerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CapabilityInstaller not implemented")
 }
+func (UnimplementedManagementServer) RefreshCapabilities(context.Context, *emptypb.Empty) (*CapabilityList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshCapabilities not implemented")
+}
+func (UnimplementedManagementServer) SetCapabilityEnabled(context.Context, *SetCapabilityEnabledRequest) (*core.ReferenceList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCapabilityEnabled not implemented")
+}
+func (UnimplementedManagementServer) DrainGateway(context.Context, *DrainGatewayRequest) (*DrainGatewayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DrainGateway not implemented")
+}
+func (UnimplementedManagementServer) ServerInfo(context.Context, *emptypb.Empty) (*ServerInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ServerInfo not implemented")
+}
+func (UnimplementedManagementServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
 func (UnimplementedManagementServer) mustEmbedUnimplementedManagementServer() {}
 
 // UnsafeManagementServer may be embedded to opt out of forward compatibility for this service.
@@ -435,6 +603,24 @@ func _Management_CreateBootstrapToken_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Management_PreviewTokenEffect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewTokenEffectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).PreviewTokenEffect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/management.Management/PreviewTokenEffect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).PreviewTokenEffect(ctx, req.(*PreviewTokenEffectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Management_RevokeBootstrapToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(core.Reference)
 	if err := dec(in); err != nil {
@@ -582,6 +768,24 @@ func _Management_GetCluster_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Management_ListRolesForCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(core.Reference)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).ListRolesForCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/management.Management/ListRolesForCluster",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).ListRolesForCluster(ctx, req.(*core.Reference))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Management_EditCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(EditClusterRequest)
 	if err := dec(in); err != nil {
@@ -600,6 +804,96 @@ func _Management_EditCluster_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Management_SetClusterLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetClusterLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).SetClusterLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/management.Management/SetClusterLimits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).SetClusterLimits(ctx, req.(*SetClusterLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_GetClusterAnnotations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(core.Reference)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).GetClusterAnnotations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/management.Management/GetClusterAnnotations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).GetClusterAnnotations(ctx, req.(*core.Reference))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_GetClusterDiagnostics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(core.Reference)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).GetClusterDiagnostics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/management.Management/GetClusterDiagnostics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).GetClusterDiagnostics(ctx, req.(*core.Reference))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_SetClusterAnnotations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetClusterAnnotationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).SetClusterAnnotations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/management.Management/SetClusterAnnotations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).SetClusterAnnotations(ctx, req.(*SetClusterAnnotationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_RenameCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).RenameCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/management.Management/RenameCluster",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).RenameCluster(ctx, req.(*RenameClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Management_CreateRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(core.Role)
 	if err := dec(in); err != nil {
@@ -852,6 +1146,96 @@ func _Management_CapabilityInstaller_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Management_RefreshCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).RefreshCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/management.Management/RefreshCapabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).RefreshCapabilities(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_SetCapabilityEnabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCapabilityEnabledRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).SetCapabilityEnabled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/management.Management/SetCapabilityEnabled",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).SetCapabilityEnabled(ctx, req.(*SetCapabilityEnabledRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_DrainGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainGatewayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).DrainGateway(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/management.Management/DrainGateway",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).DrainGateway(ctx, req.(*DrainGatewayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_ServerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).ServerInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/management.Management/ServerInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).ServerInfo(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Management_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagementServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/management.Management/ListAuditEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagementServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Management_ServiceDesc is the grpc.ServiceDesc for Management service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -863,6 +1247,10 @@ var Management_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateBootstrapToken",
 			Handler:    _Management_CreateBootstrapToken_Handler,
 		},
+		{
+			MethodName: "PreviewTokenEffect",
+			Handler:    _Management_PreviewTokenEffect_Handler,
+		},
 		{
 			MethodName: "RevokeBootstrapToken",
 			Handler:    _Management_RevokeBootstrapToken_Handler,
@@ -891,10 +1279,34 @@ var Management_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetCluster",
 			Handler:    _Management_GetCluster_Handler,
 		},
+		{
+			MethodName: "ListRolesForCluster",
+			Handler:    _Management_ListRolesForCluster_Handler,
+		},
 		{
 			MethodName: "EditCluster",
 			Handler:    _Management_EditCluster_Handler,
 		},
+		{
+			MethodName: "SetClusterLimits",
+			Handler:    _Management_SetClusterLimits_Handler,
+		},
+		{
+			MethodName: "GetClusterAnnotations",
+			Handler:    _Management_GetClusterAnnotations_Handler,
+		},
+		{
+			MethodName: "GetClusterDiagnostics",
+			Handler:    _Management_GetClusterDiagnostics_Handler,
+		},
+		{
+			MethodName: "SetClusterAnnotations",
+			Handler:    _Management_SetClusterAnnotations_Handler,
+		},
+		{
+			MethodName: "RenameCluster",
+			Handler:    _Management_RenameCluster_Handler,
+		},
 		{
 			MethodName: "CreateRole",
 			Handler:    _Management_CreateRole_Handler,
@@ -951,6 +1363,26 @@ var Management_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CapabilityInstaller",
 			Handler:    _Management_CapabilityInstaller_Handler,
 		},
+		{
+			MethodName: "RefreshCapabilities",
+			Handler:    _Management_RefreshCapabilities_Handler,
+		},
+		{
+			MethodName: "SetCapabilityEnabled",
+			Handler:    _Management_SetCapabilityEnabled_Handler,
+		},
+		{
+			MethodName: "DrainGateway",
+			Handler:    _Management_DrainGateway_Handler,
+		},
+		{
+			MethodName: "ServerInfo",
+			Handler:    _Management_ServerInfo_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _Management_ListAuditEvents_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/storage"
 	"github.com/rancher/opni-monitoring/pkg/validation"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -12,6 +13,13 @@ func (s *Server) CreateRole(ctx context.Context, in *core.Role) (*emptypb.Empty,
 	if err := validation.Validate(in); err != nil {
 		return nil, err
 	}
+	selector := storage.ClusterSelector{
+		ClusterIDs:    in.ClusterIDs,
+		LabelSelector: in.MatchLabels,
+	}
+	if err := selector.Validate(); err != nil {
+		return nil, err
+	}
 	return &emptypb.Empty{}, s.coreDataSource.StorageBackend().CreateRole(ctx, in)
 }
 
@@ -72,3 +80,32 @@ func (s *Server) SubjectAccess(ctx context.Context, sar *core.SubjectAccessReque
 	rl, err := s.rbacProvider.SubjectAccess(ctx, sar)
 	return rl, err
 }
+
+// ListRolesForCluster returns all roles whose cluster IDs or label selector
+// match the given cluster. Roles are evaluated the same way as when
+// computing subject access, so an empty selector matches no clusters.
+func (s *Server) ListRolesForCluster(ctx context.Context, ref *core.Reference) (*core.RoleList, error) {
+	if err := validation.Validate(ref); err != nil {
+		return nil, err
+	}
+	cluster, err := s.coreDataSource.StorageBackend().GetCluster(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := s.coreDataSource.StorageBackend().ListRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	matching := &core.RoleList{}
+	for _, role := range roles.Items {
+		selector := storage.ClusterSelector{
+			ClusterIDs:    role.ClusterIDs,
+			LabelSelector: role.MatchLabels,
+			MatchOptions:  core.MatchOptions_EmptySelectorMatchesNone,
+		}
+		if selector.Predicate()(cluster) {
+			matching.Items = append(matching.Items, role)
+		}
+	}
+	return matching, nil
+}
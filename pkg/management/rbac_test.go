@@ -109,6 +109,78 @@ var _ = Describe("RBAC", Ordered, Label(test.Unit, test.Slow), func() {
 		}
 	})
 
+	Context("listing roles for a cluster", func() {
+		roleIDs := func(list *core.RoleList) []string {
+			ids := []string{}
+			for _, role := range list.Items {
+				ids = append(ids, role.Id)
+			}
+			return ids
+		}
+		It("should list only the roles whose selectors match the cluster", func() {
+			for _, cluster := range []*core.Cluster{
+				{
+					Id: "selected-1",
+					Metadata: &core.ClusterMetadata{
+						Labels: map[string]string{"env": "prod", "region": "eu"},
+					},
+				},
+				{
+					Id: "selected-2",
+					Metadata: &core.ClusterMetadata{
+						Labels: map[string]string{"env": "dev", "region": "us-east"},
+					},
+				},
+			} {
+				Expect(tv.storageBackend.CreateCluster(context.Background(), cluster)).To(Succeed())
+			}
+			for _, role := range []*core.Role{
+				{
+					Id:          "prod",
+					MatchLabels: &core.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				},
+				{
+					Id:          "dev",
+					MatchLabels: &core.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+				},
+				{
+					Id: "us",
+					MatchLabels: &core.LabelSelector{
+						MatchExpressions: []*core.LabelSelectorRequirement{
+							{
+								Key:      "region",
+								Operator: string(core.LabelSelectorOpIn),
+								Values:   []string{"us-east", "us-west"},
+							},
+						},
+					},
+				},
+				{
+					Id:         "explicit",
+					ClusterIDs: []string{"selected-1"},
+				},
+				{
+					Id: "empty",
+				},
+			} {
+				_, err := tv.client.CreateRole(context.Background(), role)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			roles, err := tv.client.ListRolesForCluster(context.Background(), &core.Reference{Id: "selected-1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roleIDs(roles)).To(ConsistOf("prod", "explicit"))
+
+			roles, err = tv.client.ListRolesForCluster(context.Background(), &core.Reference{Id: "selected-2"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roleIDs(roles)).To(ConsistOf("dev", "us"))
+		})
+		It("should return an error if the cluster does not exist", func() {
+			_, err := tv.client.ListRolesForCluster(context.Background(), &core.Reference{Id: "does-not-exist"})
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+	})
+
 	Context("error handling", func() {
 		When("creating a rolebinding with taints", func() {
 			It("should error indicating the field is read-only", func() {
@@ -123,5 +195,26 @@ var _ = Describe("RBAC", Ordered, Label(test.Unit, test.Slow), func() {
 				Expect(status.Convert(err).Message()).To(Equal(validation.ErrReadOnlyField.Error()))
 			})
 		})
+		When("creating a role with a malformed selector", func() {
+			It("should reject the role without storing it", func() {
+				role := &core.Role{
+					Id: "malformed-selector",
+					MatchLabels: &core.LabelSelector{
+						MatchExpressions: []*core.LabelSelectorRequirement{
+							{
+								Key:      "env",
+								Operator: string(core.LabelSelectorOpIn),
+							},
+						},
+					},
+				}
+				_, err := tv.client.CreateRole(context.Background(), role)
+				Expect(err).To(HaveOccurred())
+				Expect(status.Convert(err).Message()).To(ContainSubstring(validation.ErrMissingRequiredField.Error()))
+
+				_, err = tv.client.GetRole(context.Background(), role.Reference())
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+			})
+		})
 	})
 })
@@ -6,17 +6,21 @@ import (
 	"crypto/x509"
 	_ "embed"
 	"errors"
+	"fmt"
 	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/jhump/protoreflect/desc"
 	"github.com/kralicky/grpc-gateway/v2/runtime"
+	"github.com/rancher/opni-monitoring/pkg/auth"
 	"github.com/rancher/opni-monitoring/pkg/capabilities"
 	"github.com/rancher/opni-monitoring/pkg/config"
 	"github.com/rancher/opni-monitoring/pkg/config/meta"
 	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
 	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/labels"
 	"github.com/rancher/opni-monitoring/pkg/logger"
 	"github.com/rancher/opni-monitoring/pkg/pkp"
 	"github.com/rancher/opni-monitoring/pkg/plugins"
@@ -28,6 +32,7 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -53,6 +58,19 @@ type CoreDataSource interface {
 // server needs to serve capabilities-related endpoints
 type CapabilitiesDataSource interface {
 	CapabilitiesStore() capabilities.BackendStore
+	// RefreshCapabilities re-queries all capability backends and updates the
+	// contents of the capabilities store.
+	RefreshCapabilities(ctx context.Context) error
+}
+
+// DrainDataSource provides a way to drain the gateway before it is shut down
+type DrainDataSource interface {
+	// Drain stops the gateway from accepting new requests, then waits until
+	// all in-flight requests have completed or the context is done.
+	Drain(ctx context.Context) error
+	// InFlightRequests returns the number of requests currently being handled
+	// by the gateway.
+	InFlightRequests() int
 }
 
 type apiExtension struct {
@@ -72,6 +90,8 @@ type Server struct {
 	coreDataSource CoreDataSource
 
 	apiExtensions []apiExtension
+	// serializes cluster renames made through this server only
+	renameMu sync.Mutex
 }
 
 var _ ManagementServer = (*Server)(nil)
@@ -83,6 +103,15 @@ type ManagementServerOptions struct {
 	apiExtPlugins          []APIExtensionPlugin
 	systemPlugins          []plugins.ActivePlugin
 	capabilitiesDataSource CapabilitiesDataSource
+	drainDataSource        DrainDataSource
+	servingTLS             bool
+	httpAuthMiddleware     auth.NamedMiddleware
+	labelTemplates         *labels.Templates
+	auditLog               *storage.AuditLog
+	diagnosticsStore       *storage.DiagnosticsStore
+	// the first error encountered while applying options, returned by
+	// ListenAndServe
+	err error
 }
 
 type ManagementServerOption func(*ManagementServerOptions)
@@ -117,6 +146,49 @@ func WithCapabilitiesDataSource(src CapabilitiesDataSource) ManagementServerOpti
 	}
 }
 
+func WithDrainDataSource(src DrainDataSource) ManagementServerOption {
+	return func(o *ManagementServerOptions) {
+		o.drainDataSource = src
+	}
+}
+
+// WithServingTLS configures the gRPC server to serve TLS using the core data
+// source's TLS config. Clients can verify the server's identity using the
+// certificate fingerprints returned by CertsInfo.
+func WithServingTLS(enabled bool) ManagementServerOption {
+	return func(o *ManagementServerOptions) {
+		o.servingTLS = enabled
+	}
+}
+
+// WithHTTPAuthMiddleware configures the HTTP API to authenticate requests
+// using the named auth middleware. If name is empty, requests are not
+// authenticated. If the middleware does not exist, ListenAndServe returns
+// an error.
+func WithHTTPAuthMiddleware(name string) ManagementServerOption {
+	return func(o *ManagementServerOptions) {
+		if name == "" {
+			return
+		}
+		mw, err := auth.GetMiddleware(name)
+		if err != nil {
+			if o.err == nil {
+				o.err = fmt.Errorf("failed to configure HTTP auth middleware: %w", err)
+			}
+			return
+		}
+		o.httpAuthMiddleware = mw
+	}
+}
+
+// WithLabelTemplates configures templates for computed cluster labels, which
+// are re-evaluated when a cluster's labels are edited.
+func WithLabelTemplates(templates *labels.Templates) ManagementServerOption {
+	return func(o *ManagementServerOptions) {
+		o.labelTemplates = templates
+	}
+}
+
 func NewServer(
 	ctx context.Context,
 	conf *v1beta1.ManagementSpec,
@@ -129,6 +201,13 @@ func NewServer(
 	}
 	options.Apply(opts...)
 
+	if options.auditLog != nil {
+		cds = &auditDataSource{
+			CoreDataSource: cds,
+			log:            options.auditLog,
+		}
+	}
+
 	return &Server{
 		ManagementServerOptions: options,
 		ctx:                     ctx,
@@ -144,6 +223,9 @@ type managementApiServer interface {
 }
 
 func (m *Server) ListenAndServe() error {
+	if m.err != nil {
+		return m.err
+	}
 	if m.config.GRPCListenAddress == "" {
 		return errors.New("GRPCListenAddress not configured")
 	}
@@ -155,10 +237,15 @@ func (m *Server) ListenAndServe() error {
 	lg.With(
 		"address", listener.Addr().String(),
 	).Info("management gRPC server starting")
+	serverCreds, clientCreds, err := m.transportCredentials()
+	if err != nil {
+		return err
+	}
 	director := m.configureApiExtensionDirector(m.ctx)
 	srv := grpc.NewServer(
-		grpc.Creds(insecure.NewCredentials()),
+		grpc.Creds(serverCreds),
 		grpc.UnknownServiceHandler(unknownServiceHandler(director)),
+		grpc.UnaryInterceptor(auditActorInterceptor),
 	)
 	RegisterManagementServer(srv, m)
 
@@ -170,7 +257,7 @@ func (m *Server) ListenAndServe() error {
 		srv.GracefulStop()
 	})
 	if m.config.HTTPListenAddress != "" {
-		go m.listenAndServeHttp(listener)
+		go m.listenAndServeHttp(listener, clientCreds)
 	}
 
 	waitctx.AddOne(m.ctx)
@@ -178,7 +265,28 @@ func (m *Server) ListenAndServe() error {
 	return srv.Serve(listener)
 }
 
-func (m *Server) listenAndServeHttp(listener net.Listener) {
+// transportCredentials returns the credentials used by the gRPC server, and
+// the credentials the HTTP gateway should use to connect to it.
+func (m *Server) transportCredentials() (server, client credentials.TransportCredentials, _ error) {
+	if !m.servingTLS {
+		return insecure.NewCredentials(), insecure.NewCredentials(), nil
+	}
+	tlsConfig := m.coreDataSource.TLSConfig()
+	if tlsConfig == nil || len(tlsConfig.Certificates) == 0 {
+		return nil, nil, errors.New("TLS enabled, but no serving certificate is configured")
+	}
+	leaf, err := x509.ParseCertificate(tlsConfig.Certificates[0].Certificate[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	clientTLSConfig, err := pkp.TLSConfig([]*pkp.PublicKeyPin{pkp.NewSha256(leaf)})
+	if err != nil {
+		return nil, nil, err
+	}
+	return credentials.NewTLS(tlsConfig), credentials.NewTLS(clientTLSConfig), nil
+}
+
+func (m *Server) listenAndServeHttp(listener net.Listener, creds credentials.TransportCredentials) {
 	lg := m.logger
 	lg.With(
 		"address", m.config.HTTPListenAddress,
@@ -191,16 +299,20 @@ func (m *Server) listenAndServeHttp(listener net.Listener) {
 	})
 	gwmux := runtime.NewServeMux()
 	if err := RegisterManagementHandlerFromEndpoint(m.ctx, gwmux, listener.Addr().String(),
-		[]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}); err != nil {
+		[]grpc.DialOption{grpc.WithTransportCredentials(creds)}); err != nil {
 		lg.With(
 			zap.Error(err),
 		).Fatal("failed to register management handler")
 	}
 	m.configureHttpApiExtensions(gwmux)
 	mux.Handle("/", gwmux)
+	var handler http.Handler = mux
+	if m.httpAuthMiddleware != nil {
+		handler = auth.HTTPMiddleware(m.httpAuthMiddleware)(mux)
+	}
 	server := &http.Server{
 		Addr:    m.config.HTTPListenAddress,
-		Handler: mux,
+		Handler: handler,
 		BaseContext: func(net.Listener) context.Context {
 			return m.ctx
 		},
@@ -253,6 +365,19 @@ func (m *Server) ListCapabilities(ctx context.Context, _ *emptypb.Empty) (*Capab
 	}, nil
 }
 
+func (m *Server) RefreshCapabilities(ctx context.Context, _ *emptypb.Empty) (*CapabilityList, error) {
+	if m.capabilitiesDataSource == nil {
+		return nil, status.Error(codes.Unavailable, "capability backend store not configured")
+	}
+
+	if err := m.capabilitiesDataSource.RefreshCapabilities(ctx); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to refresh capabilities: %v", err)
+	}
+	return &CapabilityList{
+		Items: m.capabilitiesDataSource.CapabilitiesStore().List(),
+	}, nil
+}
+
 func (m *Server) CapabilityInstaller(
 	ctx context.Context,
 	req *CapabilityInstallerRequest,
@@ -5,9 +5,11 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/golang/mock/gomock"
 	"github.com/rancher/opni-monitoring/pkg/capabilities"
 	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
 	"github.com/rancher/opni-monitoring/pkg/management"
+	"github.com/rancher/opni-monitoring/pkg/plugins/apis/capability"
 	"github.com/rancher/opni-monitoring/pkg/test"
 	"github.com/rancher/opni-monitoring/pkg/util"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -17,13 +19,27 @@ import (
 )
 
 type testCapabilityDataSource struct {
-	store capabilities.BackendStore
+	store   capabilities.BackendStore
+	clients []capability.BackendClient
 }
 
 func (t testCapabilityDataSource) CapabilitiesStore() capabilities.BackendStore {
 	return t.store
 }
 
+func (t testCapabilityDataSource) RefreshCapabilities(ctx context.Context) error {
+	backends := map[string]capability.Backend{}
+	for _, client := range t.clients {
+		info, err := client.Info(ctx, &emptypb.Empty{})
+		if err != nil {
+			return err
+		}
+		backends[info.CapabilityName] = capabilities.NewBackend(client)
+	}
+	t.store.Replace(backends)
+	return nil
+}
+
 var _ = Describe("Server", Ordered, Label(test.Unit, test.Slow), func() {
 	var tv *testVars
 	var capBackendStore capabilities.BackendStore
@@ -106,3 +122,57 @@ var _ = Describe("Server", Ordered, Label(test.Unit, test.Slow), func() {
 		Expect(err).NotTo(HaveOccurred())
 	})
 })
+
+var _ = Describe("Refreshing Capabilities", Ordered, Label(test.Unit, test.Slow), func() {
+	var tv *testVars
+	var info *test.CapabilityInfo
+	BeforeAll(func() {
+		tv = &testVars{ctrl: gomock.NewController(GinkgoT())}
+		info = &test.CapabilityInfo{
+			Name:              "capability1",
+			CanInstall:        true,
+			InstallerTemplate: "foo",
+		}
+		setupManagementServer(&tv, management.WithCapabilitiesDataSource(testCapabilityDataSource{
+			store: capabilities.NewBackendStore(capabilities.ServerInstallerTemplateSpec{}, test.Log),
+			clients: []capability.BackendClient{
+				test.NewTestCapabilityBackendClient(tv.ctrl, info),
+			},
+		}))()
+	})
+	It("should load capabilities from the backends", func() {
+		list, err := tv.client.ListCapabilities(context.Background(), &emptypb.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.Items).To(BeEmpty())
+
+		list, err = tv.client.RefreshCapabilities(context.Background(), &emptypb.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.Items).To(ConsistOf("capability1"))
+	})
+	It("should pick up changes to backend metadata", func() {
+		info.Name = "capability2"
+		info.InstallerTemplate = "bar"
+
+		list, err := tv.client.ListCapabilities(context.Background(), &emptypb.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.Items).To(ConsistOf("capability1"))
+
+		list, err = tv.client.RefreshCapabilities(context.Background(), &emptypb.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.Items).To(ConsistOf("capability2"))
+
+		list, err = tv.client.ListCapabilities(context.Background(), &emptypb.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.Items).To(ConsistOf("capability2"))
+
+		_, err = tv.client.CapabilityInstaller(context.Background(), &management.CapabilityInstallerRequest{
+			Name: "capability1",
+		})
+		Expect(err).To(HaveOccurred())
+		cmd, err := tv.client.CapabilityInstaller(context.Background(), &management.CapabilityInstallerRequest{
+			Name: "capability2",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmd.Command).To(Equal("bar"))
+	})
+})
@@ -0,0 +1,93 @@
+package management_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/rancher/opni-monitoring/pkg/management"
+	"github.com/rancher/opni-monitoring/pkg/pkp"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("TLS", Ordered, Label(test.Unit, test.Slow), func() {
+	var tv *testVars
+	var leaf *x509.Certificate
+	BeforeAll(func() {
+		setupManagementServer(&tv, management.WithServingTLS(true))()
+		var err error
+		leaf, err = x509.ParseCertificate(tv.coreDataSource.TLSConfig().Certificates[0].Certificate[0])
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	certsInfo := func(opts ...management.ManagementClientOption) error {
+		ctx, ca := context.WithTimeout(context.Background(), 2*time.Second)
+		defer ca()
+		client, err := management.NewClient(ctx,
+			append([]management.ManagementClientOption{
+				management.WithListenAddress(tv.grpcEndpoint),
+				management.WithDialOptions(grpc.WithDefaultCallOptions(grpc.WaitForReady(true))),
+			}, opts...)...,
+		)
+		if err != nil {
+			return err
+		}
+		_, err = client.CertsInfo(ctx, &emptypb.Empty{})
+		return err
+	}
+
+	When("connecting with a valid pin", func() {
+		It("should succeed", func() {
+			Expect(certsInfo(management.WithPins([]*pkp.PublicKeyPin{pkp.NewSha256(leaf)}))).To(Succeed())
+		})
+	})
+	When("connecting with a valid TLS config", func() {
+		It("should succeed", func() {
+			pool := x509.NewCertPool()
+			pool.AddCert(leaf)
+			Expect(certsInfo(management.WithTLSConfig(&tls.Config{
+				RootCAs: pool,
+			}))).To(Succeed())
+		})
+	})
+	When("connecting with an invalid pin", func() {
+		It("should fail", func() {
+			block, _ := pem.Decode(test.TestData("example.com.crt"))
+			other, err := x509.ParseCertificate(block.Bytes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(certsInfo(management.WithPins([]*pkp.PublicKeyPin{pkp.NewSha256(other)}))).NotTo(Succeed())
+		})
+	})
+	When("connecting without TLS", func() {
+		It("should fail", func() {
+			Expect(certsInfo()).NotTo(Succeed())
+		})
+	})
+	When("both a TLS config and pins are given", func() {
+		It("should return an error", func() {
+			_, err := management.NewClient(context.Background(),
+				management.WithTLSConfig(&tls.Config{}),
+				management.WithPins([]*pkp.PublicKeyPin{pkp.NewSha256(leaf)}),
+			)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+	It("should connect the HTTP gateway to the gRPC server using TLS", func() {
+		Eventually(func() (int, error) {
+			resp, err := http.Get(tv.httpEndpoint + "/management/certs")
+			if err != nil {
+				return 0, err
+			}
+			defer resp.Body.Close()
+			return resp.StatusCode, nil
+		}).Should(Equal(http.StatusOK))
+	})
+})
@@ -2,12 +2,16 @@ package management
 
 import (
 	"context"
+	"errors"
 
+	"github.com/rancher/opni-monitoring/pkg/bootstrap"
+	"github.com/rancher/opni-monitoring/pkg/capabilities"
 	"github.com/rancher/opni-monitoring/pkg/core"
 	"github.com/rancher/opni-monitoring/pkg/storage"
 	"github.com/rancher/opni-monitoring/pkg/validation"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -21,6 +25,8 @@ func (m *Server) CreateBootstrapToken(
 	token, err := m.coreDataSource.StorageBackend().CreateToken(ctx, req.Ttl.AsDuration(),
 		storage.WithLabels(req.GetLabels()),
 		storage.WithCapabilities(req.GetCapabilities()),
+		storage.WithAllowedCapabilities(req.GetAllowedCapabilities()),
+		storage.WithMaxUsages(req.GetMaxUsages()),
 	)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
@@ -28,6 +34,70 @@ func (m *Server) CreateBootstrapToken(
 	return token, nil
 }
 
+// PreviewTokenEffect returns the state a cluster would be in after an agent
+// with the given ID bootstraps using a token with the given labels and
+// capabilities, and requests the given capability. Nothing is persisted.
+func (m *Server) PreviewTokenEffect(
+	ctx context.Context,
+	req *PreviewTokenEffectRequest,
+) (*core.Cluster, error) {
+	if err := validation.Validate(req); err != nil {
+		return nil, err
+	}
+	if m.capabilitiesDataSource != nil {
+		if err := m.capabilitiesDataSource.CapabilitiesStore().CanInstall(req.Capability); err != nil {
+			if errors.Is(err, capabilities.ErrUnknownCapability) {
+				return nil, status.Error(codes.NotFound, err.Error())
+			}
+			return nil, status.Errorf(codes.Unavailable, "capability cannot be installed: %v", err)
+		}
+	}
+	token := &core.BootstrapToken{
+		Metadata: &core.BootstrapTokenMetadata{
+			Labels:              req.GetLabels(),
+			Capabilities:        req.GetCapabilities(),
+			AllowedCapabilities: req.GetAllowedCapabilities(),
+		},
+	}
+	if !capabilities.Allows(token, req.Capability) {
+		return nil, status.Errorf(codes.PermissionDenied, "capability %s is not allowed by this token", req.Capability)
+	}
+	ref := &core.Reference{
+		Id: req.ClusterID,
+	}
+	shared := capabilities.SharedIdentityOf(token)
+	if shared != nil && shared.Id != req.ClusterID {
+		return nil, status.Errorf(codes.PermissionDenied, "token can only be used to bootstrap cluster %s", shared.Id)
+	}
+	existing, err := m.coreDataSource.StorageBackend().GetCluster(ctx, ref)
+	if err == nil {
+		if capabilities.Has(existing, capabilities.Cluster(req.Capability)) {
+			if shared != nil {
+				// the agent's keys would be added to the cluster's keyring,
+				// but the cluster itself is unchanged
+				return proto.Clone(existing).(*core.Cluster), nil
+			}
+			return nil, status.Error(codes.AlreadyExists, "capability is already installed on this cluster")
+		}
+		if shared == nil && !capabilities.Has(token, capabilities.JoinExistingCluster.For(ref)) {
+			return nil, status.Error(codes.PermissionDenied, "insufficient permissions for this cluster")
+		}
+		if !capabilities.Allows(existing, req.Capability) {
+			return nil, status.Errorf(codes.PermissionDenied, "capability %s is not allowed for this cluster", req.Capability)
+		}
+		preview := proto.Clone(existing).(*core.Cluster)
+		storage.NewAddCapabilityMutator[*core.Cluster](capabilities.Cluster(req.Capability))(preview)
+		return preview, nil
+	} else if !errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	cluster, err := bootstrap.NewCluster(req.ClusterID, req.Capability, token, m.labelTemplates)
+	if err != nil {
+		return nil, validation.Errorf("failed to compute cluster labels: %v", err)
+	}
+	return cluster, nil
+}
+
 func (m *Server) RevokeBootstrapToken(
 	ctx context.Context,
 	ref *core.Reference,
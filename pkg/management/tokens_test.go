@@ -6,8 +6,16 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/rancher/opni-monitoring/pkg/bootstrap"
+	"github.com/rancher/opni-monitoring/pkg/capabilities"
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/labels"
 	"github.com/rancher/opni-monitoring/pkg/management"
+	"github.com/rancher/opni-monitoring/pkg/storage"
 	"github.com/rancher/opni-monitoring/pkg/test"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -52,6 +60,26 @@ var _ = Describe("Tokens", Ordered, Label(test.Unit, test.Slow), func() {
 			leaseIds[token.Metadata.LeaseID] = struct{}{}
 		}
 	})
+	It("should create bootstrap tokens with allowed capabilities", func() {
+		token, err := tv.client.CreateBootstrapToken(context.Background(), &management.CreateBootstrapTokenRequest{
+			Ttl:                 durationpb.New(time.Minute),
+			AllowedCapabilities: []string{"foo", "bar"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token.GetAllowedCapabilities()).To(Equal([]string{"foo", "bar"}))
+		_, err = tv.client.RevokeBootstrapToken(context.Background(), token.Reference())
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("should create bootstrap tokens with a usage limit", func() {
+		token, err := tv.client.CreateBootstrapToken(context.Background(), &management.CreateBootstrapTokenRequest{
+			Ttl:       durationpb.New(time.Minute),
+			MaxUsages: 5,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token.GetMetadata().GetMaxUsages()).To(BeEquivalentTo(5))
+		_, err = tv.client.RevokeBootstrapToken(context.Background(), token.Reference())
+		Expect(err).NotTo(HaveOccurred())
+	})
 	It("should list bootstrap tokens", func() {
 		tokens, err := tv.client.ListBootstrapTokens(context.Background(), &emptypb.Empty{})
 		Expect(err).NotTo(HaveOccurred())
@@ -81,3 +109,175 @@ var _ = Describe("Tokens", Ordered, Label(test.Unit, test.Slow), func() {
 		}
 	})
 })
+
+var _ = Describe("Token Effect Preview", Ordered, Label(test.Unit, test.Slow), func() {
+	var tv *testVars
+	BeforeAll(func() {
+		templates, err := labels.ParseTemplates(map[string]string{
+			"tier": `{{ if eq (index .Labels "env") "prod" }}critical{{ else }}standard{{ end }}`,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		setupManagementServer(&tv, management.WithLabelTemplates(templates))()
+		Expect(tv.storageBackend.CreateCluster(context.Background(), &core.Cluster{
+			Id: "existing",
+			Metadata: &core.ClusterMetadata{
+				Labels: map[string]string{"env": "dev"},
+				Capabilities: []*core.ClusterCapability{
+					capabilities.Cluster("foo"),
+				},
+			},
+		})).To(Succeed())
+	})
+
+	When("the cluster does not exist", func() {
+		It("should preview the new cluster without creating it", func() {
+			cluster, err := tv.client.PreviewTokenEffect(context.Background(), &management.PreviewTokenEffectRequest{
+				Labels:     map[string]string{"env": "prod"},
+				ClusterID:  "new",
+				Capability: "bar",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cluster.GetId()).To(Equal("new"))
+			Expect(cluster.GetLabels()).To(Equal(map[string]string{
+				"env":  "prod",
+				"tier": "critical",
+			}))
+			Expect(capabilities.Has(cluster, capabilities.Cluster("bar"))).To(BeTrue())
+
+			_, err = tv.client.GetCluster(context.Background(), &core.Reference{Id: "new"})
+			Expect(err).To(HaveOccurred())
+		})
+		It("should match the cluster created during bootstrap", func() {
+			req := &management.PreviewTokenEffectRequest{
+				Labels:     map[string]string{"env": "dev", "foo": "bar"},
+				ClusterID:  "new",
+				Capability: "bar",
+			}
+			preview, err := tv.client.PreviewTokenEffect(context.Background(), req)
+			Expect(err).NotTo(HaveOccurred())
+
+			templates, err := labels.ParseTemplates(map[string]string{
+				"tier": `{{ if eq (index .Labels "env") "prod" }}critical{{ else }}standard{{ end }}`,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			token, err := tv.storageBackend.CreateToken(context.Background(), time.Minute,
+				storage.WithLabels(req.Labels),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			expected, err := bootstrap.NewCluster(req.ClusterID, req.Capability, token, templates)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(preview, expected)).To(BeTrue())
+		})
+	})
+	When("the cluster already exists", func() {
+		It("should require the token to be able to join the cluster", func() {
+			_, err := tv.client.PreviewTokenEffect(context.Background(), &management.PreviewTokenEffectRequest{
+				ClusterID:  "existing",
+				Capability: "bar",
+			})
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+		})
+		It("should preview the added capability without modifying the cluster", func() {
+			cluster, err := tv.client.PreviewTokenEffect(context.Background(), &management.PreviewTokenEffectRequest{
+				Labels: map[string]string{"env": "prod"},
+				Capabilities: []*core.TokenCapability{
+					capabilities.JoinExistingCluster.For(&core.Reference{Id: "existing"}),
+				},
+				ClusterID:  "existing",
+				Capability: "bar",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cluster.GetLabels()).To(Equal(map[string]string{"env": "dev"}))
+			Expect(capabilities.Has(cluster, capabilities.Cluster("foo"))).To(BeTrue())
+			Expect(capabilities.Has(cluster, capabilities.Cluster("bar"))).To(BeTrue())
+
+			stored, err := tv.client.GetCluster(context.Background(), &core.Reference{Id: "existing"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(capabilities.Has(stored, capabilities.Cluster("bar"))).To(BeFalse())
+		})
+		It("should reject capabilities which are already installed", func() {
+			_, err := tv.client.PreviewTokenEffect(context.Background(), &management.PreviewTokenEffectRequest{
+				Capabilities: []*core.TokenCapability{
+					capabilities.JoinExistingCluster.For(&core.Reference{Id: "existing"}),
+				},
+				ClusterID:  "existing",
+				Capability: "foo",
+			})
+			Expect(status.Code(err)).To(Equal(codes.AlreadyExists))
+		})
+	})
+	When("the token or cluster has allowed capabilities", func() {
+		BeforeAll(func() {
+			Expect(tv.storageBackend.CreateCluster(context.Background(), &core.Cluster{
+				Id: "restricted",
+				Metadata: &core.ClusterMetadata{
+					Capabilities: []*core.ClusterCapability{
+						capabilities.Cluster("foo"),
+					},
+					AllowedCapabilities: []string{"foo", "bar"},
+				},
+			})).To(Succeed())
+		})
+		It("should copy the token's allowlist to new clusters", func() {
+			cluster, err := tv.client.PreviewTokenEffect(context.Background(), &management.PreviewTokenEffectRequest{
+				ClusterID:           "new",
+				Capability:          "bar",
+				AllowedCapabilities: []string{"bar"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cluster.GetAllowedCapabilities()).To(Equal([]string{"bar"}))
+		})
+		It("should reject capabilities not allowed by the token", func() {
+			_, err := tv.client.PreviewTokenEffect(context.Background(), &management.PreviewTokenEffectRequest{
+				ClusterID:           "new",
+				Capability:          "bar",
+				AllowedCapabilities: []string{"foo"},
+			})
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+		})
+		It("should allow installing capabilities allowed by the cluster", func() {
+			cluster, err := tv.client.PreviewTokenEffect(context.Background(), &management.PreviewTokenEffectRequest{
+				Capabilities: []*core.TokenCapability{
+					capabilities.JoinExistingCluster.For(&core.Reference{Id: "restricted"}),
+				},
+				ClusterID:  "restricted",
+				Capability: "bar",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(capabilities.Has(cluster, capabilities.Cluster("bar"))).To(BeTrue())
+		})
+		It("should reject capabilities not allowed by the cluster", func() {
+			_, err := tv.client.PreviewTokenEffect(context.Background(), &management.PreviewTokenEffectRequest{
+				Capabilities: []*core.TokenCapability{
+					capabilities.JoinExistingCluster.For(&core.Reference{Id: "restricted"}),
+				},
+				ClusterID:  "restricted",
+				Capability: "baz",
+			})
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+		})
+	})
+	When("the token has a shared identity", func() {
+		sharedIdentity := []*core.TokenCapability{
+			capabilities.SharedIdentity.For(&core.Reference{Id: "existing"}),
+		}
+		It("should allow joining the shared cluster if it already has the capability", func() {
+			cluster, err := tv.client.PreviewTokenEffect(context.Background(), &management.PreviewTokenEffectRequest{
+				Capabilities: sharedIdentity,
+				ClusterID:    "existing",
+				Capability:   "foo",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cluster.GetId()).To(Equal("existing"))
+			Expect(cluster.GetCapabilities()).To(HaveLen(1))
+		})
+		It("should reject other cluster IDs", func() {
+			_, err := tv.client.PreviewTokenEffect(context.Background(), &management.PreviewTokenEffectRequest{
+				Capabilities: sharedIdentity,
+				ClusterID:    "other",
+				Capability:   "foo",
+			})
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+		})
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/rancher/opni-monitoring/pkg/capabilities"
 	"github.com/rancher/opni-monitoring/pkg/validation"
 )
 
@@ -21,6 +22,53 @@ func (r *CreateBootstrapTokenRequest) Validate() error {
 		if err := validation.Validate(cap); err != nil {
 			return err
 		}
+		if cap.GetType() == string(capabilities.SharedIdentity) && cap.GetReference() == nil {
+			return fmt.Errorf("%w: %s", validation.ErrMissingRequiredField, "shared identity reference")
+		}
+	}
+	if err := validateAllowedCapabilities(r.GetAllowedCapabilities()); err != nil {
+		return err
+	}
+	if r.GetMaxUsages() < 0 {
+		return fmt.Errorf("%w: %s", validation.ErrInvalidValue, "maxUsages cannot be negative")
+	}
+	return nil
+}
+
+func validateAllowedCapabilities(names []string) error {
+	seen := map[string]struct{}{}
+	for _, name := range names {
+		if name == "" {
+			return fmt.Errorf("%w: %s", validation.ErrInvalidValue, "allowed capability names cannot be empty")
+		}
+		if _, ok := seen[name]; ok {
+			return fmt.Errorf("%w: duplicate allowed capability %q", validation.ErrInvalidValue, name)
+		}
+		seen[name] = struct{}{}
+	}
+	return nil
+}
+
+func (r *PreviewTokenEffectRequest) Validate() error {
+	if err := validation.ValidateLabels(r.GetLabels()); err != nil {
+		return err
+	}
+	for _, cap := range r.GetCapabilities() {
+		if err := validation.Validate(cap); err != nil {
+			return err
+		}
+	}
+	if r.ClusterID == "" {
+		return fmt.Errorf("%w: %s", validation.ErrMissingRequiredField, "clusterID")
+	}
+	if err := validation.ValidateID(r.ClusterID); err != nil {
+		return err
+	}
+	if r.Capability == "" {
+		return fmt.Errorf("%w: %s", validation.ErrMissingRequiredField, "capability")
+	}
+	if err := validateAllowedCapabilities(r.GetAllowedCapabilities()); err != nil {
+		return err
 	}
 	return nil
 }
@@ -37,6 +85,80 @@ func (r *ListClustersRequest) Validate() error {
 	return nil
 }
 
+func (r *SetCapabilityEnabledRequest) Validate() error {
+	if r.Capability == "" {
+		return fmt.Errorf("%w: %s", validation.ErrMissingRequiredField, "capability")
+	}
+	if r.MatchLabels != nil {
+		if err := validation.Validate(r.MatchLabels); err != nil {
+			return err
+		}
+	}
+	if err := validation.Validate(r.MatchOptions); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *DrainGatewayRequest) Validate() error {
+	if r.Timeout != nil {
+		if err := r.Timeout.CheckValid(); err != nil {
+			return fmt.Errorf("%w (timeout): %s", validation.ErrInvalidValue, err.Error())
+		}
+		if r.Timeout.AsDuration() < 0 {
+			return fmt.Errorf("%w: %s", validation.ErrInvalidValue, "timeout cannot be negative")
+		}
+	}
+	return nil
+}
+
+func (r *SetClusterAnnotationsRequest) Validate() error {
+	if r.Cluster == nil {
+		return fmt.Errorf("%w: %s", validation.ErrMissingRequiredField, "cluster")
+	}
+	if err := validation.Validate(r.Cluster); err != nil {
+		return err
+	}
+	if err := validation.ValidateAnnotations(r.GetAnnotations()); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *SetClusterLimitsRequest) Validate() error {
+	if r.Cluster == nil {
+		return fmt.Errorf("%w: %s", validation.ErrMissingRequiredField, "cluster")
+	}
+	if err := validation.Validate(r.Cluster); err != nil {
+		return err
+	}
+	if r.Limits != nil {
+		if err := validation.Validate(r.Limits); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *RenameClusterRequest) Validate() error {
+	if r.Cluster == nil {
+		return fmt.Errorf("%w: %s", validation.ErrMissingRequiredField, "cluster")
+	}
+	if err := validation.Validate(r.Cluster); err != nil {
+		return err
+	}
+	if r.NewID == "" {
+		return fmt.Errorf("%w: %s", validation.ErrMissingRequiredField, "newID")
+	}
+	if err := validation.ValidateID(r.NewID); err != nil {
+		return err
+	}
+	if r.NewID == r.Cluster.Id {
+		return fmt.Errorf("%w: %s", validation.ErrInvalidValue, "newID must be different from the current ID")
+	}
+	return nil
+}
+
 func (r *EditClusterRequest) Validate() error {
 	if r.Cluster == nil {
 		return fmt.Errorf("%w: %s", validation.ErrMissingRequiredField, "cluster")
@@ -70,3 +192,16 @@ func (r *UpdateConfigRequest) Validate() error {
 	}
 	return nil
 }
+
+func (r *ListAuditEventsRequest) Validate() error {
+	if r.StartTime < 0 || r.EndTime < 0 {
+		return fmt.Errorf("%w: %s", validation.ErrInvalidValue, "time range cannot be negative")
+	}
+	if r.StartTime != 0 && r.EndTime != 0 && r.EndTime <= r.StartTime {
+		return fmt.Errorf("%w: %s", validation.ErrInvalidValue, "end time must be after start time")
+	}
+	if r.Limit < 0 || r.Limit > maxAuditEventsLimit {
+		return fmt.Errorf("%w: limit must be between 0 and %d", validation.ErrInvalidValue, maxAuditEventsLimit)
+	}
+	return nil
+}
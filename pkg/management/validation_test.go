@@ -1,6 +1,9 @@
 package management_test
 
 import (
+	"math"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -43,6 +46,64 @@ var _ = Describe("Validation", func() {
 				},
 			},
 		}, validation.ErrInvalidID),
+		Entry(nil, &management.CreateBootstrapTokenRequest{
+			Ttl: durationpb.New(1),
+			Capabilities: []*core.TokenCapability{
+				{
+					Type: "shared_identity",
+				},
+			},
+		}, validation.ErrMissingRequiredField),
+	)
+	DescribeTable("CreateBootstrapTokenRequest allowed capabilities",
+		validateEntry[*management.CreateBootstrapTokenRequest],
+		Entry(nil, &management.CreateBootstrapTokenRequest{
+			Ttl:                 durationpb.New(time.Minute),
+			AllowedCapabilities: []string{"foo", "bar"},
+		}, nil),
+		Entry(nil, &management.CreateBootstrapTokenRequest{
+			Ttl:                 durationpb.New(time.Minute),
+			AllowedCapabilities: []string{""},
+		}, validation.ErrInvalidValue),
+		Entry(nil, &management.CreateBootstrapTokenRequest{
+			Ttl:                 durationpb.New(time.Minute),
+			AllowedCapabilities: []string{"foo", "foo"},
+		}, validation.ErrInvalidValue),
+	)
+	DescribeTable("CreateBootstrapTokenRequest max usages",
+		validateEntry[*management.CreateBootstrapTokenRequest],
+		Entry(nil, &management.CreateBootstrapTokenRequest{
+			Ttl:       durationpb.New(time.Minute),
+			MaxUsages: 5,
+		}, nil),
+		Entry(nil, &management.CreateBootstrapTokenRequest{
+			Ttl:       durationpb.New(time.Minute),
+			MaxUsages: -1,
+		}, validation.ErrInvalidValue),
+	)
+	DescribeTable("PreviewTokenEffectRequest",
+		validateEntry[*management.PreviewTokenEffectRequest],
+		Entry(nil, &management.PreviewTokenEffectRequest{}, validation.ErrMissingRequiredField),
+		Entry(nil, &management.PreviewTokenEffectRequest{
+			ClusterID: "\\",
+		}, validation.ErrInvalidID),
+		Entry(nil, &management.PreviewTokenEffectRequest{
+			ClusterID: "foo",
+		}, validation.ErrMissingRequiredField),
+		Entry(nil, &management.PreviewTokenEffectRequest{
+			ClusterID:  "foo",
+			Capability: "test",
+			Labels: map[string]string{
+				"\\": "bar",
+			},
+		}, validation.ErrInvalidLabelName),
+		Entry(nil, &management.PreviewTokenEffectRequest{
+			ClusterID:  "foo",
+			Capability: "test",
+			Labels: map[string]string{
+				"foo": "bar",
+			},
+		}, nil),
 	)
 	DescribeTable("ListClustersRequest",
 		validateEntry[*management.ListClustersRequest],
@@ -71,6 +132,76 @@ var _ = Describe("Validation", func() {
 			MatchOptions: core.MatchOptions_EmptySelectorMatchesNone,
 		}, nil),
 	)
+	DescribeTable("SetClusterAnnotationsRequest",
+		validateEntry[*management.SetClusterAnnotationsRequest],
+		Entry(nil, &management.SetClusterAnnotationsRequest{}, validation.ErrMissingRequiredField),
+		Entry(nil, &management.SetClusterAnnotationsRequest{
+			Cluster: &core.Reference{Id: "foo"},
+			Annotations: map[string]string{
+				"\\": "bar",
+			},
+		}, validation.ErrInvalidLabelName),
+		Entry(nil, &management.SetClusterAnnotationsRequest{
+			Cluster: &core.Reference{Id: "foo"},
+			Annotations: map[string]string{
+				"owner": "someone@example.com",
+			},
+		}, nil),
+	)
+	DescribeTable("SetClusterLimitsRequest",
+		validateEntry[*management.SetClusterLimitsRequest],
+		Entry(nil, &management.SetClusterLimitsRequest{}, validation.ErrMissingRequiredField),
+		Entry(nil, &management.SetClusterLimitsRequest{
+			Cluster: &core.Reference{Id: "foo"},
+			Limits: &core.ClusterLimits{
+				IngestionRate: -1,
+			},
+		}, validation.ErrInvalidValue),
+		Entry(nil, &management.SetClusterLimitsRequest{
+			Cluster: &core.Reference{Id: "foo"},
+			Limits: &core.ClusterLimits{
+				IngestionRate: math.Inf(1),
+			},
+		}, validation.ErrInvalidValue),
+		Entry(nil, &management.SetClusterLimitsRequest{
+			Cluster: &core.Reference{Id: "foo"},
+			Limits: &core.ClusterLimits{
+				MaxSeries: -1,
+			},
+		}, validation.ErrInvalidValue),
+		Entry(nil, &management.SetClusterLimitsRequest{
+			Cluster: &core.Reference{Id: "foo"},
+			Limits: &core.ClusterLimits{
+				RetentionSeconds: -1,
+			},
+		}, validation.ErrInvalidValue),
+		Entry(nil, &management.SetClusterLimitsRequest{
+			Cluster: &core.Reference{Id: "foo"},
+			Limits: &core.ClusterLimits{
+				MaxSampleAgeSeconds: -1,
+			},
+		}, validation.ErrInvalidValue),
+		Entry(nil, &management.SetClusterLimitsRequest{
+			Cluster: &core.Reference{Id: "foo"},
+			Limits: &core.ClusterLimits{
+				MaxSampleFutureSeconds: -1,
+			},
+		}, validation.ErrInvalidValue),
+		Entry(nil, &management.SetClusterLimitsRequest{
+			Cluster: &core.Reference{Id: "foo"},
+			Limits: &core.ClusterLimits{
+				IngestionRate:          100,
+				IngestionBurstSize:     1000,
+				MaxSeries:              1000,
+				RetentionSeconds:       3600,
+				MaxSampleAgeSeconds:    3600,
+				MaxSampleFutureSeconds: 600,
+			},
+		}, nil),
+		Entry(nil, &management.SetClusterLimitsRequest{
+			Cluster: &core.Reference{Id: "foo"},
+		}, nil),
+	)
 	DescribeTable("EditClusterRequest",
 		validateEntry[*management.EditClusterRequest],
 		Entry(nil, &management.EditClusterRequest{}, validation.ErrMissingRequiredField),
@@ -96,6 +227,25 @@ var _ = Describe("Validation", func() {
 			},
 		}, nil),
 	)
+	DescribeTable("RenameClusterRequest",
+		validateEntry[*management.RenameClusterRequest],
+		Entry(nil, &management.RenameClusterRequest{}, validation.ErrMissingRequiredField),
+		Entry(nil, &management.RenameClusterRequest{
+			Cluster: &core.Reference{Id: "foo"},
+		}, validation.ErrMissingRequiredField),
+		Entry(nil, &management.RenameClusterRequest{
+			Cluster: &core.Reference{Id: "foo"},
+			NewID:   "\\",
+		}, validation.ErrInvalidID),
+		Entry(nil, &management.RenameClusterRequest{
+			Cluster: &core.Reference{Id: "foo"},
+			NewID:   "foo",
+		}, validation.ErrInvalidValue),
+		Entry(nil, &management.RenameClusterRequest{
+			Cluster: &core.Reference{Id: "foo"},
+			NewID:   "bar",
+		}, nil),
+	)
 	DescribeTable("WatchClustersRequest",
 		validateEntry[*management.WatchClustersRequest],
 		Entry(nil, &management.WatchClustersRequest{}, nil),
@@ -136,4 +286,24 @@ var _ = Describe("Validation", func() {
 			},
 		}, nil),
 	)
+	DescribeTable("DrainGatewayRequest",
+		validateEntry[*management.DrainGatewayRequest],
+		Entry(nil, &management.DrainGatewayRequest{}, nil),
+		Entry(nil, &management.DrainGatewayRequest{Timeout: durationpb.New(0)}, nil),
+		Entry(nil, &management.DrainGatewayRequest{Timeout: durationpb.New(time.Minute)}, nil),
+		Entry(nil, &management.DrainGatewayRequest{Timeout: durationpb.New(-time.Minute)}, validation.ErrInvalidValue),
+		Entry(nil, &management.DrainGatewayRequest{Timeout: &durationpb.Duration{Seconds: 1, Nanos: -1}}, validation.ErrInvalidValue),
+	)
+	DescribeTable("ListAuditEventsRequest",
+		validateEntry[*management.ListAuditEventsRequest],
+		Entry(nil, &management.ListAuditEventsRequest{}, nil),
+		Entry(nil, &management.ListAuditEventsRequest{StartTime: 100, EndTime: 200, Limit: 10}, nil),
+		Entry(nil, &management.ListAuditEventsRequest{StartTime: 100}, nil),
+		Entry(nil, &management.ListAuditEventsRequest{EndTime: 100}, nil),
+		Entry(nil, &management.ListAuditEventsRequest{StartTime: -1}, validation.ErrInvalidValue),
+		Entry(nil, &management.ListAuditEventsRequest{StartTime: 200, EndTime: 100}, validation.ErrInvalidValue),
+		Entry(nil, &management.ListAuditEventsRequest{StartTime: 100, EndTime: 100}, validation.ErrInvalidValue),
+		Entry(nil, &management.ListAuditEventsRequest{Limit: -1}, validation.ErrInvalidValue),
+		Entry(nil, &management.ListAuditEventsRequest{Limit: 1001}, validation.ErrInvalidValue),
+	)
 })
@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/rancher/opni-monitoring/pkg/core"
 	"github.com/rancher/opni-monitoring/pkg/management"
@@ -21,7 +22,10 @@ func BuildClustersCmd() *cobra.Command {
 	}
 	clustersCmd.AddCommand(BuildClustersListCmd())
 	clustersCmd.AddCommand(BuildClustersDeleteCmd())
+	clustersCmd.AddCommand(BuildClustersRenameCmd())
 	clustersCmd.AddCommand(BuildClustersLabelCmd())
+	clustersCmd.AddCommand(BuildClustersRolesCmd())
+	clustersCmd.AddCommand(BuildClustersLimitsCmd())
 	ConfigureManagementCommand(clustersCmd)
 	return clustersCmd
 }
@@ -75,6 +79,49 @@ func BuildClustersDeleteCmd() *cobra.Command {
 	}
 }
 
+func BuildClustersRenameCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rename <cluster-id> <new-id>",
+		Short: "Move a cluster to a new ID",
+		Long: "Move a cluster's labels, annotations, capabilities, and keyring to a new ID.\n" +
+			"Metrics stored under the old ID are not migrated, and agents must be\n" +
+			"reconfigured to use the new ID.",
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			_, err := client.RenameCluster(cmd.Context(), &management.RenameClusterRequest{
+				Cluster: &core.Reference{
+					Id: args[0],
+				},
+				NewID: args[1],
+			})
+			if err != nil {
+				lg.Fatal(err)
+			}
+			lg.With(
+				"id", args[0],
+				"newID", args[1],
+			).Info("Renamed cluster")
+		},
+	}
+}
+
+func BuildClustersRolesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "roles <cluster-id>",
+		Short: "List roles whose selectors match a cluster",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			roles, err := client.ListRolesForCluster(cmd.Context(), &core.Reference{
+				Id: args[0],
+			})
+			if err != nil {
+				lg.Fatal(err)
+			}
+			fmt.Println(cliutil.RenderRoleList(roles))
+		},
+	}
+}
+
 func BuildClustersLabelCmd() *cobra.Command {
 	overwrite := false
 	cmd := &cobra.Command{
@@ -136,3 +183,44 @@ func BuildClustersLabelCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&overwrite, "overwrite", false, "Enable overwriting existing label values")
 	return cmd
 }
+
+func BuildClustersLimitsCmd() *cobra.Command {
+	var clear bool
+	limits := &core.ClusterLimits{}
+	var retention, maxSampleAge, maxSampleFuture time.Duration
+	cmd := &cobra.Command{
+		Use:   "limits <cluster-id>",
+		Short: "Set ingestion and retention limits for a cluster",
+		Long: "Set ingestion and retention limits for a cluster, replacing any existing\n" +
+			"limits. Limits which are not set use the Cortex defaults.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			req := &management.SetClusterLimitsRequest{
+				Cluster: &core.Reference{
+					Id: args[0],
+				},
+			}
+			if !clear {
+				limits.RetentionSeconds = int64(retention.Seconds())
+				limits.MaxSampleAgeSeconds = int64(maxSampleAge.Seconds())
+				limits.MaxSampleFutureSeconds = int64(maxSampleFuture.Seconds())
+				req.Limits = limits
+			}
+			_, err := client.SetClusterLimits(cmd.Context(), req)
+			if err != nil {
+				lg.Fatal(err)
+			}
+			lg.With(
+				"id", args[0],
+			).Info("Cluster limits updated")
+		},
+	}
+	cmd.Flags().BoolVar(&clear, "clear", false, "Remove all limits from the cluster")
+	cmd.Flags().Float64Var(&limits.IngestionRate, "ingestion-rate", 0, "Maximum ingestion rate, in samples per second")
+	cmd.Flags().Int64Var(&limits.IngestionBurstSize, "ingestion-burst-size", 0, "Maximum number of samples ingested in a single burst")
+	cmd.Flags().Int64Var(&limits.MaxSeries, "max-series", 0, "Maximum number of active series")
+	cmd.Flags().DurationVar(&retention, "retention", 0, "How long samples are retained")
+	cmd.Flags().DurationVar(&maxSampleAge, "max-sample-age", 0, "Reject remote-write samples older than this")
+	cmd.Flags().DurationVar(&maxSampleFuture, "max-sample-future", 0, "Reject remote-write samples this far in the future")
+	return cmd
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/rancher/opni-monitoring/pkg/config"
 	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
 	"github.com/rancher/opni-monitoring/pkg/gateway"
+	"github.com/rancher/opni-monitoring/pkg/labels"
 	"github.com/rancher/opni-monitoring/pkg/logger"
 	"github.com/rancher/opni-monitoring/pkg/machinery"
 	"github.com/rancher/opni-monitoring/pkg/management"
@@ -82,11 +83,23 @@ func BuildGatewayCmd() *cobra.Command {
 			),
 		)
 
+		labelTemplates, err := labels.ParseTemplates(gatewayConfig.Spec.LabelTemplates)
+		if err != nil {
+			lg.With(
+				zap.Error(err),
+			).Fatal("failed to parse label templates")
+		}
+
 		m := management.NewServer(ctx, &gatewayConfig.Spec.Management, g,
 			management.WithCapabilitiesDataSource(g),
+			management.WithDrainDataSource(g),
 			management.WithSystemPlugins(systemPlugins),
 			management.WithAPIExtensions(mgmtExtensionPlugins),
 			management.WithLifecycler(lifecycler),
+			management.WithHTTPAuthMiddleware(gatewayConfig.Spec.RouteAuthProviders.ManagementAPI),
+			management.WithLabelTemplates(labelTemplates),
+			management.WithAuditLog(g.AuditLog()),
+			management.WithDiagnosticsStore(g.DiagnosticsStore()),
 		)
 
 		g.MustRegisterCollector(m)
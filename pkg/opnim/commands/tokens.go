@@ -28,6 +28,8 @@ func BuildTokensCmd() *cobra.Command {
 func BuildTokensCreateCmd() *cobra.Command {
 	var ttl string
 	var labels []string
+	var allowedCapabilities []string
+	var maxUsages int64
 	tokensCreateCmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a bootstrap token",
@@ -42,8 +44,10 @@ func BuildTokensCreateCmd() *cobra.Command {
 			}
 			t, err := client.CreateBootstrapToken(cmd.Context(),
 				&management.CreateBootstrapTokenRequest{
-					Ttl:    durationpb.New(duration),
-					Labels: labelMap,
+					Ttl:                 durationpb.New(duration),
+					Labels:              labelMap,
+					AllowedCapabilities: allowedCapabilities,
+					MaxUsages:           maxUsages,
 				})
 			if err != nil {
 				lg.Fatal(err)
@@ -53,6 +57,8 @@ func BuildTokensCreateCmd() *cobra.Command {
 	}
 	tokensCreateCmd.Flags().StringVar(&ttl, "ttl", "300s", "Time to live")
 	tokensCreateCmd.Flags().StringSliceVar(&labels, "labels", []string{}, "Labels which will be auto-applied to any clusters created with this token")
+	tokensCreateCmd.Flags().StringSliceVar(&allowedCapabilities, "allowed-capabilities", []string{}, "If set, clusters created with this token can only ever have these capabilities installed")
+	tokensCreateCmd.Flags().Int64Var(&maxUsages, "max-usages", 0, "Maximum number of times the token can be used, or 0 for no limit")
 	return tokensCreateCmd
 }
 
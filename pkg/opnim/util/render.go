@@ -30,11 +30,15 @@ func RenderBootstrapTokenList(list *core.BootstrapTokenList) string {
 			return err.Error()
 		}
 
+		usages := fmt.Sprint(t.GetMetadata().GetUsageCount())
+		if limit := t.GetMetadata().GetMaxUsages(); limit > 0 {
+			usages += fmt.Sprintf("/%d", limit)
+		}
 		w.AppendRow(table.Row{
 			token.HexID(),
 			token.EncodeHex(),
 			(time.Duration(t.GetMetadata().GetTtl()) * time.Second).String(),
-			t.GetMetadata().GetUsageCount(),
+			usages,
 			strings.Join(JoinKeyValuePairs(t.GetMetadata().GetLabels()), "\n"),
 		})
 	}
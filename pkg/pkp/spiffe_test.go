@@ -0,0 +1,130 @@
+package pkp_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/pkp"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+func newSVID(uris ...string) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "gateway"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	for _, uri := range uris {
+		u, err := url.Parse(uri)
+		Expect(err).NotTo(HaveOccurred())
+		template.URIs = append(template.URIs, u)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	Expect(err).NotTo(HaveOccurred())
+	cert, err := x509.ParseCertificate(der)
+	Expect(err).NotTo(HaveOccurred())
+	return cert
+}
+
+var _ = Describe("SPIFFE ID Verification", Label(test.Unit), func() {
+	const spiffeID = "spiffe://example.org/gateway"
+
+	verify := func(cert *x509.Certificate, opts ...pkp.TLSConfigOption) error {
+		tlsConfig, err := pkp.TLSConfig([]*pkp.PublicKeyPin{pkp.NewSha256(cert)}, opts...)
+		Expect(err).NotTo(HaveOccurred())
+		return tlsConfig.VerifyConnection(tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{cert},
+		})
+	}
+
+	When("the peer certificate has a matching SPIFFE ID", func() {
+		It("should verify the connection", func() {
+			Expect(verify(newSVID(spiffeID), pkp.WithSPIFFEID(spiffeID))).To(Succeed())
+		})
+	})
+	When("the peer certificate has a mismatching SPIFFE ID", func() {
+		It("should fail to verify the connection", func() {
+			Expect(verify(newSVID("spiffe://example.org/other"), pkp.WithSPIFFEID(spiffeID))).
+				To(MatchError(pkp.ErrSPIFFEIDMismatch))
+			Expect(verify(newSVID("spiffe://other.org/gateway"), pkp.WithSPIFFEID(spiffeID))).
+				To(MatchError(pkp.ErrSPIFFEIDMismatch))
+		})
+	})
+	When("the peer certificate has no SPIFFE ID", func() {
+		It("should fail to verify the connection", func() {
+			Expect(verify(newSVID(), pkp.WithSPIFFEID(spiffeID))).
+				To(MatchError(pkp.ErrSPIFFEIDMismatch))
+		})
+	})
+	When("the peer certificate has multiple URI SANs", func() {
+		It("should fail to verify the connection", func() {
+			Expect(verify(newSVID(spiffeID, "spiffe://example.org/other"), pkp.WithSPIFFEID(spiffeID))).
+				To(MatchError(pkp.ErrSPIFFEIDMismatch))
+		})
+	})
+	When("no SPIFFE ID is expected", func() {
+		It("should only check pins", func() {
+			Expect(verify(newSVID("spiffe://example.org/other"))).To(Succeed())
+		})
+	})
+	When("the SPIFFE ID matches but the pins do not", func() {
+		It("should fail to verify the connection", func() {
+			tlsConfig, err := pkp.TLSConfig([]*pkp.PublicKeyPin{pkp.NewSha256(newSVID())}, pkp.WithSPIFFEID(spiffeID))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tlsConfig.VerifyConnection(tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{newSVID(spiffeID)},
+			})).To(MatchError(pkp.ErrCertValidationFailed))
+		})
+	})
+	When("using root CAs instead of pins", func() {
+		It("should verify the chain and the SPIFFE ID", func() {
+			cert := newSVID(spiffeID)
+			pool := x509.NewCertPool()
+			pool.AddCert(cert)
+			tlsConfig, err := pkp.TLSConfig(nil, pkp.WithSPIFFEID(spiffeID), pkp.WithRootCAs(pool))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tlsConfig.VerifyConnection(tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{cert},
+			})).To(Succeed())
+
+			By("checking that an untrusted certificate is rejected")
+			Expect(tlsConfig.VerifyConnection(tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{newSVID(spiffeID)},
+			})).To(MatchError(pkp.ErrCertValidationFailed))
+
+			By("checking that a SPIFFE ID is required")
+			_, err = pkp.TLSConfig(nil, pkp.WithRootCAs(pool))
+			Expect(err).To(MatchError(pkp.ErrNoPins))
+		})
+	})
+	When("the expected SPIFFE ID is invalid", func() {
+		It("should return an error", func() {
+			for _, id := range []string{
+				"https://example.org/gateway",
+				"spiffe:///gateway",
+				"spiffe://example.org/gateway?foo=bar",
+				"spiffe://user@example.org/gateway",
+			} {
+				_, err := pkp.TLSConfig([]*pkp.PublicKeyPin{pkp.NewSha256(newSVID())}, pkp.WithSPIFFEID(id))
+				Expect(err).To(MatchError(pkp.ErrInvalidSPIFFEID), id)
+			}
+		})
+	})
+})
@@ -2,17 +2,65 @@ package pkp
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"net/url"
 )
 
 var (
 	ErrNoPins               = errors.New("no pins provided")
 	ErrCertValidationFailed = errors.New("peer certificate validation failed")
+	ErrInvalidSPIFFEID      = errors.New("invalid SPIFFE ID")
+	ErrSPIFFEIDMismatch     = errors.New("peer certificate does not match the expected SPIFFE ID")
 )
 
-func TLSConfig(pins []*PublicKeyPin) (*tls.Config, error) {
-	if len(pins) == 0 {
+type TLSConfigOptions struct {
+	spiffeID string
+	rootCAs  *x509.CertPool
+}
+
+type TLSConfigOption func(*TLSConfigOptions)
+
+func (o *TLSConfigOptions) Apply(opts ...TLSConfigOption) {
+	for _, op := range opts {
+		op(o)
+	}
+}
+
+// WithSPIFFEID requires the peer's leaf certificate to contain a URI SAN
+// matching the given SPIFFE ID (e.g. "spiffe://example.org/gateway").
+func WithSPIFFEID(id string) TLSConfigOption {
+	return func(o *TLSConfigOptions) {
+		o.spiffeID = id
+	}
+}
+
+// WithRootCAs verifies the peer's certificate chain against the given roots.
+// This allows the connection to be verified using a SPIFFE ID instead of
+// public key pins.
+func WithRootCAs(pool *x509.CertPool) TLSConfigOption {
+	return func(o *TLSConfigOptions) {
+		o.rootCAs = pool
+	}
+}
+
+// TLSConfig returns a TLS config which verifies the peer's certificate chain
+// using the given public key pins. At least one pin is required unless both
+// WithSPIFFEID and WithRootCAs are used, in which case pins are optional.
+func TLSConfig(pins []*PublicKeyPin, opts ...TLSConfigOption) (*tls.Config, error) {
+	options := TLSConfigOptions{}
+	options.Apply(opts...)
+
+	var spiffeID *url.URL
+	if options.spiffeID != "" {
+		var err error
+		spiffeID, err = parseSPIFFEID(options.spiffeID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(pins) == 0 && (spiffeID == nil || options.rootCAs == nil) {
 		return nil, ErrNoPins
 	}
 	copiedPins := make([]*PublicKeyPin, len(pins))
@@ -29,12 +77,35 @@ func TLSConfig(pins []*PublicKeyPin) (*tls.Config, error) {
 		InsecureSkipVerify: true,
 		VerifyConnection: func(cs tls.ConnectionState) error {
 			peerCerts := cs.PeerCertificates
+			if len(peerCerts) == 0 {
+				return ErrCertValidationFailed
+			}
 			// Validate the peer's certificate chain.
 			for i := 0; i < len(peerCerts)-1; i++ {
 				if err := peerCerts[i].CheckSignatureFrom(peerCerts[i+1]); err != nil {
 					return fmt.Errorf("%w: %s", ErrCertValidationFailed, err)
 				}
 			}
+			if options.rootCAs != nil {
+				intermediates := x509.NewCertPool()
+				for _, cert := range peerCerts[1:] {
+					intermediates.AddCert(cert)
+				}
+				if _, err := peerCerts[0].Verify(x509.VerifyOptions{
+					Roots:         options.rootCAs,
+					Intermediates: intermediates,
+				}); err != nil {
+					return fmt.Errorf("%w: %s", ErrCertValidationFailed, err)
+				}
+			}
+			if spiffeID != nil {
+				if err := verifySPIFFEID(peerCerts[0], spiffeID); err != nil {
+					return err
+				}
+			}
+			if len(copiedPins) == 0 {
+				return nil
+			}
 			// Check each peer certificate for a matching pin.
 			pinnedCert := -1
 		CERTS:
@@ -55,3 +126,27 @@ func TLSConfig(pins []*PublicKeyPin) (*tls.Config, error) {
 		},
 	}, nil
 }
+
+func parseSPIFFEID(id string) (*url.URL, error) {
+	u, err := url.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSPIFFEID, err)
+	}
+	if u.Scheme != "spiffe" || u.Host == "" || u.User != nil ||
+		u.RawQuery != "" || u.Fragment != "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSPIFFEID, id)
+	}
+	return u, nil
+}
+
+// verifySPIFFEID checks that the certificate contains exactly one URI SAN,
+// and that it matches the expected SPIFFE ID.
+func verifySPIFFEID(cert *x509.Certificate, expected *url.URL) error {
+	if len(cert.URIs) != 1 {
+		return fmt.Errorf("%w: expected exactly one URI SAN, found %d", ErrSPIFFEIDMismatch, len(cert.URIs))
+	}
+	if actual := cert.URIs[0]; actual.String() != expected.String() {
+		return fmt.Errorf("%w: got %q, expected %q", ErrSPIFFEIDMismatch, actual.String(), expected.String())
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package plugins
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CallLimits bounds the resources used by unary RPCs made to a single plugin.
+// Streaming RPCs are long-lived and are not subject to these limits.
+type CallLimits struct {
+	// Maximum number of unary calls which can be in flight to the plugin at
+	// once. Additional calls wait until a slot is available, or until their
+	// context is done. If 0, the number of calls is not limited.
+	MaxConcurrentCalls int
+	// Maximum duration of a single unary call, including any time spent
+	// waiting for a slot. The caller's deadline is used instead if it is
+	// earlier. If 0, calls do not time out.
+	CallTimeout time.Duration
+}
+
+// UnaryClientInterceptor returns an interceptor which enforces the limits.
+// If a slot does not become available before the call's deadline, the call
+// fails with codes.ResourceExhausted. If the call itself exceeds the
+// deadline, it fails with codes.DeadlineExceeded.
+func (l CallLimits) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	var sem chan struct{}
+	if l.MaxConcurrentCalls > 0 {
+		sem = make(chan struct{}, l.MaxConcurrentCalls)
+	}
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if l.CallTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, l.CallTimeout)
+			defer cancel()
+		}
+		if sem != nil {
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return status.Errorf(codes.ResourceExhausted,
+					"%s: timed out waiting for a free slot (limit: %d concurrent calls)",
+					method, l.MaxConcurrentCalls)
+			}
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// ApplyCallLimits configures the plugin client to enforce the given limits
+// on all unary calls made to the plugin.
+func ApplyCallLimits(cc *plugin.ClientConfig, limits CallLimits) {
+	if limits.MaxConcurrentCalls <= 0 && limits.CallTimeout <= 0 {
+		return
+	}
+	cc.GRPCDialOptions = append(cc.GRPCDialOptions,
+		grpc.WithChainUnaryInterceptor(limits.UnaryClientInterceptor()))
+}
@@ -0,0 +1,142 @@
+package plugins_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/rancher/opni-monitoring/pkg/plugins"
+	"github.com/rancher/opni-monitoring/pkg/plugins/apis/capability"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+// slowBackend is a capability backend whose Info method blocks until it is
+// released, or until the caller gives up.
+type slowBackend struct {
+	capability.UnimplementedBackendServer
+	release chan struct{}
+	mu      sync.Mutex
+	active  int
+	peak    int
+}
+
+func (b *slowBackend) Info(ctx context.Context, _ *emptypb.Empty) (*capability.InfoResponse, error) {
+	b.mu.Lock()
+	b.active++
+	if b.active > b.peak {
+		b.peak = b.active
+	}
+	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		b.active--
+		b.mu.Unlock()
+	}()
+	select {
+	case <-b.release:
+		return &capability.InfoResponse{CapabilityName: "slow"}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (b *slowBackend) Peak() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.peak
+}
+
+var _ = Describe("Call Limits", Label(test.Unit), func() {
+	var backend *slowBackend
+	var client capability.BackendClient
+	newClient := func(limits plugins.CallLimits) {
+		listener := bufconn.Listen(1024 * 1024)
+		server := grpc.NewServer(grpc.Creds(insecure.NewCredentials()))
+		backend = &slowBackend{
+			release: make(chan struct{}),
+		}
+		capability.RegisterBackendServer(server, backend)
+		go server.Serve(listener)
+		DeferCleanup(server.Stop)
+
+		cc, err := grpc.Dial("bufconn",
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+				return listener.Dial()
+			}),
+			grpc.WithChainUnaryInterceptor(limits.UnaryClientInterceptor()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(cc.Close)
+		client = capability.NewBackendClient(cc)
+	}
+
+	When("a call timeout is configured", func() {
+		It("should fail calls which take too long", func() {
+			newClient(plugins.CallLimits{
+				CallTimeout: 100 * time.Millisecond,
+			})
+			start := time.Now()
+			_, err := client.Info(context.Background(), &emptypb.Empty{})
+			Expect(status.Code(err)).To(Equal(codes.DeadlineExceeded))
+			Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+		})
+		It("should not affect calls which complete in time", func() {
+			newClient(plugins.CallLimits{
+				CallTimeout: time.Second,
+			})
+			close(backend.release)
+			info, err := client.Info(context.Background(), &emptypb.Empty{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.CapabilityName).To(Equal("slow"))
+		})
+	})
+	When("a concurrency limit is configured", func() {
+		It("should not exceed the limit", func() {
+			newClient(plugins.CallLimits{
+				MaxConcurrentCalls: 2,
+				CallTimeout:        5 * time.Second,
+			})
+			var wg sync.WaitGroup
+			for i := 0; i < 6; i++ {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					_, err := client.Info(context.Background(), &emptypb.Empty{})
+					Expect(err).NotTo(HaveOccurred())
+				}()
+			}
+			Eventually(backend.Peak).Should(Equal(2))
+			Consistently(backend.Peak, 100*time.Millisecond).Should(Equal(2))
+			close(backend.release)
+			wg.Wait()
+			Expect(backend.Peak()).To(Equal(2))
+		})
+		It("should fail calls which cannot obtain a slot in time", func() {
+			newClient(plugins.CallLimits{
+				MaxConcurrentCalls: 1,
+				CallTimeout:        5 * time.Second,
+			})
+			go client.Info(context.Background(), &emptypb.Empty{})
+			Eventually(backend.Peak).Should(Equal(1))
+
+			ctx, ca := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer ca()
+			_, err := client.Info(ctx, &emptypb.Empty{})
+			Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+			Expect(backend.Peak()).To(Equal(1))
+			close(backend.release)
+		})
+	})
+})
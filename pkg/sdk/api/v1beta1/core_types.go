@@ -66,6 +66,9 @@ type Keyring struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 	Data              []byte `json:"data,omitempty"`
+	// Previous versions of the keyring, from newest to oldest, which are
+	// kept during a key rotation until they are pruned.
+	PreviousData [][]byte `json:"previousData,omitempty"`
 }
 
 //+kubebuilder:object:root=true
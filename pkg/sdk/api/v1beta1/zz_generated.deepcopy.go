@@ -327,6 +327,17 @@ func (in *Keyring) DeepCopyInto(out *Keyring) {
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	if in.PreviousData != nil {
+		in, out := &in.PreviousData, &out.PreviousData
+		*out = make([][]byte, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make([]byte, len(*in))
+				copy(*out, *in)
+			}
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Keyring.
@@ -0,0 +1,288 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/keyring"
+	"github.com/rancher/opni-monitoring/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+const (
+	AuditResourceToken       = "token"
+	AuditResourceCluster     = "cluster"
+	AuditResourceRole        = "role"
+	AuditResourceRoleBinding = "roleBinding"
+	AuditResourceKeyring     = "keyring"
+)
+
+type auditActorKeyType struct{}
+
+var auditActorKey auditActorKeyType
+
+// WithAuditActor returns a context which attributes changes made using it
+// to the given actor in the audit log.
+func WithAuditActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, auditActorKey, actor)
+}
+
+// AuditActor returns the actor set on the context using WithAuditActor, or
+// an empty string if none was set.
+func AuditActor(ctx context.Context) string {
+	actor, _ := ctx.Value(auditActorKey).(string)
+	return actor
+}
+
+// AuditLog records audit events in a key-value store. Events are keyed by
+// the time they were recorded, so they can be listed in order.
+type AuditLog struct {
+	store  KeyValueStore
+	logger *zap.SugaredLogger
+
+	mu     sync.Mutex
+	lastID int64
+}
+
+func NewAuditLog(store KeyValueStore) *AuditLog {
+	return &AuditLog{
+		store:  store,
+		logger: logger.New().Named("audit"),
+	}
+}
+
+// nextID returns a unique, increasing event ID based on the current time.
+func (l *AuditLog) nextID(now time.Time) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	id := now.UnixNano()
+	if id <= l.lastID {
+		id = l.lastID + 1
+	}
+	l.lastID = id
+	// zero-padded so that keys sort in the same order as the IDs
+	return fmt.Sprintf("%020d", id)
+}
+
+// Record adds an event to the audit log, attributed to the context's actor.
+func (l *AuditLog) Record(ctx context.Context, action, resourceType, resourceID string) error {
+	now := time.Now()
+	event := &core.AuditEvent{
+		Id:           l.nextID(now),
+		Timestamp:    now.Unix(),
+		Actor:        AuditActor(ctx),
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+	}
+	data, err := proto.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return l.store.Put(ctx, event.Id, data)
+}
+
+// List returns up to limit events matching the given function, oldest
+// first, starting after the event with the given ID (or from the beginning
+// if after is empty). Only events recorded within [start, end) are checked;
+// zero times are unbounded. If there may be more matching events, the ID of
+// the last returned event is also returned, to be used as after in the next
+// call. If limit is not positive, all matching events are returned.
+func (l *AuditLog) List(
+	ctx context.Context,
+	match func(*core.AuditEvent) bool,
+	start, end time.Time,
+	after string,
+	limit int,
+) (_ []*core.AuditEvent, next string, _ error) {
+	keys, err := l.store.ListKeys(ctx, "")
+	if err != nil {
+		return nil, "", err
+	}
+	sort.Strings(keys)
+	lower := after
+	if !start.IsZero() {
+		if s := fmt.Sprintf("%020d", start.UnixNano()-1); s > lower {
+			lower = s
+		}
+	}
+	i := sort.SearchStrings(keys, lower)
+	if i < len(keys) && keys[i] == lower {
+		i++
+	}
+
+	events := []*core.AuditEvent{}
+	for ; i < len(keys); i++ {
+		if !end.IsZero() && keys[i] >= fmt.Sprintf("%020d", end.UnixNano()) {
+			break
+		}
+		if limit > 0 && len(events) == limit {
+			return events, events[len(events)-1].Id, nil
+		}
+		data, err := l.store.Get(ctx, keys[i])
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			return nil, "", err
+		}
+		event := &core.AuditEvent{}
+		if err := proto.Unmarshal(data, event); err != nil {
+			return nil, "", err
+		}
+		if match == nil || match(event) {
+			events = append(events, event)
+		}
+	}
+	return events, "", nil
+}
+
+type auditBackend struct {
+	Backend
+	log *AuditLog
+}
+
+// NewAuditBackend returns a Backend which records an audit event for every
+// successful change to a token, cluster, role, role binding, or keyring made
+// using the given backend. Events only identify the changed resource, so
+// secrets such as token secrets and keyring contents are never recorded.
+func NewAuditBackend(backend Backend, log *AuditLog) Backend {
+	return &auditBackend{
+		Backend: backend,
+		log:     log,
+	}
+}
+
+func (b *auditBackend) record(ctx context.Context, err error, action, resourceType, resourceID string) {
+	if err != nil {
+		return
+	}
+	if err := b.log.Record(ctx, action, resourceType, resourceID); err != nil {
+		b.log.logger.With(
+			zap.Error(err),
+			"action", action,
+			"resourceType", resourceType,
+			"resourceID", resourceID,
+		).Error("failed to record audit event")
+	}
+}
+
+func (b *auditBackend) CreateToken(ctx context.Context, ttl time.Duration, opts ...TokenCreateOption) (*core.BootstrapToken, error) {
+	token, err := b.Backend.CreateToken(ctx, ttl, opts...)
+	b.record(ctx, err, AuditActionCreate, AuditResourceToken, token.GetTokenID())
+	return token, err
+}
+
+func (b *auditBackend) DeleteToken(ctx context.Context, ref *core.Reference) error {
+	err := b.Backend.DeleteToken(ctx, ref)
+	b.record(ctx, err, AuditActionDelete, AuditResourceToken, ref.GetId())
+	return err
+}
+
+func (b *auditBackend) UpdateToken(ctx context.Context, ref *core.Reference, mutator TokenMutator) (*core.BootstrapToken, error) {
+	token, err := b.Backend.UpdateToken(ctx, ref, mutator)
+	b.record(ctx, err, AuditActionUpdate, AuditResourceToken, ref.GetId())
+	return token, err
+}
+
+func (b *auditBackend) CreateCluster(ctx context.Context, cluster *core.Cluster) error {
+	err := b.Backend.CreateCluster(ctx, cluster)
+	b.record(ctx, err, AuditActionCreate, AuditResourceCluster, cluster.GetId())
+	return err
+}
+
+func (b *auditBackend) DeleteCluster(ctx context.Context, ref *core.Reference) error {
+	err := b.Backend.DeleteCluster(ctx, ref)
+	b.record(ctx, err, AuditActionDelete, AuditResourceCluster, ref.GetId())
+	return err
+}
+
+func (b *auditBackend) UpdateCluster(ctx context.Context, ref *core.Reference, mutator ClusterMutator) (*core.Cluster, error) {
+	cluster, err := b.Backend.UpdateCluster(ctx, ref, mutator)
+	b.record(ctx, err, AuditActionUpdate, AuditResourceCluster, ref.GetId())
+	return cluster, err
+}
+
+func (b *auditBackend) CreateRole(ctx context.Context, role *core.Role) error {
+	err := b.Backend.CreateRole(ctx, role)
+	b.record(ctx, err, AuditActionCreate, AuditResourceRole, role.GetId())
+	return err
+}
+
+func (b *auditBackend) DeleteRole(ctx context.Context, ref *core.Reference) error {
+	err := b.Backend.DeleteRole(ctx, ref)
+	b.record(ctx, err, AuditActionDelete, AuditResourceRole, ref.GetId())
+	return err
+}
+
+func (b *auditBackend) CreateRoleBinding(ctx context.Context, rb *core.RoleBinding) error {
+	err := b.Backend.CreateRoleBinding(ctx, rb)
+	b.record(ctx, err, AuditActionCreate, AuditResourceRoleBinding, rb.GetId())
+	return err
+}
+
+func (b *auditBackend) DeleteRoleBinding(ctx context.Context, ref *core.Reference) error {
+	err := b.Backend.DeleteRoleBinding(ctx, ref)
+	b.record(ctx, err, AuditActionDelete, AuditResourceRoleBinding, ref.GetId())
+	return err
+}
+
+func (b *auditBackend) KeyringStore(ctx context.Context, namespace string, ref *core.Reference) (KeyringStore, error) {
+	store, err := b.Backend.KeyringStore(ctx, namespace, ref)
+	if err != nil {
+		return nil, err
+	}
+	return &auditKeyringStore{
+		KeyringStore: store,
+		backend:      b,
+		resourceID:   namespace + "/" + ref.GetId(),
+	}, nil
+}
+
+type auditKeyringStore struct {
+	KeyringStore
+	backend    *auditBackend
+	resourceID string
+}
+
+func (s *auditKeyringStore) Put(ctx context.Context, kr keyring.Keyring) error {
+	err := s.KeyringStore.Put(ctx, kr)
+	s.backend.record(ctx, err, AuditActionUpdate, AuditResourceKeyring, s.resourceID)
+	return err
+}
+
+func (s *auditKeyringStore) Append(ctx context.Context, kr keyring.Keyring) error {
+	err := s.KeyringStore.Append(ctx, kr)
+	s.backend.record(ctx, err, AuditActionUpdate, AuditResourceKeyring, s.resourceID)
+	return err
+}
+
+func (s *auditKeyringStore) Rotate(ctx context.Context, kr keyring.Keyring) error {
+	err := s.KeyringStore.Rotate(ctx, kr)
+	s.backend.record(ctx, err, AuditActionUpdate, AuditResourceKeyring, s.resourceID)
+	return err
+}
+
+func (s *auditKeyringStore) Prune(ctx context.Context) error {
+	err := s.KeyringStore.Prune(ctx)
+	s.backend.record(ctx, err, AuditActionUpdate, AuditResourceKeyring, s.resourceID)
+	return err
+}
+
+func (s *auditKeyringStore) Delete(ctx context.Context) error {
+	err := s.KeyringStore.Delete(ctx)
+	s.backend.record(ctx, err, AuditActionDelete, AuditResourceKeyring, s.resourceID)
+	return err
+}
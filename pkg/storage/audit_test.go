@@ -0,0 +1,124 @@
+package storage_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/keyring"
+	"github.com/rancher/opni-monitoring/pkg/storage"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Audit Log", Label(test.Unit), func() {
+	var log *storage.AuditLog
+	var backend storage.Backend
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		log = storage.NewAuditLog(test.NewTestKeyValueStore(ctrl))
+		backend = storage.NewAuditBackend(test.NewTestStorageBackend(context.Background(), ctrl), log)
+	})
+	listAll := func() []*core.AuditEvent {
+		events, next, err := log.List(context.Background(), nil, time.Time{}, time.Time{}, "", 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(next).To(BeEmpty())
+		return events
+	}
+	type event struct {
+		actor, action, resourceType, resourceID string
+	}
+	summarize := func(events []*core.AuditEvent) []event {
+		var summary []event
+		for _, e := range events {
+			summary = append(summary, event{e.Actor, e.Action, e.ResourceType, e.ResourceID})
+		}
+		return summary
+	}
+
+	It("should record successful changes in order", func() {
+		alice := storage.WithAuditActor(context.Background(), "alice")
+		bob := storage.WithAuditActor(context.Background(), "bob")
+
+		token, err := backend.CreateToken(alice, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backend.CreateCluster(bob, &core.Cluster{Id: "foo"})).To(Succeed())
+		_, err = backend.UpdateCluster(alice, &core.Reference{Id: "foo"}, func(c *core.Cluster) {
+			c.Metadata = &core.ClusterMetadata{Labels: map[string]string{"a": "b"}}
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backend.CreateRole(bob, &core.Role{Id: "role"})).To(Succeed())
+		Expect(backend.CreateRoleBinding(bob, &core.RoleBinding{Id: "rb", RoleId: "role"})).To(Succeed())
+		ks, err := backend.KeyringStore(alice, "gateway", &core.Reference{Id: "foo"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ks.Put(alice, keyring.New(keyring.NewSharedKeys(make([]byte, 64))))).To(Succeed())
+		Expect(backend.DeleteCluster(context.Background(), &core.Reference{Id: "foo"})).To(Succeed())
+		Expect(backend.DeleteToken(alice, &core.Reference{Id: token.TokenID})).To(Succeed())
+
+		Expect(summarize(listAll())).To(Equal([]event{
+			{"alice", storage.AuditActionCreate, storage.AuditResourceToken, token.TokenID},
+			{"bob", storage.AuditActionCreate, storage.AuditResourceCluster, "foo"},
+			{"alice", storage.AuditActionUpdate, storage.AuditResourceCluster, "foo"},
+			{"bob", storage.AuditActionCreate, storage.AuditResourceRole, "role"},
+			{"bob", storage.AuditActionCreate, storage.AuditResourceRoleBinding, "rb"},
+			{"alice", storage.AuditActionUpdate, storage.AuditResourceKeyring, "gateway/foo"},
+			{"", storage.AuditActionDelete, storage.AuditResourceCluster, "foo"},
+			{"alice", storage.AuditActionDelete, storage.AuditResourceToken, token.TokenID},
+		}))
+	})
+	It("should not record failed changes", func() {
+		Expect(backend.DeleteCluster(context.Background(), &core.Reference{Id: "missing"})).NotTo(Succeed())
+		Expect(listAll()).To(BeEmpty())
+	})
+	It("should not record reads", func() {
+		Expect(backend.CreateCluster(context.Background(), &core.Cluster{Id: "foo"})).To(Succeed())
+		_, err := backend.GetCluster(context.Background(), &core.Reference{Id: "foo"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = backend.ListClusters(context.Background(), nil, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listAll()).To(HaveLen(1))
+	})
+	It("should list events in pages", func() {
+		for i := 0; i < 5; i++ {
+			Expect(log.Record(context.Background(), storage.AuditActionCreate, storage.AuditResourceRole, "role")).To(Succeed())
+		}
+		var all []*core.AuditEvent
+		after := ""
+		for pages := 1; ; pages++ {
+			events, next, err := log.List(context.Background(), nil, time.Time{}, time.Time{}, after, 2)
+			Expect(err).NotTo(HaveOccurred())
+			all = append(all, events...)
+			if next == "" {
+				Expect(pages).To(Equal(3))
+				break
+			}
+			Expect(events).To(HaveLen(2))
+			after = next
+		}
+		Expect(all).To(HaveLen(5))
+		Expect(summarize(all)).To(Equal(summarize(listAll())))
+	})
+	It("should only list events matching the filter and time range", func() {
+		Expect(log.Record(context.Background(), storage.AuditActionCreate, storage.AuditResourceRole, "a")).To(Succeed())
+		Expect(log.Record(context.Background(), storage.AuditActionDelete, storage.AuditResourceRole, "a")).To(Succeed())
+		events, _, err := log.List(context.Background(), func(e *core.AuditEvent) bool {
+			return e.Action == storage.AuditActionDelete
+		}, time.Time{}, time.Time{}, "", 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Action).To(Equal(storage.AuditActionDelete))
+
+		events, _, err = log.List(context.Background(), nil, time.Now().Add(time.Hour), time.Time{}, "", 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(BeEmpty())
+		events, _, err = log.List(context.Background(), nil, time.Time{}, time.Now().Add(-time.Hour), "", 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(BeEmpty())
+		events, _, err = log.List(context.Background(), nil, time.Now().Add(-time.Hour), time.Now().Add(time.Hour), "", 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(HaveLen(2))
+	})
+})
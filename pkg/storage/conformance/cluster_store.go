@@ -60,6 +60,15 @@ func ClusterStoreTestSuite[T storage.ClusterStore](
 				Expect(cluster.Metadata.Capabilities).To(HaveLen(1))
 				Expect(cluster.Metadata.Capabilities[0].Name).To(Equal("foo"))
 			})
+			It("should not replace an existing cluster", func() {
+				err := ts.CreateCluster(context.Background(), &core.Cluster{
+					Id: "foo",
+				})
+				Expect(err).To(MatchError(storage.ErrAlreadyExists))
+				cluster, err := ts.GetCluster(context.Background(), &core.Reference{Id: "foo"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cluster.Metadata.Labels).To(HaveKeyWithValue("foo", "bar"))
+			})
 			It("should appear in the list of clusters", func() {
 				clusters, err := ts.ListClusters(context.Background(), &core.LabelSelector{}, 0)
 				Expect(err).NotTo(HaveOccurred())
@@ -246,6 +255,65 @@ func ClusterStoreTestSuite[T storage.ClusterStore](
 			Expect(err).NotTo(HaveOccurred())
 			Expect(cluster.Metadata.Labels).To(HaveKeyWithValue("value", strconv.Itoa(count)))
 		})
+		It("should watch for changes to clusters", func() {
+			ctx, ca := context.WithCancel(context.Background())
+			defer ca()
+			existing := &core.Cluster{
+				Id: uuid.NewString(),
+				Metadata: &core.ClusterMetadata{
+					Labels: map[string]string{},
+				},
+			}
+			Expect(ts.CreateCluster(ctx, existing)).To(Succeed())
+			all, err := ts.ListClusters(ctx, nil, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			// clusters the watcher already knows about are not sent again
+			eventC, err := ts.WatchClusters(ctx, all.Items)
+			Expect(err).NotTo(HaveOccurred())
+			Consistently(eventC, 100*time.Millisecond).ShouldNot(Receive())
+
+			cluster := &core.Cluster{
+				Id: uuid.NewString(),
+				Metadata: &core.ClusterMetadata{
+					Labels: map[string]string{},
+				},
+			}
+			Expect(ts.CreateCluster(ctx, cluster)).To(Succeed())
+			var event storage.ClusterWatchEvent
+			Eventually(eventC, 5*time.Second).Should(Receive(&event))
+			Expect(event.EventType).To(Equal(storage.WatchEventCreate))
+			Expect(event.Current.GetId()).To(Equal(cluster.Id))
+
+			_, err = ts.UpdateCluster(ctx, cluster.Reference(), func(c *core.Cluster) {
+				c.Metadata.Labels["foo"] = "bar"
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(eventC, 5*time.Second).Should(Receive(&event))
+			Expect(event.EventType).To(Equal(storage.WatchEventUpdate))
+			Expect(event.Current.GetLabels()).To(HaveKeyWithValue("foo", "bar"))
+			Expect(event.Previous.GetLabels()).NotTo(HaveKey("foo"))
+
+			Expect(ts.DeleteCluster(ctx, cluster.Reference())).To(Succeed())
+			Eventually(eventC, 5*time.Second).Should(Receive(&event))
+			Expect(event.EventType).To(Equal(storage.WatchEventDelete))
+			Expect(event.Previous.GetId()).To(Equal(cluster.Id))
+
+			// a new watch with no known clusters starts with the existing ones
+			eventC2, err := ts.WatchClusters(ctx, nil)
+			Expect(err).NotTo(HaveOccurred())
+			ids := []string{}
+			for range all.Items {
+				Eventually(eventC2, 5*time.Second).Should(Receive(&event))
+				Expect(event.EventType).To(Equal(storage.WatchEventCreate))
+				ids = append(ids, event.Current.GetId())
+			}
+			Expect(ids).To(ContainElement(existing.Id))
+
+			ca()
+			Eventually(eventC, 5*time.Second).Should(BeClosed())
+			Eventually(eventC2, 5*time.Second).Should(BeClosed())
+		})
 		Context("error handling", func() {
 			if runtime.GOOS != "linux" {
 				Skip("skipping tests on non-linux OS")
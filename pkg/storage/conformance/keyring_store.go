@@ -131,6 +131,145 @@ func KeyringStoreTestSuite[T storage.KeyringStoreBroker](
 			Expect(err).NotTo(HaveOccurred())
 			Expect(kr2).To(Equal(kr))
 		})
+		When("rotating the keyring", func() {
+			It("should keep previous versions until they are pruned", func() {
+				kr1 := keyring.New(sharedKeys())
+				kr2 := keyring.New(sharedKeys(), pkpKey(1))
+				kr3 := keyring.New(sharedKeys(), pkpKey(2))
+				Expect(ts.Put(context.Background(), kr1)).To(Succeed())
+				Expect(ts.Rotate(context.Background(), kr2)).To(Succeed())
+				Expect(ts.Rotate(context.Background(), kr3)).To(Succeed())
+
+				active, err := ts.Get(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(active).To(Equal(kr3))
+				keyrings, err := ts.List(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keyrings).To(Equal([]keyring.Keyring{kr3, kr2, kr1}))
+
+				By("replacing the active keyring without affecting previous versions")
+				kr4 := keyring.New(sharedKeys())
+				Expect(ts.Put(context.Background(), kr4)).To(Succeed())
+				keyrings, err = ts.List(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keyrings).To(Equal([]keyring.Keyring{kr4, kr2, kr1}))
+
+				By("pruning previous versions")
+				Expect(ts.Prune(context.Background())).To(Succeed())
+				keyrings, err = ts.List(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keyrings).To(Equal([]keyring.Keyring{kr4}))
+				active, err = ts.Get(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(active).To(Equal(kr4))
+			})
+			It("should delete all versions", func() {
+				ks, err := tsF.Get().KeyringStore(context.Background(), "test", &core.Reference{
+					Id: "test-delete",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ks.Delete(context.Background())).To(Succeed())
+
+				Expect(ks.Put(context.Background(), keyring.New(sharedKeys()))).To(Succeed())
+				Expect(ks.Rotate(context.Background(), keyring.New(sharedKeys()))).To(Succeed())
+				Expect(ks.Delete(context.Background())).To(Succeed())
+				_, err = ks.List(context.Background())
+				Expect(err).To(MatchError(storage.ErrNotFound))
+				_, err = ks.Get(context.Background())
+				Expect(err).To(MatchError(storage.ErrNotFound))
+			})
+			It("should store the keyring as the active version if none exists", func() {
+				ks, err := tsF.Get().KeyringStore(context.Background(), "test", &core.Reference{
+					Id: "test-rotate",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				_, err = ks.List(context.Background())
+				Expect(err).To(MatchError(storage.ErrNotFound))
+
+				kr := keyring.New(sharedKeys())
+				Expect(ks.Rotate(context.Background(), kr)).To(Succeed())
+				keyrings, err := ks.List(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keyrings).To(Equal([]keyring.Keyring{kr}))
+			})
+			It("should handle concurrent rotations", func() {
+				ks, err := tsF.Get().KeyringStore(context.Background(), "test", &core.Reference{
+					Id: "test-concurrent-rotate",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ks.Put(context.Background(), keyring.New(sharedKeys()))).To(Succeed())
+
+				count := testutil.IfCI(5).Else(10)
+				var wg sync.WaitGroup
+				start := make(chan struct{})
+				for i := 0; i < count; i++ {
+					wg.Add(1)
+					go func() {
+						defer GinkgoRecover()
+						defer wg.Done()
+						<-start
+						Expect(ks.Rotate(context.Background(), keyring.New(sharedKeys()))).To(Succeed())
+					}()
+				}
+				close(start)
+				wg.Wait()
+
+				keyrings, err := ks.List(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keyrings).To(HaveLen(storage.MaxPreviousKeyringVersions + 1))
+			})
+			It("should delete the oldest versions once the limit is reached", func() {
+				ks, err := tsF.Get().KeyringStore(context.Background(), "test", &core.Reference{
+					Id: "test-rotate-limit",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				rotated := []keyring.Keyring{}
+				for i := 0; i < storage.MaxPreviousKeyringVersions+3; i++ {
+					kr := keyring.New(sharedKeys())
+					Expect(ks.Rotate(context.Background(), kr)).To(Succeed())
+					rotated = append([]keyring.Keyring{kr}, rotated...)
+				}
+				keyrings, err := ks.List(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keyrings).To(Equal(rotated[:storage.MaxPreviousKeyringVersions+1]))
+			})
+		})
+		When("appending to the keyring", func() {
+			It("should keep keys appended concurrently", func() {
+				ks, err := tsF.Get().KeyringStore(context.Background(), "test", &core.Reference{
+					Id: "test-concurrent-append",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				count := testutil.IfCI(5).Else(10)
+				keys := make([]interface{}, count)
+				for i := range keys {
+					keys[i] = sharedKeys()
+				}
+				var wg sync.WaitGroup
+				start := make(chan struct{})
+				for _, key := range keys {
+					key := key
+					wg.Add(1)
+					go func() {
+						defer GinkgoRecover()
+						defer wg.Done()
+						<-start
+						Expect(ks.Append(context.Background(), keyring.New(key))).To(Succeed())
+					}()
+				}
+				close(start)
+				wg.Wait()
+
+				active, err := ks.Get(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+				stored := []interface{}{}
+				active.ForEach(func(key interface{}) {
+					stored = append(stored, key)
+				})
+				Expect(stored).To(ConsistOf(keys...))
+			})
+		})
 		It("should handle errors", func() {
 			errCtrl.EnableErrors()
 			defer errCtrl.DisableErrors()
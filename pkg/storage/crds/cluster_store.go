@@ -7,14 +7,25 @@ import (
 	"github.com/rancher/opni-monitoring/pkg/core"
 	"github.com/rancher/opni-monitoring/pkg/sdk/api/v1beta1"
 	"github.com/rancher/opni-monitoring/pkg/storage"
+	"github.com/rancher/opni-monitoring/pkg/util/backoff"
+	"go.uber.org/zap"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var (
+	// watchRetryPolicy is the policy used to wait between attempts to restart
+	// a watch after it is closed.
+	watchRetryPolicy = backoff.Policy{
+		Base:       100 * time.Millisecond,
+		Max:        10 * time.Second,
+		Multiplier: 2,
+		Jitter:     0.2,
+	}
 	defaultBackoff = wait.Backoff{
 		Steps:    20,
 		Duration: 10 * time.Millisecond,
@@ -25,7 +36,7 @@ var (
 )
 
 func (c *CRDStore) CreateCluster(ctx context.Context, cluster *core.Cluster) error {
-	return c.client.Create(ctx, &v1beta1.Cluster{
+	err := c.client.Create(ctx, &v1beta1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cluster.Id,
 			Namespace: c.namespace,
@@ -33,6 +44,10 @@ func (c *CRDStore) CreateCluster(ctx context.Context, cluster *core.Cluster) err
 		},
 		Spec: cluster,
 	})
+	if k8serrors.IsAlreadyExists(err) {
+		return storage.ErrAlreadyExists
+	}
+	return err
 }
 
 func (c *CRDStore) DeleteCluster(ctx context.Context, ref *core.Reference) error {
@@ -85,8 +100,7 @@ func (c *CRDStore) UpdateCluster(ctx context.Context, ref *core.Reference, mutat
 }
 
 func (c *CRDStore) ListClusters(ctx context.Context, matchLabels *core.LabelSelector, matchOptions core.MatchOptions) (*core.ClusterList, error) {
-	list := &v1beta1.ClusterList{}
-	err := c.client.List(ctx, list, client.InNamespace(c.namespace))
+	all, _, err := c.listClusters(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -95,12 +109,134 @@ func (c *CRDStore) ListClusters(ctx context.Context, matchLabels *core.LabelSele
 		MatchOptions:  matchOptions,
 	}.Predicate()
 	clusters := &core.ClusterList{
-		Items: make([]*core.Cluster, 0, len(list.Items)),
+		Items: make([]*core.Cluster, 0, len(all)),
 	}
-	for _, item := range list.Items {
-		if selectorPredicate(item.Spec) {
-			clusters.Items = append(clusters.Items, item.Spec)
+	for _, cluster := range all {
+		if selectorPredicate(cluster) {
+			clusters.Items = append(clusters.Items, cluster)
 		}
 	}
 	return clusters, nil
 }
+
+// listClusters returns all clusters, and the resource version of the list.
+func (c *CRDStore) listClusters(ctx context.Context) ([]*core.Cluster, string, error) {
+	list := &v1beta1.ClusterList{}
+	err := c.client.List(ctx, list, client.InNamespace(c.namespace))
+	if err != nil {
+		return nil, "", err
+	}
+	clusters := make([]*core.Cluster, 0, len(list.Items))
+	for _, item := range list.Items {
+		clusters = append(clusters, item.Spec)
+	}
+	return clusters, list.ResourceVersion, nil
+}
+
+func (c *CRDStore) WatchClusters(ctx context.Context, knownClusters []*core.Cluster) (<-chan storage.ClusterWatchEvent, error) {
+	clusters, resourceVersion, err := c.listClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	state := storage.NewClusterWatchState(knownClusters)
+	eventC := make(chan storage.ClusterWatchEvent, 64)
+	send := func(event storage.ClusterWatchEvent) bool {
+		select {
+		case eventC <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	go func() {
+		defer close(eventC)
+		b := watchRetryPolicy.Start()
+		for {
+			for _, event := range state.Sync(clusters) {
+				if !send(event) {
+					return
+				}
+			}
+			err := c.watchClusterChanges(ctx, resourceVersion, state, send)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				c.logger.With(
+					zap.Error(err),
+				).Warn("cluster watch failed, restarting")
+			}
+
+			// watches are periodically closed by the server, and cannot always be
+			// resumed from the last resource version, so list the clusters again
+			// and send any changes which were missed
+			for {
+				select {
+				case <-time.After(b.Next()):
+				case <-ctx.Done():
+					return
+				}
+				clusters, resourceVersion, err = c.listClusters(ctx)
+				if err == nil {
+					b.Reset()
+					break
+				}
+				c.logger.With(
+					zap.Error(err),
+				).Warn("failed to list clusters")
+			}
+		}
+	}()
+	return eventC, nil
+}
+
+// watchClusterChanges sends events for changes to clusters made after the
+// given resource version, until the watch is closed or ctx is done.
+func (c *CRDStore) watchClusterChanges(
+	ctx context.Context,
+	resourceVersion string,
+	state *storage.ClusterWatchState,
+	send func(storage.ClusterWatchEvent) bool,
+) error {
+	w, err := c.watchClient.Watch(ctx, &v1beta1.ClusterList{}, &client.ListOptions{
+		Namespace: c.namespace,
+		Raw: &metav1.ListOptions{
+			ResourceVersion: resourceVersion,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer w.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ev, ok := <-w.ResultChan():
+			if !ok {
+				return nil
+			}
+			var event storage.ClusterWatchEvent
+			var changed bool
+			switch ev.Type {
+			case watch.Added, watch.Modified:
+				cluster, ok := ev.Object.(*v1beta1.Cluster)
+				if !ok {
+					continue
+				}
+				event, changed = state.Put(cluster.Spec)
+			case watch.Deleted:
+				cluster, ok := ev.Object.(*v1beta1.Cluster)
+				if !ok {
+					continue
+				}
+				event, changed = state.Delete(cluster.GetName())
+			case watch.Error:
+				return k8serrors.FromObject(ev.Object)
+			}
+			if changed && !send(event) {
+				return ctx.Err()
+			}
+		}
+	}
+}
@@ -29,11 +29,13 @@ var _ = BeforeSuite(func() {
 	}
 	config, err := env.StartK8s()
 	Expect(err).NotTo(HaveOccurred())
+	DeferCleanup(env.Stop)
 
 	store.Set(crds.NewCRDStore(crds.WithRestConfig(config), crds.WithCommandTimeout(100*time.Millisecond)))
+	if !env.Processes.APIServer.IsSet() {
+		Skip("kube-apiserver process could not be found, so storage errors cannot be simulated")
+	}
 	errCtrl.Set(conformance.NewProcessErrorController(env.Processes.APIServer.Get()))
-
-	DeferCleanup(env.Stop)
 })
 
 var _ = Describe("Token Store", Ordered, conformance.TokenStoreTestSuite(store, errCtrl))
@@ -18,7 +18,10 @@ import (
 type CRDStore struct {
 	CRDStoreOptions
 	client client.Client
-	logger *zap.SugaredLogger
+	// watches are long-running, so they use a separate client which is not
+	// bound by the command timeout
+	watchClient client.WithWatch
+	logger      *zap.SugaredLogger
 }
 
 var _ storage.TokenStore = (*CRDStore)(nil)
@@ -72,12 +75,17 @@ func NewCRDStore(opts ...CRDStoreOption) *CRDStore {
 	if options.restConfig == nil {
 		options.restConfig = util.Must(rest.InClusterConfig())
 	}
+	watchConfig := rest.CopyConfig(options.restConfig)
+	watchConfig.Timeout = 0
 	options.restConfig.Timeout = options.commandTimeout
 	return &CRDStore{
 		CRDStoreOptions: options,
 		client: util.Must(client.New(options.restConfig, client.Options{
 			Scheme: api.NewScheme(),
 		})),
+		watchClient: util.Must(client.NewWithWatch(watchConfig, client.Options{
+			Scheme: api.NewScheme(),
+		})),
 		logger: lg,
 	}
 }
@@ -69,3 +69,130 @@ func (ks *crdKeyringStore) Get(ctx context.Context) (keyring.Keyring, error) {
 	}
 	return keyring.Unmarshal(kr.Data)
 }
+
+func (ks *crdKeyringStore) Append(ctx context.Context, kr keyring.Keyring) error {
+	return retry.OnError(defaultBackoff, isConflictOrAlreadyExists, func() error {
+		obj := &v1beta1.Keyring{}
+		if err := ks.client.Get(ctx, types.NamespacedName{
+			Name:      ks.ref.Id,
+			Namespace: ks.namespace,
+		}, obj); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return err
+			}
+			data, err := kr.Marshal()
+			if err != nil {
+				return err
+			}
+			return ks.client.Create(ctx, &v1beta1.Keyring{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      ks.ref.Id,
+					Namespace: ks.namespace,
+				},
+				Data: data,
+			})
+		}
+		existing, err := keyring.Unmarshal(obj.Data)
+		if err != nil {
+			return err
+		}
+		data, err := kr.Merge(existing).Marshal()
+		if err != nil {
+			return err
+		}
+		obj.Data = data
+		return ks.client.Update(ctx, obj)
+	})
+}
+
+func (ks *crdKeyringStore) Rotate(ctx context.Context, keyring keyring.Keyring) error {
+	data, err := keyring.Marshal()
+	if err != nil {
+		return err
+	}
+	return retry.OnError(defaultBackoff, isConflictOrAlreadyExists, func() error {
+		kr := &v1beta1.Keyring{}
+		if err := ks.client.Get(ctx, types.NamespacedName{
+			Name:      ks.ref.Id,
+			Namespace: ks.namespace,
+		}, kr); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return err
+			}
+			return ks.client.Create(ctx, &v1beta1.Keyring{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      ks.ref.Id,
+					Namespace: ks.namespace,
+				},
+				Data: data,
+			})
+		}
+		kr.PreviousData = append([][]byte{kr.Data}, kr.PreviousData...)
+		if len(kr.PreviousData) > storage.MaxPreviousKeyringVersions {
+			kr.PreviousData = kr.PreviousData[:storage.MaxPreviousKeyringVersions]
+		}
+		kr.Data = data
+		return ks.client.Update(ctx, kr)
+	})
+}
+
+func (ks *crdKeyringStore) List(ctx context.Context) ([]keyring.Keyring, error) {
+	kr := &v1beta1.Keyring{}
+	if err := ks.client.Get(ctx, types.NamespacedName{
+		Name:      ks.ref.Id,
+		Namespace: ks.namespace,
+	}, kr); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, storage.ErrNotFound
+		}
+		return nil, err
+	}
+	keyrings := make([]keyring.Keyring, 0, 1+len(kr.PreviousData))
+	for _, data := range append([][]byte{kr.Data}, kr.PreviousData...) {
+		k, err := keyring.Unmarshal(data)
+		if err != nil {
+			return nil, err
+		}
+		keyrings = append(keyrings, k)
+	}
+	return keyrings, nil
+}
+
+func (ks *crdKeyringStore) Prune(ctx context.Context) error {
+	return retry.OnError(defaultBackoff, k8serrors.IsConflict, func() error {
+		kr := &v1beta1.Keyring{}
+		if err := ks.client.Get(ctx, types.NamespacedName{
+			Name:      ks.ref.Id,
+			Namespace: ks.namespace,
+		}, kr); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if len(kr.PreviousData) == 0 {
+			return nil
+		}
+		kr.PreviousData = nil
+		return ks.client.Update(ctx, kr)
+	})
+}
+
+func (ks *crdKeyringStore) Delete(ctx context.Context) error {
+	err := ks.client.Delete(ctx, &v1beta1.Keyring{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ks.ref.Id,
+			Namespace: ks.namespace,
+		},
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// isConflictOrAlreadyExists reports whether a keyring update should be
+// retried, because the keyring was modified or created concurrently.
+func isConflictOrAlreadyExists(err error) bool {
+	return k8serrors.IsConflict(err) || k8serrors.IsAlreadyExists(err)
+}
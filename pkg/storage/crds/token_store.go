@@ -21,12 +21,17 @@ func (c *CRDStore) CreateToken(ctx context.Context, ttl time.Duration, opts ...s
 	options.Apply(opts...)
 
 	token := tokens.NewToken().ToBootstrapToken()
+	now := time.Now()
 	token.Metadata = &core.BootstrapTokenMetadata{
-		LeaseID:      -1,
-		Ttl:          int64(ttl.Seconds()),
-		UsageCount:   0,
-		Labels:       options.Labels,
-		Capabilities: options.Capabilities,
+		LeaseID:             -1,
+		Ttl:                 int64(ttl.Seconds()),
+		UsageCount:          0,
+		Labels:              options.Labels,
+		Capabilities:        options.Capabilities,
+		AllowedCapabilities: options.AllowedCapabilities,
+		MaxUsages:           options.MaxUsages,
+		NotBefore:           now.Unix(),
+		NotAfter:            now.Add(ttl).Unix(),
 	}
 	err := c.client.Create(ctx, &v1beta1.BootstrapToken{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// AgentDiagnostics is a diagnostics bundle uploaded by an agent.
+type AgentDiagnostics struct {
+	// When the gateway received the bundle.
+	ReportedAt time.Time `json:"reportedAt"`
+	// The JSON-encoded bundle, as sent by the agent.
+	Bundle json.RawMessage `json:"bundle"`
+}
+
+// DiagnosticsStore keeps the most recent diagnostics bundle uploaded by each
+// agent in a key-value store, keyed by cluster ID.
+type DiagnosticsStore struct {
+	store KeyValueStore
+}
+
+func NewDiagnosticsStore(store KeyValueStore) *DiagnosticsStore {
+	return &DiagnosticsStore{
+		store: store,
+	}
+}
+
+// Put replaces the stored diagnostics bundle for the cluster. The bundle
+// must be valid JSON.
+func (s *DiagnosticsStore) Put(ctx context.Context, clusterID string, bundle []byte) error {
+	data, err := json.Marshal(AgentDiagnostics{
+		ReportedAt: time.Now(),
+		Bundle:     bundle,
+	})
+	if err != nil {
+		return err
+	}
+	return s.store.Put(ctx, clusterID, data)
+}
+
+// Get returns the most recent diagnostics bundle uploaded for the cluster.
+// If the cluster's agent has not uploaded a bundle, ErrNotFound is returned.
+func (s *DiagnosticsStore) Get(ctx context.Context, clusterID string) (*AgentDiagnostics, error) {
+	data, err := s.store.Get(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	diag := &AgentDiagnostics{}
+	if err := json.Unmarshal(data, diag); err != nil {
+		return nil, err
+	}
+	return diag, nil
+}
+
+// Delete removes the stored diagnostics bundle for the cluster, if any.
+func (s *DiagnosticsStore) Delete(ctx context.Context, clusterID string) error {
+	return s.store.Delete(ctx, clusterID)
+}
@@ -1,6 +1,9 @@
 package storage
 
 import (
+	"fmt"
+	"strings"
+
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -16,3 +19,52 @@ func (e *NotFoundError) Error() string {
 func (e *NotFoundError) GRPCStatus() *status.Status {
 	return status.New(codes.NotFound, e.Error())
 }
+
+// ErrAlreadyExists is returned when creating an object which already exists.
+var ErrAlreadyExists = &AlreadyExistsError{}
+
+type AlreadyExistsError struct{}
+
+func (e *AlreadyExistsError) Error() string {
+	return "already exists"
+}
+
+func (e *AlreadyExistsError) GRPCStatus() *status.Status {
+	return status.New(codes.AlreadyExists, e.Error())
+}
+
+// ErrStorageUnavailable matches any *StorageUnavailableError using errors.Is.
+var ErrStorageUnavailable = &StorageUnavailableError{}
+
+// StorageUnavailableError is returned when a storage backend cannot be
+// reached, such as when none of the configured etcd endpoints respond.
+type StorageUnavailableError struct {
+	// The endpoints which were tried
+	Endpoints []string
+	// The last error encountered while trying to reach the backend
+	Err error
+}
+
+func (e *StorageUnavailableError) Error() string {
+	msg := "storage backend is unavailable"
+	if len(e.Endpoints) > 0 {
+		msg += fmt.Sprintf(" (tried endpoints: %s)", strings.Join(e.Endpoints, ", "))
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *StorageUnavailableError) Unwrap() error {
+	return e.Err
+}
+
+func (e *StorageUnavailableError) Is(target error) bool {
+	_, ok := target.(*StorageUnavailableError)
+	return ok
+}
+
+func (e *StorageUnavailableError) GRPCStatus() *status.Status {
+	return status.New(codes.Unavailable, e.Error())
+}
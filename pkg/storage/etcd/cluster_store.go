@@ -2,8 +2,11 @@ package etcd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path"
+	"strings"
+	"time"
 
 	"github.com/rancher/opni-monitoring/pkg/core"
 	"github.com/rancher/opni-monitoring/pkg/storage"
@@ -20,10 +23,17 @@ func (e *EtcdStore) CreateCluster(ctx context.Context, cluster *core.Cluster) er
 	}
 	ctx, ca := context.WithTimeout(ctx, e.CommandTimeout)
 	defer ca()
-	_, err = e.Client.Put(ctx, path.Join(e.Prefix, clusterKey, cluster.Id), string(data))
+	key := path.Join(e.Prefix, clusterKey, cluster.Id)
+	resp, err := e.Client.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(key), "=", 0)).
+		Then(clientv3.OpPut(key, string(data))).
+		Commit()
 	if err != nil {
 		return fmt.Errorf("failed to create cluster: %w", err)
 	}
+	if !resp.Succeeded {
+		return storage.ErrAlreadyExists
+	}
 	return nil
 }
 
@@ -45,13 +55,9 @@ func (e *EtcdStore) ListClusters(
 	matchLabels *core.LabelSelector,
 	matchOptions core.MatchOptions,
 ) (*core.ClusterList, error) {
-	ctx, ca := context.WithTimeout(ctx, e.CommandTimeout)
-	defer ca()
-	resp, err := e.Client.Get(ctx, path.Join(e.Prefix, clusterKey),
-		clientv3.WithPrefix(),
-		clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
+	all, _, err := e.listClusters(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list clusters: %w", err)
+		return nil, err
 	}
 	clusters := &core.ClusterList{
 		Items: []*core.Cluster{},
@@ -61,11 +67,7 @@ func (e *EtcdStore) ListClusters(
 		MatchOptions:  matchOptions,
 	}.Predicate()
 
-	for _, kv := range resp.Kvs {
-		cluster := &core.Cluster{}
-		if err := protojson.Unmarshal(kv.Value, cluster); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal cluster: %w", err)
-		}
+	for _, cluster := range all {
 		if selectorPredicate(cluster) {
 			clusters.Items = append(clusters.Items, cluster)
 		}
@@ -73,6 +75,28 @@ func (e *EtcdStore) ListClusters(
 	return clusters, nil
 }
 
+// listClusters returns all clusters, and the revision at which they were
+// read.
+func (e *EtcdStore) listClusters(ctx context.Context) ([]*core.Cluster, int64, error) {
+	ctx, ca := context.WithTimeout(ctx, e.CommandTimeout)
+	defer ca()
+	resp, err := e.Client.Get(ctx, path.Join(e.Prefix, clusterKey),
+		clientv3.WithPrefix(),
+		clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list clusters: %w", err)
+	}
+	clusters := make([]*core.Cluster, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		cluster := &core.Cluster{}
+		if err := protojson.Unmarshal(kv.Value, cluster); err != nil {
+			return nil, 0, fmt.Errorf("failed to unmarshal cluster: %w", err)
+		}
+		clusters = append(clusters, cluster)
+	}
+	return clusters, resp.Header.Revision, nil
+}
+
 func (e *EtcdStore) GetCluster(ctx context.Context, ref *core.Reference) (*core.Cluster, error) {
 	ctx, ca := context.WithTimeout(ctx, e.CommandTimeout)
 	defer ca()
@@ -137,3 +161,103 @@ func (e *EtcdStore) UpdateCluster(
 	}
 	return retCluster, nil
 }
+
+func (e *EtcdStore) WatchClusters(
+	ctx context.Context,
+	knownClusters []*core.Cluster,
+) (<-chan storage.ClusterWatchEvent, error) {
+	clusters, revision, err := e.listClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	state := storage.NewClusterWatchState(knownClusters)
+	eventC := make(chan storage.ClusterWatchEvent, 64)
+	send := func(event storage.ClusterWatchEvent) bool {
+		select {
+		case eventC <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	go func() {
+		defer close(eventC)
+		b := watchRetryPolicy.Start()
+		for {
+			for _, event := range state.Sync(clusters) {
+				if !send(event) {
+					return
+				}
+			}
+			err := e.watchClusterChanges(ctx, revision+1, state, send)
+			if ctx.Err() != nil {
+				return
+			}
+			e.Logger.With(
+				zap.Error(err),
+			).Warn("cluster watch failed, restarting")
+
+			// the watch cannot always be resumed from the last revision (e.g. if
+			// it has been compacted), so list the clusters again and send any
+			// changes which were missed
+			for {
+				select {
+				case <-time.After(b.Next()):
+				case <-ctx.Done():
+					return
+				}
+				clusters, revision, err = e.listClusters(ctx)
+				if err == nil {
+					b.Reset()
+					break
+				}
+				e.Logger.With(
+					zap.Error(err),
+				).Warn("failed to list clusters")
+			}
+		}
+	}()
+	return eventC, nil
+}
+
+// watchClusterChanges sends events for changes to clusters made at or after
+// the given revision, until the watch fails or ctx is done.
+func (e *EtcdStore) watchClusterChanges(
+	ctx context.Context,
+	revision int64,
+	state *storage.ClusterWatchState,
+	send func(storage.ClusterWatchEvent) bool,
+) error {
+	ctx, ca := context.WithCancel(ctx)
+	defer ca()
+	prefix := path.Join(e.Prefix, clusterKey) + "/"
+	wc := e.Client.Watch(clientv3.WithRequireLeader(ctx), prefix,
+		clientv3.WithPrefix(),
+		clientv3.WithRev(revision))
+	for resp := range wc {
+		if err := resp.Err(); err != nil {
+			return err
+		}
+		for _, ev := range resp.Events {
+			var event storage.ClusterWatchEvent
+			var ok bool
+			switch ev.Type {
+			case clientv3.EventTypePut:
+				cluster := &core.Cluster{}
+				if err := protojson.Unmarshal(ev.Kv.Value, cluster); err != nil {
+					return fmt.Errorf("failed to unmarshal cluster: %w", err)
+				}
+				event, ok = state.Put(cluster)
+			case clientv3.EventTypeDelete:
+				event, ok = state.Delete(strings.TrimPrefix(string(ev.Kv.Key), prefix))
+			}
+			if ok && !send(event) {
+				return ctx.Err()
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errors.New("watch channel closed")
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/rancher/opni-monitoring/pkg/logger"
 	"github.com/rancher/opni-monitoring/pkg/storage"
 	"github.com/rancher/opni-monitoring/pkg/util"
+	"github.com/rancher/opni-monitoring/pkg/util/backoff"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -33,11 +34,12 @@ func isRetryErr(err error) bool {
 }
 
 const (
-	tokensKey      = "tokens"
-	clusterKey     = "clusters"
-	keyringKey     = "keyrings"
-	roleKey        = "roles"
-	roleBindingKey = "rolebindings"
+	tokensKey         = "tokens"
+	clusterKey        = "clusters"
+	keyringKey        = "keyrings"
+	keyringVersionKey = "keyring-versions"
+	roleKey           = "roles"
+	roleBindingKey    = "rolebindings"
 )
 
 // EtcdStore implements TokenStore and TenantStore.
@@ -52,6 +54,7 @@ var _ storage.Backend = (*EtcdStore)(nil)
 type EtcdStoreOptions struct {
 	Prefix         string
 	CommandTimeout time.Duration
+	RetryPolicy    backoff.Policy
 }
 
 type EtcdStoreOption func(*EtcdStoreOptions)
@@ -74,11 +77,38 @@ func WithCommandTimeout(timeout time.Duration) EtcdStoreOption {
 	}
 }
 
-func NewEtcdStore(ctx context.Context, conf *v1beta1.EtcdStorageSpec, opts ...EtcdStoreOption) *EtcdStore {
-	options := EtcdStoreOptions{
-		CommandTimeout: 5 * time.Second,
+// WithRetryPolicy sets the policy used to retry requests which fail due to
+// transient errors, such as an etcd leader change. Requests are still bounded
+// by the command timeout.
+func WithRetryPolicy(policy backoff.Policy) EtcdStoreOption {
+	return func(o *EtcdStoreOptions) {
+		o.RetryPolicy = policy
 	}
-	options.Apply(opts...)
+}
+
+// DefaultStartupCheckWindow is how long CheckConnectivity waits for etcd to
+// become reachable when the gateway starts.
+const DefaultStartupCheckWindow = 30 * time.Second
+
+// startupCheckPolicy is the policy used to retry connecting to etcd within
+// the startup check window.
+var startupCheckPolicy = backoff.Policy{
+	Base:       100 * time.Millisecond,
+	Max:        5 * time.Second,
+	Multiplier: 2,
+	Jitter:     0.2,
+}
+
+// watchRetryPolicy is the policy used to wait between attempts to restart
+// a watch after it fails.
+var watchRetryPolicy = backoff.Policy{
+	Base:       100 * time.Millisecond,
+	Max:        10 * time.Second,
+	Multiplier: 2,
+	Jitter:     0.2,
+}
+
+func NewEtcdStore(ctx context.Context, conf *v1beta1.EtcdStorageSpec, opts ...EtcdStoreOption) *EtcdStore {
 	lg := logger.New().Named("etcd")
 	var tlsConfig *tls.Config
 	if conf.Certs != nil {
@@ -103,13 +133,57 @@ func NewEtcdStore(ctx context.Context, conf *v1beta1.EtcdStorageSpec, opts ...Et
 	lg.With(
 		"endpoints", clientConfig.Endpoints,
 	).Info("connecting to etcd")
+	return NewEtcdStoreWithClient(cli, opts...)
+}
+
+// NewEtcdStoreWithClient creates a new EtcdStore using an existing client.
+// The client's KV and Lease implementations will be replaced with ones that
+// retry requests which fail due to transient errors.
+func NewEtcdStoreWithClient(cli *clientv3.Client, opts ...EtcdStoreOption) *EtcdStore {
+	options := EtcdStoreOptions{
+		CommandTimeout: 5 * time.Second,
+		RetryPolicy:    DefaultRetryPolicy,
+	}
+	options.Apply(opts...)
+	wrapClientWithRetry(cli, options.RetryPolicy)
 	return &EtcdStore{
 		EtcdStoreOptions: options,
-		Logger:           lg,
+		Logger:           logger.New().Named("etcd"),
 		Client:           cli,
 	}
 }
 
+// CheckConnectivity checks that at least one etcd endpoint is reachable,
+// retrying with backoff for up to the given window. If no endpoint could be
+// reached in time, a *storage.StorageUnavailableError listing the endpoints
+// is returned, which matches storage.ErrStorageUnavailable.
+func (e *EtcdStore) CheckConnectivity(ctx context.Context, window time.Duration) error {
+	ctx, ca := context.WithTimeout(ctx, window)
+	defer ca()
+	var lastErr error
+	err := backoff.Retry(ctx, func(ctx context.Context) error {
+		for _, endpoint := range e.Client.Endpoints() {
+			attemptCtx, ca := context.WithTimeout(ctx, e.CommandTimeout)
+			_, lastErr = e.Client.Status(attemptCtx, endpoint)
+			ca()
+			if lastErr == nil {
+				return nil
+			}
+		}
+		return lastErr
+	}, startupCheckPolicy)
+	if err != nil {
+		if lastErr == nil {
+			lastErr = err
+		}
+		return &storage.StorageUnavailableError{
+			Endpoints: e.Client.Endpoints(),
+			Err:       lastErr,
+		}
+	}
+	return nil
+}
+
 func (e *EtcdStore) KeyringStore(ctx context.Context, prefix string, ref *core.Reference) (storage.KeyringStore, error) {
 	pfx := e.Prefix
 	if prefix != "" {
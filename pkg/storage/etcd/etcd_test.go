@@ -0,0 +1,48 @@
+package etcd_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
+	"github.com/rancher/opni-monitoring/pkg/storage"
+	"github.com/rancher/opni-monitoring/pkg/storage/etcd"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Startup Check", Label(test.Unit, test.Slow), func() {
+	It("should succeed if etcd is reachable", func() {
+		Expect(store.Get().CheckConnectivity(context.Background(), time.Second)).To(Succeed())
+	})
+	It("should return ErrStorageUnavailable after the window if etcd is unreachable", func() {
+		listener, err := net.Listen("tcp4", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		addr := listener.Addr().String()
+		listener.Close()
+
+		endpoints := []string{"http://" + addr}
+		unreachable := etcd.NewEtcdStore(context.Background(), &v1beta1.EtcdStorageSpec{
+			Endpoints: endpoints,
+		}, etcd.WithCommandTimeout(100*time.Millisecond))
+		DeferCleanup(unreachable.Client.Close)
+
+		window := 1 * time.Second
+		start := time.Now()
+		err = unreachable.CheckConnectivity(context.Background(), window)
+		Expect(time.Since(start)).To(And(
+			BeNumerically(">=", window),
+			BeNumerically("<", 2*window),
+		))
+		Expect(errors.Is(err, storage.ErrStorageUnavailable)).To(BeTrue())
+		var unavailable *storage.StorageUnavailableError
+		Expect(errors.As(err, &unavailable)).To(BeTrue())
+		Expect(unavailable.Endpoints).To(Equal(endpoints))
+		Expect(unavailable.Err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(addr))
+	})
+})
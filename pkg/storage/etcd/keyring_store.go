@@ -9,8 +9,13 @@ import (
 	"github.com/rancher/opni-monitoring/pkg/keyring"
 	"github.com/rancher/opni-monitoring/pkg/storage"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"k8s.io/client-go/util/retry"
 )
 
+// The active keyring is stored at keyrings/<id>. When the keyring is
+// rotated, the previously active keyring is moved to
+// keyring-versions/<id>/<revision>, where revision is the etcd revision at
+// which it was last modified, so that versions sort from oldest to newest.
 type etcdKeyringStore struct {
 	EtcdStoreOptions
 	client *clientv3.Client
@@ -18,6 +23,18 @@ type etcdKeyringStore struct {
 	prefix string
 }
 
+func (ks *etcdKeyringStore) activeKey() string {
+	return path.Join(ks.prefix, keyringKey, ks.ref.Id)
+}
+
+func (ks *etcdKeyringStore) versionsPrefix() string {
+	return path.Join(ks.prefix, keyringVersionKey, ks.ref.Id) + "/"
+}
+
+func (ks *etcdKeyringStore) versionKey(revision int64) string {
+	return ks.versionsPrefix() + fmt.Sprintf("%020d", revision)
+}
+
 func (ks *etcdKeyringStore) Put(ctx context.Context, keyring keyring.Keyring) error {
 	ctx, ca := context.WithTimeout(ctx, ks.CommandTimeout)
 	defer ca()
@@ -25,7 +42,7 @@ func (ks *etcdKeyringStore) Put(ctx context.Context, keyring keyring.Keyring) er
 	if err != nil {
 		return fmt.Errorf("failed to marshal keyring: %w", err)
 	}
-	_, err = ks.client.Put(ctx, path.Join(ks.prefix, keyringKey, ks.ref.Id), string(k))
+	_, err = ks.client.Put(ctx, ks.activeKey(), string(k))
 	if err != nil {
 		return fmt.Errorf("failed to put keyring: %w", err)
 	}
@@ -35,7 +52,7 @@ func (ks *etcdKeyringStore) Put(ctx context.Context, keyring keyring.Keyring) er
 func (ks *etcdKeyringStore) Get(ctx context.Context) (keyring.Keyring, error) {
 	ctx, ca := context.WithTimeout(ctx, ks.CommandTimeout)
 	defer ca()
-	resp, err := ks.client.Get(ctx, path.Join(ks.prefix, keyringKey, ks.ref.Id))
+	resp, err := ks.client.Get(ctx, ks.activeKey())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get keyring: %w", err)
 	}
@@ -48,3 +65,134 @@ func (ks *etcdKeyringStore) Get(ctx context.Context) (keyring.Keyring, error) {
 	}
 	return k, nil
 }
+
+func (ks *etcdKeyringStore) Append(ctx context.Context, kr keyring.Keyring) error {
+	return retry.OnError(defaultBackoff, isRetryErr, func() error {
+		ctx, ca := context.WithTimeout(ctx, ks.CommandTimeout)
+		defer ca()
+		key := ks.activeKey()
+		resp, err := ks.client.Get(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to get keyring: %w", err)
+		}
+		merged := kr
+		var modRevision int64
+		if len(resp.Kvs) > 0 {
+			modRevision = resp.Kvs[0].ModRevision
+			existing, err := keyring.Unmarshal(resp.Kvs[0].Value)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal keyring: %w", err)
+			}
+			merged = kr.Merge(existing)
+		}
+		k, err := merged.Marshal()
+		if err != nil {
+			return fmt.Errorf("failed to marshal keyring: %w", err)
+		}
+		txnResp, err := ks.client.Txn(ctx).
+			If(clientv3.Compare(clientv3.ModRevision(key), "=", modRevision)).
+			Then(clientv3.OpPut(key, string(k))).
+			Commit()
+		if err != nil {
+			return fmt.Errorf("failed to append keyring: %w", err)
+		}
+		if !txnResp.Succeeded {
+			return retryErr
+		}
+		return nil
+	})
+}
+
+func (ks *etcdKeyringStore) Rotate(ctx context.Context, keyring keyring.Keyring) error {
+	k, err := keyring.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal keyring: %w", err)
+	}
+	return retry.OnError(defaultBackoff, isRetryErr, func() error {
+		ctx, ca := context.WithTimeout(ctx, ks.CommandTimeout)
+		defer ca()
+		key := ks.activeKey()
+		resp, err := ks.client.Txn(ctx).Then(
+			clientv3.OpGet(key),
+			clientv3.OpGet(ks.versionsPrefix(), clientv3.WithPrefix(), clientv3.WithKeysOnly(),
+				clientv3.WithSort(clientv3.SortByKey, clientv3.SortDescend)),
+		).Commit()
+		if err != nil {
+			return fmt.Errorf("failed to get keyring: %w", err)
+		}
+		active := resp.Responses[0].GetResponseRange().GetKvs()
+		versions := resp.Responses[1].GetResponseRange().GetKvs()
+		var modRevision int64
+		ops := []clientv3.Op{clientv3.OpPut(key, string(k))}
+		if len(active) > 0 {
+			prev := active[0]
+			modRevision = prev.ModRevision
+			ops = append(ops, clientv3.OpPut(ks.versionKey(prev.ModRevision), string(prev.Value)))
+			// make room for the previously active keyring
+			for i := storage.MaxPreviousKeyringVersions - 1; i < len(versions); i++ {
+				ops = append(ops, clientv3.OpDelete(string(versions[i].Key)))
+			}
+		}
+		txnResp, err := ks.client.Txn(ctx).
+			If(clientv3.Compare(clientv3.ModRevision(key), "=", modRevision)).
+			Then(ops...).
+			Commit()
+		if err != nil {
+			return fmt.Errorf("failed to rotate keyring: %w", err)
+		}
+		if !txnResp.Succeeded {
+			return retryErr
+		}
+		return nil
+	})
+}
+
+func (ks *etcdKeyringStore) List(ctx context.Context) ([]keyring.Keyring, error) {
+	ctx, ca := context.WithTimeout(ctx, ks.CommandTimeout)
+	defer ca()
+	txnResp, err := ks.client.Txn(ctx).Then(
+		clientv3.OpGet(ks.activeKey()),
+		clientv3.OpGet(ks.versionsPrefix(), clientv3.WithPrefix(),
+			clientv3.WithSort(clientv3.SortByKey, clientv3.SortDescend)),
+	).Commit()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keyrings: %w", err)
+	}
+	active := txnResp.Responses[0].GetResponseRange().GetKvs()
+	if len(active) == 0 {
+		return nil, storage.ErrNotFound
+	}
+	kvs := append(active, txnResp.Responses[1].GetResponseRange().GetKvs()...)
+	keyrings := make([]keyring.Keyring, 0, len(kvs))
+	for _, kv := range kvs {
+		k, err := keyring.Unmarshal(kv.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal keyring: %w", err)
+		}
+		keyrings = append(keyrings, k)
+	}
+	return keyrings, nil
+}
+
+func (ks *etcdKeyringStore) Prune(ctx context.Context) error {
+	ctx, ca := context.WithTimeout(ctx, ks.CommandTimeout)
+	defer ca()
+	_, err := ks.client.Delete(ctx, ks.versionsPrefix(), clientv3.WithPrefix())
+	if err != nil {
+		return fmt.Errorf("failed to prune keyrings: %w", err)
+	}
+	return nil
+}
+
+func (ks *etcdKeyringStore) Delete(ctx context.Context) error {
+	ctx, ca := context.WithTimeout(ctx, ks.CommandTimeout)
+	defer ca()
+	_, err := ks.client.Txn(ctx).Then(
+		clientv3.OpDelete(ks.activeKey()),
+		clientv3.OpDelete(ks.versionsPrefix(), clientv3.WithPrefix()),
+	).Commit()
+	if err != nil {
+		return fmt.Errorf("failed to delete keyrings: %w", err)
+	}
+	return nil
+}
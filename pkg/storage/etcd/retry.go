@@ -0,0 +1,200 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rancher/opni-monitoring/pkg/util/backoff"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultRetryPolicy is the policy used to retry etcd requests which fail
+// due to transient errors, such as a leader election.
+var DefaultRetryPolicy = backoff.Policy{
+	Base:        10 * time.Millisecond,
+	Max:         500 * time.Millisecond,
+	Multiplier:  2,
+	Jitter:      0.1,
+	MaxAttempts: 5,
+}
+
+// isTransientErr returns true if the error could have been caused by a
+// leader change or election in the etcd cluster, and a read request can
+// safely be retried. Writes which fail with these errors may still have been
+// applied, so they must only be retried using isUnappliedErr.
+func isTransientErr(err error) bool {
+	if isUnappliedErr(err) {
+		return true
+	}
+	if errors.Is(err, rpctypes.ErrLeaderChanged) {
+		return true
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	return status.Code(err) == codes.DeadlineExceeded
+}
+
+// isUnappliedErr returns true if the error guarantees that the request was
+// rejected before it was applied, so that a write can safely be retried.
+func isUnappliedErr(err error) bool {
+	return errors.Is(err, rpctypes.ErrNoLeader)
+}
+
+// withRetry calls fn until it succeeds or returns an error for which
+// retryable returns false. Errors caused by the caller's context being
+// canceled or exceeding its deadline are never retried.
+func withRetry(ctx context.Context, policy backoff.Policy, retryable func(error) bool, fn func() error) error {
+	return backoff.Retry(ctx, func(ctx context.Context) error {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil || !retryable(err) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}, policy)
+}
+
+// wrapClientWithRetry replaces the client's KV and Lease implementations with
+// ones that retry requests which fail due to transient errors. Reads are
+// retried after any transient error, but writes are only retried if they were
+// not applied. Callers which need to retry writes after other errors must do
+// so using a compare-and-swap, so that a write is never applied twice.
+func wrapClientWithRetry(cli *clientv3.Client, policy backoff.Policy) {
+	cli.KV = &retryKV{
+		KV:     cli.KV,
+		policy: policy,
+	}
+	cli.Lease = &retryLease{
+		Lease:  cli.Lease,
+		policy: policy,
+	}
+}
+
+// retryKV retries requests which fail due to transient errors, as described
+// in wrapClientWithRetry.
+type retryKV struct {
+	clientv3.KV
+	policy backoff.Policy
+}
+
+func (kv *retryKV) Put(ctx context.Context, key, val string, opts ...clientv3.OpOption) (resp *clientv3.PutResponse, err error) {
+	err = withRetry(ctx, kv.policy, isUnappliedErr, func() error {
+		resp, err = kv.KV.Put(ctx, key, val, opts...)
+		return err
+	})
+	return
+}
+
+func (kv *retryKV) Get(ctx context.Context, key string, opts ...clientv3.OpOption) (resp *clientv3.GetResponse, err error) {
+	err = withRetry(ctx, kv.policy, isTransientErr, func() error {
+		resp, err = kv.KV.Get(ctx, key, opts...)
+		return err
+	})
+	return
+}
+
+func (kv *retryKV) Delete(ctx context.Context, key string, opts ...clientv3.OpOption) (resp *clientv3.DeleteResponse, err error) {
+	err = withRetry(ctx, kv.policy, isUnappliedErr, func() error {
+		resp, err = kv.KV.Delete(ctx, key, opts...)
+		return err
+	})
+	return
+}
+
+func (kv *retryKV) Txn(ctx context.Context) clientv3.Txn {
+	return &retryTxn{
+		kv:  kv,
+		ctx: ctx,
+	}
+}
+
+// retryTxn records the comparisons and operations of a transaction so that
+// it can be rebuilt and committed again if the commit fails.
+type retryTxn struct {
+	kv      *retryKV
+	ctx     context.Context
+	cmps    []clientv3.Cmp
+	thenOps []clientv3.Op
+	elseOps []clientv3.Op
+}
+
+func (t *retryTxn) If(cs ...clientv3.Cmp) clientv3.Txn {
+	t.cmps = append(t.cmps, cs...)
+	return t
+}
+
+func (t *retryTxn) Then(ops ...clientv3.Op) clientv3.Txn {
+	t.thenOps = append(t.thenOps, ops...)
+	return t
+}
+
+func (t *retryTxn) Else(ops ...clientv3.Op) clientv3.Txn {
+	t.elseOps = append(t.elseOps, ops...)
+	return t
+}
+
+// readOnly returns true if none of the transaction's operations are writes.
+func (t *retryTxn) readOnly() bool {
+	for _, ops := range [][]clientv3.Op{t.thenOps, t.elseOps} {
+		for _, op := range ops {
+			if !op.IsGet() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (t *retryTxn) Commit() (resp *clientv3.TxnResponse, err error) {
+	retryable := isUnappliedErr
+	if t.readOnly() {
+		retryable = isTransientErr
+	}
+	err = withRetry(t.ctx, t.kv.policy, retryable, func() error {
+		resp, err = t.kv.KV.Txn(t.ctx).
+			If(t.cmps...).
+			Then(t.thenOps...).
+			Else(t.elseOps...).
+			Commit()
+		return err
+	})
+	return
+}
+
+// retryLease retries lease requests which fail due to transient errors, as
+// described in wrapClientWithRetry.
+type retryLease struct {
+	clientv3.Lease
+	policy backoff.Policy
+}
+
+func (l *retryLease) Grant(ctx context.Context, ttl int64) (resp *clientv3.LeaseGrantResponse, err error) {
+	err = withRetry(ctx, l.policy, isUnappliedErr, func() error {
+		resp, err = l.Lease.Grant(ctx, ttl)
+		return err
+	})
+	return
+}
+
+func (l *retryLease) Revoke(ctx context.Context, id clientv3.LeaseID) (resp *clientv3.LeaseRevokeResponse, err error) {
+	err = withRetry(ctx, l.policy, isUnappliedErr, func() error {
+		resp, err = l.Lease.Revoke(ctx, id)
+		return err
+	})
+	return
+}
+
+func (l *retryLease) TimeToLive(ctx context.Context, id clientv3.LeaseID, opts ...clientv3.LeaseOption) (resp *clientv3.LeaseTimeToLiveResponse, err error) {
+	err = withRetry(ctx, l.policy, isTransientErr, func() error {
+		resp, err = l.Lease.TimeToLive(ctx, id, opts...)
+		return err
+	})
+	return
+}
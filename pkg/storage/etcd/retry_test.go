@@ -0,0 +1,190 @@
+package etcd_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/storage/etcd"
+	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/pkg/util/backoff"
+)
+
+// stubKV returns the queued errors for each request in order, then succeeds.
+type stubKV struct {
+	clientv3.KV
+	errors  []error
+	calls   int
+	value   []byte
+	version int64
+}
+
+func (kv *stubKV) next() error {
+	kv.calls++
+	if len(kv.errors) > 0 {
+		err := kv.errors[0]
+		kv.errors = kv.errors[1:]
+		return err
+	}
+	return nil
+}
+
+func (kv *stubKV) Put(context.Context, string, string, ...clientv3.OpOption) (*clientv3.PutResponse, error) {
+	if err := kv.next(); err != nil {
+		return nil, err
+	}
+	return &clientv3.PutResponse{}, nil
+}
+
+func (kv *stubKV) Get(context.Context, string, ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	if err := kv.next(); err != nil {
+		return nil, err
+	}
+	return &clientv3.GetResponse{
+		Kvs: []*mvccpb.KeyValue{
+			{Value: kv.value, Version: kv.version},
+		},
+	}, nil
+}
+
+func (kv *stubKV) Txn(context.Context) clientv3.Txn {
+	return &stubTxn{kv: kv}
+}
+
+type stubTxn struct {
+	clientv3.Txn
+	kv *stubKV
+}
+
+func (t *stubTxn) If(...clientv3.Cmp) clientv3.Txn  { return t }
+func (t *stubTxn) Then(...clientv3.Op) clientv3.Txn { return t }
+func (t *stubTxn) Else(...clientv3.Op) clientv3.Txn { return t }
+
+func (t *stubTxn) Commit() (*clientv3.TxnResponse, error) {
+	if err := t.kv.next(); err != nil {
+		return nil, err
+	}
+	return &clientv3.TxnResponse{Succeeded: true}, nil
+}
+
+var _ = Describe("Retries", Label(test.Unit), func() {
+	var kv *stubKV
+	var store *etcd.EtcdStore
+	BeforeEach(func() {
+		data, err := protojson.Marshal(&core.Cluster{Id: "foo"})
+		Expect(err).NotTo(HaveOccurred())
+		kv = &stubKV{
+			value:   data,
+			version: 1,
+		}
+		store = etcd.NewEtcdStoreWithClient(&clientv3.Client{KV: kv},
+			etcd.WithPrefix("test"),
+			etcd.WithRetryPolicy(backoff.Policy{
+				Base:        time.Millisecond,
+				MaxAttempts: 3,
+			}),
+		)
+	})
+
+	When("the leader changes during a read", func() {
+		It("should retry the request", func() {
+			kv.errors = []error{rpctypes.ErrLeaderChanged}
+			cluster, err := store.GetCluster(context.Background(), &core.Reference{Id: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cluster.GetId()).To(Equal("foo"))
+			Expect(kv.calls).To(Equal(2))
+		})
+		It("should retry read-only transactions", func() {
+			kv.errors = []error{rpctypes.ErrLeaderChanged}
+			_, err := store.Client.Txn(context.Background()).
+				Then(clientv3.OpGet("foo")).
+				Commit()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(kv.calls).To(Equal(2))
+		})
+	})
+	When("the leader changes during a write", func() {
+		// the write may have been applied, so retrying it could apply it twice
+		It("should not retry the request", func() {
+			kv.errors = []error{rpctypes.ErrLeaderChanged}
+			err := store.CreateCluster(context.Background(), &core.Cluster{Id: "foo"})
+			Expect(err).To(MatchError(rpctypes.ErrLeaderChanged))
+			Expect(kv.calls).To(Equal(1))
+		})
+		It("should not retry transactions", func() {
+			kv.errors = []error{nil, rpctypes.ErrLeaderChanged}
+			_, err := store.UpdateCluster(context.Background(), &core.Reference{Id: "foo"},
+				func(c *core.Cluster) {
+					c.Metadata = &core.ClusterMetadata{
+						Labels: map[string]string{"foo": "bar"},
+					}
+				})
+			Expect(err).To(MatchError(rpctypes.ErrLeaderChanged))
+			Expect(kv.calls).To(Equal(2))
+		})
+		It("should not retry after a deadline exceeded error", func() {
+			kv.errors = []error{status.Error(codes.DeadlineExceeded, "context deadline exceeded")}
+			err := store.CreateCluster(context.Background(), &core.Cluster{Id: "foo"})
+			Expect(err).To(HaveOccurred())
+			Expect(kv.calls).To(Equal(1))
+		})
+	})
+	When("a write is rejected because there is no leader", func() {
+		It("should retry the request", func() {
+			kv.errors = []error{rpctypes.ErrNoLeader}
+			Expect(store.CreateCluster(context.Background(), &core.Cluster{Id: "foo"})).To(Succeed())
+			Expect(kv.calls).To(Equal(2))
+		})
+		It("should retry transactions", func() {
+			kv.errors = []error{nil, rpctypes.ErrNoLeader}
+			cluster, err := store.UpdateCluster(context.Background(), &core.Reference{Id: "foo"},
+				func(c *core.Cluster) {
+					c.Metadata = &core.ClusterMetadata{
+						Labels: map[string]string{"foo": "bar"},
+					}
+				})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cluster.GetLabels()).To(HaveKeyWithValue("foo", "bar"))
+			Expect(kv.calls).To(Equal(3))
+		})
+	})
+	When("the server reports a deadline exceeded error for a read", func() {
+		It("should retry the request", func() {
+			kv.errors = []error{status.Error(codes.DeadlineExceeded, "context deadline exceeded")}
+			cluster, err := store.GetCluster(context.Background(), &core.Reference{Id: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cluster.GetId()).To(Equal("foo"))
+			Expect(kv.calls).To(Equal(2))
+		})
+	})
+	When("a request fails with a non-retryable error", func() {
+		It("should return the error immediately", func() {
+			kv.errors = []error{rpctypes.ErrDuplicateKey}
+			err := store.CreateCluster(context.Background(), &core.Cluster{Id: "foo"})
+			Expect(err).To(MatchError(rpctypes.ErrDuplicateKey))
+			Expect(kv.calls).To(Equal(1))
+		})
+	})
+	When("the leader keeps changing", func() {
+		It("should give up after the maximum number of attempts", func() {
+			kv.errors = []error{
+				rpctypes.ErrLeaderChanged,
+				rpctypes.ErrLeaderChanged,
+				rpctypes.ErrLeaderChanged,
+				rpctypes.ErrLeaderChanged,
+			}
+			_, err := store.GetCluster(context.Background(), &core.Reference{Id: "foo"})
+			Expect(err).To(MatchError(backoff.ErrMaxAttemptsExceeded))
+			Expect(kv.calls).To(Equal(3))
+		})
+	})
+})
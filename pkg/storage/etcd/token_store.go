@@ -26,11 +26,16 @@ func (e *EtcdStore) CreateToken(ctx context.Context, ttl time.Duration, opts ...
 		return nil, fmt.Errorf("failed to create lease: %w", err)
 	}
 	token := tokens.NewToken().ToBootstrapToken()
+	now := time.Now()
 	token.Metadata = &core.BootstrapTokenMetadata{
-		LeaseID:      int64(lease.ID),
-		UsageCount:   0,
-		Labels:       options.Labels,
-		Capabilities: options.Capabilities,
+		LeaseID:             int64(lease.ID),
+		UsageCount:          0,
+		Labels:              options.Labels,
+		Capabilities:        options.Capabilities,
+		AllowedCapabilities: options.AllowedCapabilities,
+		MaxUsages:           options.MaxUsages,
+		NotBefore:           now.Unix(),
+		NotAfter:            now.Add(ttl).Unix(),
 	}
 	data, err := protojson.Marshal(token)
 	if err != nil {
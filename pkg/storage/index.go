@@ -0,0 +1,281 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+)
+
+type idSet = map[string]struct{}
+
+// ClusterIndex is an in-memory inverted index of cluster labels. It maps each
+// label key and value to the set of clusters which have that label, which
+// allows equality-based selectors (MatchLabels, and the In and Exists
+// operators) to be evaluated by intersecting posting lists instead of scanning
+// every known cluster.
+//
+// The index is kept up to date by a cluster watch, either by calling Sync or
+// Watch, or by calling Put when clusters are created or updated and Delete
+// when they are removed.
+type ClusterIndex struct {
+	mu       sync.RWMutex
+	synced   bool
+	clusters map[string]*core.Cluster
+	// label key -> label value -> cluster ids
+	postings map[string]map[string]idSet
+}
+
+func NewClusterIndex() *ClusterIndex {
+	return &ClusterIndex{
+		clusters: map[string]*core.Cluster{},
+		postings: map[string]map[string]idSet{},
+	}
+}
+
+// Put adds a cluster to the index, or replaces the existing entry if a
+// cluster with the same ID has already been indexed.
+func (i *ClusterIndex) Put(cluster *core.Cluster) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.put(cluster)
+}
+
+func (i *ClusterIndex) put(cluster *core.Cluster) {
+	i.remove(cluster.GetId())
+	i.clusters[cluster.GetId()] = cluster
+	for k, v := range cluster.GetLabels() {
+		values, ok := i.postings[k]
+		if !ok {
+			values = map[string]idSet{}
+			i.postings[k] = values
+		}
+		ids, ok := values[v]
+		if !ok {
+			ids = idSet{}
+			values[v] = ids
+		}
+		ids[cluster.GetId()] = struct{}{}
+	}
+}
+
+// Delete removes a cluster from the index. It is a no-op if the cluster
+// has not been indexed.
+func (i *ClusterIndex) Delete(id string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.remove(id)
+}
+
+// Reset replaces the contents of the index with the given clusters.
+func (i *ClusterIndex) Reset(clusters []*core.Cluster) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.clusters = map[string]*core.Cluster{}
+	i.postings = map[string]map[string]idSet{}
+	for _, c := range clusters {
+		i.put(c)
+	}
+}
+
+// Get returns the indexed cluster with the given ID. The returned cluster is
+// shared with the index, and must not be modified.
+func (i *ClusterIndex) Get(id string) (*core.Cluster, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	c, ok := i.clusters[id]
+	return c, ok
+}
+
+// Sync replaces the contents of the index with all clusters in the store,
+// then keeps it up to date using Watch until ctx is done. Once Sync returns
+// without an error, Synced will return true.
+func (i *ClusterIndex) Sync(ctx context.Context, store ClusterStore) error {
+	list, err := store.ListClusters(ctx, nil, 0)
+	if err != nil {
+		return err
+	}
+	i.Reset(list.Items)
+	if err := i.Watch(ctx, store); err != nil {
+		return err
+	}
+	i.mu.Lock()
+	i.synced = true
+	i.mu.Unlock()
+	return nil
+}
+
+// Synced returns true once the index has been populated by Sync.
+func (i *ClusterIndex) Synced() bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.synced
+}
+
+// Watch keeps the index up to date with the clusters in the store until ctx
+// is done. Clusters which have already been indexed are not sent again by
+// the watch, so the index can be populated from an existing list first.
+// Watch returns once the watch has started, or if it could not be started.
+func (i *ClusterIndex) Watch(ctx context.Context, store ClusterStore) error {
+	i.mu.RLock()
+	known := make([]*core.Cluster, 0, len(i.clusters))
+	for _, c := range i.clusters {
+		known = append(known, c)
+	}
+	i.mu.RUnlock()
+
+	eventC, err := store.WatchClusters(ctx, known)
+	if err != nil {
+		return err
+	}
+	go func() {
+		for event := range eventC {
+			switch event.EventType {
+			case WatchEventCreate, WatchEventUpdate:
+				i.Put(event.Current)
+			case WatchEventDelete:
+				i.Delete(event.Previous.GetId())
+			}
+		}
+	}()
+	return nil
+}
+
+// Len returns the number of indexed clusters.
+func (i *ClusterIndex) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.clusters)
+}
+
+func (i *ClusterIndex) remove(id string) {
+	existing, ok := i.clusters[id]
+	if !ok {
+		return
+	}
+	delete(i.clusters, id)
+	for k, v := range existing.GetLabels() {
+		values := i.postings[k]
+		delete(values[v], id)
+		if len(values[v]) == 0 {
+			delete(values, v)
+		}
+		if len(values) == 0 {
+			delete(i.postings, k)
+		}
+	}
+}
+
+// Select returns all indexed clusters matched by the selector, sorted by ID.
+// The results are identical to evaluating selector.Predicate() against every
+// indexed cluster. When the selector contains equality-based requirements,
+// only the clusters found in the intersection of the relevant posting lists
+// are evaluated; otherwise, every cluster is evaluated.
+func (i *ClusterIndex) Select(selector ClusterSelector) []*core.Cluster {
+	predicate := selector.Predicate()
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	results := []*core.Cluster{}
+	candidates, ok := i.candidates(selector)
+	if !ok {
+		for _, c := range i.clusters {
+			if predicate(c) {
+				results = append(results, c)
+			}
+		}
+	} else {
+		for id := range candidates {
+			if c, ok := i.clusters[id]; ok && predicate(c) {
+				results = append(results, c)
+			}
+		}
+	}
+	sort.Slice(results, func(a, b int) bool {
+		return results[a].GetId() < results[b].GetId()
+	})
+	return results
+}
+
+// candidates returns the set of cluster IDs which could possibly be matched
+// by the selector. If the set cannot be narrowed using the index, ok will
+// be false and all clusters must be considered.
+func (i *ClusterIndex) candidates(selector ClusterSelector) (_ idSet, ok bool) {
+	ls := selector.LabelSelector
+	if ls.IsEmpty() {
+		if len(selector.ClusterIDs) == 0 || len(selector.MatchAny) > 0 {
+			return nil, false
+		}
+		ids := idSet{}
+		for _, id := range selector.ClusterIDs {
+			ids[id] = struct{}{}
+		}
+		return ids, true
+	}
+
+	for _, req := range ls.MatchExpressions {
+		// a matching NotIn requirement matches the selector without checking
+		// the remaining requirements, so their posting lists cannot be used
+		if !req.Negate && core.LabelSelectorOperator(req.Operator) == core.LabelSelectorOpNotIn {
+			return nil, false
+		}
+	}
+
+	lists := []idSet{}
+	for k, v := range ls.MatchLabels {
+		lists = append(lists, i.postings[k][v])
+	}
+	for _, req := range ls.MatchExpressions {
+		if req.Negate {
+			continue
+		}
+		switch core.LabelSelectorOperator(req.Operator) {
+		case core.LabelSelectorOpIn:
+			union := idSet{}
+			for _, v := range req.Values {
+				for id := range i.postings[req.Key][v] {
+					union[id] = struct{}{}
+				}
+			}
+			lists = append(lists, union)
+		case core.LabelSelectorOpExists:
+			union := idSet{}
+			for _, ids := range i.postings[req.Key] {
+				for id := range ids {
+					union[id] = struct{}{}
+				}
+			}
+			lists = append(lists, union)
+		}
+	}
+	if len(lists) == 0 {
+		return nil, false
+	}
+
+	// intersect the posting lists, starting with the smallest
+	sort.Slice(lists, func(a, b int) bool {
+		return len(lists[a]) < len(lists[b])
+	})
+	ids := idSet{}
+	for id := range lists[0] {
+		ids[id] = struct{}{}
+	}
+	for _, list := range lists[1:] {
+		if len(ids) == 0 {
+			break
+		}
+		for id := range ids {
+			if _, ok := list[id]; !ok {
+				delete(ids, id)
+			}
+		}
+	}
+
+	// clusters listed explicitly by ID always match
+	for _, id := range selector.ClusterIDs {
+		ids[id] = struct{}{}
+	}
+	return ids, true
+}
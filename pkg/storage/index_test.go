@@ -0,0 +1,213 @@
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gmeasure"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/storage"
+	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/pkg/test/testutil"
+)
+
+func linearSelect(clusters []*core.Cluster, selector storage.ClusterSelector) []*core.Cluster {
+	predicate := selector.Predicate()
+	results := []*core.Cluster{}
+	for _, c := range clusters {
+		if predicate(c) {
+			results = append(results, c)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Id < results[j].Id
+	})
+	return results
+}
+
+func randomClusters(n int) []*core.Cluster {
+	rng := rand.New(rand.NewSource(0))
+	clusters := make([]*core.Cluster, 0, n)
+	for i := 0; i < n; i++ {
+		labels := []string{
+			"env", []string{"prod", "staging", "dev"}[rng.Intn(3)],
+			"region", fmt.Sprintf("region-%d", rng.Intn(10)),
+			"shard", fmt.Sprint(rng.Intn(100)),
+		}
+		if rng.Intn(2) == 0 {
+			labels = append(labels, "gpu", "true")
+		}
+		clusters = append(clusters, cluster(fmt.Sprintf("c%05d", i), labels...))
+	}
+	return clusters
+}
+
+var _ = Describe("Cluster Index", Label(test.Unit), func() {
+	clusters := []*core.Cluster{
+		cluster("c1"),
+		cluster("c2", "foo", "bar"),
+		cluster("c3", "foo", "baz"),
+		cluster("c4", "foo", "bar", "bar", "quux"),
+		cluster("c5", "bar", "baz"),
+		cluster("c6", "foo", "quux", "bar", "baz"),
+	}
+	var index *storage.ClusterIndex
+	BeforeEach(func() {
+		index = storage.NewClusterIndex()
+		for _, c := range clusters {
+			index.Put(c)
+		}
+	})
+
+	DescribeTable("should return the same results as the linear path",
+		func(selector storage.ClusterSelector) {
+			Expect(index.Select(selector)).To(Equal(linearSelect(clusters, selector)))
+		},
+		Entry(nil, selector()),
+		Entry(nil, selector(core.MatchOptions_EmptySelectorMatchesNone)),
+		Entry(nil, selector("c1")),
+		Entry(nil, selector("c1", "c2", "c9")),
+		Entry(nil, selector(matchLabels("foo", "bar"))),
+		Entry(nil, selector(matchLabels("foo", "bar", "bar", "quux"))),
+		Entry(nil, selector(matchLabels("foo", "missing"))),
+		Entry(nil, selector("c1", matchLabels("foo", "bar"))),
+		Entry(nil, selector(matchExprs("foo In bar,baz"))),
+		Entry(nil, selector(matchExprs("foo In bar,baz", "bar Exists"))),
+		Entry(nil, selector(matchExprs("foo Exists"))),
+		Entry(nil, selector(matchExprs("foo NotIn bar"))),
+		Entry(nil, selector(matchExprs("bar NotIn x", "foo In bar"))),
+		Entry(nil, selector(matchExprs("foo In bar", "bar NotIn x"))),
+		Entry(nil, selector(matchLabels("bar", "baz"), matchExprs("foo NotIn bar", "foo Exists"))),
+		Entry(nil, selector(matchExprs("foo DoesNotExist"))),
+		Entry(nil, selector(matchExprs("foo In bar", "bar DoesNotExist"))),
+		Entry(nil, selector("c5", matchExprs("foo In quux"))),
+		Entry(nil, selector(matchExprs("!foo In bar"))),
+		Entry(nil, selector(matchExprs("!foo Exists"))),
+		Entry(nil, selector(matchExprs("foo Exists", "!foo In bar,quux"))),
+		Entry(nil, selector(exclude{"c1", "c2"})),
+		Entry(nil, selector("c1", "c2", exclude{"c2"})),
+		Entry(nil, selector(matchLabels("foo", "bar"), exclude{"c4"})),
+		Entry(nil, selector(matchExprs("foo Exists", "replicas Gt 1"))),
+		Entry(nil, selector(matchAny{matchLabels("foo", "bar"), matchExprs("bar Exists")})),
+		Entry(nil, selector("c5", matchAny{matchLabels("foo", "quux")})),
+		Entry(nil, selector(matchExprs("foo Exists"), matchAny{matchLabels("foo", "bar"), matchLabels("bar", "baz")})),
+	)
+
+	It("should track updates and deletions", func() {
+		index.Put(cluster("c2", "foo", "changed"))
+		Expect(index.Select(selector(matchLabels("foo", "bar")))).To(ConsistOf(
+			HaveField("Id", "c4"),
+		))
+		Expect(index.Select(selector(matchLabels("foo", "changed")))).To(ConsistOf(
+			HaveField("Id", "c2"),
+		))
+		index.Delete("c4")
+		Expect(index.Select(selector(matchLabels("foo", "bar")))).To(BeEmpty())
+		Expect(index.Len()).To(Equal(len(clusters) - 1))
+
+		index.Reset(clusters[:2])
+		Expect(index.Len()).To(Equal(2))
+		Expect(index.Select(selector(matchExprs("foo Exists")))).To(ConsistOf(
+			HaveField("Id", "c2"),
+		))
+	})
+
+	It("should be kept up to date by a cluster watch", func() {
+		ctx, ca := context.WithCancel(context.Background())
+		defer ca()
+		store := test.NewTestClusterStore(gomock.NewController(GinkgoT()))
+		for _, c := range clusters[:3] {
+			Expect(store.CreateCluster(ctx, c)).To(Succeed())
+		}
+		index := storage.NewClusterIndex()
+		index.Put(clusters[0])
+		Expect(index.Watch(ctx, store)).To(Succeed())
+		Eventually(index.Len).Should(Equal(3))
+
+		Expect(store.CreateCluster(ctx, cluster("c7", "foo", "bar"))).To(Succeed())
+		_, err := store.UpdateCluster(ctx, clusters[1].Reference(), func(c *core.Cluster) {
+			c.Metadata.Labels["foo"] = "changed"
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.DeleteCluster(ctx, clusters[2].Reference())).To(Succeed())
+		Eventually(func() []*core.Cluster {
+			return index.Select(selector(matchExprs("foo Exists")))
+		}).Should(ConsistOf(
+			HaveField("Id", "c2"),
+			HaveField("Id", "c7"),
+		))
+		Expect(index.Select(selector(matchLabels("foo", "changed")))).To(ConsistOf(
+			HaveField("Id", "c2"),
+		))
+		Expect(index.Len()).To(Equal(3))
+	})
+
+	It("should sync with the existing clusters in the store", func() {
+		ctx, ca := context.WithCancel(context.Background())
+		defer ca()
+		store := test.NewTestClusterStore(gomock.NewController(GinkgoT()))
+		for _, c := range clusters[:3] {
+			Expect(store.CreateCluster(ctx, c)).To(Succeed())
+		}
+		index := storage.NewClusterIndex()
+		index.Put(cluster("stale"))
+		Expect(index.Synced()).To(BeFalse())
+		Expect(index.Sync(ctx, store)).To(Succeed())
+		Expect(index.Synced()).To(BeTrue())
+		Expect(index.Len()).To(Equal(3))
+		_, ok := index.Get("stale")
+		Expect(ok).To(BeFalse())
+
+		Expect(store.DeleteCluster(ctx, clusters[0].Reference())).To(Succeed())
+		Eventually(index.Len).Should(Equal(2))
+		c, ok := index.Get("c2")
+		Expect(ok).To(BeTrue())
+		Expect(c.GetLabels()).To(HaveKeyWithValue("foo", "bar"))
+	})
+
+	It("should return identical results for large randomized inputs", func() {
+		clusters := randomClusters(5000)
+		index.Reset(clusters)
+		selectors := []storage.ClusterSelector{
+			selector(matchLabels("env", "prod")),
+			selector(matchLabels("env", "prod", "region", "region-3")),
+			selector(matchExprs("region In region-1,region-2", "gpu Exists")),
+			selector(matchExprs("shard In 1,2,3", "env NotIn dev")),
+			selector(matchExprs("gpu DoesNotExist")),
+			selector("c00001", "c00002", matchLabels("env", "dev")),
+		}
+		for _, s := range selectors {
+			Expect(index.Select(s)).To(Equal(linearSelect(clusters, s)))
+		}
+	})
+
+	Specify("index-backed selection should be faster than linear selection", func() {
+		clusters := randomClusters(testutil.IfCI(5000).Else(20000))
+		index.Reset(clusters)
+		s := selector(matchLabels("region", "region-3", "shard", "42"))
+
+		exp := gmeasure.NewExperiment("cluster-index")
+		exp.SampleDuration("linear", func(int) {
+			linearSelect(clusters, s)
+		}, gmeasure.SamplingConfig{
+			N: 100,
+		}, gmeasure.Precision(time.Microsecond))
+		exp.SampleDuration("indexed", func(int) {
+			index.Select(s)
+		}, gmeasure.SamplingConfig{
+			N: 100,
+		}, gmeasure.Precision(time.Microsecond))
+		AddReportEntry(exp.Name, exp)
+
+		linear := exp.GetStats("linear").DurationFor(gmeasure.StatMedian)
+		indexed := exp.GetStats("indexed").DurationFor(gmeasure.StatMedian)
+		Expect(indexed).To(BeNumerically("<", linear))
+	})
+})
@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/util/backoff"
+	"google.golang.org/protobuf/proto"
+)
+
+type indexedBackend struct {
+	Backend
+	index *ClusterIndex
+}
+
+var _ Backend = (*indexedBackend)(nil)
+
+// NewIndexedBackend returns a Backend which answers ListClusters using a
+// ClusterIndex, kept up to date by a cluster watch on the given backend
+// until ctx is done. Until the index has been synced, and for all other
+// operations, the given backend is used directly.
+//
+// Clusters created, updated, or deleted through the returned backend are
+// applied to the index as soon as the write succeeds, so that they are
+// immediately visible to ListClusters. Changes made elsewhere, such as by
+// other gateways, become visible once they are received from the watch.
+func NewIndexedBackend(ctx context.Context, backend Backend) Backend {
+	b := &indexedBackend{
+		Backend: backend,
+		index:   NewClusterIndex(),
+	}
+	go backoff.Retry(ctx, func(ctx context.Context) error {
+		return b.index.Sync(ctx, backend)
+	}, backoff.DefaultPolicy)
+	return b
+}
+
+func (b *indexedBackend) CreateCluster(ctx context.Context, cluster *core.Cluster) error {
+	if err := b.Backend.CreateCluster(ctx, cluster); err != nil {
+		return err
+	}
+	b.index.Put(proto.Clone(cluster).(*core.Cluster))
+	return nil
+}
+
+func (b *indexedBackend) DeleteCluster(ctx context.Context, ref *core.Reference) error {
+	if err := b.Backend.DeleteCluster(ctx, ref); err != nil {
+		return err
+	}
+	b.index.Delete(ref.GetId())
+	return nil
+}
+
+func (b *indexedBackend) UpdateCluster(ctx context.Context, ref *core.Reference, mutator ClusterMutator) (*core.Cluster, error) {
+	cluster, err := b.Backend.UpdateCluster(ctx, ref, mutator)
+	if err != nil {
+		return nil, err
+	}
+	b.index.Put(proto.Clone(cluster).(*core.Cluster))
+	return cluster, nil
+}
+
+func (b *indexedBackend) ListClusters(ctx context.Context, matchLabels *core.LabelSelector, matchOptions core.MatchOptions) (*core.ClusterList, error) {
+	if !b.index.Synced() {
+		return b.Backend.ListClusters(ctx, matchLabels, matchOptions)
+	}
+	selected := b.index.Select(ClusterSelector{
+		LabelSelector: matchLabels,
+		MatchOptions:  matchOptions,
+	})
+	clusters := &core.ClusterList{
+		Items: make([]*core.Cluster, 0, len(selected)),
+	}
+	for _, c := range selected {
+		clusters.Items = append(clusters.Items, proto.Clone(c).(*core.Cluster))
+	}
+	return clusters, nil
+}
@@ -0,0 +1,106 @@
+package storage_test
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/storage"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+type countingClusterStore struct {
+	storage.ClusterStore
+	listCalls int32
+}
+
+func (s *countingClusterStore) ListClusters(ctx context.Context, matchLabels *core.LabelSelector, matchOptions core.MatchOptions) (*core.ClusterList, error) {
+	atomic.AddInt32(&s.listCalls, 1)
+	return s.ClusterStore.ListClusters(ctx, matchLabels, matchOptions)
+}
+
+var _ = Describe("Indexed Backend", Label(test.Unit), func() {
+	var ctx context.Context
+	var inner *storage.CompositeBackend
+	var store *countingClusterStore
+	var backend storage.Backend
+	BeforeEach(func() {
+		var ca context.CancelFunc
+		ctx, ca = context.WithCancel(context.Background())
+		DeferCleanup(ca)
+		inner = test.NewTestStorageBackend(ctx, gomock.NewController(GinkgoT())).(*storage.CompositeBackend)
+		store = &countingClusterStore{
+			ClusterStore: inner.ClusterStore,
+		}
+		inner.ClusterStore = store
+		Expect(inner.CreateCluster(ctx, cluster("c1", "foo", "bar"))).To(Succeed())
+		Expect(inner.CreateCluster(ctx, cluster("c2", "foo", "baz"))).To(Succeed())
+		backend = storage.NewIndexedBackend(ctx, inner)
+	})
+
+	listIDs := func(matchLabels *core.LabelSelector) []string {
+		list, err := backend.ListClusters(ctx, matchLabels, 0)
+		Expect(err).NotTo(HaveOccurred())
+		ids := []string{}
+		for _, c := range list.Items {
+			ids = append(ids, c.GetId())
+		}
+		return ids
+	}
+	// once synced, listing clusters no longer calls the store
+	waitSynced := func() {
+		Eventually(func() bool {
+			calls := atomic.LoadInt32(&store.listCalls)
+			listIDs(nil)
+			return atomic.LoadInt32(&store.listCalls) == calls
+		}).Should(BeTrue())
+	}
+
+	It("should list clusters from the index once synced", func() {
+		waitSynced()
+		Expect(listIDs(nil)).To(Equal([]string{"c1", "c2"}))
+		Expect(listIDs(&core.LabelSelector{
+			MatchLabels: map[string]string{"foo": "bar"},
+		})).To(Equal([]string{"c1"}))
+	})
+
+	It("should make writes through the backend visible immediately", func() {
+		waitSynced()
+		Expect(backend.CreateCluster(ctx, cluster("c3", "foo", "bar"))).To(Succeed())
+		Expect(listIDs(nil)).To(ContainElement("c3"))
+
+		_, err := backend.UpdateCluster(ctx, &core.Reference{Id: "c3"}, func(c *core.Cluster) {
+			c.Metadata.Labels["foo"] = "quux"
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listIDs(&core.LabelSelector{
+			MatchLabels: map[string]string{"foo": "quux"},
+		})).To(Equal([]string{"c3"}))
+
+		Expect(backend.DeleteCluster(ctx, &core.Reference{Id: "c3"})).To(Succeed())
+		Expect(listIDs(nil)).NotTo(ContainElement("c3"))
+	})
+
+	It("should receive changes made elsewhere from the watch", func() {
+		waitSynced()
+		Expect(inner.CreateCluster(ctx, cluster("c4"))).To(Succeed())
+		Expect(inner.DeleteCluster(ctx, &core.Reference{Id: "c1"})).To(Succeed())
+		Eventually(func() []string {
+			return listIDs(nil)
+		}).Should(Equal([]string{"c2", "c4"}))
+	})
+
+	It("should not share listed clusters with the index", func() {
+		waitSynced()
+		list, err := backend.ListClusters(ctx, nil, 0)
+		Expect(err).NotTo(HaveOccurred())
+		list.Items[0].Metadata.Labels["foo"] = "modified"
+		Expect(listIDs(&core.LabelSelector{
+			MatchLabels: map[string]string{"foo": "bar"},
+		})).To(Equal([]string{"c1"}))
+	})
+})
@@ -0,0 +1,273 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/keyring"
+)
+
+// Metrics holds the metrics recorded by storage backends wrapped using
+// NewMetricsBackend. It implements prometheus.Collector.
+type Metrics struct {
+	operationDuration *prometheus.HistogramVec
+	operationErrors   *prometheus.CounterVec
+}
+
+var _ prometheus.Collector = (*Metrics)(nil)
+
+func NewMetrics() *Metrics {
+	return &Metrics{
+		operationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "opni",
+			Subsystem: "storage",
+			Name:      "operation_duration_seconds",
+			Help:      "Latency of storage backend operations",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+		}, []string{"backend", "method"}),
+		operationErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "opni",
+			Subsystem: "storage",
+			Name:      "operation_errors_total",
+			Help:      "Total number of failed storage backend operations",
+		}, []string{"backend", "method"}),
+	}
+}
+
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.operationDuration.Describe(ch)
+	m.operationErrors.Describe(ch)
+}
+
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.operationDuration.Collect(ch)
+	m.operationErrors.Collect(ch)
+}
+
+// OperationDuration returns the latency histogram for the given backend and
+// method.
+func (m *Metrics) OperationDuration(backend, method string) prometheus.Observer {
+	return m.operationDuration.WithLabelValues(backend, method)
+}
+
+// OperationErrors returns the error counter for the given backend and method.
+func (m *Metrics) OperationErrors(backend, method string) prometheus.Counter {
+	return m.operationErrors.WithLabelValues(backend, method)
+}
+
+type metricsBackend struct {
+	backend Backend
+	name    string
+	metrics *Metrics
+}
+
+var _ Backend = (*metricsBackend)(nil)
+
+// NewMetricsBackend returns a Backend which records the latency and errors
+// of every operation on the given backend, labeled with the method name and
+// the given backend name. Stores returned by the keyring and key-value store
+// brokers are wrapped as well. Not-found errors are part of normal operation
+// and are not counted as errors.
+func NewMetricsBackend(backend Backend, name string, metrics *Metrics) Backend {
+	return &metricsBackend{
+		backend: backend,
+		name:    name,
+		metrics: metrics,
+	}
+}
+
+// record should be deferred at the start of each operation, with a pointer
+// to the operation's named error result.
+func (b *metricsBackend) record(method string, start time.Time, err *error) {
+	b.metrics.OperationDuration(b.name, method).Observe(time.Since(start).Seconds())
+	if *err != nil && !errors.Is(*err, ErrNotFound) {
+		b.metrics.OperationErrors(b.name, method).Inc()
+	}
+}
+
+func (b *metricsBackend) CreateToken(ctx context.Context, ttl time.Duration, opts ...TokenCreateOption) (_ *core.BootstrapToken, err error) {
+	defer b.record("CreateToken", time.Now(), &err)
+	return b.backend.CreateToken(ctx, ttl, opts...)
+}
+
+func (b *metricsBackend) DeleteToken(ctx context.Context, ref *core.Reference) (err error) {
+	defer b.record("DeleteToken", time.Now(), &err)
+	return b.backend.DeleteToken(ctx, ref)
+}
+
+func (b *metricsBackend) GetToken(ctx context.Context, ref *core.Reference) (_ *core.BootstrapToken, err error) {
+	defer b.record("GetToken", time.Now(), &err)
+	return b.backend.GetToken(ctx, ref)
+}
+
+func (b *metricsBackend) UpdateToken(ctx context.Context, ref *core.Reference, mutator TokenMutator) (_ *core.BootstrapToken, err error) {
+	defer b.record("UpdateToken", time.Now(), &err)
+	return b.backend.UpdateToken(ctx, ref, mutator)
+}
+
+func (b *metricsBackend) ListTokens(ctx context.Context) (_ []*core.BootstrapToken, err error) {
+	defer b.record("ListTokens", time.Now(), &err)
+	return b.backend.ListTokens(ctx)
+}
+
+func (b *metricsBackend) CreateCluster(ctx context.Context, cluster *core.Cluster) (err error) {
+	defer b.record("CreateCluster", time.Now(), &err)
+	return b.backend.CreateCluster(ctx, cluster)
+}
+
+func (b *metricsBackend) DeleteCluster(ctx context.Context, ref *core.Reference) (err error) {
+	defer b.record("DeleteCluster", time.Now(), &err)
+	return b.backend.DeleteCluster(ctx, ref)
+}
+
+func (b *metricsBackend) GetCluster(ctx context.Context, ref *core.Reference) (_ *core.Cluster, err error) {
+	defer b.record("GetCluster", time.Now(), &err)
+	return b.backend.GetCluster(ctx, ref)
+}
+
+func (b *metricsBackend) UpdateCluster(ctx context.Context, ref *core.Reference, mutator ClusterMutator) (_ *core.Cluster, err error) {
+	defer b.record("UpdateCluster", time.Now(), &err)
+	return b.backend.UpdateCluster(ctx, ref, mutator)
+}
+
+func (b *metricsBackend) ListClusters(ctx context.Context, matchLabels *core.LabelSelector, matchOptions core.MatchOptions) (_ *core.ClusterList, err error) {
+	defer b.record("ListClusters", time.Now(), &err)
+	return b.backend.ListClusters(ctx, matchLabels, matchOptions)
+}
+
+func (b *metricsBackend) WatchClusters(ctx context.Context, knownClusters []*core.Cluster) (_ <-chan ClusterWatchEvent, err error) {
+	defer b.record("WatchClusters", time.Now(), &err)
+	return b.backend.WatchClusters(ctx, knownClusters)
+}
+
+func (b *metricsBackend) CreateRole(ctx context.Context, role *core.Role) (err error) {
+	defer b.record("CreateRole", time.Now(), &err)
+	return b.backend.CreateRole(ctx, role)
+}
+
+func (b *metricsBackend) DeleteRole(ctx context.Context, ref *core.Reference) (err error) {
+	defer b.record("DeleteRole", time.Now(), &err)
+	return b.backend.DeleteRole(ctx, ref)
+}
+
+func (b *metricsBackend) GetRole(ctx context.Context, ref *core.Reference) (_ *core.Role, err error) {
+	defer b.record("GetRole", time.Now(), &err)
+	return b.backend.GetRole(ctx, ref)
+}
+
+func (b *metricsBackend) CreateRoleBinding(ctx context.Context, rb *core.RoleBinding) (err error) {
+	defer b.record("CreateRoleBinding", time.Now(), &err)
+	return b.backend.CreateRoleBinding(ctx, rb)
+}
+
+func (b *metricsBackend) DeleteRoleBinding(ctx context.Context, ref *core.Reference) (err error) {
+	defer b.record("DeleteRoleBinding", time.Now(), &err)
+	return b.backend.DeleteRoleBinding(ctx, ref)
+}
+
+func (b *metricsBackend) GetRoleBinding(ctx context.Context, ref *core.Reference) (_ *core.RoleBinding, err error) {
+	defer b.record("GetRoleBinding", time.Now(), &err)
+	return b.backend.GetRoleBinding(ctx, ref)
+}
+
+func (b *metricsBackend) ListRoles(ctx context.Context) (_ *core.RoleList, err error) {
+	defer b.record("ListRoles", time.Now(), &err)
+	return b.backend.ListRoles(ctx)
+}
+
+func (b *metricsBackend) ListRoleBindings(ctx context.Context) (_ *core.RoleBindingList, err error) {
+	defer b.record("ListRoleBindings", time.Now(), &err)
+	return b.backend.ListRoleBindings(ctx)
+}
+
+func (b *metricsBackend) KeyringStore(ctx context.Context, namespace string, ref *core.Reference) (_ KeyringStore, err error) {
+	defer b.record("KeyringStore", time.Now(), &err)
+	store, err := b.backend.KeyringStore(ctx, namespace, ref)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsKeyringStore{
+		store:   store,
+		backend: b,
+	}, nil
+}
+
+func (b *metricsBackend) KeyValueStore(namespace string) (_ KeyValueStore, err error) {
+	defer b.record("KeyValueStore", time.Now(), &err)
+	store, err := b.backend.KeyValueStore(namespace)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsKeyValueStore{
+		store:   store,
+		backend: b,
+	}, nil
+}
+
+type metricsKeyringStore struct {
+	store   KeyringStore
+	backend *metricsBackend
+}
+
+func (s *metricsKeyringStore) Put(ctx context.Context, kr keyring.Keyring) (err error) {
+	defer s.backend.record("KeyringStore.Put", time.Now(), &err)
+	return s.store.Put(ctx, kr)
+}
+
+func (s *metricsKeyringStore) Get(ctx context.Context) (_ keyring.Keyring, err error) {
+	defer s.backend.record("KeyringStore.Get", time.Now(), &err)
+	return s.store.Get(ctx)
+}
+
+func (s *metricsKeyringStore) Append(ctx context.Context, kr keyring.Keyring) (err error) {
+	defer s.backend.record("KeyringStore.Append", time.Now(), &err)
+	return s.store.Append(ctx, kr)
+}
+
+func (s *metricsKeyringStore) Rotate(ctx context.Context, kr keyring.Keyring) (err error) {
+	defer s.backend.record("KeyringStore.Rotate", time.Now(), &err)
+	return s.store.Rotate(ctx, kr)
+}
+
+func (s *metricsKeyringStore) List(ctx context.Context) (_ []keyring.Keyring, err error) {
+	defer s.backend.record("KeyringStore.List", time.Now(), &err)
+	return s.store.List(ctx)
+}
+
+func (s *metricsKeyringStore) Prune(ctx context.Context) (err error) {
+	defer s.backend.record("KeyringStore.Prune", time.Now(), &err)
+	return s.store.Prune(ctx)
+}
+
+func (s *metricsKeyringStore) Delete(ctx context.Context) (err error) {
+	defer s.backend.record("KeyringStore.Delete", time.Now(), &err)
+	return s.store.Delete(ctx)
+}
+
+type metricsKeyValueStore struct {
+	store   KeyValueStore
+	backend *metricsBackend
+}
+
+func (s *metricsKeyValueStore) Put(ctx context.Context, key string, value []byte) (err error) {
+	defer s.backend.record("KeyValueStore.Put", time.Now(), &err)
+	return s.store.Put(ctx, key, value)
+}
+
+func (s *metricsKeyValueStore) Get(ctx context.Context, key string) (_ []byte, err error) {
+	defer s.backend.record("KeyValueStore.Get", time.Now(), &err)
+	return s.store.Get(ctx, key)
+}
+
+func (s *metricsKeyValueStore) Delete(ctx context.Context, key string) (err error) {
+	defer s.backend.record("KeyValueStore.Delete", time.Now(), &err)
+	return s.store.Delete(ctx, key)
+}
+
+func (s *metricsKeyValueStore) ListKeys(ctx context.Context, prefix string) (_ []string, err error) {
+	defer s.backend.record("KeyValueStore.ListKeys", time.Now(), &err)
+	return s.store.ListKeys(ctx, prefix)
+}
@@ -0,0 +1,102 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/storage"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var errStorageFailure = errors.New("storage failure")
+
+type failingClusterStore struct {
+	storage.ClusterStore
+}
+
+func (failingClusterStore) ListClusters(context.Context, *core.LabelSelector, core.MatchOptions) (*core.ClusterList, error) {
+	return nil, errStorageFailure
+}
+
+var _ = Describe("Metrics Backend", Label(test.Unit), func() {
+	var metrics *storage.Metrics
+	var backend storage.Backend
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		inner := test.NewTestStorageBackend(context.Background(), ctrl).(*storage.CompositeBackend)
+		inner.ClusterStore = failingClusterStore{
+			ClusterStore: inner.ClusterStore,
+		}
+		metrics = storage.NewMetrics()
+		backend = storage.NewMetricsBackend(inner, "test", metrics)
+	})
+
+	sampleCount := func(method string) uint64 {
+		m := &dto.Metric{}
+		Expect(metrics.OperationDuration("test", method).(prometheus.Metric).Write(m)).To(Succeed())
+		return m.GetHistogram().GetSampleCount()
+	}
+	errorCount := func(method string) float64 {
+		return testutil.ToFloat64(metrics.OperationErrors("test", method))
+	}
+
+	It("should record a sample for each call", func() {
+		for i := 0; i < 3; i++ {
+			_, err := backend.ListTokens(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(backend.CreateCluster(context.Background(), &core.Cluster{Id: "foo"})).To(Succeed())
+
+		Expect(sampleCount("ListTokens")).To(BeEquivalentTo(3))
+		Expect(sampleCount("CreateCluster")).To(BeEquivalentTo(1))
+		Expect(sampleCount("GetCluster")).To(BeZero())
+		Expect(errorCount("ListTokens")).To(BeZero())
+	})
+	It("should count errors", func() {
+		_, err := backend.ListClusters(context.Background(), nil, 0)
+		Expect(err).To(MatchError(errStorageFailure))
+		_, err = backend.ListClusters(context.Background(), nil, 0)
+		Expect(err).To(MatchError(errStorageFailure))
+
+		Expect(sampleCount("ListClusters")).To(BeEquivalentTo(2))
+		Expect(errorCount("ListClusters")).To(BeEquivalentTo(2))
+	})
+	It("should not count not found errors", func() {
+		_, err := backend.GetCluster(context.Background(), &core.Reference{Id: "missing"})
+		Expect(err).To(MatchError(storage.ErrNotFound))
+
+		Expect(sampleCount("GetCluster")).To(BeEquivalentTo(1))
+		Expect(errorCount("GetCluster")).To(BeZero())
+	})
+	It("should record metrics for keyring and key-value stores", func() {
+		ks, err := backend.KeyringStore(context.Background(), "test", &core.Reference{Id: "foo"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = ks.Get(context.Background())
+		Expect(err).To(MatchError(storage.ErrNotFound))
+
+		kv, err := backend.KeyValueStore("test")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kv.Put(context.Background(), "foo", []byte("bar"))).To(Succeed())
+
+		Expect(sampleCount("KeyringStore")).To(BeEquivalentTo(1))
+		Expect(sampleCount("KeyringStore.Get")).To(BeEquivalentTo(1))
+		Expect(sampleCount("KeyValueStore.Put")).To(BeEquivalentTo(1))
+	})
+	It("should be registrable", func() {
+		reg := prometheus.NewRegistry()
+		Expect(reg.Register(metrics)).To(Succeed())
+		_, err := backend.ListTokens(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		count, err := testutil.GatherAndCount(reg, "opni_storage_operation_duration_seconds")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(1))
+	})
+})
@@ -18,6 +18,27 @@ func NewIncrementUsageCountMutator() MutatorFunc[*core.BootstrapToken] {
 	}
 }
 
+func NewDecrementUsageCountMutator() MutatorFunc[*core.BootstrapToken] {
+	return func(obj *core.BootstrapToken) {
+		if obj.Metadata.UsageCount > 0 {
+			obj.Metadata.UsageCount--
+		}
+	}
+}
+
+// NewReserveUsageMutator increments the token's usage count, unless the token
+// has a usage limit which has already been reached. In that case, the token is
+// not modified and exhausted is set to true.
+func NewReserveUsageMutator(exhausted *bool) MutatorFunc[*core.BootstrapToken] {
+	return func(obj *core.BootstrapToken) {
+		md := obj.Metadata
+		*exhausted = md.MaxUsages > 0 && md.UsageCount >= md.MaxUsages
+		if !*exhausted {
+			md.UsageCount++
+		}
+	}
+}
+
 func NewAddCapabilityMutator[O core.MetadataAccessor[T], T core.Capability[T]](capability T) MutatorFunc[O] {
 	return func(obj O) {
 		exists := false
@@ -3,8 +3,10 @@ package storage
 import "github.com/rancher/opni-monitoring/pkg/core"
 
 type TokenCreateOptions struct {
-	Labels       map[string]string
-	Capabilities []*core.TokenCapability
+	Labels              map[string]string
+	Capabilities        []*core.TokenCapability
+	AllowedCapabilities []string
+	MaxUsages           int64
 }
 
 func NewTokenCreateOptions() TokenCreateOptions {
@@ -33,3 +35,19 @@ func WithCapabilities(capabilities []*core.TokenCapability) TokenCreateOption {
 		o.Capabilities = capabilities
 	}
 }
+
+// WithAllowedCapabilities restricts the capabilities which can ever be
+// installed on clusters created using the token.
+func WithAllowedCapabilities(capabilities []string) TokenCreateOption {
+	return func(o *TokenCreateOptions) {
+		o.AllowedCapabilities = capabilities
+	}
+}
+
+// WithMaxUsages limits the number of times the token can be used to
+// bootstrap. If maxUsages is 0, the token can be used any number of times.
+func WithMaxUsages(maxUsages int64) TokenCreateOption {
+	return func(o *TokenCreateOptions) {
+		o.MaxUsages = maxUsages
+	}
+}
@@ -1,6 +1,16 @@
 package storage
 
-import "github.com/rancher/opni-monitoring/pkg/core"
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/validation"
+)
 
 type SelectorPredicate func(*core.Cluster) bool
 
@@ -8,12 +18,258 @@ type ClusterSelector struct {
 	ClusterIDs    []string
 	LabelSelector *core.LabelSelector
 	MatchOptions  core.MatchOptions
+	// Clusters which are never matched, even if they are listed in ClusterIDs
+	// or match the label selector. If this is the only field set, the
+	// selector matches every cluster except these.
+	ExcludeClusterIDs []string
+	// If set, clusters must match at least one of these selectors, in
+	// addition to LabelSelector. Clusters listed in ClusterIDs always match.
+	MatchAny []*core.LabelSelector
+	// If set, clusters must have all of these capabilities installed, in
+	// addition to matching the other fields. This also applies to clusters
+	// listed in ClusterIDs. If this is the only field set (other than
+	// ExcludeClusterIDs), the selector matches every cluster which has the
+	// capabilities.
+	Capabilities []string
+
+	sampleFraction *float64
+}
+
+// SampleFraction returns a copy of the selector which only matches a subset
+// of the clusters matched by p, containing approximately the given fraction
+// of them (between 0 and 1). Clusters are chosen by a hash of their ID, so the
+// same clusters are chosen every time the selector is evaluated, and every
+// cluster chosen for a fraction is also chosen for any larger fraction. This
+// allows a change to be rolled out to a growing set of canary clusters.
+func (p ClusterSelector) SampleFraction(f float64) ClusterSelector {
+	p.sampleFraction = &f
+	return p
+}
+
+// Validate checks that the selector is well-formed. In addition to the
+// checks made by LabelSelector.Validate, In and NotIn requirements must have
+// at least one value, Exists and DoesNotExist requirements must not have any
+// values, since they would otherwise be ignored, and Gt and Lt requirements
+// must have a single integer value.
+func (p ClusterSelector) Validate() error {
+	for _, ids := range [][]string{p.ClusterIDs, p.ExcludeClusterIDs} {
+		for _, id := range ids {
+			if err := validation.ValidateID(id); err != nil {
+				return fmt.Errorf("%w: %q", err, id)
+			}
+		}
+	}
+	if err := validateLabelSelector(p.LabelSelector); err != nil {
+		return err
+	}
+	for _, ls := range p.MatchAny {
+		if err := validateLabelSelector(ls); err != nil {
+			return err
+		}
+	}
+	for _, name := range p.Capabilities {
+		if name == "" {
+			return fmt.Errorf("%w: capability name cannot be empty", validation.ErrInvalidValue)
+		}
+	}
+	if err := validation.Validate(p.MatchOptions); err != nil {
+		return err
+	}
+	if f := p.sampleFraction; f != nil && (*f < 0 || *f > 1) {
+		return fmt.Errorf("%w: sample fraction %v is not between 0 and 1", validation.ErrInvalidValue, *f)
+	}
+	return nil
+}
+
+func validateLabelSelector(ls *core.LabelSelector) error {
+	if ls != nil {
+		if err := ls.Validate(); err != nil {
+			return err
+		}
+		for _, req := range ls.MatchExpressions {
+			switch core.LabelSelectorOperator(req.Operator) {
+			case core.LabelSelectorOpIn, core.LabelSelectorOpNotIn:
+				if len(req.Values) == 0 {
+					return fmt.Errorf("%w: operator %q requires at least one value (key %q)",
+						validation.ErrMissingRequiredField, req.Operator, req.Key)
+				}
+			case core.LabelSelectorOpExists, core.LabelSelectorOpDoesNotExist:
+				if len(req.Values) > 0 {
+					return fmt.Errorf("%w: operator %q does not take values (key %q)",
+						validation.ErrInvalidValue, req.Operator, req.Key)
+				}
+			case core.LabelSelectorOpGt, core.LabelSelectorOpLt:
+				if len(req.Values) == 0 {
+					return fmt.Errorf("%w: operator %q requires a value (key %q)",
+						validation.ErrMissingRequiredField, req.Operator, req.Key)
+				}
+				if len(req.Values) > 1 {
+					return fmt.Errorf("%w: operator %q takes a single value (key %q)",
+						validation.ErrInvalidValue, req.Operator, req.Key)
+				}
+				if _, err := strconv.ParseInt(req.Values[0], 10, 64); err != nil {
+					return fmt.Errorf("%w: operator %q requires an integer value (key %q)",
+						validation.ErrInvalidValue, req.Operator, req.Key)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// String returns a human-readable description of the selector, for use in
+// log and error messages, e.g. "ids=[c1,c2] labels=(foo in [bar,baz], tier
+// exists)". A selector which matches every cluster is shown as "<all>", and
+// an empty selector which matches no clusters is shown as "<none>".
+func (p ClusterSelector) String() string {
+	parts := []string{}
+	if len(p.ClusterIDs) > 0 {
+		parts = append(parts, "ids=["+strings.Join(p.ClusterIDs, ",")+"]")
+	}
+	if !p.LabelSelector.IsEmpty() {
+		parts = append(parts, "labels="+formatLabelSelector(p.LabelSelector))
+	}
+	if len(p.MatchAny) > 0 {
+		selectors := make([]string, 0, len(p.MatchAny))
+		for _, ls := range p.MatchAny {
+			selectors = append(selectors, formatLabelSelector(ls))
+		}
+		parts = append(parts, "any=["+strings.Join(selectors, ", ")+"]")
+	}
+	if len(p.Capabilities) > 0 {
+		parts = append(parts, "capabilities=["+strings.Join(p.Capabilities, ",")+"]")
+	}
+	if len(p.ExcludeClusterIDs) > 0 {
+		parts = append(parts, "exclude=["+strings.Join(p.ExcludeClusterIDs, ",")+"]")
+	}
+	if len(parts) == 0 {
+		if p.MatchOptions&core.MatchOptions_EmptySelectorMatchesNone != 0 {
+			parts = append(parts, "<none>")
+		} else {
+			parts = append(parts, "<all>")
+		}
+	}
+	if p.sampleFraction != nil {
+		parts = append(parts, "sample="+strconv.FormatFloat(*p.sampleFraction, 'g', -1, 64))
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatLabelSelector(ls *core.LabelSelector) string {
+	if ls.IsEmpty() {
+		return "()"
+	}
+	keys := make([]string, 0, len(ls.MatchLabels))
+	for k := range ls.MatchLabels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	reqs := make([]string, 0, len(keys)+len(ls.MatchExpressions))
+	for _, k := range keys {
+		reqs = append(reqs, k+"="+ls.MatchLabels[k])
+	}
+	for _, req := range ls.MatchExpressions {
+		if req == nil {
+			continue
+		}
+		s := req.Key + " " + strings.ToLower(req.Operator)
+		if len(req.Values) > 0 {
+			s += " [" + strings.Join(req.Values, ",") + "]"
+		}
+		if req.Negate {
+			s = "!(" + s + ")"
+		}
+		reqs = append(reqs, s)
+	}
+	return "(" + strings.Join(reqs, ", ") + ")"
 }
 
+// Predicate returns a function which reports whether a cluster is matched by
+// the selector. Building the predicate is more expensive than evaluating it,
+// since the selector's ID sets are built up front, so the predicate should
+// be reused when evaluating many clusters rather than calling Predicate for
+// each one. See also Matcher.
 func (p ClusterSelector) Predicate() SelectorPredicate {
-	emptyLabelSelector := p.LabelSelector.IsEmpty()
+	predicate := p.matchPredicate()
+	if p.sampleFraction == nil {
+		return predicate
+	}
+	f := *p.sampleFraction
+	return func(c *core.Cluster) bool {
+		return predicate(c) && sampleValue(c.GetId()) < f
+	}
+}
+
+// ClusterMatcher matches clusters against a prebuilt selector predicate. It
+// is immutable once built, and is safe for concurrent use by multiple
+// goroutines.
+type ClusterMatcher struct {
+	predicate SelectorPredicate
+}
+
+// Matcher builds a ClusterMatcher for the selector, which can be used to
+// evaluate the selector against many clusters without rebuilding its ID sets.
+func (p ClusterSelector) Matcher() *ClusterMatcher {
+	return &ClusterMatcher{
+		predicate: p.Predicate(),
+	}
+}
+
+// Matches reports whether the cluster is matched by the selector.
+func (m *ClusterMatcher) Matches(c *core.Cluster) bool {
+	return m.predicate(c)
+}
+
+// sampleValue maps a cluster ID to a stable value uniformly distributed
+// in [0, 1).
+func sampleValue(id string) float64 {
+	sum := sha256.Sum256([]byte(id))
+	// use the top 53 bits, which are exactly representable as a float64
+	return float64(binary.BigEndian.Uint64(sum[:8])>>11) / (1 << 53)
+}
+
+func (p ClusterSelector) matchPredicate() SelectorPredicate {
+	predicate := p.includePredicate()
+	if len(p.ExcludeClusterIDs) == 0 && len(p.Capabilities) == 0 {
+		return predicate
+	}
+	excludeSet := map[string]struct{}{}
+	for _, id := range p.ExcludeClusterIDs {
+		excludeSet[id] = struct{}{}
+	}
+	return func(c *core.Cluster) bool {
+		if _, ok := excludeSet[c.Id]; ok {
+			return false
+		}
+		return p.hasCapabilities(c) && predicate(c)
+	}
+}
+
+// hasCapabilities reports whether the cluster has all of the capabilities
+// required by the selector.
+func (p ClusterSelector) hasCapabilities(c *core.Cluster) bool {
+	installed := c.GetCapabilities()
+	for _, name := range p.Capabilities {
+		found := false
+		for _, capability := range installed {
+			if capability.GetName() == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (p ClusterSelector) includePredicate() SelectorPredicate {
+	emptyLabelSelector := p.LabelSelector.IsEmpty() && len(p.MatchAny) == 0
 	if emptyLabelSelector && len(p.ClusterIDs) == 0 {
 		switch {
+		case len(p.ExcludeClusterIDs) > 0, len(p.Capabilities) > 0:
+			return func(c *core.Cluster) bool { return true }
 		case p.MatchOptions&core.MatchOptions_EmptySelectorMatchesNone != 0:
 			return func(cluster *core.Cluster) bool { return false }
 		default:
@@ -32,10 +288,27 @@ func (p ClusterSelector) Predicate() SelectorPredicate {
 		if emptyLabelSelector {
 			return false
 		}
-		return labelSelectorMatches(p.LabelSelector, c.GetMetadata().GetLabels())
+		return p.labelsMatch(c.GetMetadata().GetLabels())
 	}
 }
 
+// labelsMatch reports whether the labels match LabelSelector, and at least
+// one of the MatchAny selectors, if any are set.
+func (p ClusterSelector) labelsMatch(labels map[string]string) bool {
+	if !p.LabelSelector.IsEmpty() && !labelSelectorMatches(p.LabelSelector, labels) {
+		return false
+	}
+	if len(p.MatchAny) == 0 {
+		return true
+	}
+	for _, ls := range p.MatchAny {
+		if ls.IsEmpty() || labelSelectorMatches(ls, labels) {
+			return true
+		}
+	}
+	return false
+}
+
 func labelSelectorMatches(selector *core.LabelSelector, labels map[string]string) bool {
 	for key, value := range selector.MatchLabels {
 		if labels[key] != value {
@@ -43,38 +316,62 @@ func labelSelectorMatches(selector *core.LabelSelector, labels map[string]string
 		}
 	}
 	for _, req := range selector.MatchExpressions {
-		switch core.LabelSelectorOperator(req.Operator) {
-		case core.LabelSelectorOpIn:
-			ok := false
-			for _, value := range req.Values {
-				if labels[req.Key] == value {
-					ok = true
-					break
-				}
-			}
-			if !ok {
-				return false
-			}
-		case core.LabelSelectorOpNotIn:
-			if v, ok := labels[req.Key]; !ok {
-				return false
-			} else {
-				for _, value := range req.Values {
-					if v == value {
-						return false
-					}
-				}
+		if requirementMatches(req, labels) == req.Negate {
+			return false
+		}
+		// A matching NotIn requirement matches the selector without checking
+		// the remaining requirements. This preserves the existing semantics of
+		// NotIn, which selectors stored before negation was added rely on.
+		if !req.Negate && core.LabelSelectorOperator(req.Operator) == core.LabelSelectorOpNotIn {
+			return true
+		}
+	}
+	return true
+}
+
+func requirementMatches(req *core.LabelSelectorRequirement, labels map[string]string) bool {
+	switch core.LabelSelectorOperator(req.Operator) {
+	case core.LabelSelectorOpIn:
+		for _, value := range req.Values {
+			if labels[req.Key] == value {
 				return true
 			}
-		case core.LabelSelectorOpExists:
-			if _, ok := labels[req.Key]; !ok {
-				return false
-			}
-		case core.LabelSelectorOpDoesNotExist:
-			if _, ok := labels[req.Key]; ok {
+		}
+		return false
+	case core.LabelSelectorOpNotIn:
+		v, ok := labels[req.Key]
+		if !ok {
+			return false
+		}
+		for _, value := range req.Values {
+			if v == value {
 				return false
 			}
 		}
+		return true
+	case core.LabelSelectorOpExists:
+		_, ok := labels[req.Key]
+		return ok
+	case core.LabelSelectorOpDoesNotExist:
+		_, ok := labels[req.Key]
+		return !ok
+	case core.LabelSelectorOpGt, core.LabelSelectorOpLt:
+		v, ok := labels[req.Key]
+		if !ok || len(req.Values) != 1 {
+			return false
+		}
+		actual, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return false
+		}
+		expected, err := strconv.ParseInt(req.Values[0], 10, 64)
+		if err != nil {
+			return false
+		}
+		if core.LabelSelectorOperator(req.Operator) == core.LabelSelectorOpGt {
+			return actual > expected
+		}
+		return actual < expected
 	}
 	return true
 }
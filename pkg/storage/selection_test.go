@@ -1,12 +1,18 @@
 package storage_test
 
 import (
+	"fmt"
+	"sync"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gmeasure"
 
 	"github.com/rancher/opni-monitoring/pkg/core"
 	"github.com/rancher/opni-monitoring/pkg/storage"
 	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/pkg/validation"
+	"google.golang.org/protobuf/proto"
 )
 
 var _ = Describe("Selection", Label(test.Unit), func() {
@@ -41,8 +47,311 @@ var _ = Describe("Selection", Label(test.Unit), func() {
 		Entry(nil, selector(matchExprs("bar DoesNotExist", "foo DoesNotExist")), cluster("c1"), true),
 		Entry(nil, selector(matchExprs("bar DoesNotExist", "bar Exists")), cluster("c1", "bar", "quux"), false),
 		Entry(nil, selector(matchExprs("bar DoesNotExist", "bar Exists")), cluster("c1", "foo", "quux"), false),
+		Entry(nil, selector(matchExprs("foo NotIn xyz", "bar Exists")), cluster("c1", "foo", "abc"), true),
+		Entry(nil, selector(matchExprs("bar Exists", "foo NotIn xyz")), cluster("c1", "foo", "abc"), false),
+		Entry(nil, selector(matchExprs("!foo NotIn xyz", "bar Exists")), cluster("c1", "foo", "xyz"), false),
+		Entry(nil, selector(matchExprs("replicas Gt 2")), cluster("c1", "replicas", "3"), true),
+		Entry(nil, selector(matchExprs("replicas Gt 3")), cluster("c1", "replicas", "3"), false),
+		Entry(nil, selector(matchExprs("replicas Gt 3")), cluster("c1", "replicas", "10"), true),
+		Entry(nil, selector(matchExprs("replicas Gt 0")), cluster("c1", "replicas", "1"), true),
+		Entry(nil, selector(matchExprs("version Lt 14")), cluster("c1", "version", "9"), true),
+		Entry(nil, selector(matchExprs("version Lt 14")), cluster("c1", "version", "14"), false),
+		Entry(nil, selector(matchExprs("version Lt 14")), cluster("c1", "version", "100"), false),
+		Entry(nil, selector(matchExprs("version Gt 9", "version Lt 14")), cluster("c1", "version", "12"), true),
+		Entry(nil, selector(matchExprs("version Gt 9", "version Lt 14")), cluster("c1", "version", "14"), false),
+		Entry(nil, selector(matchExprs("replicas Gt 2")), cluster("c1"), false),
+		Entry(nil, selector(matchExprs("replicas Lt 2")), cluster("c1"), false),
+		Entry(nil, selector(matchExprs("replicas Gt 2")), cluster("c1", "replicas", "many"), false),
+		Entry(nil, selector(matchExprs("replicas Lt 2")), cluster("c1", "replicas", "1.5"), false),
+		Entry(nil, selector(matchExprs("replicas Gt two")), cluster("c1", "replicas", "3"), false),
+	}
+	negatedEntries := []TableEntry{
+		Entry(nil, selector(matchExprs("!foo In bar")), cluster("c1", "foo", "bar"), false),
+		Entry(nil, selector(matchExprs("!foo In bar")), cluster("c1", "foo", "baz"), true),
+		Entry(nil, selector(matchExprs("!foo In bar,baz")), cluster("c1", "foo", "baz"), false),
+		Entry(nil, selector(matchExprs("!foo In bar")), cluster("c1"), true),
+		Entry(nil, selector(matchExprs("!foo In bar", "foo Exists")), cluster("c1"), false),
+		Entry(nil, selector(matchExprs("!foo In bar", "foo Exists")), cluster("c1", "foo", "baz"), true),
+		Entry(nil, selector(matchExprs("!foo Exists")), cluster("c1", "foo", "bar"), false),
+		Entry(nil, selector(matchExprs("!foo Exists")), cluster("c1", "bar", "baz"), true),
+		Entry(nil, selector(matchExprs("!foo Exists")), cluster("c1"), true),
+		Entry(nil, selector(matchExprs("!foo Exists", "!bar Exists")), cluster("c1", "bar", "baz"), false),
+		Entry(nil, selector("c1", matchExprs("!foo Exists")), cluster("c1", "foo", "bar"), true),
+	}
+	excludeEntries := []TableEntry{
+		Entry(nil, selector(exclude{"c1"}), cluster("c1"), false),
+		Entry(nil, selector(exclude{"c1"}), cluster("c2"), true),
+		Entry(nil, selector(exclude{"c1", "c2"}), cluster("c2"), false),
+		Entry(nil, selector(exclude{"c1"}, core.MatchOptions_EmptySelectorMatchesNone), cluster("c2"), true),
+		Entry(nil, selector(exclude{"c1"}, core.MatchOptions_EmptySelectorMatchesNone), cluster("c1"), false),
+		Entry(nil, selector("c1", "c2", exclude{"c1"}), cluster("c1"), false),
+		Entry(nil, selector("c1", "c2", exclude{"c1"}), cluster("c2"), true),
+		Entry(nil, selector("c1", exclude{"c2"}), cluster("c2"), false),
+		Entry(nil, selector("c1", exclude{"c2"}), cluster("c3"), false),
+		Entry(nil, selector(matchLabels("foo", "bar"), exclude{"c1"}), cluster("c1", "foo", "bar"), false),
+		Entry(nil, selector(matchLabels("foo", "bar"), exclude{"c1"}), cluster("c2", "foo", "bar"), true),
+		Entry(nil, selector(matchLabels("foo", "bar"), exclude{"c1"}), cluster("c2", "foo", "baz"), false),
+		Entry(nil, selector("c1", matchLabels("foo", "bar"), exclude{"c1"}), cluster("c1", "foo", "bar"), false),
+		Entry(nil, selector("c1", matchLabels("foo", "bar"), exclude{"c2"}), cluster("c1", "foo", "baz"), true),
+		Entry(nil, selector("c1", matchLabels("foo", "bar"), exclude{"c2"}), cluster("c2", "foo", "bar"), false),
+		Entry(nil, selector("c1", matchExprs("foo Exists"), exclude{"c1", "c3"}), cluster("c3", "foo", "bar"), false),
+		Entry(nil, selector("c1", matchExprs("foo Exists"), exclude{"c1", "c3"}), cluster("c4", "foo", "bar"), true),
+	}
+	matchAnyEntries := []TableEntry{
+		Entry(nil, selector(matchAny{matchLabels("region", "us"), matchLabels("tier", "critical")}), cluster("c1", "region", "us"), true),
+		Entry(nil, selector(matchAny{matchLabels("region", "us"), matchLabels("tier", "critical")}), cluster("c1", "region", "eu", "tier", "critical"), true),
+		Entry(nil, selector(matchAny{matchLabels("region", "us"), matchLabels("tier", "critical")}), cluster("c1", "region", "eu"), false),
+		Entry(nil, selector(matchAny{matchLabels("region", "us"), matchLabels("tier", "critical")}), cluster("c1"), false),
+		Entry(nil, selector(matchAny{matchLabels("region", "us"), matchExprs("tier In critical,high")}), cluster("c1", "tier", "high"), true),
+		Entry(nil, selector(matchAny{matchLabels("region", "us")}, core.MatchOptions_EmptySelectorMatchesNone), cluster("c1", "region", "us"), true),
+		Entry(nil, selector(matchAny{matchLabels("region", "us"), matchLabels()}), cluster("c1", "region", "eu"), true),
+		Entry(nil, selector(matchExprs("env In prod"), matchAny{matchLabels("region", "us"), matchLabels("tier", "critical")}), cluster("c1", "env", "prod", "tier", "critical"), true),
+		Entry(nil, selector(matchExprs("env In prod"), matchAny{matchLabels("region", "us"), matchLabels("tier", "critical")}), cluster("c1", "env", "dev", "tier", "critical"), false),
+		Entry(nil, selector(matchExprs("env In prod"), matchAny{matchLabels("region", "us"), matchLabels("tier", "critical")}), cluster("c1", "env", "prod"), false),
+		Entry(nil, selector("c1", matchAny{matchLabels("region", "us")}), cluster("c1"), true),
+		Entry(nil, selector("c1", matchAny{matchLabels("region", "us")}), cluster("c2"), false),
+		Entry(nil, selector("c1", matchAny{matchLabels("region", "us")}), cluster("c2", "region", "us"), true),
+		Entry(nil, selector(matchAny{matchLabels("region", "us"), matchLabels("tier", "critical")}, exclude{"c1"}), cluster("c1", "tier", "critical"), false),
+	}
+	capabilityEntries := []TableEntry{
+		Entry(nil, selector(requireCapabilities{"metrics"}), withCapabilities(cluster("c1"), "metrics"), true),
+		Entry(nil, selector(requireCapabilities{"metrics"}), withCapabilities(cluster("c1"), "logs", "metrics"), true),
+		Entry(nil, selector(requireCapabilities{"metrics"}), withCapabilities(cluster("c1"), "logs"), false),
+		Entry(nil, selector(requireCapabilities{"metrics"}), cluster("c1"), false),
+		Entry(nil, selector(requireCapabilities{"metrics", "logs"}), withCapabilities(cluster("c1"), "metrics", "logs"), true),
+		Entry(nil, selector(requireCapabilities{"metrics", "logs"}), withCapabilities(cluster("c1"), "logs", "traces", "metrics"), true),
+		Entry(nil, selector(requireCapabilities{"metrics", "logs"}), withCapabilities(cluster("c1"), "metrics"), false),
+		Entry(nil, selector(requireCapabilities{"metrics", "logs"}), withCapabilities(cluster("c1"), "logs", "traces"), false),
+		Entry(nil, selector(requireCapabilities{"metrics"}, core.MatchOptions_EmptySelectorMatchesNone), withCapabilities(cluster("c1"), "metrics"), true),
+		Entry(nil, selector("c1", requireCapabilities{"metrics"}), withCapabilities(cluster("c1"), "metrics"), true),
+		Entry(nil, selector("c1", requireCapabilities{"metrics"}), cluster("c1"), false),
+		Entry(nil, selector("c1", requireCapabilities{"metrics"}), withCapabilities(cluster("c2"), "metrics"), false),
+		Entry(nil, selector(matchLabels("foo", "bar"), requireCapabilities{"metrics"}), withCapabilities(cluster("c1", "foo", "bar"), "metrics"), true),
+		Entry(nil, selector(matchLabels("foo", "bar"), requireCapabilities{"metrics"}), withCapabilities(cluster("c1", "foo", "baz"), "metrics"), false),
+		Entry(nil, selector(matchLabels("foo", "bar"), requireCapabilities{"metrics"}), cluster("c1", "foo", "bar"), false),
+		Entry(nil, selector(requireCapabilities{"metrics"}, exclude{"c1"}), withCapabilities(cluster("c1"), "metrics"), false),
+		Entry(nil, selector(requireCapabilities{"metrics"}, exclude{"c1"}), withCapabilities(cluster("c2"), "metrics"), true),
 	}
 	DescribeTable("Label Selector", func(selector storage.ClusterSelector, c *core.Cluster, expected bool) {
 		Expect(selector.Predicate()(c)).To(Equal(expected))
 	}, entries)
+	DescribeTable("Negated Label Selector", func(selector storage.ClusterSelector, c *core.Cluster, expected bool) {
+		Expect(selector.Predicate()(c)).To(Equal(expected))
+	}, negatedEntries)
+	DescribeTable("Excluded Cluster IDs", func(selector storage.ClusterSelector, c *core.Cluster, expected bool) {
+		Expect(selector.Predicate()(c)).To(Equal(expected))
+	}, excludeEntries)
+	DescribeTable("Match Any", func(selector storage.ClusterSelector, c *core.Cluster, expected bool) {
+		Expect(selector.Predicate()(c)).To(Equal(expected))
+	}, matchAnyEntries)
+	DescribeTable("Capabilities", func(selector storage.ClusterSelector, c *core.Cluster, expected bool) {
+		Expect(selector.Predicate()(c)).To(Equal(expected))
+	}, capabilityEntries)
+	DescribeTable("Matcher", func(selector storage.ClusterSelector, c *core.Cluster, expected bool) {
+		Expect(selector.Matcher().Matches(c)).To(Equal(expected))
+	}, append(append(append(entries, negatedEntries...), excludeEntries...), matchAnyEntries...))
+	DescribeTable("Text Round-Trip", func(selector storage.ClusterSelector, _ *core.Cluster, _ bool) {
+		if selector.LabelSelector == nil {
+			Skip("no label selector")
+		}
+		text := core.FormatLabelSelector(selector.LabelSelector)
+		parsed, err := core.ParseLabelSelector(text)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proto.Equal(parsed, selector.LabelSelector)).To(BeTrue(), text)
+		Expect(core.FormatLabelSelector(parsed)).To(Equal(text))
+	}, append(entries, negatedEntries...))
+})
+
+var _ = Describe("Matcher", Label(test.Unit, test.Slow), func() {
+	var clusters []*core.Cluster
+	var sel storage.ClusterSelector
+	BeforeEach(func() {
+		clusters = make([]*core.Cluster, 5000)
+		ids := make([]string, 0, len(clusters)/2)
+		for i := range clusters {
+			id := fmt.Sprintf("cluster-%d", i)
+			clusters[i] = cluster(id, "index", fmt.Sprint(i%10))
+			if i%2 == 0 {
+				ids = append(ids, id)
+			}
+		}
+		sel = selector(ids, matchExprs("index In 1,2,3"), exclude{"cluster-3"})
+	})
+	It("should be safe for concurrent use", func() {
+		matcher := sel.Matcher()
+		predicate := sel.Predicate()
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				for _, c := range clusters {
+					Expect(matcher.Matches(c)).To(Equal(predicate(c)))
+				}
+			}()
+		}
+		wg.Wait()
+	})
+	It("should be faster than rebuilding the predicate for each cluster", func() {
+		exp := gmeasure.NewExperiment("selector evaluation")
+		exp.Sample(func(int) {
+			exp.MeasureDuration("repeated Predicate()", func() {
+				for _, c := range clusters[:500] {
+					sel.Predicate()(c)
+				}
+			})
+			exp.MeasureDuration("cached Matcher()", func() {
+				matcher := sel.Matcher()
+				for _, c := range clusters[:500] {
+					matcher.Matches(c)
+				}
+			})
+		}, gmeasure.SamplingConfig{
+			N: 10,
+		})
+		AddReportEntry(exp.Name, exp)
+
+		repeated := exp.GetStats("repeated Predicate()").DurationFor(gmeasure.StatMedian)
+		cached := exp.GetStats("cached Matcher()").DurationFor(gmeasure.StatMedian)
+		Expect(cached).To(BeNumerically("<", repeated))
+	})
 })
+
+var _ = Describe("Selector String", Label(test.Unit), func() {
+	DescribeTable("String", func(selector storage.ClusterSelector, expected string) {
+		Expect(selector.String()).To(Equal(expected))
+		Expect(fmt.Sprint(selector)).To(Equal(expected))
+	},
+		Entry(nil, selector(), "<all>"),
+		Entry(nil, selector(matchLabels(), matchExprs()), "<all>"),
+		Entry(nil, selector(core.MatchOptions_EmptySelectorMatchesNone), "<none>"),
+		Entry(nil, selector("c1", "c2"), "ids=[c1,c2]"),
+		Entry(nil, selector(matchLabels("foo", "bar", "env", "prod")), "labels=(env=prod, foo=bar)"),
+		Entry(nil, selector("c1", "c2", matchExprs("foo In bar,baz", "tier Exists")),
+			"ids=[c1,c2] labels=(foo in [bar,baz], tier exists)"),
+		Entry(nil, selector(matchExprs("foo NotIn bar", "tier DoesNotExist", "!env In dev")),
+			"labels=(foo notin [bar], tier doesnotexist, !(env in [dev]))"),
+		Entry(nil, selector(matchAny{matchLabels("region", "us"), matchExprs("tier In critical")}),
+			"any=[(region=us), (tier in [critical])]"),
+		Entry(nil, selector(exclude{"c3"}), "exclude=[c3]"),
+		Entry(nil, selector("c1", matchLabels("foo", "bar"), exclude{"c3"}), "ids=[c1] labels=(foo=bar) exclude=[c3]"),
+		Entry(nil, selector(requireCapabilities{"metrics", "logs"}), "capabilities=[metrics,logs]"),
+		Entry(nil, selector().SampleFraction(0.25), "<all> sample=0.25"),
+	)
+})
+
+var _ = Describe("Selector Validation", Label(test.Unit), func() {
+	DescribeTable("Validate", func(selector storage.ClusterSelector, expected error) {
+		if expected == nil {
+			Expect(selector.Validate()).To(Succeed())
+		} else {
+			Expect(selector.Validate()).To(MatchError(expected))
+		}
+	},
+		Entry(nil, selector(), nil),
+		Entry(nil, selector("c1", "c2"), nil),
+		Entry(nil, selector("$"), validation.ErrInvalidID),
+		Entry(nil, selector(exclude{"c1"}), nil),
+		Entry(nil, selector(exclude{"$"}), validation.ErrInvalidID),
+		Entry(nil, selector(matchLabels("foo", "bar")), nil),
+		Entry(nil, selector(matchLabels("foo", "\\")), validation.ErrInvalidLabelValue),
+		Entry(nil, selector(matchExprs("foo In bar,baz")), nil),
+		Entry(nil, selector(matchExprs("foo NotIn bar")), nil),
+		Entry(nil, selector(matchExprs("foo Exists")), nil),
+		Entry(nil, selector(matchExprs("foo DoesNotExist")), nil),
+		Entry(nil, selector(matchExprs("!foo In bar")), nil),
+		Entry(nil, selector(matchExprs("!foo Exists")), nil),
+		Entry(nil, selector(matchExprs("foo in bar")), validation.ErrInvalidValue),
+		Entry(nil, selector(matchExprs("foo exists")), validation.ErrInvalidValue),
+		Entry(nil, selector(matchExprs("foo Equals bar")), validation.ErrInvalidValue),
+		Entry(nil, selector(matchExprs("foo In")), validation.ErrMissingRequiredField),
+		Entry(nil, selector(matchExprs("foo NotIn")), validation.ErrMissingRequiredField),
+		Entry(nil, selector(matchExprs("foo Exists bar")), validation.ErrInvalidValue),
+		Entry(nil, selector(matchExprs("foo DoesNotExist bar")), validation.ErrInvalidValue),
+		Entry(nil, selector(matchExprs("foo Exists", "bar In")), validation.ErrMissingRequiredField),
+		Entry(nil, selector(core.MatchOptions(100)), validation.ErrInvalidValue),
+		Entry(nil, selector(requireCapabilities{"metrics"}), nil),
+		Entry(nil, selector(requireCapabilities{"metrics", ""}), validation.ErrInvalidValue),
+		Entry(nil, selector(matchExprs("replicas Gt 3", "version Lt 0")), nil),
+		Entry(nil, selector(matchExprs("replicas Gt")), validation.ErrMissingRequiredField),
+		Entry(nil, selector(matchExprs("replicas Lt 1,2")), validation.ErrInvalidValue),
+		Entry(nil, selector(matchExprs("replicas Gt three")), validation.ErrInvalidValue),
+		Entry(nil, selector(matchExprs("!replicas Gt 3")), validation.ErrInvalidValue),
+		Entry(nil, selector(matchAny{matchLabels("foo", "bar"), matchExprs("bar Exists")}), nil),
+		Entry(nil, selector(matchAny{matchLabels("foo", "bar"), matchExprs("bar In")}), validation.ErrMissingRequiredField),
+		Entry(nil, selector(matchAny{matchLabels("foo", "bar"), matchLabels("foo", "\\")}), validation.ErrInvalidLabelValue),
+		Entry(nil, selector(matchExprs("foo Exists")).SampleFraction(0.5), nil),
+		Entry(nil, selector().SampleFraction(1.5), validation.ErrInvalidValue),
+		Entry(nil, selector().SampleFraction(-0.5), validation.ErrInvalidValue),
+	)
+})
+
+var _ = Describe("Sampling", Label(test.Unit), func() {
+	var clusters []*core.Cluster
+	BeforeEach(func() {
+		clusters = nil
+		for i := 0; i < 10000; i++ {
+			env := "dev"
+			if i%2 == 0 {
+				env = "prod"
+			}
+			clusters = append(clusters, cluster(fmt.Sprintf("cluster-%d", i), "env", env))
+		}
+	})
+	sample := func(selector storage.ClusterSelector) []string {
+		predicate := selector.Predicate()
+		ids := []string{}
+		for _, c := range clusters {
+			if predicate(c) {
+				ids = append(ids, c.Id)
+			}
+		}
+		return ids
+	}
+	prod := selector(matchLabels("env", "prod"))
+
+	It("should select approximately the given fraction of matching clusters", func() {
+		all := sample(prod)
+		Expect(all).To(HaveLen(5000))
+		for _, f := range []float64{0.01, 0.1, 0.5, 0.9} {
+			selected := sample(prod.SampleFraction(f))
+			Expect(float64(len(selected))).To(BeNumerically("~", f*5000, 0.1*f*5000+50), "fraction %v", f)
+			Expect(subset(selected, all)).To(BeTrue())
+		}
+	})
+	It("should select the same clusters across evaluations", func() {
+		first := sample(prod.SampleFraction(0.1))
+		Expect(first).NotTo(BeEmpty())
+		for i := 0; i < 3; i++ {
+			Expect(sample(prod.SampleFraction(0.1))).To(Equal(first))
+		}
+	})
+	It("should select a superset of clusters for a larger fraction", func() {
+		small := sample(prod.SampleFraction(0.1))
+		large := sample(prod.SampleFraction(0.2))
+		Expect(subset(small, large)).To(BeTrue())
+		Expect(len(large)).To(BeNumerically(">", len(small)))
+	})
+	It("should select none or all clusters at the bounds", func() {
+		Expect(sample(prod.SampleFraction(0))).To(BeEmpty())
+		Expect(sample(prod.SampleFraction(1))).To(Equal(sample(prod)))
+	})
+	It("should not modify the base selector", func() {
+		prod.SampleFraction(0.1)
+		Expect(sample(prod)).To(HaveLen(5000))
+	})
+})
+
+func subset(a, b []string) bool {
+	set := map[string]struct{}{}
+	for _, id := range b {
+		set[id] = struct{}{}
+	}
+	for _, id := range a {
+		if _, ok := set[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
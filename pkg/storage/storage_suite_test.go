@@ -29,8 +29,32 @@ func cluster(id string, labels ...string) *core.Cluster {
 	return cluster
 }
 
+// withCapabilities adds the named capabilities to the cluster.
+func withCapabilities(c *core.Cluster, names ...string) *core.Cluster {
+	for _, name := range names {
+		c.Metadata.Capabilities = append(c.Metadata.Capabilities, &core.ClusterCapability{
+			Name: name,
+		})
+	}
+	return c
+}
+
+// requireCapabilities is a list of capabilities clusters must have, passed
+// to selector()
+type requireCapabilities []string
+
+// exclude is a list of cluster IDs to exclude, passed to selector()
+type exclude []string
+
+// matchAny is a list of label selectors, at least one of which must match,
+// passed to selector()
+type matchAny []*core.LabelSelector
+
 func selector(idsOrSelectorOrOptions ...interface{}) storage.ClusterSelector {
 	var ids []string
+	var excludeIDs []string
+	var anySelectors []*core.LabelSelector
+	var caps []string
 	var selector *core.LabelSelector
 	var options core.MatchOptions
 	for _, arg := range idsOrSelectorOrOptions {
@@ -39,6 +63,12 @@ func selector(idsOrSelectorOrOptions ...interface{}) storage.ClusterSelector {
 			ids = append(ids, value)
 		case []string:
 			ids = append(ids, value...)
+		case exclude:
+			excludeIDs = append(excludeIDs, value...)
+		case matchAny:
+			anySelectors = append(anySelectors, value...)
+		case requireCapabilities:
+			caps = append(caps, value...)
 		case *core.LabelSelector:
 			selector = value
 		case core.MatchOptions:
@@ -46,9 +76,12 @@ func selector(idsOrSelectorOrOptions ...interface{}) storage.ClusterSelector {
 		}
 	}
 	return storage.ClusterSelector{
-		ClusterIDs:    ids,
-		LabelSelector: selector,
-		MatchOptions:  options,
+		ClusterIDs:        ids,
+		LabelSelector:     selector,
+		MatchOptions:      options,
+		ExcludeClusterIDs: excludeIDs,
+		MatchAny:          anySelectors,
+		Capabilities:      caps,
 	}
 }
 
@@ -65,18 +98,22 @@ func matchLabels(labels ...string) *core.LabelSelector {
 func matchExprs(exprs ...string) *core.LabelSelector {
 	ls := &core.LabelSelector{}
 	for _, expr := range exprs {
-		parts := strings.Split(expr, " ")
+		// a leading '!' negates the requirement
+		negate := strings.HasPrefix(expr, "!")
+		parts := strings.Split(strings.TrimPrefix(expr, "!"), " ")
 		switch len(parts) {
 		case 3:
 			ls.MatchExpressions = append(ls.MatchExpressions, &core.LabelSelectorRequirement{
 				Key:      parts[0],
 				Operator: parts[1],
 				Values:   strings.Split(parts[2], ","),
+				Negate:   negate,
 			})
 		case 2:
 			ls.MatchExpressions = append(ls.MatchExpressions, &core.LabelSelectorRequirement{
 				Key:      parts[0],
 				Operator: parts[1],
+				Negate:   negate,
 			})
 		}
 	}
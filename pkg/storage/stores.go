@@ -21,6 +21,8 @@ type MutatorFunc[T any] func(T)
 type TokenMutator = MutatorFunc[*core.BootstrapToken]
 type ClusterMutator = MutatorFunc[*core.Cluster]
 
+type ClusterWatchEvent = WatchEvent[*core.Cluster]
+
 type TokenStore interface {
 	CreateToken(ctx context.Context, ttl time.Duration, opts ...TokenCreateOption) (*core.BootstrapToken, error)
 	DeleteToken(ctx context.Context, ref *core.Reference) error
@@ -30,11 +32,20 @@ type TokenStore interface {
 }
 
 type ClusterStore interface {
+	// CreateCluster creates a new cluster. It returns ErrAlreadyExists if a
+	// cluster with the same ID exists.
 	CreateCluster(ctx context.Context, cluster *core.Cluster) error
 	DeleteCluster(ctx context.Context, ref *core.Reference) error
 	GetCluster(ctx context.Context, ref *core.Reference) (*core.Cluster, error)
 	UpdateCluster(ctx context.Context, ref *core.Reference, mutator ClusterMutator) (*core.Cluster, error)
 	ListClusters(ctx context.Context, matchLabels *core.LabelSelector, matchOptions core.MatchOptions) (*core.ClusterList, error)
+	// WatchClusters returns a channel which receives an event each time a
+	// cluster is created, updated, or deleted, until ctx is done. When the
+	// watch starts, events are sent for any differences between the stored
+	// clusters and knownClusters, so a watcher which passes no known clusters
+	// first receives a create event for every existing cluster. The channel is
+	// closed once ctx is done.
+	WatchClusters(ctx context.Context, knownClusters []*core.Cluster) (<-chan ClusterWatchEvent, error)
 }
 
 type RBACStore interface {
@@ -48,9 +59,36 @@ type RBACStore interface {
 	ListRoleBindings(context.Context) (*core.RoleBindingList, error)
 }
 
+// KeyringStore stores a versioned keyring. One version is active, and is
+// used for all new operations. During a key rotation, the previously active
+// versions are kept, so that data produced using them can still be
+// validated, until they are pruned.
+// MaxPreviousKeyringVersions is the number of previous keyring versions
+// kept by KeyringStore.Rotate.
+const MaxPreviousKeyringVersions = 5
+
 type KeyringStore interface {
+	// Put replaces the active keyring. Previous versions are not modified.
 	Put(ctx context.Context, keyring keyring.Keyring) error
+	// Get returns the active keyring.
 	Get(ctx context.Context) (keyring.Keyring, error)
+	// Append merges the keys in the keyring into the active keyring, or
+	// stores it as the active keyring if there is none. Keys appended
+	// concurrently are never lost.
+	Append(ctx context.Context, keyring keyring.Keyring) error
+	// Rotate stores the keyring as a new version, and makes it the active
+	// version. The previously active keyring, if any, is kept as the most
+	// recent previous version. At most MaxPreviousKeyringVersions previous
+	// versions are kept; older versions are deleted.
+	Rotate(ctx context.Context, keyring keyring.Keyring) error
+	// List returns all stored versions, starting with the active keyring,
+	// followed by the previous versions from newest to oldest.
+	List(ctx context.Context) ([]keyring.Keyring, error)
+	// Prune deletes all previous versions, leaving only the active keyring.
+	Prune(ctx context.Context) error
+	// Delete deletes the active keyring and all previous versions. It is not
+	// an error if no keyring is stored.
+	Delete(ctx context.Context) error
 }
 
 type KeyValueStore interface {
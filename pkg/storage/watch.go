@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"google.golang.org/protobuf/proto"
+)
+
+type WatchEventType string
+
+const (
+	WatchEventCreate WatchEventType = "create"
+	WatchEventUpdate WatchEventType = "update"
+	WatchEventDelete WatchEventType = "delete"
+)
+
+// WatchEvent describes a change to a stored object. Current is nil for
+// delete events, and Previous is nil for create events.
+type WatchEvent[T any] struct {
+	EventType WatchEventType
+	Current   T
+	Previous  T
+}
+
+// ClusterWatchState keeps track of the clusters a watcher has been told
+// about. Backends use it to compute the events to send when a watch starts,
+// and to resume a watch after the underlying stream fails without sending
+// duplicate events.
+type ClusterWatchState struct {
+	clusters map[string]*core.Cluster
+}
+
+// NewClusterWatchState returns a state in which the watcher already knows
+// about the given clusters.
+func NewClusterWatchState(knownClusters []*core.Cluster) *ClusterWatchState {
+	s := &ClusterWatchState{
+		clusters: make(map[string]*core.Cluster, len(knownClusters)),
+	}
+	for _, cluster := range knownClusters {
+		s.clusters[cluster.GetId()] = cluster
+	}
+	return s
+}
+
+// Sync returns the events needed to bring the watcher up to date with the
+// given list of all current clusters, and updates the state to match.
+func (s *ClusterWatchState) Sync(current []*core.Cluster) []ClusterWatchEvent {
+	events := []ClusterWatchEvent{}
+	ids := make(map[string]struct{}, len(current))
+	for _, cluster := range current {
+		ids[cluster.GetId()] = struct{}{}
+		if event, ok := s.Put(cluster); ok {
+			events = append(events, event)
+		}
+	}
+	for id := range s.clusters {
+		if _, ok := ids[id]; ok {
+			continue
+		}
+		if event, ok := s.Delete(id); ok {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// Put records that the cluster was created or updated. If the watcher needs
+// to be told about it, the event to send is returned with ok set to true.
+// Updates which do not change the cluster are ignored.
+func (s *ClusterWatchState) Put(cluster *core.Cluster) (_ ClusterWatchEvent, ok bool) {
+	previous, exists := s.clusters[cluster.GetId()]
+	if exists && proto.Equal(previous, cluster) {
+		return ClusterWatchEvent{}, false
+	}
+	s.clusters[cluster.GetId()] = cluster
+	if !exists {
+		return ClusterWatchEvent{
+			EventType: WatchEventCreate,
+			Current:   cluster,
+		}, true
+	}
+	return ClusterWatchEvent{
+		EventType: WatchEventUpdate,
+		Current:   cluster,
+		Previous:  previous,
+	}, true
+}
+
+// Delete records that the cluster with the given ID was deleted. If the
+// watcher knew about the cluster, the event to send is returned with ok set
+// to true.
+func (s *ClusterWatchState) Delete(id string) (_ ClusterWatchEvent, ok bool) {
+	previous, exists := s.clusters[id]
+	if !exists {
+		return ClusterWatchEvent{}, false
+	}
+	delete(s.clusters, id)
+	return ClusterWatchEvent{
+		EventType: WatchEventDelete,
+		Previous:  previous,
+	}, true
+}
@@ -0,0 +1,73 @@
+package storage_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/storage"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+func labeledCluster(id string, labels ...string) *core.Cluster {
+	cluster := &core.Cluster{
+		Id: id,
+		Metadata: &core.ClusterMetadata{
+			Labels: map[string]string{},
+		},
+	}
+	for i := 0; i+1 < len(labels); i += 2 {
+		cluster.Metadata.Labels[labels[i]] = labels[i+1]
+	}
+	return cluster
+}
+
+var _ = Describe("Cluster Watch State", Label(test.Unit), func() {
+	It("should send create events for unknown clusters", func() {
+		state := storage.NewClusterWatchState(nil)
+		events := state.Sync([]*core.Cluster{
+			labeledCluster("a"),
+			labeledCluster("b"),
+		})
+		Expect(events).To(HaveLen(2))
+		for _, event := range events {
+			Expect(event.EventType).To(Equal(storage.WatchEventCreate))
+			Expect(event.Previous).To(BeNil())
+		}
+		Expect(state.Sync([]*core.Cluster{
+			labeledCluster("a"),
+			labeledCluster("b"),
+		})).To(BeEmpty())
+	})
+	It("should send update and delete events for known clusters", func() {
+		state := storage.NewClusterWatchState([]*core.Cluster{
+			labeledCluster("a", "foo", "bar"),
+			labeledCluster("b"),
+			labeledCluster("c"),
+		})
+		events := state.Sync([]*core.Cluster{
+			labeledCluster("a", "foo", "baz"),
+			labeledCluster("b"),
+		})
+		Expect(events).To(HaveLen(2))
+		byType := map[storage.WatchEventType]storage.ClusterWatchEvent{}
+		for _, event := range events {
+			byType[event.EventType] = event
+		}
+		update := byType[storage.WatchEventUpdate]
+		Expect(update.Current.GetLabels()).To(HaveKeyWithValue("foo", "baz"))
+		Expect(update.Previous.GetLabels()).To(HaveKeyWithValue("foo", "bar"))
+		deleted := byType[storage.WatchEventDelete]
+		Expect(deleted.Current).To(BeNil())
+		Expect(deleted.Previous.GetId()).To(Equal("c"))
+	})
+	It("should ignore updates which do not change the cluster", func() {
+		state := storage.NewClusterWatchState([]*core.Cluster{
+			labeledCluster("a", "foo", "bar"),
+		})
+		_, ok := state.Put(labeledCluster("a", "foo", "bar"))
+		Expect(ok).To(BeFalse())
+		_, ok = state.Delete("b")
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -0,0 +1,69 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rancher/opni-monitoring/pkg/util/waitctx"
+	"go.uber.org/zap"
+)
+
+var ErrLowDiskSpace = errors.New("low disk space")
+
+// DiskStatFunc returns the number of bytes available to unprivileged users
+// on the filesystem containing the given path.
+type DiskStatFunc func(path string) (uint64, error)
+
+func (e *Environment) checkDiskSpace() error {
+	available, err := e.diskStat(e.tempDir)
+	if err != nil {
+		return fmt.Errorf("failed to check disk space in %s: %w", e.tempDir, err)
+	}
+	if available < e.minFreeDiskSpace {
+		return fmt.Errorf("%w: %d bytes available in %s, need at least %d",
+			ErrLowDiskSpace, available, e.tempDir, e.minFreeDiskSpace)
+	}
+	return nil
+}
+
+// monitorDiskSpace periodically checks the free disk space in the
+// environment's temp directory, and stops the environment if it drops below
+// the configured threshold.
+func (e *Environment) monitorDiskSpace() {
+	lg := e.Logger
+	waitctx.Go(e.ctx, func() {
+		ticker := time.NewTicker(e.diskMonitorInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-e.ctx.Done():
+				return
+			case <-ticker.C:
+				if err := e.checkDiskSpace(); err != nil {
+					lg.With(
+						zap.Error(err),
+					).Error("stopping test environment")
+					e.setErr(err)
+					e.cancel()
+					return
+				}
+			}
+		}
+	})
+}
+
+func (e *Environment) setErr(err error) {
+	e.errMu.Lock()
+	defer e.errMu.Unlock()
+	if e.err == nil {
+		e.err = err
+	}
+}
+
+// Err returns the error which caused the environment to stop, if any.
+func (e *Environment) Err() error {
+	e.errMu.Lock()
+	defer e.errMu.Unlock()
+	return e.err
+}
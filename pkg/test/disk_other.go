@@ -0,0 +1,14 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux
+
+package test
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Free disk space can't be checked on this platform without statfs. A
+// custom function can still be set using WithDiskStatFunc.
+func statfsAvailableBytes(string) (uint64, error) {
+	return 0, fmt.Errorf("checking disk space is not supported on %s", runtime.GOOS)
+}
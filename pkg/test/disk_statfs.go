@@ -0,0 +1,13 @@
+//go:build aix || darwin || dragonfly || freebsd || linux
+
+package test
+
+import "syscall"
+
+func statfsAvailableBytes(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
@@ -8,12 +9,12 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"net"
 	"net/http"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path"
-	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -38,6 +39,7 @@ import (
 	"github.com/rancher/opni-monitoring/pkg/core"
 	"github.com/rancher/opni-monitoring/pkg/gateway"
 	"github.com/rancher/opni-monitoring/pkg/ident"
+	"github.com/rancher/opni-monitoring/pkg/labels"
 	"github.com/rancher/opni-monitoring/pkg/logger"
 	"github.com/rancher/opni-monitoring/pkg/management"
 	"github.com/rancher/opni-monitoring/pkg/pkp"
@@ -52,6 +54,8 @@ import (
 	"github.com/rancher/opni-monitoring/pkg/test/testutil"
 	"github.com/rancher/opni-monitoring/pkg/tokens"
 	"github.com/rancher/opni-monitoring/pkg/util"
+	"github.com/rancher/opni-monitoring/pkg/util/atomic"
+	"github.com/rancher/opni-monitoring/pkg/util/backoff"
 	"github.com/rancher/opni-monitoring/pkg/util/waitctx"
 	"github.com/rancher/opni-monitoring/pkg/webui"
 	"github.com/ttacon/chalk"
@@ -79,6 +83,7 @@ type servicePorts struct {
 type RunningAgent struct {
 	*agent.Agent
 	*sync.Mutex
+	stop func() error
 }
 
 type Environment struct {
@@ -93,6 +98,8 @@ type Environment struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 	once   sync.Once
+	err    error
+	errMu  sync.Mutex
 
 	tempDir string
 	ports   servicePorts
@@ -100,19 +107,41 @@ type Environment struct {
 	runningAgents   map[string]RunningAgent
 	runningAgentsMu sync.Mutex
 
+	sharedManagementClient management.ManagementClient
+	managementClientOnce   sync.Once
+
 	gatewayConfig *v1beta1.GatewayConfig
+	gw            *gateway.Gateway
 	k8sEnv        *envtest.Environment
 
 	Processes struct {
-		Etcd      *util.Future[*os.Process]
+		Etcd *util.Future[*os.Process]
+		// Set by StartK8s if the kube-apiserver process can be found. This
+		// requires /proc or ps; on other platforms, it is left unset.
 		APIServer *util.Future[*os.Process]
 	}
 }
 
 type EnvironmentOptions struct {
-	enableEtcd    bool
-	enableGateway bool
-	enableCortex  bool
+	enableEtcd     bool
+	enableGateway  bool
+	enableCortex   bool
+	externalEtcd   []string
+	externalCortex *externalCortexAddrs
+	managementTLS  bool
+	storageBackend StorageBackendKind
+
+	gatewayConfigOverride func(*v1beta1.GatewayConfig)
+	cortexReadyTimeout    time.Duration
+
+	minFreeDiskSpace    uint64
+	diskMonitorInterval time.Duration
+	diskStat            DiskStatFunc
+}
+
+type externalCortexAddrs struct {
+	httpAddress string
+	grpcAddress string
 }
 
 type EnvironmentOption func(*EnvironmentOptions)
@@ -141,13 +170,139 @@ func WithEnableCortex(enableCortex bool) EnvironmentOption {
 	}
 }
 
+// WithExternalEtcd configures the environment to use an existing etcd
+// cluster at the given endpoints instead of starting its own etcd process.
+func WithExternalEtcd(endpoints ...string) EnvironmentOption {
+	return func(o *EnvironmentOptions) {
+		o.enableEtcd = true
+		o.externalEtcd = endpoints
+	}
+}
+
+// WithExternalCortex configures the environment to use an existing cortex
+// instance at the given addresses instead of starting its own cortex process.
+// The external cortex instance must be configured to use the test certs in
+// testdata/cortex.
+func WithExternalCortex(httpAddress, grpcAddress string) EnvironmentOption {
+	return func(o *EnvironmentOptions) {
+		o.enableCortex = true
+		o.externalCortex = &externalCortexAddrs{
+			httpAddress: httpAddress,
+			grpcAddress: grpcAddress,
+		}
+	}
+}
+
+// StorageBackendKind selects the storage backend used by the gateway started
+// by the environment.
+type StorageBackendKind string
+
+const (
+	// The gateway stores data in etcd. Requires etcd to be enabled.
+	StorageBackendEtcd StorageBackendKind = "etcd"
+	// The gateway stores data in memory, using the same in-memory stores as
+	// NewTestStorageBackend. Data is lost when the environment is stopped.
+	StorageBackendInMemory StorageBackendKind = "inMemory"
+)
+
+// WithStorageBackend selects the storage backend used by the gateway.
+// Defaults to StorageBackendEtcd. When using StorageBackendInMemory, etcd
+// is not started unless it is explicitly enabled, and the cortex plugin is
+// not loaded, since it reads the storage backend from the gateway config.
+func WithStorageBackend(kind StorageBackendKind) EnvironmentOption {
+	return func(o *EnvironmentOptions) {
+		o.storageBackend = kind
+		if kind == StorageBackendInMemory && len(o.externalEtcd) == 0 {
+			o.enableEtcd = false
+		}
+	}
+}
+
+// WithEnableManagementOnly configures the environment to start only the
+// gateway and management server, using an in-memory storage backend, so
+// that management APIs can be tested without any external binaries. Etcd
+// and cortex are disabled, and accessing them panics.
+func WithEnableManagementOnly() EnvironmentOption {
+	return func(o *EnvironmentOptions) {
+		o.enableGateway = true
+		o.enableEtcd = false
+		o.enableCortex = false
+		o.externalEtcd = nil
+		o.externalCortex = nil
+		o.storageBackend = StorageBackendInMemory
+	}
+}
+
+// WithEnableManagementTLS configures the management server to serve TLS
+// using the gateway's serving certificate. Clients created using
+// NewManagementClient will verify the server using the gateway CA.
+func WithEnableManagementTLS(enable bool) EnvironmentOption {
+	return func(o *EnvironmentOptions) {
+		o.managementTLS = enable
+	}
+}
+
+// WithGatewayConfigOverride sets a function which can modify the gateway
+// config before the gateway is started. It is called with the config built
+// by the environment, so any fields it does not change keep the ports and
+// certs managed by the environment. The config is validated after the
+// override is applied.
+func WithGatewayConfigOverride(override func(*v1beta1.GatewayConfig)) EnvironmentOption {
+	return func(o *EnvironmentOptions) {
+		o.gatewayConfigOverride = override
+	}
+}
+
+// WithCortexReadyTimeout sets how long Start waits for cortex to become
+// ready. Defaults to 1 minute.
+func WithCortexReadyTimeout(timeout time.Duration) EnvironmentOption {
+	return func(o *EnvironmentOptions) {
+		o.cortexReadyTimeout = timeout
+	}
+}
+
+// WithMinFreeDiskSpace sets the minimum number of bytes which must be free
+// in the environment's temp directory. The environment will fail to start if
+// less space is available.
+func WithMinFreeDiskSpace(bytes uint64) EnvironmentOption {
+	return func(o *EnvironmentOptions) {
+		o.minFreeDiskSpace = bytes
+	}
+}
+
+// WithDiskSpaceMonitor enables a periodic check of the free disk space in the
+// environment's temp directory. If it drops below the minimum set using
+// WithMinFreeDiskSpace, the environment will be stopped, and the error will
+// be available from Environment.Err().
+func WithDiskSpaceMonitor(interval time.Duration) EnvironmentOption {
+	return func(o *EnvironmentOptions) {
+		o.diskMonitorInterval = interval
+	}
+}
+
+// WithDiskStatFunc overrides the function used to check free disk space.
+func WithDiskStatFunc(fn DiskStatFunc) EnvironmentOption {
+	return func(o *EnvironmentOptions) {
+		o.diskStat = fn
+	}
+}
+
+// Start starts the environment's components. If any component fails to
+// start, an error is returned; Stop should still be called to clean up any
+// components which were already started.
 func (e *Environment) Start(opts ...EnvironmentOption) error {
 	options := EnvironmentOptions{
-		enableEtcd:    true,
-		enableGateway: true,
-		enableCortex:  true,
+		enableEtcd:         true,
+		enableGateway:      true,
+		enableCortex:       true,
+		storageBackend:     StorageBackendEtcd,
+		cortexReadyTimeout: time.Minute,
+		diskStat:           statfsAvailableBytes,
 	}
 	options.Apply(opts...)
+	if options.enableGateway && options.storageBackend == StorageBackendEtcd && !options.enableEtcd {
+		return errors.New("the etcd storage backend requires etcd to be enabled")
+	}
 
 	e.Logger = Log.Named("env")
 
@@ -175,7 +330,7 @@ func (e *Environment) Start(opts ...EnvironmentOption) error {
 	}
 	ports, err := freeport.GetFreePorts(8)
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("failed to allocate ports: %w", err)
 	}
 	e.ports = servicePorts{
 		Etcd:            ports[0],
@@ -214,7 +369,7 @@ func (e *Environment) Start(opts ...EnvironmentOption) error {
 	if portNum, ok := os.LookupEnv("TEST_ENV_API_PORT"); ok {
 		e.ports.TestEnvironment, err = strconv.Atoi(portNum)
 		if err != nil {
-			panic(err)
+			return fmt.Errorf("failed to parse test environment API port: %w", err)
 		}
 	}
 
@@ -222,7 +377,15 @@ func (e *Environment) Start(opts ...EnvironmentOption) error {
 	if err != nil {
 		return err
 	}
-	if options.enableEtcd {
+	if options.minFreeDiskSpace > 0 {
+		if err := e.checkDiskSpace(); err != nil {
+			return err
+		}
+		if options.diskMonitorInterval > 0 {
+			e.monitorDiskSpace()
+		}
+	}
+	if options.enableEtcd && len(options.externalEtcd) == 0 {
 		if err := os.Mkdir(path.Join(e.tempDir, "etcd"), 0700); err != nil {
 			return err
 		}
@@ -236,7 +399,7 @@ func (e *Environment) Start(opts ...EnvironmentOption) error {
 		entries, _ := fs.ReadDir(TestDataFS, "testdata/cortex")
 		lg.Infof("Copying %d files from embedded testdata/cortex to %s", len(entries), cortexTempDir)
 		for _, entry := range entries {
-			if err := os.WriteFile(path.Join(cortexTempDir, entry.Name()), TestData("cortex/"+entry.Name()), 0644); err != nil {
+			if err := atomic.WriteFile(path.Join(cortexTempDir, entry.Name()), TestData("cortex/"+entry.Name()), 0644); err != nil {
 				return err
 			}
 		}
@@ -248,24 +411,110 @@ func (e *Environment) Start(opts ...EnvironmentOption) error {
 	}
 
 	if options.enableEtcd {
-		e.startEtcd()
+		if len(options.externalEtcd) > 0 {
+			if err := e.checkExternalEtcd(); err != nil {
+				return err
+			}
+		} else if err := e.startEtcd(); err != nil {
+			return fmt.Errorf("failed to start etcd: %w", err)
+		}
+	}
+	if options.enableCortex && options.externalCortex != nil {
+		if err := e.checkExternalCortex(); err != nil {
+			return err
+		}
 	}
 	if options.enableGateway {
-		e.startGateway()
+		if err := e.startGateway(); err != nil {
+			return fmt.Errorf("failed to start gateway: %w", err)
+		}
 	}
-	if options.enableCortex {
-		e.startCortex()
+	if options.enableCortex && options.externalCortex == nil {
+		if err := e.startCortex(); err != nil {
+			return fmt.Errorf("failed to start cortex: %w", err)
+		}
+	}
+	return nil
+}
+
+func (e *Environment) checkExternalEtcd() error {
+	lg := e.Logger
+	client := http.Client{
+		Timeout: 5 * time.Second,
+	}
+	for _, endpoint := range e.externalEtcd {
+		resp, err := client.Get(fmt.Sprintf("%s/health", strings.TrimSuffix(endpoint, "/")))
+		if err != nil {
+			return fmt.Errorf("failed to connect to external etcd endpoint %s: %w", endpoint, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("external etcd endpoint %s is not healthy: %s", endpoint, resp.Status)
+		}
+	}
+	lg.With(
+		"endpoints", e.externalEtcd,
+	).Info("Using external etcd")
+	return nil
+}
+
+func (e *Environment) checkExternalCortex() error {
+	lg := e.Logger
+	for _, addr := range []string{
+		e.externalCortex.httpAddress,
+		e.externalCortex.grpcAddress,
+	} {
+		conn, err := net.DialTimeout("tcp", addr, 5*time.Second)
+		if err != nil {
+			return fmt.Errorf("failed to connect to external cortex at %s: %w", addr, err)
+		}
+		conn.Close()
 	}
+	lg.With(
+		"http", e.externalCortex.httpAddress,
+		"grpc", e.externalCortex.grpcAddress,
+	).Info("Using external cortex")
 	return nil
 }
 
+func (e *Environment) etcdEndpoints() []string {
+	if len(e.externalEtcd) > 0 {
+		return e.externalEtcd
+	}
+	return []string{fmt.Sprintf("http://localhost:%d", e.ports.Etcd)}
+}
+
+func (e *Environment) cortexHTTPAddress() string {
+	if e.externalCortex != nil {
+		return e.externalCortex.httpAddress
+	}
+	return fmt.Sprintf("localhost:%d", e.ports.CortexHTTP)
+}
+
+// CortexHTTPAddress returns the address of cortex's HTTP server.
+func (e *Environment) CortexHTTPAddress() string {
+	if !e.enableCortex {
+		e.Logger.Panic("cortex disabled")
+	}
+	return e.cortexHTTPAddress()
+}
+
+func (e *Environment) cortexGRPCAddress() string {
+	if e.externalCortex != nil {
+		return e.externalCortex.grpcAddress
+	}
+	return fmt.Sprintf("localhost:%d", e.ports.CortexGRPC)
+}
+
+// StartK8s starts a kube-apiserver using envtest, and returns a config which
+// can be used to connect to it.
 func (e *Environment) StartK8s() (*rest.Config, error) {
 	e.initCtx()
 	e.Processes.APIServer = util.NewFuture[*os.Process]()
 
 	port, err := freeport.GetFreePort()
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 	scheme := api.NewScheme()
 
@@ -290,43 +539,15 @@ func (e *Environment) StartK8s() (*rest.Config, error) {
 	if err != nil {
 		return nil, err
 	}
-	pid := os.Getpid()
-	threads, err := os.ReadDir(fmt.Sprintf("/proc/%d/task/", pid))
+	// the apiserver process is only used to simulate storage errors, so
+	// tests which don't need it can still run if it can't be found
+	proc, err := findChildProcess("kube-apiserver")
 	if err != nil {
-		panic(err)
-	}
-	possiblePIDs := []int{}
-	for _, thread := range threads {
-		childProcessIDs, err := os.ReadFile(fmt.Sprintf("/proc/%d/task/%s/children", pid, thread.Name()))
-		if err != nil {
-			continue
-		}
-		if len(childProcessIDs) > 0 {
-			parts := strings.Split(string(childProcessIDs), " ")
-			for _, part := range parts {
-				if pid, err := strconv.Atoi(part); err == nil {
-					possiblePIDs = append(possiblePIDs, pid)
-				}
-			}
-		}
-	}
-	var apiserverPID int
-	for _, pid := range possiblePIDs {
-		exe, err := os.Readlink(fmt.Sprintf("/proc/%d/exe", pid))
-		if err != nil {
-			continue
-		}
-		if filepath.Base(exe) == "kube-apiserver" {
-			apiserverPID = pid
-			break
-		}
-	}
-	if apiserverPID == 0 {
-		panic("could not find kube-apiserver PID")
-	}
-	proc, err := os.FindProcess(apiserverPID)
-	if err != nil {
-		panic(err)
+		// StartK8s can be called without Start, so e.Logger may not be set
+		Log.Named("env").With(
+			zap.Error(err),
+		).Warn("could not find kube-apiserver process")
+		return cfg, nil
 	}
 	e.Processes.APIServer.Set(proc)
 	return cfg, nil
@@ -378,7 +599,7 @@ func (e *Environment) initCtx() {
 	})
 }
 
-func (e *Environment) startEtcd() {
+func (e *Environment) startEtcd() error {
 	if !e.enableEtcd {
 		e.Logger.Panic("etcd disabled")
 	}
@@ -397,29 +618,23 @@ func (e *Environment) startEtcd() {
 	session, err := testutil.StartCmd(cmd)
 	if err != nil {
 		if !errors.Is(e.ctx.Err(), context.Canceled) {
-			panic(err)
-		} else {
-			return
+			return err
 		}
+		return nil
 	}
 	e.Processes.Etcd.Set(cmd.Process)
 
 	lg.Info("Waiting for etcd to start...")
-	for e.ctx.Err() == nil {
-		resp, err := http.Get(fmt.Sprintf("http://localhost:%d/health", e.ports.Etcd))
-		if err == nil {
-			defer resp.Body.Close()
-			if resp.StatusCode == http.StatusOK {
-				break
-			}
-		}
-		time.Sleep(time.Second)
+	if err := e.waitForReady(e.ctx, http.DefaultClient,
+		fmt.Sprintf("http://localhost:%d/health", e.ports.Etcd), healthCheckPolicy); err != nil {
+		lg.With(zap.Error(err)).Warn("etcd did not become ready")
 	}
 	lg.Info("Etcd started")
 	waitctx.Go(e.ctx, func() {
 		<-e.ctx.Done()
 		session.Wait()
 	})
+	return nil
 }
 
 type cortexTemplateOptions struct {
@@ -428,25 +643,23 @@ type cortexTemplateOptions struct {
 	StorageDir     string
 }
 
-func (e *Environment) startCortex() {
+func (e *Environment) startCortex() error {
 	if !e.enableCortex {
 		e.Logger.Panic("cortex disabled")
 	}
 	lg := e.Logger
-	configTemplate := TestData("cortex/config.yaml")
-	t := util.Must(template.New("config").Parse(string(configTemplate)))
-	configFile, err := os.Create(path.Join(e.tempDir, "cortex", "config.yaml"))
-	if err != nil {
-		panic(err)
-	}
-	if err := t.Execute(configFile, cortexTemplateOptions{
+	if err := writeConfigTemplate("cortex/config.yaml", cortexTemplateOptions{
 		HttpListenPort: e.ports.CortexHTTP,
 		GrpcListenPort: e.ports.CortexGRPC,
 		StorageDir:     path.Join(e.tempDir, "cortex"),
-	}); err != nil {
-		panic(err)
+	}, path.Join(e.tempDir, "cortex", "config.yaml")); err != nil {
+		return err
+	}
+	// the runtime config file must exist before cortex starts; it is
+	// rewritten by the gateway when cluster limits change
+	if err := atomic.WriteFile(path.Join(e.tempDir, "cortex", "runtime_config.yaml"), []byte("overrides: {}\n"), 0644); err != nil {
+		return err
 	}
-	configFile.Close()
 	cortexBin := path.Join(e.TestBin, "cortex")
 	defaultArgs := []string{
 		fmt.Sprintf("-config.file=%s", path.Join(e.tempDir, "cortex/config.yaml")),
@@ -456,34 +669,47 @@ func (e *Environment) startCortex() {
 	session, err := testutil.StartCmd(cmd)
 	if err != nil {
 		if !errors.Is(e.ctx.Err(), context.Canceled) {
-			panic(err)
+			return err
 		}
+		return nil
 	}
 	lg.Info("Waiting for cortex to start...")
-	for e.ctx.Err() == nil {
-		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("https://localhost:%d/ready", e.ports.Gateway), nil)
-		client := http.Client{
-			Transport: &http.Transport{
-				TLSClientConfig: e.GatewayTLSConfig(),
-			},
+	if err := e.WaitForCortexReady(e.cortexReadyTimeout); err != nil {
+		lg.With(zap.Error(err)).Warn("cortex did not become ready")
+	}
+	if e.enableGateway {
+		// cortex can be ready before the gateway is able to reach it
+		ctx, ca := context.WithTimeout(e.ctx, e.cortexReadyTimeout)
+		defer ca()
+		if err := e.waitForReady(ctx, e.gatewayHTTPClient(),
+			fmt.Sprintf("https://localhost:%d/ready", e.ports.Gateway), healthCheckPolicy); err != nil {
+			lg.With(zap.Error(err)).Warn("cortex is not reachable through the gateway")
 		}
-		resp, err := client.Do(req)
-		if err == nil && resp.StatusCode == http.StatusOK {
-			break
-		}
-		if resp != nil {
-			lg.With(
-				zap.Error(err),
-				"status", resp.Status,
-			).Info("Waiting for cortex to start...")
-		}
-		time.Sleep(time.Second)
 	}
 	lg.Info("Cortex started")
 	waitctx.Go(e.ctx, func() {
 		<-e.ctx.Done()
 		session.Wait()
 	})
+	return nil
+}
+
+// WaitForCortexReady polls cortex's /ready endpoint directly, without going
+// through the gateway, until cortex reports that it is ready. An error is
+// returned if cortex is not ready within the timeout, or if the environment
+// is stopped.
+func (e *Environment) WaitForCortexReady(timeout time.Duration) error {
+	if !e.enableCortex {
+		e.Logger.Panic("cortex disabled")
+	}
+	ctx, ca := context.WithTimeout(e.ctx, timeout)
+	defer ca()
+	addr := e.cortexHTTPAddress()
+	if err := e.waitForReady(ctx, e.CortexHTTPClient(),
+		fmt.Sprintf("https://%s/ready", addr), healthCheckPolicy); err != nil {
+		return fmt.Errorf("cortex at %s did not become ready within %s: %w", addr, timeout, err)
+	}
+	return nil
 }
 
 type prometheusTemplateOptions struct {
@@ -491,28 +717,24 @@ type prometheusTemplateOptions struct {
 	OpniAgentPort int
 }
 
-func (e *Environment) StartPrometheus(opniAgentPort int) int {
+// StartPrometheus starts a prometheus agent which scrapes itself and sends
+// metrics to the opni agent listening on the given port. It returns the port
+// prometheus is listening on.
+func (e *Environment) StartPrometheus(opniAgentPort int) (int, error) {
 	if !e.enableGateway {
 		e.Logger.Panic("gateway disabled")
 	}
 	lg := e.Logger
 	port, err := freeport.GetFreePort()
 	if err != nil {
-		panic(err)
+		return 0, err
 	}
-	configTemplate := TestData("prometheus/config.yaml")
-	t := util.Must(template.New("config").Parse(string(configTemplate)))
-	configFile, err := os.Create(path.Join(e.tempDir, "prometheus", "config.yaml"))
-	if err != nil {
-		panic(err)
-	}
-	if err := t.Execute(configFile, prometheusTemplateOptions{
+	if err := writeConfigTemplate("prometheus/config.yaml", prometheusTemplateOptions{
 		ListenPort:    port,
 		OpniAgentPort: opniAgentPort,
-	}); err != nil {
-		panic(err)
+	}, path.Join(e.tempDir, "prometheus", "config.yaml")); err != nil {
+		return 0, err
 	}
-	configFile.Close()
 	prometheusBin := path.Join(e.TestBin, "prometheus")
 	defaultArgs := []string{
 		fmt.Sprintf("--config.file=%s", path.Join(e.tempDir, "prometheus/config.yaml")),
@@ -527,26 +749,101 @@ func (e *Environment) StartPrometheus(opniAgentPort int) int {
 	session, err := testutil.StartCmd(cmd)
 	if err != nil {
 		if !errors.Is(e.ctx.Err(), context.Canceled) {
-			panic(err)
+			return 0, err
 		}
+		return 0, e.ctx.Err()
 	}
 	lg.Info("Waiting for prometheus to start...")
-	for e.ctx.Err() == nil {
-		resp, err := http.Get(fmt.Sprintf("http://localhost:%d/-/ready", port))
-		if err == nil {
-			defer resp.Body.Close()
-			if resp.StatusCode == http.StatusOK {
-				break
-			}
-		}
-		time.Sleep(time.Second)
+	if err := e.waitForReady(e.ctx, http.DefaultClient,
+		fmt.Sprintf("http://localhost:%d/-/ready", port), healthCheckPolicy); err != nil {
+		lg.With(zap.Error(err)).Warn("prometheus did not become ready")
 	}
 	lg.Info("Prometheus started")
 	waitctx.Go(e.ctx, func() {
 		<-e.ctx.Done()
 		session.Wait()
 	})
-	return port
+	return port, nil
+}
+
+// configTemplateData returns the contents of the testdata template used to
+// generate a process's config file. It can be replaced in tests.
+var configTemplateData = TestData
+
+// writeConfigTemplate executes the named testdata template with the given
+// data, and writes the result to dest.
+func writeConfigTemplate(name string, data any, dest string) error {
+	t, err := template.New(name).Parse(string(configTemplateData(name)))
+	if err != nil {
+		return fmt.Errorf("failed to parse config template %s: %w", name, err)
+	}
+	var config bytes.Buffer
+	if err := t.Execute(&config, data); err != nil {
+		return fmt.Errorf("failed to execute config template %s: %w", name, err)
+	}
+	return atomic.WriteFile(dest, config.Bytes(), 0644)
+}
+
+var healthCheckPolicy = backoff.Policy{
+	Base:       100 * time.Millisecond,
+	Max:        time.Second,
+	Multiplier: 2,
+}
+
+// waitForReady polls the given url until it responds with 200 OK, the
+// policy's max attempts are reached, or ctx is canceled.
+func (e *Environment) waitForReady(ctx context.Context, client *http.Client, url string, policy backoff.Policy) error {
+	return backoff.Retry(ctx, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return backoff.Permanent(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status: %s", resp.Status)
+		}
+		return nil
+	}, policy)
+}
+
+func (e *Environment) gatewayHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: e.GatewayTLSConfig(),
+		},
+	}
+}
+
+// CortexHTTPClient returns a client which can connect directly to cortex's
+// HTTP server at CortexHTTPAddress, using the same client certificate as the
+// gateway.
+func (e *Environment) CortexHTTPClient() *http.Client {
+	if !e.enableCortex {
+		e.Logger.Panic("cortex disabled")
+	}
+	cert, err := tls.LoadX509KeyPair(
+		path.Join(e.tempDir, "cortex/client.crt"),
+		path.Join(e.tempDir, "cortex/client.key"),
+	)
+	if err != nil {
+		panic(err)
+	}
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(TestData("cortex/root.crt"))
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{cert},
+				RootCAs:      pool,
+				ServerName:   "localhost",
+			},
+		},
+	}
 }
 
 func (e *Environment) newGatewayConfig() *v1beta1.GatewayConfig {
@@ -584,22 +881,22 @@ func (e *Environment) newGatewayConfig() *v1beta1.GatewayConfig {
 			},
 			Cortex: v1beta1.CortexSpec{
 				Distributor: v1beta1.DistributorSpec{
-					HTTPAddress: fmt.Sprintf("localhost:%d", e.ports.CortexHTTP),
-					GRPCAddress: fmt.Sprintf("localhost:%d", e.ports.CortexGRPC),
+					HTTPAddress: e.cortexHTTPAddress(),
+					GRPCAddress: e.cortexGRPCAddress(),
 				},
 				Ingester: v1beta1.IngesterSpec{
-					HTTPAddress: fmt.Sprintf("localhost:%d", e.ports.CortexHTTP),
-					GRPCAddress: fmt.Sprintf("localhost:%d", e.ports.CortexGRPC),
+					HTTPAddress: e.cortexHTTPAddress(),
+					GRPCAddress: e.cortexGRPCAddress(),
 				},
 				Alertmanager: v1beta1.AlertmanagerSpec{
-					HTTPAddress: fmt.Sprintf("localhost:%d", e.ports.CortexHTTP),
+					HTTPAddress: e.cortexHTTPAddress(),
 				},
 				Ruler: v1beta1.RulerSpec{
-					HTTPAddress: fmt.Sprintf("localhost:%d", e.ports.CortexHTTP),
+					HTTPAddress: e.cortexHTTPAddress(),
 				},
 				QueryFrontend: v1beta1.QueryFrontendSpec{
-					HTTPAddress: fmt.Sprintf("localhost:%d", e.ports.CortexHTTP),
-					GRPCAddress: fmt.Sprintf("localhost:%d", e.ports.CortexGRPC),
+					HTTPAddress: e.cortexHTTPAddress(),
+					GRPCAddress: e.cortexGRPCAddress(),
 				},
 				Certs: v1beta1.MTLSSpec{
 					ServerCA:   path.Join(e.tempDir, "cortex/root.crt"),
@@ -607,11 +904,12 @@ func (e *Environment) newGatewayConfig() *v1beta1.GatewayConfig {
 					ClientCert: path.Join(e.tempDir, "cortex/client.crt"),
 					ClientKey:  path.Join(e.tempDir, "cortex/client.key"),
 				},
+				RuntimeConfigFile: path.Join(e.tempDir, "cortex/runtime_config.yaml"),
 			},
 			Storage: v1beta1.StorageSpec{
 				Type: v1beta1.StorageTypeEtcd,
 				Etcd: &v1beta1.EtcdStorageSpec{
-					Endpoints: []string{fmt.Sprintf("http://localhost:%d", e.ports.Etcd)},
+					Endpoints: e.etcdEndpoints(),
 				},
 			},
 		},
@@ -622,10 +920,14 @@ func (e *Environment) NewManagementClient() management.ManagementClient {
 	if !e.enableGateway {
 		e.Logger.Panic("gateway disabled")
 	}
-	c, err := management.NewClient(e.ctx,
+	opts := []management.ManagementClientOption{
 		management.WithListenAddress(fmt.Sprintf("127.0.0.1:%d", e.ports.ManagementGRPC)),
 		management.WithDialOptions(grpc.WithDefaultCallOptions(grpc.WaitForReady(true))),
-	)
+	}
+	if e.managementTLS {
+		opts = append(opts, management.WithTLSConfig(e.GatewayTLSConfig()))
+	}
+	c, err := management.NewClient(e.ctx, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -639,14 +941,26 @@ func (e *Environment) PrometheusAPIEndpoint() string {
 	return fmt.Sprintf("https://localhost:%d/prometheus/api/v1", e.ports.Gateway)
 }
 
-func (e *Environment) startGateway() {
+func (e *Environment) startGateway() error {
 	if !e.enableGateway {
 		e.Logger.Panic("gateway disabled")
 	}
 	lg := e.Logger
 	e.gatewayConfig = e.newGatewayConfig()
+	if e.gatewayConfigOverride != nil {
+		e.gatewayConfigOverride(e.gatewayConfig)
+	}
+	if err := e.gatewayConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid gateway config: %w", err)
+	}
 	pluginLoader := plugins.NewPluginLoader()
-	LoadPlugins(pluginLoader)
+	if e.storageBackend == StorageBackendInMemory {
+		// the cortex plugin connects to the storage backend configured in
+		// the gateway config, so it can't share the in-memory store
+		LoadPlugins(pluginLoader, "github.com/rancher/opni-monitoring/plugins/example")
+	} else {
+		LoadPlugins(pluginLoader)
+	}
 	mgmtExtensionPlugins := plugins.DispenseAllAs[apiextensions.ManagementAPIExtensionClient](
 		pluginLoader, managementext.ManagementAPIExtensionPluginID)
 	gatewayExtensionPlugins := plugins.DispenseAllAs[apiextensions.GatewayAPIExtensionClient](
@@ -669,7 +983,7 @@ func (e *Environment) startGateway() {
 			Type: "test",
 		},
 	}})
-	g := gateway.NewGateway(e.ctx, e.gatewayConfig,
+	gatewayOpts := []gateway.GatewayOption{
 		gateway.WithSystemPlugins(systemPlugins),
 		gateway.WithLifecycler(lifecycler),
 		gateway.WithCapabilityBackendPlugins(capBackendPlugins),
@@ -678,12 +992,28 @@ func (e *Environment) startGateway() {
 			gateway.WithAuthMiddleware(e.gatewayConfig.Spec.AuthProvider),
 			gateway.WithMetricsPlugins(metricsPlugins),
 		),
-	)
+	}
+	if e.storageBackend == StorageBackendInMemory {
+		gatewayOpts = append(gatewayOpts,
+			gateway.WithStorageBackend(NewTestStorageBackend(e.ctx, e.mockCtrl)))
+	}
+	g := gateway.NewGateway(e.ctx, e.gatewayConfig, gatewayOpts...)
+	e.gw = g
+	labelTemplates, err := labels.ParseTemplates(e.gatewayConfig.Spec.LabelTemplates)
+	if err != nil {
+		return fmt.Errorf("failed to parse label templates: %w", err)
+	}
 	m := management.NewServer(e.ctx, &e.gatewayConfig.Spec.Management, g,
 		management.WithCapabilitiesDataSource(g),
+		management.WithDrainDataSource(g),
 		management.WithSystemPlugins(systemPlugins),
 		management.WithLifecycler(lifecycler),
 		management.WithAPIExtensions(mgmtExtensionPlugins),
+		management.WithServingTLS(e.managementTLS),
+		management.WithHTTPAuthMiddleware(e.gatewayConfig.Spec.RouteAuthProviders.ManagementAPI),
+		management.WithLabelTemplates(labelTemplates),
+		management.WithAuditLog(g.AuditLog()),
+		management.WithDiagnosticsStore(g.DiagnosticsStore()),
 	)
 	go func() {
 		if err := g.ListenAndServe(); err != nil {
@@ -696,30 +1026,23 @@ func (e *Environment) startGateway() {
 		}
 	}()
 	lg.Info("Waiting for gateway to start...")
-	for i := 0; i < 10; i++ {
-		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("https://%s/healthz",
-			e.gatewayConfig.Spec.ListenAddress), nil)
-		client := http.Client{
-			Transport: &http.Transport{
-				TLSClientConfig: e.GatewayTLSConfig(),
-			},
-		}
-		resp, err := client.Do(req)
-		if err == nil {
-			defer resp.Body.Close()
-			if resp.StatusCode == http.StatusOK {
-				break
-			}
-		}
+	gatewayPolicy := healthCheckPolicy
+	gatewayPolicy.MaxAttempts = 10
+	if err := e.waitForReady(e.ctx, e.gatewayHTTPClient(),
+		fmt.Sprintf("https://%s/healthz", e.gatewayConfig.Spec.ListenAddress), gatewayPolicy); err != nil {
+		lg.With(zap.Error(err)).Warn("gateway did not become ready")
 	}
 	lg.Info("Gateway started")
 	waitctx.Go(e.ctx, func() {
 		<-e.ctx.Done()
 	})
+	return nil
 }
 
 type StartAgentOptions struct {
-	ctx context.Context
+	ctx              context.Context
+	profiling        *v1beta1.ProfilingSpec
+	waitForConnected time.Duration
 }
 
 type StartAgentOption func(*StartAgentOptions)
@@ -730,12 +1053,31 @@ func (o *StartAgentOptions) Apply(opts ...StartAgentOption) {
 	}
 }
 
+// WithContext sets a context which stops the agent when it is done. The
+// agent is always stopped when the environment is stopped.
 func WithContext(ctx context.Context) StartAgentOption {
 	return func(o *StartAgentOptions) {
 		o.ctx = ctx
 	}
 }
 
+func WithAgentProfiling(spec *v1beta1.ProfilingSpec) StartAgentOption {
+	return func(o *StartAgentOptions) {
+		o.profiling = spec
+	}
+}
+
+// WithWaitForConnected makes StartAgent block until the agent has
+// bootstrapped with the gateway and is serving requests, or until it fails
+// to start. If the agent fails, or does not become ready within the timeout,
+// the error can be received from the returned channel as soon as StartAgent
+// returns. By default, StartAgent returns without waiting for the agent.
+func WithWaitForConnected(timeout time.Duration) StartAgentOption {
+	return func(o *StartAgentOptions) {
+		o.waitForConnected = timeout
+	}
+}
+
 func (e *Environment) StartAgent(id string, token *core.BootstrapToken, pins []string, opts ...StartAgentOption) (int, <-chan error) {
 	if !e.enableGateway {
 		e.Logger.Panic("gateway disabled")
@@ -748,28 +1090,33 @@ func (e *Environment) StartAgent(id string, token *core.BootstrapToken, pins []s
 	errC := make(chan error, 1)
 	port, err := freeport.GetFreePort()
 	if err != nil {
-		panic(err)
+		errC <- err
+		return 0, errC
 	}
 
-	if err := ident.RegisterProvider(id, func() ident.Provider {
+	// Each agent gets its own ident provider, so that agents can be
+	// restarted with the same id, and providers registered by other
+	// environments are never reused.
+	identProviderName := fmt.Sprintf("test-%s-%s", id, uuid.NewString())
+	if err := ident.RegisterProvider(identProviderName, func() ident.Provider {
 		return NewTestIdentProvider(e.mockCtrl, id)
 	}); err != nil {
-		if !errors.Is(err, ident.ErrProviderAlreadyExists) {
-			panic(err)
-		}
+		errC <- err
+		return 0, errC
 	}
 
 	agentConfig := &v1beta1.AgentConfig{
 		Spec: v1beta1.AgentConfigSpec{
 			ListenAddress:    fmt.Sprintf("localhost:%d", port),
-			GatewayAddress:   fmt.Sprintf("https://localhost:%d", e.ports.Gateway),
-			IdentityProvider: id,
+			GatewayAddress:   e.GatewayAddress(),
+			IdentityProvider: identProviderName,
 			Storage: v1beta1.StorageSpec{
 				Type: v1beta1.StorageTypeEtcd,
 				Etcd: &v1beta1.EtcdStorageSpec{
-					Endpoints: []string{fmt.Sprintf("http://localhost:%d", e.ports.Etcd)},
+					Endpoints: e.etcdEndpoints(),
 				},
 			},
+			Profiling: options.profiling,
 		},
 	}
 
@@ -789,9 +1136,32 @@ func (e *Environment) StartAgent(id string, token *core.BootstrapToken, pins []s
 	}
 	var a *agent.Agent
 	mu := &sync.Mutex{}
+	agentCtx, agentCancel := context.WithCancel(e.ctx)
+	stopped := make(chan struct{})
+	var stopOnce sync.Once
+	var stopErr error
+	stop := func() error {
+		stopOnce.Do(func() {
+			defer close(stopped)
+			defer agentCancel()
+			mu.Lock()
+			defer mu.Unlock()
+			if a == nil {
+				return
+			}
+			stopErr = a.Shutdown()
+			e.runningAgentsMu.Lock()
+			// the agent may have been replaced by a new agent with the same id
+			if e.runningAgents[id].Agent == a {
+				delete(e.runningAgents, id)
+			}
+			e.runningAgentsMu.Unlock()
+		})
+		return stopErr
+	}
 	go func() {
 		mu.Lock()
-		a, err = agent.New(e.ctx, agentConfig,
+		a, err = agent.New(agentCtx, agentConfig,
 			agent.WithBootstrapper(&bootstrap.ClientConfig{
 				Capability: wellknown.CapabilityMetrics,
 				Token:      bt,
@@ -807,6 +1177,7 @@ func (e *Environment) StartAgent(id string, token *core.BootstrapToken, pins []s
 		e.runningAgents[id] = RunningAgent{
 			Agent: a,
 			Mutex: mu,
+			stop:  stop,
 		}
 		e.runningAgentsMu.Unlock()
 		mu.Unlock()
@@ -815,28 +1186,160 @@ func (e *Environment) StartAgent(id string, token *core.BootstrapToken, pins []s
 		}
 	}()
 	waitctx.Go(e.ctx, func() {
-		<-e.ctx.Done()
-		mu.Lock()
-		defer mu.Unlock()
-		if a == nil {
+		select {
+		case <-e.ctx.Done():
+		case <-options.ctx.Done():
+		case <-stopped:
+			// stopped by StopAgent
 			return
 		}
-		if err := a.Shutdown(); err != nil {
+		if err := stop(); err != nil {
 			errC <- err
 		}
-		e.runningAgentsMu.Lock()
-		delete(e.runningAgents, id)
-		e.runningAgentsMu.Unlock()
 	})
+	if options.waitForConnected > 0 {
+		e.waitForStartedAgentConnected(id, errC, options.waitForConnected)
+	}
 	return port, errC
 }
 
+// WaitForAgentConnected waits until the agent with the given id is serving
+// requests, and its cluster has been registered with the gateway, as
+// reported by the management API. An error is returned if this does not
+// happen within the timeout.
+func (e *Environment) WaitForAgentConnected(id string, timeout time.Duration) error {
+	ctx, ca := context.WithTimeout(e.ctx, timeout)
+	defer ca()
+	return e.waitForAgentConnected(ctx, id, timeout)
+}
+
+func (e *Environment) waitForAgentConnected(ctx context.Context, id string, timeout time.Duration) error {
+	client := e.managementClient()
+	err := backoff.Retry(ctx, func(ctx context.Context) error {
+		ra := e.GetAgent(id)
+		if ra.Agent == nil {
+			return fmt.Errorf("agent %q is not running", id)
+		}
+		if err := e.waitForReady(ctx, http.DefaultClient,
+			fmt.Sprintf("http://%s/healthz", ra.ListenAddress),
+			backoff.Policy{MaxAttempts: 1}); err != nil {
+			return err
+		}
+		if _, err := client.GetCluster(ctx, &core.Reference{Id: id}); err != nil {
+			return fmt.Errorf("cluster %q is not registered: %w", id, err)
+		}
+		return nil
+	}, healthCheckPolicy)
+	if err != nil {
+		return fmt.Errorf("agent %q did not connect within %s: %w", id, timeout, err)
+	}
+	return nil
+}
+
+// waitForStartedAgentConnected waits until the agent with the given id is
+// connected, or an error is received from errC. Errors are sent back on errC.
+func (e *Environment) waitForStartedAgentConnected(id string, errC chan error, timeout time.Duration) {
+	ctx, ca := context.WithTimeout(e.ctx, timeout)
+	defer ca()
+	readyC := make(chan error, 1)
+	go func() {
+		readyC <- e.waitForAgentConnected(ctx, id, timeout)
+	}()
+	select {
+	case err := <-errC:
+		errC <- err
+	case err := <-readyC:
+		if err != nil {
+			select {
+			case errC <- err:
+			default:
+			}
+		}
+	}
+}
+
+// managementClient returns a management client shared by the environment's
+// helpers.
+func (e *Environment) managementClient() management.ManagementClient {
+	e.managementClientOnce.Do(func() {
+		e.sharedManagementClient = e.NewManagementClient()
+	})
+	return e.sharedManagementClient
+}
+
 func (e *Environment) GetAgent(id string) RunningAgent {
 	e.runningAgentsMu.Lock()
 	defer e.runningAgentsMu.Unlock()
 	return e.runningAgents[id]
 }
 
+// StopAgent shuts down the running agent with the given id and removes it
+// from the environment. The gateway and any other agents are left running.
+func (e *Environment) StopAgent(id string) error {
+	ra := e.GetAgent(id)
+	if ra.Agent == nil {
+		return fmt.Errorf("agent %q is not running", id)
+	}
+	return ra.stop()
+}
+
+// MigrateAgent moves a running agent from this environment's gateway to the
+// gateway in another environment. The agent bootstraps with the target
+// gateway using the given token and pins, then sends all further requests
+// to it. The agent remains owned by this environment, and is still stopped
+// along with it.
+func (e *Environment) MigrateAgent(id string, to *Environment, token *core.BootstrapToken, pins []string) error {
+	if !to.enableGateway {
+		return errors.New("target environment has no gateway")
+	}
+	ra := e.GetAgent(id)
+	if ra.Agent == nil {
+		return fmt.Errorf("agent %q is not running", id)
+	}
+	publicKeyPins := []*pkp.PublicKeyPin{}
+	for _, pin := range pins {
+		d, err := pkp.DecodePin(pin)
+		if err != nil {
+			return err
+		}
+		publicKeyPins = append(publicKeyPins, d)
+	}
+	bt, err := tokens.FromBootstrapToken(token)
+	if err != nil {
+		return err
+	}
+	ra.Lock()
+	defer ra.Unlock()
+	return ra.SetGatewayAddress(e.ctx, to.GatewayAddress(), &bootstrap.ClientConfig{
+		Capability: wellknown.CapabilityMetrics,
+		Token:      bt,
+		Pins:       publicKeyPins,
+		Endpoint:   fmt.Sprintf("http://localhost:%d", to.ports.Gateway),
+	})
+}
+
+// GatewayAddress returns the address agents use to connect to the gateway.
+func (e *Environment) GatewayAddress() string {
+	return fmt.Sprintf("https://localhost:%d", e.ports.Gateway)
+}
+
+// RotateGatewayCert replaces the serving certificate of the running gateway
+// with the given PEM encoded certificate and key, without restarting it.
+// Agents which pinned a public key from the previous certificate chain will
+// no longer be able to connect. GatewayTLSConfig still trusts only the
+// environment's CA, so the new certificate must be signed by it for
+// clients using GatewayTLSConfig to connect.
+func (e *Environment) RotateGatewayCert(certPEM, keyPEM []byte) error {
+	if !e.enableGateway {
+		e.Logger.Panic("gateway disabled")
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return err
+	}
+	return e.gw.RotateServingCertificate(cert)
+}
+
 func (e *Environment) GatewayTLSConfig() *tls.Config {
 	pool := x509.NewCertPool()
 	pool.AppendCertsFromPEM([]byte(*e.gatewayConfig.Spec.Certs.CACertData))
@@ -855,7 +1358,7 @@ func (e *Environment) EtcdClient() (*clientv3.Client, error) {
 		e.Logger.Panic("etcd disabled")
 	}
 	return clientv3.New(clientv3.Config{
-		Endpoints: []string{fmt.Sprintf("http://localhost:%d", e.ports.Etcd)},
+		Endpoints: e.etcdEndpoints(),
 		Context:   e.ctx,
 		Logger:    e.Logger.Desugar(),
 	})
@@ -866,11 +1369,59 @@ func (e *Environment) EtcdConfig() *v1beta1.EtcdStorageSpec {
 		e.Logger.Panic("etcd disabled")
 	}
 	return &v1beta1.EtcdStorageSpec{
-		Endpoints: []string{fmt.Sprintf("http://localhost:%d", e.ports.Etcd)},
+		Endpoints: e.etcdEndpoints(),
 	}
 }
 
-func StartStandaloneTestEnvironment() {
+type StandaloneEnvironmentOptions struct {
+	idGenerator         IDGenerator
+	maxConcurrentStarts int
+	maxQueuedStarts     int
+}
+
+type StandaloneEnvironmentOption func(*StandaloneEnvironmentOptions)
+
+func (o *StandaloneEnvironmentOptions) Apply(opts ...StandaloneEnvironmentOption) {
+	for _, op := range opts {
+		op(o)
+	}
+}
+
+// WithIDGenerator sets the generator used to assign cluster IDs to agents
+// started through the test environment API. Use NewSeededIDGenerator to
+// produce the same cluster IDs each time the environment is started.
+func WithIDGenerator(gen IDGenerator) StandaloneEnvironmentOption {
+	return func(o *StandaloneEnvironmentOptions) {
+		o.idGenerator = gen
+	}
+}
+
+// WithMaxConcurrentStarts sets the maximum number of agents which can be
+// starting at the same time. Defaults to 4.
+func WithMaxConcurrentStarts(n int) StandaloneEnvironmentOption {
+	return func(o *StandaloneEnvironmentOptions) {
+		o.maxConcurrentStarts = n
+	}
+}
+
+// WithMaxQueuedStarts sets the maximum number of agent start requests which
+// can wait for another agent to finish starting. Requests beyond this limit
+// are rejected with 429 Too Many Requests. Defaults to 32.
+func WithMaxQueuedStarts(n int) StandaloneEnvironmentOption {
+	return func(o *StandaloneEnvironmentOptions) {
+		o.maxQueuedStarts = n
+	}
+}
+
+func StartStandaloneTestEnvironment(opts ...StandaloneEnvironmentOption) {
+	options := StandaloneEnvironmentOptions{
+		idGenerator:         RandomIDGenerator,
+		maxConcurrentStarts: 4,
+		maxQueuedStarts:     32,
+	}
+	options.Apply(opts...)
+	startPool := NewStartPool(options.maxConcurrentStarts, options.maxQueuedStarts)
+
 	environment := &Environment{
 		TestBin: "testbin/bin",
 	}
@@ -893,15 +1444,29 @@ func StartStandaloneTestEnvironment() {
 				rw.Write([]byte(err.Error()))
 				return
 			}
-			port, errC := environment.StartAgent(uuid.New().String(), token.ToBootstrapToken(), body.Pins)
-			select {
-			case err := <-errC:
+			var port int
+			err = startPool.Do(r.Context(), func() error {
+				var errC <-chan error
+				port, errC = environment.StartAgent(options.idGenerator(), token.ToBootstrapToken(), body.Pins,
+					WithWaitForConnected(30*time.Second))
+				select {
+				case err := <-errC:
+					return err
+				default:
+				}
+				_, err := environment.StartPrometheus(port)
+				return err
+			})
+			switch {
+			case errors.Is(err, ErrStartQueueFull):
+				rw.WriteHeader(http.StatusTooManyRequests)
+				rw.Write([]byte(err.Error()))
+				return
+			case err != nil:
 				rw.WriteHeader(http.StatusInternalServerError)
 				rw.Write([]byte(err.Error()))
 				return
-			case <-time.After(time.Second):
 			}
-			environment.StartPrometheus(port)
 			rw.WriteHeader(http.StatusOK)
 			rw.Write([]byte(fmt.Sprintf("%d", port)))
 		}
@@ -0,0 +1,122 @@
+package test_test
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/management"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Environment", Ordered, Label(test.Unit, test.Slow), func() {
+	When("only the management server is enabled", func() {
+		var environment *test.Environment
+		var client management.ManagementClient
+		BeforeAll(func() {
+			environment = &test.Environment{}
+			Expect(environment.Start(test.WithEnableManagementOnly())).To(Succeed())
+			DeferCleanup(environment.Stop)
+			client = environment.NewManagementClient()
+		})
+		It("should serve the management API using an in-memory store", func() {
+			ctx, ca := context.WithTimeout(context.Background(), 10*time.Second)
+			defer ca()
+			clusters, err := client.ListClusters(ctx, &management.ListClustersRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusters.Items).To(BeEmpty())
+
+			token, err := client.CreateBootstrapToken(ctx, &management.CreateBootstrapTokenRequest{
+				Ttl: durationpb.New(time.Hour),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			t, err := client.GetBootstrapToken(ctx, &core.Reference{Id: token.TokenID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.TokenID).To(Equal(token.TokenID))
+		})
+		It("should panic if a disabled dependency is accessed", func() {
+			Expect(func() { environment.EtcdConfig() }).To(Panic())
+			Expect(func() { environment.CortexHTTPAddress() }).To(Panic())
+		})
+	})
+	When("the gateway config is overridden", func() {
+		monitorStatus := func(environment *test.Environment) int {
+			client := &http.Client{
+				Transport: &http.Transport{
+					TLSClientConfig: environment.GatewayTLSConfig(),
+				},
+			}
+			resp, err := client.Get(environment.GatewayAddress() + "/monitor")
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+			return resp.StatusCode
+		}
+		It("should enable the monitor by default", func() {
+			environment := &test.Environment{}
+			Expect(environment.Start(test.WithEnableManagementOnly())).To(Succeed())
+			DeferCleanup(environment.Stop)
+			Expect(monitorStatus(environment)).To(Equal(http.StatusOK))
+		})
+		It("should apply the override before starting the gateway", func() {
+			environment := &test.Environment{}
+			Expect(environment.Start(
+				test.WithEnableManagementOnly(),
+				test.WithGatewayConfigOverride(func(gc *v1beta1.GatewayConfig) {
+					gc.Spec.EnableMonitor = false
+				}),
+			)).To(Succeed())
+			DeferCleanup(environment.Stop)
+			Expect(monitorStatus(environment)).To(Equal(http.StatusNotFound))
+
+			// fields not changed by the override are kept
+			client := environment.NewManagementClient()
+			ctx, ca := context.WithTimeout(context.Background(), 10*time.Second)
+			defer ca()
+			_, err := client.ListClusters(ctx, &management.ListClustersRequest{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+	When("a component fails to start", func() {
+		It("should return an error from StartPrometheus", func() {
+			environment := &test.Environment{}
+			Expect(environment.Start(test.WithEnableManagementOnly())).To(Succeed())
+			DeferCleanup(environment.Stop)
+
+			DeferCleanup(test.SetConfigTemplateData(func(name string) []byte {
+				return []byte("{{ .Invalid")
+			}))
+			_, err := environment.StartPrometheus(12345)
+			Expect(err).To(MatchError(ContainSubstring("prometheus/config.yaml")))
+
+			// the environment should still be usable
+			ctx, ca := context.WithTimeout(context.Background(), 10*time.Second)
+			defer ca()
+			_, err = environment.NewManagementClient().ListClusters(ctx, &management.ListClustersRequest{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("should return an error from Start if the gateway config is invalid", func() {
+			environment := &test.Environment{}
+			DeferCleanup(environment.Stop)
+			err := environment.Start(
+				test.WithEnableManagementOnly(),
+				test.WithGatewayConfigOverride(func(gc *v1beta1.GatewayConfig) {
+					gc.Spec.BootstrapClientIDPattern = "[a-z"
+				}),
+			)
+			Expect(err).To(MatchError(ContainSubstring("invalid gateway config")))
+		})
+	})
+	When("the etcd storage backend is used without etcd", func() {
+		It("should fail to start", func() {
+			environment := &test.Environment{}
+			Expect(environment.Start(test.WithEnableEtcd(false))).To(HaveOccurred())
+			Expect(environment.Stop()).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,14 @@
+package test
+
+var (
+	FindChildProcess = findChildProcess
+	FindChildPIDPs   = findChildPIDPs
+)
+
+func SetConfigTemplateData(fn func(string) []byte) (restore func()) {
+	prev := configTemplateData
+	configTemplateData = fn
+	return func() {
+		configTemplateData = prev
+	}
+}
@@ -0,0 +1,34 @@
+package test
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// IDGenerator returns a new unique ID each time it is called.
+type IDGenerator func() string
+
+// RandomIDGenerator generates random UUIDs.
+func RandomIDGenerator() string {
+	return uuid.New().String()
+}
+
+// NewSeededIDGenerator returns an IDGenerator which produces the same
+// sequence of UUIDs for a given seed. The generator is safe for concurrent
+// use, although the order in which concurrent callers receive IDs is not
+// deterministic.
+func NewSeededIDGenerator(seed int64) IDGenerator {
+	var mu sync.Mutex
+	rng := rand.New(rand.NewSource(seed)) //#nosec G404
+	return func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		id, err := uuid.NewRandomFromReader(rng)
+		if err != nil {
+			panic(err)
+		}
+		return id.String()
+	}
+}
@@ -0,0 +1,39 @@
+package test_test
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("ID Generators", Label(test.Unit), func() {
+	generate := func(gen test.IDGenerator, n int) []string {
+		ids := make([]string, n)
+		for i := range ids {
+			ids[i] = gen()
+		}
+		return ids
+	}
+	It("should generate the same IDs for the same seed", func() {
+		run1 := generate(test.NewSeededIDGenerator(1234), 10)
+		run2 := generate(test.NewSeededIDGenerator(1234), 10)
+		Expect(run1).To(Equal(run2))
+	})
+	It("should generate different IDs for different seeds", func() {
+		run1 := generate(test.NewSeededIDGenerator(1234), 10)
+		run2 := generate(test.NewSeededIDGenerator(5678), 10)
+		Expect(run1).NotTo(Equal(run2))
+	})
+	It("should generate unique, valid UUIDs", func() {
+		ids := generate(test.NewSeededIDGenerator(1234), 100)
+		unique := map[string]struct{}{}
+		for _, id := range ids {
+			_, err := uuid.Parse(id)
+			Expect(err).NotTo(HaveOccurred())
+			unique[id] = struct{}{}
+		}
+		Expect(unique).To(HaveLen(len(ids)))
+	})
+})
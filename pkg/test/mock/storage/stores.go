@@ -336,6 +336,21 @@ func (mr *MockBackendMockRecorder) UpdateToken(ctx, ref, mutator interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateToken", reflect.TypeOf((*MockBackend)(nil).UpdateToken), ctx, ref, mutator)
 }
 
+// WatchClusters mocks base method.
+func (m *MockBackend) WatchClusters(ctx context.Context, knownClusters []*core.Cluster) (<-chan storage.ClusterWatchEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchClusters", ctx, knownClusters)
+	ret0, _ := ret[0].(<-chan storage.ClusterWatchEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchClusters indicates an expected call of WatchClusters.
+func (mr *MockBackendMockRecorder) WatchClusters(ctx, knownClusters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchClusters", reflect.TypeOf((*MockBackend)(nil).WatchClusters), ctx, knownClusters)
+}
+
 // MockTokenStore is a mock of TokenStore interface.
 type MockTokenStore struct {
 	ctrl     *gomock.Controller
@@ -534,6 +549,21 @@ func (mr *MockClusterStoreMockRecorder) UpdateCluster(ctx, ref, mutator interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCluster", reflect.TypeOf((*MockClusterStore)(nil).UpdateCluster), ctx, ref, mutator)
 }
 
+// WatchClusters mocks base method.
+func (m *MockClusterStore) WatchClusters(ctx context.Context, knownClusters []*core.Cluster) (<-chan storage.ClusterWatchEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchClusters", ctx, knownClusters)
+	ret0, _ := ret[0].(<-chan storage.ClusterWatchEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchClusters indicates an expected call of WatchClusters.
+func (mr *MockClusterStoreMockRecorder) WatchClusters(ctx, knownClusters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchClusters", reflect.TypeOf((*MockClusterStore)(nil).WatchClusters), ctx, knownClusters)
+}
+
 // MockRBACStore is a mock of RBACStore interface.
 type MockRBACStore struct {
 	ctrl     *gomock.Controller
@@ -696,6 +726,34 @@ func (m *MockKeyringStore) EXPECT() *MockKeyringStoreMockRecorder {
 	return m.recorder
 }
 
+// Append mocks base method.
+func (m *MockKeyringStore) Append(ctx context.Context, keyring keyring.Keyring) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", ctx, keyring)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockKeyringStoreMockRecorder) Append(ctx, keyring interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockKeyringStore)(nil).Append), ctx, keyring)
+}
+
+// Delete mocks base method.
+func (m *MockKeyringStore) Delete(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockKeyringStoreMockRecorder) Delete(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockKeyringStore)(nil).Delete), ctx)
+}
+
 // Get mocks base method.
 func (m *MockKeyringStore) Get(ctx context.Context) (keyring.Keyring, error) {
 	m.ctrl.T.Helper()
@@ -711,6 +769,35 @@ func (mr *MockKeyringStoreMockRecorder) Get(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockKeyringStore)(nil).Get), ctx)
 }
 
+// List mocks base method.
+func (m *MockKeyringStore) List(ctx context.Context) ([]keyring.Keyring, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]keyring.Keyring)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockKeyringStoreMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockKeyringStore)(nil).List), ctx)
+}
+
+// Prune mocks base method.
+func (m *MockKeyringStore) Prune(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prune", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Prune indicates an expected call of Prune.
+func (mr *MockKeyringStoreMockRecorder) Prune(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prune", reflect.TypeOf((*MockKeyringStore)(nil).Prune), ctx)
+}
+
 // Put mocks base method.
 func (m *MockKeyringStore) Put(ctx context.Context, keyring keyring.Keyring) error {
 	m.ctrl.T.Helper()
@@ -725,6 +812,20 @@ func (mr *MockKeyringStoreMockRecorder) Put(ctx, keyring interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockKeyringStore)(nil).Put), ctx, keyring)
 }
 
+// Rotate mocks base method.
+func (m *MockKeyringStore) Rotate(ctx context.Context, keyring keyring.Keyring) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", ctx, keyring)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockKeyringStoreMockRecorder) Rotate(ctx, keyring interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockKeyringStore)(nil).Rotate), ctx, keyring)
+}
+
 // MockKeyValueStore is a mock of KeyValueStore interface.
 type MockKeyValueStore struct {
 	ctrl     *gomock.Controller
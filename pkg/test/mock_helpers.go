@@ -3,6 +3,7 @@ package test
 import (
 	"context"
 	"errors"
+	"strings"
 	"sync"
 	"time"
 
@@ -68,9 +69,11 @@ func NewTestCapabilityBackendClient(
 	client := mock_capability.NewMockBackendClient(ctrl)
 	client.EXPECT().
 		Info(gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(&capability.InfoResponse{
-			CapabilityName: capBackend.Name,
-		}, nil).
+		DoAndReturn(func(context.Context, *emptypb.Empty, ...grpc.CallOption) (*capability.InfoResponse, error) {
+			return &capability.InfoResponse{
+				CapabilityName: capBackend.Name,
+			}, nil
+		}).
 		AnyTimes()
 	client.EXPECT().
 		CanInstall(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -90,9 +93,11 @@ func NewTestCapabilityBackendClient(
 		AnyTimes()
 	client.EXPECT().
 		InstallerTemplate(gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(&capability.InstallerTemplateResponse{
-			Template: capBackend.InstallerTemplate,
-		}, nil).
+		DoAndReturn(func(context.Context, *emptypb.Empty, ...grpc.CallOption) (*capability.InstallerTemplateResponse, error) {
+			return &capability.InstallerTemplateResponse{
+				Template: capBackend.InstallerTemplate,
+			}, nil
+		}).
 		AnyTimes()
 	return client
 }
@@ -106,13 +111,24 @@ func NewTestClusterStore(ctrl *gomock.Controller) storage.ClusterStore {
 
 	clusters := map[string]*core.Cluster{}
 	mu := sync.Mutex{}
+	// closed and replaced each time the clusters are modified, to wake up
+	// any watchers
+	changed := make(chan struct{})
+	notify := func() {
+		close(changed)
+		changed = make(chan struct{})
+	}
 
 	mockClusterStore.EXPECT().
 		CreateCluster(gomock.Any(), gomock.Any()).
 		DoAndReturn(func(_ context.Context, cluster *core.Cluster) error {
 			mu.Lock()
 			defer mu.Unlock()
+			if _, ok := clusters[cluster.Id]; ok {
+				return storage.ErrAlreadyExists
+			}
 			clusters[cluster.Id] = cluster
+			notify()
 			return nil
 		}).
 		AnyTimes()
@@ -125,6 +141,7 @@ func NewTestClusterStore(ctrl *gomock.Controller) storage.ClusterStore {
 				return storage.ErrNotFound
 			}
 			delete(clusters, ref.Id)
+			notify()
 			return nil
 		}).
 		AnyTimes()
@@ -172,9 +189,42 @@ func NewTestClusterStore(ctrl *gomock.Controller) storage.ClusterStore {
 				return nil, storage.ErrNotFound
 			}
 			clusters[ref.Id] = cloned
+			notify()
 			return cloned, nil
 		}).
 		AnyTimes()
+	mockClusterStore.EXPECT().
+		WatchClusters(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, knownClusters []*core.Cluster) (<-chan storage.ClusterWatchEvent, error) {
+			state := storage.NewClusterWatchState(knownClusters)
+			eventC := make(chan storage.ClusterWatchEvent, 64)
+			go func() {
+				defer close(eventC)
+				for {
+					mu.Lock()
+					current := make([]*core.Cluster, 0, len(clusters))
+					for _, cluster := range clusters {
+						current = append(current, cluster)
+					}
+					wait := changed
+					mu.Unlock()
+					for _, event := range state.Sync(current) {
+						select {
+						case eventC <- event:
+						case <-ctx.Done():
+							return
+						}
+					}
+					select {
+					case <-wait:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+			return eventC, nil
+		}).
+		AnyTimes()
 	return mockClusterStore
 }
 
@@ -214,22 +264,88 @@ func NewTestKeyringStoreBroker(ctrl *gomock.Controller, handler ...KeyringStoreH
 
 func NewTestKeyringStore(ctrl *gomock.Controller, prefix string, ref *core.Reference) storage.KeyringStore {
 	mockKeyringStore := mock_storage.NewMockKeyringStore(ctrl)
-	keyrings := map[string]keyring.Keyring{}
+	// the active keyring is stored first, followed by previous versions
+	// from newest to oldest
+	var keyrings []keyring.Keyring
+	mu := sync.Mutex{}
 	mockKeyringStore.EXPECT().
 		Put(gomock.Any(), gomock.Any()).
 		DoAndReturn(func(_ context.Context, keyring keyring.Keyring) error {
-			keyrings[prefix+ref.Id] = keyring
+			mu.Lock()
+			defer mu.Unlock()
+			if len(keyrings) == 0 {
+				keyrings = append(keyrings, keyring)
+			} else {
+				keyrings[0] = keyring
+			}
 			return nil
 		}).
 		AnyTimes()
 	mockKeyringStore.EXPECT().
 		Get(gomock.Any()).
 		DoAndReturn(func(_ context.Context) (keyring.Keyring, error) {
-			keyring, ok := keyrings[prefix+ref.Id]
-			if !ok {
+			mu.Lock()
+			defer mu.Unlock()
+			if len(keyrings) == 0 {
 				return nil, storage.ErrNotFound
 			}
-			return keyring, nil
+			return keyrings[0], nil
+		}).
+		AnyTimes()
+	mockKeyringStore.EXPECT().
+		Append(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, kr keyring.Keyring) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if len(keyrings) == 0 {
+				keyrings = append(keyrings, kr)
+			} else {
+				keyrings[0] = kr.Merge(keyrings[0])
+			}
+			return nil
+		}).
+		AnyTimes()
+	mockKeyringStore.EXPECT().
+		Rotate(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, kr keyring.Keyring) error {
+			mu.Lock()
+			defer mu.Unlock()
+			keyrings = append([]keyring.Keyring{kr}, keyrings...)
+			if len(keyrings) > storage.MaxPreviousKeyringVersions+1 {
+				keyrings = keyrings[:storage.MaxPreviousKeyringVersions+1]
+			}
+			return nil
+		}).
+		AnyTimes()
+	mockKeyringStore.EXPECT().
+		List(gomock.Any()).
+		DoAndReturn(func(_ context.Context) ([]keyring.Keyring, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if len(keyrings) == 0 {
+				return nil, storage.ErrNotFound
+			}
+			return append([]keyring.Keyring{}, keyrings...), nil
+		}).
+		AnyTimes()
+	mockKeyringStore.EXPECT().
+		Prune(gomock.Any()).
+		DoAndReturn(func(_ context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if len(keyrings) > 1 {
+				keyrings = keyrings[:1]
+			}
+			return nil
+		}).
+		AnyTimes()
+	mockKeyringStore.EXPECT().
+		Delete(gomock.Any()).
+		DoAndReturn(func(_ context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			keyrings = nil
+			return nil
 		}).
 		AnyTimes()
 	return mockKeyringStore
@@ -256,9 +372,12 @@ func NewTestKeyValueStoreBroker(ctrl *gomock.Controller) storage.KeyValueStoreBr
 func NewTestKeyValueStore(ctrl *gomock.Controller) storage.KeyValueStore {
 	mockKvStore := mock_storage.NewMockKeyValueStore(ctrl)
 	kvs := map[string][]byte{}
+	mu := sync.Mutex{}
 	mockKvStore.EXPECT().
 		Put(gomock.Any(), gomock.Any(), gomock.Any()).
 		DoAndReturn(func(_ context.Context, key string, value []byte) error {
+			mu.Lock()
+			defer mu.Unlock()
 			kvs[key] = value
 			return nil
 		}).
@@ -266,6 +385,8 @@ func NewTestKeyValueStore(ctrl *gomock.Controller) storage.KeyValueStore {
 	mockKvStore.EXPECT().
 		Get(gomock.Any(), gomock.Any()).
 		DoAndReturn(func(_ context.Context, key string) ([]byte, error) {
+			mu.Lock()
+			defer mu.Unlock()
 			v, ok := kvs[key]
 			if !ok {
 				return nil, storage.ErrNotFound
@@ -273,6 +394,32 @@ func NewTestKeyValueStore(ctrl *gomock.Controller) storage.KeyValueStore {
 			return v, nil
 		}).
 		AnyTimes()
+	mockKvStore.EXPECT().
+		Delete(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, key string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if _, ok := kvs[key]; !ok {
+				return storage.ErrNotFound
+			}
+			delete(kvs, key)
+			return nil
+		}).
+		AnyTimes()
+	mockKvStore.EXPECT().
+		ListKeys(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, prefix string) ([]string, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			keys := []string{}
+			for k := range kvs {
+				if strings.HasPrefix(k, prefix) {
+					keys = append(keys, k)
+				}
+			}
+			return keys, nil
+		}).
+		AnyTimes()
 	return mockKvStore
 }
 
@@ -409,12 +556,17 @@ func NewTestTokenStore(ctx context.Context, ctrl *gomock.Controller) storage.Tok
 			options.Apply(opts...)
 			t := tokens.NewToken().ToBootstrapToken()
 			lease := leaseStore.New(t.TokenID, ttl)
+			now := time.Now()
 			t.Metadata = &core.BootstrapTokenMetadata{
-				LeaseID:      int64(lease.ID),
-				Ttl:          int64(ttl),
-				UsageCount:   0,
-				Labels:       options.Labels,
-				Capabilities: options.Capabilities,
+				LeaseID:             int64(lease.ID),
+				Ttl:                 int64(ttl),
+				UsageCount:          0,
+				Labels:              options.Labels,
+				Capabilities:        options.Capabilities,
+				AllowedCapabilities: options.AllowedCapabilities,
+				MaxUsages:           options.MaxUsages,
+				NotBefore:           now.Unix(),
+				NotAfter:            now.Add(ttl).Unix(),
 			}
 			tks[t.TokenID] = t
 			return t, nil
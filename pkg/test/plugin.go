@@ -74,7 +74,10 @@ type testPlugin struct {
 	Metadata meta.PluginMeta
 }
 
-func LoadPlugins(loader *plugins.PluginLoader) int {
+// LoadPlugins serves the test plugins in-process and loads them into the
+// loader. If any modules are given, only the plugins with those module paths
+// are loaded. Returns the number of plugins loaded.
+func LoadPlugins(loader *plugins.PluginLoader, modules ...string) int {
 	testPlugins := []testPlugin{
 		{
 			Scheme: cortex.Scheme(context.Background()),
@@ -93,6 +96,18 @@ func LoadPlugins(loader *plugins.PluginLoader) int {
 			},
 		},
 	}
+	if len(modules) > 0 {
+		filtered := []testPlugin{}
+		for _, p := range testPlugins {
+			for _, module := range modules {
+				if p.Metadata.Module == module {
+					filtered = append(filtered, p)
+					break
+				}
+			}
+		}
+		testPlugins = filtered
+	}
 	for _, p := range testPlugins {
 		sc := plugins.ServeConfig(p.Scheme)
 		ch := make(chan *plugin.ReattachConfig, 1)
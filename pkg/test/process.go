@@ -0,0 +1,55 @@
+package test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var ErrProcessNotFound = errors.New("process not found")
+
+// findChildProcess returns the child process of the current process whose
+// executable has the given name.
+func findChildProcess(name string) (*os.Process, error) {
+	pid, err := findChildPID(os.Getpid(), name)
+	if err != nil {
+		return nil, err
+	}
+	return os.FindProcess(pid)
+}
+
+// findChildPIDPs finds a child process of the given process using ps, which
+// is available on most unix systems, including macOS.
+func findChildPIDPs(ppid int, name string) (int, error) {
+	out, err := exec.Command("ps", "-A", "-o", "pid=,ppid=,comm=").Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list processes: %w", err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		parent, err := strconv.Atoi(fields[1])
+		if err != nil || parent != ppid {
+			continue
+		}
+		// comm is the executable path on some platforms, and may contain
+		// spaces
+		if filepath.Base(strings.Join(fields[2:], " ")) == name {
+			return pid, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrProcessNotFound, name)
+}
@@ -0,0 +1,38 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// findChildPID finds a child process of the given process using /proc. If
+// /proc is not mounted, ps is used instead.
+func findChildPID(ppid int, name string) (int, error) {
+	threads, err := os.ReadDir(fmt.Sprintf("/proc/%d/task/", ppid))
+	if err != nil {
+		return findChildPIDPs(ppid, name)
+	}
+	for _, thread := range threads {
+		children, err := os.ReadFile(fmt.Sprintf("/proc/%d/task/%s/children", ppid, thread.Name()))
+		if err != nil {
+			continue
+		}
+		for _, part := range strings.Fields(string(children)) {
+			pid, err := strconv.Atoi(part)
+			if err != nil {
+				continue
+			}
+			exe, err := os.Readlink(fmt.Sprintf("/proc/%d/exe", pid))
+			if err != nil {
+				continue
+			}
+			if filepath.Base(exe) == name {
+				return pid, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrProcessNotFound, name)
+}
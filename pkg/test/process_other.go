@@ -0,0 +1,7 @@
+//go:build !linux
+
+package test
+
+func findChildPID(ppid int, name string) (int, error) {
+	return findChildPIDPs(ppid, name)
+}
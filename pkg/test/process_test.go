@@ -0,0 +1,42 @@
+//go:build linux || darwin
+
+package test_test
+
+import (
+	"os"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Child Process Lookup", Label(test.Unit), func() {
+	var cmd *exec.Cmd
+	BeforeEach(func() {
+		cmd = exec.Command("sleep", "30")
+		Expect(cmd.Start()).To(Succeed())
+		DeferCleanup(func() {
+			cmd.Process.Kill()
+			cmd.Wait()
+		})
+	})
+	It("should find a child process by name", func() {
+		proc, err := test.FindChildProcess("sleep")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proc.Pid).To(Equal(cmd.Process.Pid))
+	})
+	It("should find a child process using ps", func() {
+		if _, err := exec.LookPath("ps"); err != nil {
+			Skip("ps is not available")
+		}
+		pid, err := test.FindChildPIDPs(os.Getpid(), "sleep")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pid).To(Equal(cmd.Process.Pid))
+	})
+	It("should return ErrProcessNotFound if there is no such child process", func() {
+		_, err := test.FindChildProcess("kube-apiserver")
+		Expect(err).To(MatchError(test.ErrProcessNotFound))
+	})
+})
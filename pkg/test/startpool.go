@@ -0,0 +1,51 @@
+package test
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrStartQueueFull = errors.New("too many pending starts")
+
+// StartPool limits the number of agents (and their Prometheus instances)
+// which can be started concurrently. Starts beyond the limit wait in a
+// bounded queue; once the queue is full, new starts are rejected.
+type StartPool struct {
+	running chan struct{}
+	pending chan struct{}
+}
+
+// NewStartPool returns a pool which runs at most maxConcurrent starts at
+// once, and allows up to maxQueued additional starts to wait for a slot.
+func NewStartPool(maxConcurrent, maxQueued int) *StartPool {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	if maxQueued < 0 {
+		maxQueued = 0
+	}
+	return &StartPool{
+		running: make(chan struct{}, maxConcurrent),
+		pending: make(chan struct{}, maxConcurrent+maxQueued),
+	}
+}
+
+// Do runs fn once a slot is available, and returns its error. If the queue
+// is full, fn is not run and ErrStartQueueFull is returned immediately. If
+// the context is canceled while waiting, the context's error is returned.
+func (p *StartPool) Do(ctx context.Context, fn func() error) error {
+	select {
+	case p.pending <- struct{}{}:
+	default:
+		return ErrStartQueueFull
+	}
+	defer func() { <-p.pending }()
+
+	select {
+	case p.running <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-p.running }()
+	return fn()
+}
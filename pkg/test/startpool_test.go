@@ -0,0 +1,93 @@
+package test_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Start Pool", Label(test.Unit), func() {
+	It("should limit the number of concurrent starts", func() {
+		const maxConcurrent = 3
+		pool := test.NewStartPool(maxConcurrent, 100)
+		var running, maxRunning, completed int32
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				err := pool.Do(context.Background(), func() error {
+					n := atomic.AddInt32(&running, 1)
+					for {
+						max := atomic.LoadInt32(&maxRunning)
+						if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+							break
+						}
+					}
+					time.Sleep(5 * time.Millisecond)
+					atomic.AddInt32(&running, -1)
+					atomic.AddInt32(&completed, 1)
+					return nil
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}()
+		}
+		wg.Wait()
+		Expect(completed).To(BeEquivalentTo(50))
+		Expect(maxRunning).To(BeEquivalentTo(maxConcurrent))
+	})
+	It("should reject starts when the queue is full", func() {
+		pool := test.NewStartPool(1, 0)
+		release := make(chan struct{})
+		started := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			pool.Do(context.Background(), func() error {
+				close(started)
+				<-release
+				return nil
+			})
+		}()
+		<-started
+		Expect(pool.Do(context.Background(), func() error {
+			Fail("should not run")
+			return nil
+		})).To(MatchError(test.ErrStartQueueFull))
+		close(release)
+		<-done
+		Expect(pool.Do(context.Background(), func() error {
+			return nil
+		})).To(Succeed())
+	})
+	It("should stop waiting when the context is canceled", func() {
+		pool := test.NewStartPool(1, 1)
+		release := make(chan struct{})
+		started := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			pool.Do(context.Background(), func() error {
+				close(started)
+				<-release
+				return nil
+			})
+		}()
+		<-started
+		ctx, ca := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer ca()
+		Expect(pool.Do(ctx, func() error {
+			Fail("should not run")
+			return nil
+		})).To(MatchError(context.DeadlineExceeded))
+		close(release)
+		<-done
+	})
+})
@@ -0,0 +1,125 @@
+package testutil
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// logBuffer keeps the last n lines written to any of its writers.
+type logBuffer struct {
+	mu      sync.Mutex
+	lines   []string
+	next    int
+	full    bool
+	written int
+	updated chan struct{}
+}
+
+func newLogBuffer(size int) *logBuffer {
+	return &logBuffer{
+		lines:   make([]string, size),
+		updated: make(chan struct{}),
+	}
+}
+
+// Writer returns a new io.Writer which splits its output into lines and
+// adds them to the buffer. Separate writers should be used for each stream,
+// so that partial lines written to different streams are not combined.
+func (b *logBuffer) Writer() io.Writer {
+	return &lineWriter{
+		buffer: b,
+	}
+}
+
+type lineWriter struct {
+	buffer  *logBuffer
+	partial []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	data := append(w.partial, p...)
+	var lines []string
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		lines = append(lines, string(bytes.TrimSuffix(data[:i], []byte("\r"))))
+		data = data[i+1:]
+	}
+	w.partial = append([]byte(nil), data...)
+	if len(lines) > 0 {
+		w.buffer.add(lines)
+	}
+	return len(p), nil
+}
+
+func (b *logBuffer) add(lines []string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, line := range lines {
+		b.append(line)
+	}
+	close(b.updated)
+	b.updated = make(chan struct{})
+}
+
+func (b *logBuffer) append(line string) {
+	b.lines[b.next] = line
+	b.next = (b.next + 1) % len(b.lines)
+	if b.next == 0 {
+		b.full = true
+	}
+	b.written++
+}
+
+// snapshot returns the buffered lines in order, the total number of lines
+// written so far, and a channel which is closed when more lines are written.
+func (b *logBuffer) snapshot() ([]string, int, <-chan struct{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var lines []string
+	if b.full {
+		lines = append(lines, b.lines[b.next:]...)
+	}
+	lines = append(lines, b.lines[:b.next]...)
+	return lines, b.written, b.updated
+}
+
+func (b *logBuffer) Lines() []string {
+	lines, _, _ := b.snapshot()
+	return lines
+}
+
+func (b *logBuffer) WaitForLine(pattern string, timeout time.Duration) (string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", err
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	seen := 0
+	for {
+		lines, written, updated := b.snapshot()
+		// only check lines which have not been checked yet
+		unchecked := written - seen
+		if unchecked > len(lines) {
+			unchecked = len(lines)
+		}
+		for _, line := range lines[len(lines)-unchecked:] {
+			if re.MatchString(line) {
+				return line, nil
+			}
+		}
+		seen = written
+		select {
+		case <-updated:
+		case <-timer.C:
+			return "", fmt.Errorf("timed out after %s waiting for a log line matching %q", timeout, pattern)
+		}
+	}
+}
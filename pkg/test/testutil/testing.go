@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega/gexec"
@@ -45,11 +46,20 @@ func IfTesting[T any](t T) ifExpr[T] {
 type Session interface {
 	G() (*gexec.Session, bool)
 	Wait() error
+	// Logs returns the most recent lines of the command's output, if log
+	// capture was enabled using WithLogCapture.
+	Logs() []string
+	// WaitForLogLine waits until the command writes a line of output matching
+	// the given regular expression, and returns the matching line. Lines
+	// still held in the capture buffer are checked first. Log capture must be
+	// enabled using WithLogCapture.
+	WaitForLogLine(pattern string, timeout time.Duration) (string, error)
 }
 
 type sessionWrapper struct {
-	g   *gexec.Session
-	cmd *exec.Cmd
+	g    *gexec.Session
+	cmd  *exec.Cmd
+	logs *logBuffer
 }
 
 func (s *sessionWrapper) G() (*gexec.Session, bool) {
@@ -70,16 +80,84 @@ func (s *sessionWrapper) Wait() error {
 	return s.cmd.Wait()
 }
 
-func StartCmd(cmd *exec.Cmd) (Session, error) {
+func (s *sessionWrapper) Logs() []string {
+	if s.logs == nil {
+		return nil
+	}
+	return s.logs.Lines()
+}
+
+func (s *sessionWrapper) WaitForLogLine(pattern string, timeout time.Duration) (string, error) {
+	if s.logs == nil {
+		return "", ErrLogCaptureDisabled
+	}
+	return s.logs.WaitForLine(pattern, timeout)
+}
+
+var ErrLogCaptureDisabled = errors.New("log capture is not enabled for this command")
+
+type StartCmdOptions struct {
+	logCaptureLines int
+}
+
+type StartCmdOption func(*StartCmdOptions)
+
+func (o *StartCmdOptions) Apply(opts ...StartCmdOption) {
+	for _, op := range opts {
+		op(o)
+	}
+}
+
+// WithLogCapture keeps the last n lines of the command's combined stdout
+// and stderr in memory, so that they can be inspected using the session's
+// Logs and WaitForLogLine methods. Output is still written to the usual
+// destinations.
+func WithLogCapture(lines int) StartCmdOption {
+	return func(o *StartCmdOptions) {
+		o.logCaptureLines = lines
+	}
+}
+
+func StartCmd(cmd *exec.Cmd, opts ...StartCmdOption) (Session, error) {
+	options := StartCmdOptions{}
+	options.Apply(opts...)
+
+	var logs *logBuffer
+	if options.logCaptureLines > 0 {
+		logs = newLogBuffer(options.logCaptureLines)
+	}
 	if IsTesting {
-		session, err := gexec.Start(cmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+		var stdout, stderr io.Writer = ginkgo.GinkgoWriter, ginkgo.GinkgoWriter
+		if logs != nil {
+			stdout = io.MultiWriter(stdout, logs.Writer())
+			stderr = io.MultiWriter(stderr, logs.Writer())
+		}
+		session, err := gexec.Start(cmd, stdout, stderr)
 		if err != nil {
 			return nil, err
 		}
 		return &sessionWrapper{
-			g:   session,
-			cmd: cmd,
+			g:    session,
+			cmd:  cmd,
+			logs: logs,
 		}, nil
 	}
-	return nil, cmd.Start()
+	if logs != nil {
+		cmd.Stdout = teeWriter(cmd.Stdout, logs)
+		cmd.Stderr = teeWriter(cmd.Stderr, logs)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &sessionWrapper{
+		cmd:  cmd,
+		logs: logs,
+	}, nil
+}
+
+func teeWriter(w io.Writer, logs *logBuffer) io.Writer {
+	if w == nil {
+		return logs.Writer()
+	}
+	return io.MultiWriter(w, logs.Writer())
 }
@@ -0,0 +1,68 @@
+package testutil_test
+
+import (
+	"os/exec"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/pkg/test/testutil"
+)
+
+var _ = Describe("StartCmd", Label(test.Unit), func() {
+	start := func(script string, opts ...testutil.StartCmdOption) testutil.Session {
+		session, err := testutil.StartCmd(exec.Command("sh", "-c", script), opts...)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(func() {
+			if g, ok := session.G(); ok {
+				g.Kill()
+			}
+			session.Wait()
+		})
+		return session
+	}
+
+	It("should wait for a matching log line", func() {
+		session := start(`echo starting; sleep 0.1; echo "server ready on port 1234" >&2; exec sleep 10`,
+			testutil.WithLogCapture(10))
+		line, err := session.WaitForLogLine(`ready on port \d+`, 5*time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(line).To(Equal("server ready on port 1234"))
+		Expect(session.Logs()).To(Equal([]string{"starting", "server ready on port 1234"}))
+	})
+	It("should match lines written before waiting", func() {
+		session := start(`echo ready; exec sleep 10`, testutil.WithLogCapture(10))
+		Eventually(session.Logs).Should(ContainElement("ready"))
+		Expect(session.WaitForLogLine(`^ready$`, time.Second)).To(Equal("ready"))
+	})
+	It("should time out if no line matches", func() {
+		session := start(`echo starting; exec sleep 10`, testutil.WithLogCapture(10))
+		start := time.Now()
+		_, err := session.WaitForLogLine(`ready`, 200*time.Millisecond)
+		Expect(err).To(MatchError(ContainSubstring("timed out")))
+		Expect(time.Since(start)).To(BeNumerically(">=", 200*time.Millisecond))
+		Expect(session.Logs()).To(Equal([]string{"starting"}))
+	})
+	It("should keep only the most recent lines", func() {
+		session := start(`for i in 1 2 3 4 5; do echo line $i; done; exec sleep 10`, testutil.WithLogCapture(3))
+		Expect(session.WaitForLogLine(`line 5`, 5*time.Second)).To(Equal("line 5"))
+		Expect(session.Logs()).To(Equal([]string{"line 3", "line 4", "line 5"}))
+	})
+	It("should return an error if log capture is not enabled", func() {
+		session := start(`echo ready; exec sleep 10`)
+		_, err := session.WaitForLogLine(`ready`, time.Second)
+		Expect(err).To(MatchError(testutil.ErrLogCaptureDisabled))
+		Expect(session.Logs()).To(BeEmpty())
+	})
+	It("should report an invalid pattern", func() {
+		session := start(`exec sleep 10`, testutil.WithLogCapture(10))
+		_, err := session.WaitForLogLine(`(`, time.Second)
+		Expect(err).To(HaveOccurred())
+	})
+	It("should handle lines split across writes", func() {
+		session := start(`printf "rea"; sleep 0.1; printf "dy\n"; exec sleep 10`, testutil.WithLogCapture(10))
+		Expect(session.WaitForLogLine(`^ready$`, 5*time.Second)).To(Equal("ready"))
+	})
+})
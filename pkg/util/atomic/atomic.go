@@ -0,0 +1,53 @@
+// Package atomic provides helpers for writing files atomically.
+package atomic
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// WriteFile writes data to the named file, replacing it if it exists. The
+// data is first written to a temporary file in the same directory, which is
+// synced to disk and then renamed to the target path. Readers will see either
+// the old contents or the new contents of the file, and a crash or error
+// during the write will never leave a partially written file in its place.
+//
+// Unlike os.WriteFile, perm is applied as-is and is not modified by the
+// process umask.
+func WriteFile(filename string, data []byte, perm os.FileMode) (err error) {
+	dir, base := filepath.Split(filename)
+	if dir == "" {
+		dir = "."
+	}
+	f, err := os.CreateTemp(dir, "."+base+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+	if _, err = f.Write(data); err != nil {
+		return err
+	}
+	if err = f.Chmod(perm); err != nil {
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(f.Name(), filename); err != nil {
+		return err
+	}
+	// sync the directory so the rename itself is persisted
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package atomic_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/pkg/util/atomic"
+)
+
+var _ = Describe("WriteFile", Label(test.Unit), func() {
+	var dir string
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+	entries := func() []string {
+		des, err := os.ReadDir(dir)
+		Expect(err).NotTo(HaveOccurred())
+		names := []string{}
+		for _, de := range des {
+			names = append(names, de.Name())
+		}
+		return names
+	}
+
+	It("should write new files", func() {
+		filename := filepath.Join(dir, "config.yaml")
+		Expect(atomic.WriteFile(filename, []byte("foo"), 0640)).To(Succeed())
+		Expect(os.ReadFile(filename)).To(Equal([]byte("foo")))
+		info, err := os.Stat(filename)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0640)))
+		Expect(entries()).To(ConsistOf("config.yaml"))
+	})
+	It("should replace existing files", func() {
+		filename := filepath.Join(dir, "config.yaml")
+		Expect(os.WriteFile(filename, []byte("old contents"), 0644)).To(Succeed())
+		Expect(atomic.WriteFile(filename, []byte("new"), 0644)).To(Succeed())
+		Expect(os.ReadFile(filename)).To(Equal([]byte("new")))
+		Expect(entries()).To(ConsistOf("config.yaml"))
+	})
+	It("should not leave a partial file if the write is interrupted", func() {
+		// renaming a file over a non-empty directory fails after the data
+		// has been written to the temporary file
+		filename := filepath.Join(dir, "config.yaml")
+		Expect(os.Mkdir(filename, 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(filename, "keep"), nil, 0644)).To(Succeed())
+
+		Expect(atomic.WriteFile(filename, []byte("foo"), 0644)).NotTo(Succeed())
+		Expect(entries()).To(ConsistOf("config.yaml"))
+		info, err := os.Stat(filename)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.IsDir()).To(BeTrue())
+	})
+	It("should return an error if the directory does not exist", func() {
+		filename := filepath.Join(dir, "missing", "config.yaml")
+		Expect(atomic.WriteFile(filename, []byte("foo"), 0644)).To(MatchError(os.ErrNotExist))
+		Expect(entries()).To(BeEmpty())
+	})
+})
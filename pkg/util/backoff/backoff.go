@@ -0,0 +1,161 @@
+// Package backoff provides exponential backoff with jitter, and a helper
+// for retrying operations until they succeed or a context is canceled.
+package backoff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+var ErrMaxAttemptsExceeded = errors.New("max attempts exceeded")
+
+// Policy configures the delays returned by a Backoff.
+type Policy struct {
+	// Delay before the first retry.
+	Base time.Duration
+	// Upper bound on the delay between retries. If zero, delays are not
+	// bounded.
+	Max time.Duration
+	// Factor by which the delay is multiplied after each retry. Values less
+	// than 1 are treated as 1.
+	Multiplier float64
+	// Fraction of each delay to randomize, between 0 and 1. A jitter of 0.2
+	// produces delays within +/- 20% of the computed delay. Jittered delays
+	// are still bounded by Max.
+	Jitter float64
+	// Maximum number of attempts made by Retry, including the first. If zero,
+	// Retry will keep trying until the context is canceled.
+	MaxAttempts int
+}
+
+var DefaultPolicy = Policy{
+	Base:       100 * time.Millisecond,
+	Max:        10 * time.Second,
+	Multiplier: 2,
+	Jitter:     0.2,
+}
+
+// Backoff produces a sequence of delays according to a policy. It is safe
+// for concurrent use.
+type Backoff struct {
+	Policy
+	mu      sync.Mutex
+	attempt int
+	rand    *rand.Rand
+}
+
+// Start returns a new Backoff using this policy.
+func (p Policy) Start() *Backoff {
+	return &Backoff{
+		Policy: p,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Next returns the delay to wait before the next retry, and advances the
+// backoff.
+func (b *Backoff) Next() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	multiplier := math.Max(b.Multiplier, 1)
+	delay := float64(b.Base) * math.Pow(multiplier, float64(b.attempt))
+	b.attempt++
+	if b.Max > 0 && delay > float64(b.Max) {
+		delay = float64(b.Max)
+	}
+	if jitter := math.Min(math.Max(b.Jitter, 0), 1); jitter > 0 {
+		delay += delay * jitter * (2*b.rand.Float64() - 1)
+		if b.Max > 0 && delay > float64(b.Max) {
+			delay = float64(b.Max)
+		}
+	}
+	return time.Duration(delay)
+}
+
+// Attempt returns the number of delays returned by Next since the backoff
+// was started or last reset.
+func (b *Backoff) Attempt() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.attempt
+}
+
+// Reset restarts the sequence of delays from the beginning.
+func (b *Backoff) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.attempt = 0
+}
+
+// Delays returns a channel which receives the sequence of delays from a new
+// backoff, until the context is canceled.
+func (p Policy) Delays(ctx context.Context) <-chan time.Duration {
+	ch := make(chan time.Duration)
+	b := p.Start()
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case ch <- b.Next():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent wraps an error to indicate that Retry should stop retrying and
+// return the wrapped error immediately.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// Retry calls fn until it returns nil, it returns an error wrapped with
+// Permanent, the policy's MaxAttempts is reached, or ctx is canceled. The
+// delay between attempts is determined by the policy. If ctx is canceled,
+// the returned error wraps both the context error and the last error
+// returned by fn.
+func Retry(ctx context.Context, fn func(context.Context) error, policy Policy) error {
+	b := policy.Start()
+	for {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		var perr *permanentError
+		if errors.As(err, &perr) {
+			return perr.err
+		}
+		if policy.MaxAttempts > 0 && b.Attempt()+1 >= policy.MaxAttempts {
+			return fmt.Errorf("%w: %v", ErrMaxAttemptsExceeded, err)
+		}
+		timer := time.NewTimer(b.Next())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w (last error: %v)", ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package backoff_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/pkg/util/backoff"
+)
+
+var _ = Describe("Backoff", Label(test.Unit), func() {
+	It("should return exponentially increasing delays", func() {
+		b := backoff.Policy{
+			Base:       10 * time.Millisecond,
+			Max:        100 * time.Millisecond,
+			Multiplier: 2,
+		}.Start()
+		delays := []time.Duration{}
+		for i := 0; i < 6; i++ {
+			delays = append(delays, b.Next())
+		}
+		Expect(delays).To(Equal([]time.Duration{
+			10 * time.Millisecond,
+			20 * time.Millisecond,
+			40 * time.Millisecond,
+			80 * time.Millisecond,
+			100 * time.Millisecond,
+			100 * time.Millisecond,
+		}))
+		Expect(b.Attempt()).To(Equal(6))
+	})
+	It("should return a constant delay if the multiplier is less than 1", func() {
+		b := backoff.Policy{
+			Base: 10 * time.Millisecond,
+		}.Start()
+		for i := 0; i < 3; i++ {
+			Expect(b.Next()).To(Equal(10 * time.Millisecond))
+		}
+	})
+	It("should restart the sequence when reset", func() {
+		b := backoff.Policy{
+			Base:       10 * time.Millisecond,
+			Multiplier: 3,
+		}.Start()
+		Expect(b.Next()).To(Equal(10 * time.Millisecond))
+		Expect(b.Next()).To(Equal(30 * time.Millisecond))
+		b.Reset()
+		Expect(b.Attempt()).To(Equal(0))
+		Expect(b.Next()).To(Equal(10 * time.Millisecond))
+	})
+	It("should apply jitter within bounds", func() {
+		b := backoff.Policy{
+			Base:       100 * time.Millisecond,
+			Max:        110 * time.Millisecond,
+			Multiplier: 1,
+			Jitter:     0.5,
+		}.Start()
+		distinct := map[time.Duration]struct{}{}
+		for i := 0; i < 100; i++ {
+			d := b.Next()
+			Expect(d).To(BeNumerically(">=", 50*time.Millisecond))
+			Expect(d).To(BeNumerically("<=", 110*time.Millisecond))
+			distinct[d] = struct{}{}
+		}
+		Expect(len(distinct)).To(BeNumerically(">", 1))
+	})
+	It("should send delays on a channel until the context is canceled", func() {
+		ctx, ca := context.WithCancel(context.Background())
+		delays := backoff.Policy{
+			Base:       time.Millisecond,
+			Multiplier: 2,
+		}.Delays(ctx)
+		Expect(<-delays).To(Equal(1 * time.Millisecond))
+		Expect(<-delays).To(Equal(2 * time.Millisecond))
+		Expect(<-delays).To(Equal(4 * time.Millisecond))
+		ca()
+		Eventually(delays).Should(BeClosed())
+	})
+
+	Context("Retry", func() {
+		policy := backoff.Policy{
+			Base:       time.Millisecond,
+			Max:        10 * time.Millisecond,
+			Multiplier: 2,
+		}
+		It("should retry until the function succeeds", func() {
+			attempts := 0
+			err := backoff.Retry(context.Background(), func(context.Context) error {
+				attempts++
+				if attempts < 5 {
+					return errors.New("not yet")
+				}
+				return nil
+			}, policy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(attempts).To(Equal(5))
+		})
+		It("should stop after the maximum number of attempts", func() {
+			attempts := 0
+			p := policy
+			p.MaxAttempts = 3
+			err := backoff.Retry(context.Background(), func(context.Context) error {
+				attempts++
+				return errors.New("test error")
+			}, p)
+			Expect(err).To(MatchError(backoff.ErrMaxAttemptsExceeded))
+			Expect(err.Error()).To(ContainSubstring("test error"))
+			Expect(attempts).To(Equal(3))
+		})
+		It("should stop on permanent errors", func() {
+			attempts := 0
+			testErr := errors.New("test error")
+			err := backoff.Retry(context.Background(), func(context.Context) error {
+				attempts++
+				return backoff.Permanent(testErr)
+			}, policy)
+			Expect(err).To(Equal(testErr))
+			Expect(attempts).To(Equal(1))
+		})
+		It("should stop when the context is canceled", func() {
+			ctx, ca := context.WithCancel(context.Background())
+			time.AfterFunc(10*time.Millisecond, ca)
+			attempts := 0
+			start := time.Now()
+			err := backoff.Retry(ctx, func(context.Context) error {
+				attempts++
+				return errors.New("test error")
+			}, backoff.Policy{
+				Base: time.Hour,
+			})
+			Expect(err).To(MatchError(context.Canceled))
+			Expect(err.Error()).To(ContainSubstring("test error"))
+			Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+			Expect(attempts).To(Equal(1))
+		})
+	})
+})
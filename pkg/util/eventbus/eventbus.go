@@ -0,0 +1,129 @@
+// Package eventbus implements a simple in-process typed publish/subscribe
+// mechanism. Each subscriber receives events on its own bounded buffer;
+// publishers never block, and events are dropped for subscribers whose
+// buffers are full.
+package eventbus
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+const DefaultBufferSize = 16
+
+type BusOptions struct {
+	bufferSize int
+}
+
+type BusOption func(*BusOptions)
+
+func (o *BusOptions) Apply(opts ...BusOption) {
+	for _, op := range opts {
+		op(o)
+	}
+}
+
+// WithBufferSize sets the number of events which can be buffered for each
+// subscriber before new events are dropped. Defaults to DefaultBufferSize.
+func WithBufferSize(size int) BusOption {
+	return func(o *BusOptions) {
+		o.bufferSize = size
+	}
+}
+
+type Bus[T any] struct {
+	BusOptions
+	mu          sync.RWMutex
+	subscribers map[*Subscription[T]]struct{}
+	dropped     uint64
+}
+
+func New[T any](opts ...BusOption) *Bus[T] {
+	options := BusOptions{
+		bufferSize: DefaultBufferSize,
+	}
+	options.Apply(opts...)
+	if options.bufferSize < 1 {
+		options.bufferSize = 1
+	}
+	return &Bus[T]{
+		BusOptions:  options,
+		subscribers: map[*Subscription[T]]struct{}{},
+	}
+}
+
+// Subscribe registers a new subscriber. The subscriber will receive all
+// events published after Subscribe returns, until Unsubscribe is called.
+func (b *Bus[T]) Subscribe() *Subscription[T] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s := &Subscription[T]{
+		bus: b,
+		c:   make(chan T, b.bufferSize),
+	}
+	b.subscribers[s] = struct{}{}
+	return s
+}
+
+// Publish sends an event to all current subscribers without blocking. If a
+// subscriber's buffer is full, the event is dropped for that subscriber.
+// Returns the number of subscribers the event was delivered to.
+func (b *Bus[T]) Publish(event T) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	delivered := 0
+	for s := range b.subscribers {
+		select {
+		case s.c <- event:
+			delivered++
+		default:
+			atomic.AddUint64(&s.dropped, 1)
+			atomic.AddUint64(&b.dropped, 1)
+		}
+	}
+	return delivered
+}
+
+// NumSubscribers returns the number of active subscribers.
+func (b *Bus[T]) NumSubscribers() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers)
+}
+
+// Dropped returns the total number of events dropped across all current and
+// past subscribers.
+func (b *Bus[T]) Dropped() uint64 {
+	return atomic.LoadUint64(&b.dropped)
+}
+
+type Subscription[T any] struct {
+	bus     *Bus[T]
+	c       chan T
+	dropped uint64
+	once    sync.Once
+}
+
+// C returns the channel on which events are received. The channel is closed
+// when the subscription is cancelled.
+func (s *Subscription[T]) C() <-chan T {
+	return s.c
+}
+
+// Dropped returns the number of events which were dropped because this
+// subscriber's buffer was full.
+func (s *Subscription[T]) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Unsubscribe removes the subscriber from the bus and closes its channel.
+// Any events remaining in the buffer can still be received. It is safe to
+// call Unsubscribe more than once.
+func (s *Subscription[T]) Unsubscribe() {
+	s.once.Do(func() {
+		s.bus.mu.Lock()
+		defer s.bus.mu.Unlock()
+		delete(s.bus.subscribers, s)
+		close(s.c)
+	})
+}
@@ -0,0 +1,105 @@
+package eventbus_test
+
+import (
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/pkg/util/eventbus"
+)
+
+var _ = Describe("Event Bus", Label(test.Unit), func() {
+	It("should deliver events to all subscribers", func() {
+		bus := eventbus.New[string]()
+		subs := []*eventbus.Subscription[string]{
+			bus.Subscribe(),
+			bus.Subscribe(),
+			bus.Subscribe(),
+		}
+		Expect(bus.NumSubscribers()).To(Equal(3))
+		Expect(bus.Publish("foo")).To(Equal(3))
+		Expect(bus.Publish("bar")).To(Equal(3))
+		for _, s := range subs {
+			Expect(s.C()).To(Receive(Equal("foo")))
+			Expect(s.C()).To(Receive(Equal("bar")))
+			Expect(s.C()).NotTo(Receive())
+		}
+	})
+	It("should not deliver events published before subscribing", func() {
+		bus := eventbus.New[int]()
+		Expect(bus.Publish(1)).To(Equal(0))
+		s := bus.Subscribe()
+		bus.Publish(2)
+		Expect(s.C()).To(Receive(Equal(2)))
+		Expect(s.C()).NotTo(Receive())
+	})
+	It("should drop events for slow subscribers without blocking", func() {
+		bus := eventbus.New[int](eventbus.WithBufferSize(2))
+		slow := bus.Subscribe()
+		fast := bus.Subscribe()
+
+		received := []int{}
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range fast.C() {
+				received = append(received, i)
+			}
+		}()
+
+		for i := 0; i < 100; i++ {
+			bus.Publish(i)
+			// keep the fast subscriber's buffer from filling up
+			Eventually(fast.C()).Should(HaveLen(0))
+		}
+		fast.Unsubscribe()
+		wg.Wait()
+		Expect(received).To(HaveLen(100))
+		Expect(fast.Dropped()).To(BeZero())
+
+		Expect(slow.C()).To(Receive(Equal(0)))
+		Expect(slow.C()).To(Receive(Equal(1)))
+		Expect(slow.C()).NotTo(Receive())
+		Expect(slow.Dropped()).To(BeEquivalentTo(98))
+		Expect(bus.Dropped()).To(BeEquivalentTo(98))
+	})
+	It("should stop delivering events after unsubscribing", func() {
+		bus := eventbus.New[int]()
+		s1 := bus.Subscribe()
+		s2 := bus.Subscribe()
+		bus.Publish(1)
+		s1.Unsubscribe()
+		Expect(bus.NumSubscribers()).To(Equal(1))
+		Expect(bus.Publish(2)).To(Equal(1))
+
+		Expect(s1.C()).To(Receive(Equal(1)))
+		Expect(s1.C()).To(BeClosed())
+		Expect(s2.C()).To(Receive(Equal(1)))
+		Expect(s2.C()).To(Receive(Equal(2)))
+
+		Expect(s1.Unsubscribe).NotTo(Panic())
+	})
+	It("should allow concurrent publishing and unsubscribing", func() {
+		bus := eventbus.New[int](eventbus.WithBufferSize(1))
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			s := bus.Subscribe()
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					bus.Publish(j)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				s.Unsubscribe()
+			}()
+		}
+		wg.Wait()
+		Expect(bus.NumSubscribers()).To(BeZero())
+	})
+})
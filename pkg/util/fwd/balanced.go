@@ -0,0 +1,99 @@
+package fwd
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/valyala/fasthttp"
+)
+
+// upstream is a single upstream server, with its own connection pool and
+// optional health checker.
+type upstream struct {
+	addr   string
+	client *fasthttp.HostClient
+	health *healthChecker
+
+	// only used in streaming mode
+	streamingTransport *http.Transport
+}
+
+func newUpstream(addr string, options *ForwarderOptions) *upstream {
+	client := &fasthttp.HostClient{
+		MaxConns:                 options.maxConns,
+		MaxConnWaitTimeout:       options.maxConnWaitTimeout,
+		ReadTimeout:              options.readTimeout,
+		WriteTimeout:             options.writeTimeout,
+		NoDefaultUserAgentHeader: true,
+		DisablePathNormalizing:   true,
+		Addr:                     addr,
+		IsTLS:                    options.tlsConfig != nil,
+		TLSConfig:                options.tlsConfig,
+	}
+	if options.retryMaxAttempts > 1 {
+		// retries are handled by the forwarder, using backoff between attempts
+		client.MaxIdemponentCallAttempts = 1
+	}
+	u := &upstream{
+		addr:   addr,
+		client: client,
+	}
+	if options.streaming {
+		u.streamingTransport = newStreamingTransport(options)
+	}
+	if options.healthCheckPath != "" {
+		u.health = newHealthChecker(options.healthCheckCtx, client, options.healthCheckPath,
+			options.healthCheckInterval, options.logger)
+	}
+	return u
+}
+
+func (u *upstream) Healthy() bool {
+	return u.health == nil || u.health.Healthy()
+}
+
+// balancer distributes requests across upstreams in round-robin order.
+type balancer struct {
+	upstreams []*upstream
+	next      uint32
+}
+
+// Candidates returns the healthy upstreams in the order they should be tried
+// for the next request.
+func (b *balancer) Candidates() []*upstream {
+	if len(b.upstreams) == 0 {
+		return nil
+	}
+	start := int(atomic.AddUint32(&b.next, 1)-1) % len(b.upstreams)
+	candidates := make([]*upstream, 0, len(b.upstreams))
+	for i := range b.upstreams {
+		u := b.upstreams[(start+i)%len(b.upstreams)]
+		if u.Healthy() {
+			candidates = append(candidates, u)
+		}
+	}
+	return candidates
+}
+
+// canFallBack reports whether a request which failed with the given error
+// can be sent to another upstream. Idempotent requests can always be re-sent;
+// other requests only if the connection could not be established, in which
+// case the failed upstream never received them.
+func canFallBack(req *fasthttp.Request, err error) bool {
+	if isRetryable(req) {
+		return true
+	}
+	return isDialError(err)
+}
+
+// isDialError reports whether the error occurred while establishing a
+// connection, before any part of the request was sent.
+func isDialError(err error) bool {
+	if errors.Is(err, fasthttp.ErrDialTimeout) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
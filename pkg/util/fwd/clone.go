@@ -0,0 +1,73 @@
+package fwd
+
+import (
+	"errors"
+
+	"github.com/valyala/fasthttp"
+)
+
+// DefaultMaxBufferedBodySize is the default value for
+// WithMaxBufferedBodySize.
+const DefaultMaxBufferedBodySize = 8 * 1024 * 1024
+
+var errBodyTooLarge = errors.New("request body is too large to buffer")
+
+// WithMaxBufferedBodySize sets the largest request body which is buffered in
+// memory so that the request can be sent more than once, such as when it is
+// retried. Requests with larger bodies, or streamed bodies of unknown length,
+// are only sent once. Defaults to DefaultMaxBufferedBodySize. Non-positive
+// values are ignored.
+func WithMaxBufferedBodySize(bytes int) ForwarderOption {
+	return func(o *ForwarderOptions) {
+		if bytes > 0 {
+			o.maxBufferedBodySize = bytes
+		}
+	}
+}
+
+// clonedRequest is a copy of the method, URI, headers, and body of a request,
+// which can be used to build identical requests for multiple attempts. The
+// fasthttp client modifies requests while sending them, and streamed bodies
+// can only be read once, so the same request cannot safely be sent twice.
+//
+// A clonedRequest is not modified once it has been created, and is safe for
+// concurrent use.
+type clonedRequest struct {
+	header        fasthttp.RequestHeader
+	uri           fasthttp.URI
+	useHostHeader bool
+	body          []byte
+}
+
+// cloneRequest copies the request. If the request body is larger than
+// maxBodySize bytes, or is streamed and its length is unknown, errBodyTooLarge
+// is returned and the request is left unread. Otherwise, streamed bodies are
+// read into memory, and the original request can still be used.
+func cloneRequest(req *fasthttp.Request, maxBodySize int) (*clonedRequest, error) {
+	if n := req.Header.ContentLength(); n > maxBodySize ||
+		(n < 0 && req.IsBodyStream()) {
+		return nil, errBodyTooLarge
+	}
+	body := req.Body()
+	if len(body) > maxBodySize {
+		return nil, errBodyTooLarge
+	}
+	c := &clonedRequest{
+		useHostHeader: req.UseHostHeader,
+		body:          append([]byte(nil), body...),
+	}
+	req.Header.CopyTo(&c.header)
+	req.URI().CopyTo(&c.uri)
+	return c, nil
+}
+
+// Request builds a new request identical to the cloned request. The request
+// should be released using fasthttp.ReleaseRequest once it has been sent.
+func (c *clonedRequest) Request() *fasthttp.Request {
+	req := fasthttp.AcquireRequest()
+	c.header.CopyTo(&req.Header)
+	c.uri.CopyTo(req.URI())
+	req.UseHostHeader = c.useHostHeader
+	req.SetBody(c.body)
+	return req
+}
@@ -0,0 +1,115 @@
+package fwd_test
+
+import (
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/valyala/fasthttp"
+
+	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/pkg/util/fwd"
+)
+
+var _ = Describe("Request Cloning", Label(test.Unit), func() {
+	var req *fasthttp.Request
+	BeforeEach(func() {
+		req = fasthttp.AcquireRequest()
+		DeferCleanup(fasthttp.ReleaseRequest, req)
+		req.Header.SetMethod(fasthttp.MethodPut)
+		req.SetRequestURI("https://example.com:8443/foo/bar?a=1&b=2")
+		req.Header.Set("X-Scope-OrgID", "tenant-1")
+		req.Header.Add("X-Multi", "one")
+		req.Header.Add("X-Multi", "two")
+		req.SetBodyString("hello world")
+	})
+
+	expectIdentical := func(actual *fasthttp.Request) {
+		ExpectWithOffset(1, string(actual.Header.Method())).To(Equal(fasthttp.MethodPut))
+		ExpectWithOffset(1, actual.URI().String()).To(Equal("https://example.com:8443/foo/bar?a=1&b=2"))
+		ExpectWithOffset(1, string(actual.Host())).To(Equal("example.com:8443"))
+		ExpectWithOffset(1, string(actual.Header.Peek("X-Scope-OrgID"))).To(Equal("tenant-1"))
+		var multi []string
+		actual.Header.VisitAll(func(key, value []byte) {
+			if string(key) == "X-Multi" {
+				multi = append(multi, string(value))
+			}
+		})
+		ExpectWithOffset(1, multi).To(Equal([]string{"one", "two"}))
+		ExpectWithOffset(1, string(actual.Body())).To(Equal("hello world"))
+	}
+
+	It("should build identical requests each time it is used", func() {
+		clone, err := fwd.CloneRequest(req, 1024)
+		Expect(err).NotTo(HaveOccurred())
+		for i := 0; i < 3; i++ {
+			r := clone.Request()
+			expectIdentical(r)
+			// modifying a built request must not affect later requests
+			r.Header.Set("X-Scope-OrgID", "tenant-2")
+			r.Header.Del("X-Multi")
+			r.URI().SetPath("/changed")
+			r.AppendBodyString("!")
+			fasthttp.ReleaseRequest(r)
+		}
+	})
+	It("should not be affected by changes to the original request", func() {
+		clone, err := fwd.CloneRequest(req, 1024)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Scope-OrgID", "tenant-2")
+		req.URI().SetPath("/changed")
+		req.Body()[0] = 'j'
+		r := clone.Request()
+		defer fasthttp.ReleaseRequest(r)
+		expectIdentical(r)
+	})
+	It("should be safe for concurrent use", func() {
+		clone, err := fwd.CloneRequest(req, 1024)
+		Expect(err).NotTo(HaveOccurred())
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					r := clone.Request()
+					expectIdentical(r)
+					fasthttp.ReleaseRequest(r)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+	It("should read streamed bodies of known length", func() {
+		req.SetBodyStream(strings.NewReader("hello world"), len("hello world"))
+		clone, err := fwd.CloneRequest(req, 1024)
+		Expect(err).NotTo(HaveOccurred())
+		for i := 0; i < 2; i++ {
+			r := clone.Request()
+			expectIdentical(r)
+			fasthttp.ReleaseRequest(r)
+		}
+		// the original request can still be sent
+		Expect(string(req.Body())).To(Equal("hello world"))
+	})
+	It("should not buffer bodies larger than the limit", func() {
+		_, err := fwd.CloneRequest(req, len("hello world")-1)
+		Expect(err).To(MatchError(fwd.ErrBodyTooLarge))
+
+		req.SetBodyStream(strings.NewReader("hello world"), len("hello world"))
+		_, err = fwd.CloneRequest(req, len("hello world")-1)
+		Expect(err).To(MatchError(fwd.ErrBodyTooLarge))
+		// the stream is left unread
+		Expect(string(req.Body())).To(Equal("hello world"))
+
+		_, err = fwd.CloneRequest(req, len("hello world"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("should not buffer streamed bodies of unknown length", func() {
+		req.SetBodyStream(strings.NewReader("hello world"), -1)
+		_, err := fwd.CloneRequest(req, 1024)
+		Expect(err).To(MatchError(fwd.ErrBodyTooLarge))
+	})
+})
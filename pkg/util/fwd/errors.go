@@ -0,0 +1,128 @@
+package fwd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+
+	"github.com/valyala/fasthttp"
+)
+
+// ErrorClass is a stable name for the kind of error that occurred while
+// forwarding a request. Unlike the error messages returned by fasthttp, error
+// classes do not contain addresses or other variable details, so they can be
+// used reliably in log-based alerts. They are logged in the "error_class"
+// field.
+type ErrorClass string
+
+const (
+	// The connection to the upstream could not be established in time.
+	ErrorClassDialTimeout ErrorClass = "dial_timeout"
+	// The connection to the upstream failed, for example because it was
+	// refused.
+	ErrorClassDialFailed ErrorClass = "dial_failed"
+	// The client's connection limit for the upstream was reached.
+	ErrorClassNoFreeConns ErrorClass = "no_free_conns"
+	// The TLS handshake with the upstream failed or timed out, including
+	// when the upstream's certificate could not be verified.
+	ErrorClassTLSHandshake ErrorClass = "tls_handshake"
+	// The connection was reset by the upstream.
+	ErrorClassConnectionReset ErrorClass = "connection_reset"
+	// The upstream closed the connection before sending a response.
+	ErrorClassConnectionClosed ErrorClass = "connection_closed"
+	// The upstream did not respond in time.
+	ErrorClassTimeout ErrorClass = "timeout"
+	// The request was canceled before the upstream responded.
+	ErrorClassCanceled ErrorClass = "canceled"
+	// The error is not one of the known classes.
+	ErrorClassUnknown ErrorClass = "unknown"
+)
+
+// ForwardError is returned by the forwarder when a request could not be
+// forwarded to any upstream. It contains the classification of the
+// underlying error, which can be retrieved with errors.As.
+type ForwardError struct {
+	Class ErrorClass
+	Err   error
+}
+
+func (e *ForwardError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Class, e.Err)
+}
+
+func (e *ForwardError) Unwrap() error {
+	return e.Err
+}
+
+// ClassifyError returns the class of an error returned by the fasthttp
+// client. Errors which are already classified as a *ForwardError keep
+// their class.
+func ClassifyError(err error) ErrorClass {
+	var forwardErr *ForwardError
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &forwardErr):
+		return forwardErr.Class
+	case errors.Is(err, fasthttp.ErrDialTimeout):
+		return ErrorClassDialTimeout
+	case errors.Is(err, fasthttp.ErrNoFreeConns):
+		return ErrorClassNoFreeConns
+	case isTLSHandshakeError(err):
+		return ErrorClassTLSHandshake
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.EPIPE):
+		return ErrorClassConnectionReset
+	case isDialError(err):
+		return ErrorClassDialFailed
+	case errors.Is(err, fasthttp.ErrConnectionClosed):
+		return ErrorClassConnectionClosed
+	case errors.Is(err, context.Canceled):
+		return ErrorClassCanceled
+	case errors.Is(err, fasthttp.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return ErrorClassTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrorClassTimeout
+	}
+	return ErrorClassUnknown
+}
+
+func isTLSHandshakeError(err error) bool {
+	if errors.Is(err, fasthttp.ErrTLSHandshakeTimeout) {
+		return true
+	}
+	var (
+		recordErr      tls.RecordHeaderError
+		authorityErr   x509.UnknownAuthorityError
+		hostnameErr    x509.HostnameError
+		certInvalidErr x509.CertificateInvalidError
+	)
+	if errors.As(err, &recordErr) ||
+		errors.As(err, &authorityErr) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &certInvalidErr) {
+		return true
+	}
+	// Alerts and other handshake errors from crypto/tls are not exported
+	// types, but their messages share a common prefix.
+	msg := err.Error()
+	return strings.HasPrefix(msg, "tls: ") || strings.Contains(msg, "remote error: tls: ")
+}
+
+// classifiedError wraps err in a *ForwardError, if it is not one already.
+func classifiedError(err error) *ForwardError {
+	var forwardErr *ForwardError
+	if errors.As(err, &forwardErr) {
+		return forwardErr
+	}
+	return &ForwardError{
+		Class: ClassifyError(err),
+		Err:   err,
+	}
+}
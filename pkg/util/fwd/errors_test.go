@@ -0,0 +1,126 @@
+package fwd_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/gofiber/fiber/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/valyala/fasthttp"
+
+	"github.com/rancher/opni-monitoring/pkg/logger"
+	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/pkg/util/fwd"
+)
+
+var _ = Describe("Error Classification", Label(test.Unit), func() {
+	DescribeTable("should classify known errors",
+		func(err error, class fwd.ErrorClass) {
+			Expect(fwd.ClassifyError(err)).To(Equal(class))
+			Expect(fwd.ClassifyError(fmt.Errorf("wrapped: %w", err))).To(Equal(class))
+		},
+		Entry("dial timeout", fasthttp.ErrDialTimeout, fwd.ErrorClassDialTimeout),
+		Entry("no free connections", fasthttp.ErrNoFreeConns, fwd.ErrorClassNoFreeConns),
+		Entry("TLS handshake timeout", fasthttp.ErrTLSHandshakeTimeout, fwd.ErrorClassTLSHandshake),
+		Entry("unknown certificate authority", x509.UnknownAuthorityError{}, fwd.ErrorClassTLSHandshake),
+		Entry("certificate hostname mismatch", x509.HostnameError{
+			Certificate: &x509.Certificate{},
+			Host:        "example.com",
+		}, fwd.ErrorClassTLSHandshake),
+		Entry("TLS alert", &net.OpError{
+			Op:  "remote error",
+			Err: errors.New("tls: bad certificate"),
+		}, fwd.ErrorClassTLSHandshake),
+		Entry("connection reset", &net.OpError{
+			Op:  "read",
+			Net: "tcp",
+			Err: os.NewSyscallError("read", syscall.ECONNRESET),
+		}, fwd.ErrorClassConnectionReset),
+		Entry("broken pipe", &net.OpError{
+			Op:  "write",
+			Net: "tcp",
+			Err: os.NewSyscallError("write", syscall.EPIPE),
+		}, fwd.ErrorClassConnectionReset),
+		Entry("connection refused", &net.OpError{
+			Op:  "dial",
+			Net: "tcp",
+			Err: os.NewSyscallError("connect", syscall.ECONNREFUSED),
+		}, fwd.ErrorClassDialFailed),
+		Entry("connection closed", fasthttp.ErrConnectionClosed, fwd.ErrorClassConnectionClosed),
+		Entry("timeout", fasthttp.ErrTimeout, fwd.ErrorClassTimeout),
+		Entry("deadline exceeded", context.DeadlineExceeded, fwd.ErrorClassTimeout),
+		Entry("canceled", context.Canceled, fwd.ErrorClassCanceled),
+		Entry("unknown", errors.New("something went wrong"), fwd.ErrorClassUnknown),
+		Entry("already classified", &fwd.ForwardError{
+			Class: fwd.ErrorClassTimeout,
+			Err:   errors.New("something went wrong"),
+		}, fwd.ErrorClassTimeout),
+	)
+	It("should not classify nil errors", func() {
+		Expect(fwd.ClassifyError(nil)).To(BeEmpty())
+	})
+
+	When("a request cannot be forwarded", func() {
+		var addr string
+		BeforeEach(func() {
+			l, err := net.Listen("tcp4", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			addr = l.Addr().String()
+			l.Close()
+		})
+		newApp := func(forwardErr **fwd.ForwardError, addr string, opts ...fwd.ForwarderOption) *fiber.App {
+			app := fiber.New(fiber.Config{
+				DisableStartupMessage: true,
+			})
+			app.Use(func(c *fiber.Ctx) error {
+				err := c.Next()
+				errors.As(err, forwardErr)
+				return err
+			})
+			app.All("/*", fwd.To(addr, opts...))
+			return app
+		}
+		It("should return a classified error", func() {
+			var forwardErr *fwd.ForwardError
+			app := newApp(&forwardErr, addr)
+			_, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(forwardErr).NotTo(BeNil())
+			Expect(forwardErr.Class).To(Equal(fwd.ErrorClassDialFailed))
+		})
+		It("should log the error class", func() {
+			buf := gbytes.NewBuffer()
+			lg := logger.New(logger.WithWriter(buf), logger.WithColor(false)).Named("test")
+			var forwardErr *fwd.ForwardError
+			app := newApp(&forwardErr, addr, fwd.WithLogger(lg))
+			_, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buf).To(gbytes.Say(`error forwarding request.*"error_class": "dial_failed"`))
+		})
+		It("should classify TLS handshake failures", func() {
+			upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			DeferCleanup(upstream.Close)
+
+			var forwardErr *fwd.ForwardError
+			app := newApp(&forwardErr, strings.TrimPrefix(upstream.URL, "http://"),
+				fwd.WithTLS(&tls.Config{}))
+			_, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(forwardErr).NotTo(BeNil())
+			Expect(forwardErr.Class).To(Equal(fwd.ErrorClassTLSHandshake))
+		})
+	})
+})
@@ -0,0 +1,6 @@
+package fwd
+
+var (
+	CloneRequest    = cloneRequest
+	ErrBodyTooLarge = errBodyTooLarge
+)
@@ -1,6 +1,7 @@
 package fwd
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"strings"
@@ -8,15 +9,48 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/utils"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rancher/opni-monitoring/pkg/logger"
 	"github.com/valyala/fasthttp"
 	"go.uber.org/zap"
 )
 
 type ForwarderOptions struct {
-	logger    *zap.SugaredLogger
-	tlsConfig *tls.Config
-	name      string
+	logger          *zap.SugaredLogger
+	tlsConfig       *tls.Config
+	tlsServerName   string
+	name            string
+	bodyLogPaths    []string
+	bodyLogMaxBytes int
+
+	healthCheckCtx      context.Context
+	healthCheckPath     string
+	healthCheckInterval time.Duration
+
+	queryParamHeaders []queryParamHeader
+
+	retryMaxAttempts int
+	retryBackoff     time.Duration
+	retryStatusCodes map[int]struct{}
+
+	errorOnNon2xx bool
+
+	headerInjector func(*fiber.Ctx) map[string]string
+
+	forwardedHeaders bool
+
+	singleFlightKey func(*fiber.Ctx) string
+
+	streaming bool
+
+	maxBufferedBodySize int
+
+	metricsRegisterer prometheus.Registerer
+
+	maxConns           int
+	maxConnWaitTimeout time.Duration
+	readTimeout        time.Duration
+	writeTimeout       time.Duration
 }
 
 type ForwarderOption func(*ForwarderOptions)
@@ -45,7 +79,153 @@ func WithTLS(tlsConfig *tls.Config) ForwarderOption {
 	}
 }
 
+// WithTLSServerName sets the server name used for SNI and certificate
+// verification when connecting to the upstream server. This is useful when
+// the forwarder dials an IP address, but the upstream server's certificate
+// is issued for a DNS name. If no TLS config was provided using WithTLS, a
+// default config is created, enabling TLS. The config passed to WithTLS is
+// not modified.
+func WithTLSServerName(name string) ForwarderOption {
+	return func(o *ForwarderOptions) {
+		o.tlsServerName = name
+	}
+}
+
+// WithBodyLogging enables debug logging of request and response bodies for
+// requests whose path starts with any of the given prefixes. Logged bodies are
+// truncated to maxBytes; if maxBytes is not positive, bodies are logged in full.
+//
+// Request and response bodies can contain sensitive data such as credentials
+// or tenant metrics. This option is intended only for temporary debugging and
+// should not be left enabled in production.
+func WithBodyLogging(paths []string, maxBytes int) ForwarderOption {
+	return func(o *ForwarderOptions) {
+		o.bodyLogPaths = paths
+		o.bodyLogMaxBytes = maxBytes
+	}
+}
+
+// WithErrorOnNon2xx causes the forwarder to return an *UpstreamError if the
+// upstream server replies with a status outside of the 200-399 range. The
+// upstream response is left in place, so middleware handling the error can
+// still relay it to the client by returning nil.
+func WithErrorOnNon2xx() ForwarderOption {
+	return func(o *ForwarderOptions) {
+		o.errorOnNon2xx = true
+	}
+}
+
+// WithMaxConns sets the maximum number of connections which can be open to
+// the upstream server at once. Defaults to 8192. Non-positive values are
+// ignored.
+func WithMaxConns(maxConns int) ForwarderOption {
+	return func(o *ForwarderOptions) {
+		if maxConns > 0 {
+			o.maxConns = maxConns
+		}
+	}
+}
+
+// WithMaxConnWaitTimeout sets how long a request will wait for a connection
+// to become available if the maximum number of connections are in use. By
+// default, such requests fail immediately. Non-positive values are ignored.
+func WithMaxConnWaitTimeout(timeout time.Duration) ForwarderOption {
+	return func(o *ForwarderOptions) {
+		if timeout > 0 {
+			o.maxConnWaitTimeout = timeout
+		}
+	}
+}
+
+// WithReadTimeout sets the maximum duration for reading the full response
+// from the upstream server. Defaults to 10 seconds. Non-positive values are
+// ignored.
+func WithReadTimeout(timeout time.Duration) ForwarderOption {
+	return func(o *ForwarderOptions) {
+		if timeout > 0 {
+			o.readTimeout = timeout
+		}
+	}
+}
+
+// WithWriteTimeout sets the maximum duration for writing the full request to
+// the upstream server. Defaults to 10 seconds. Non-positive values are
+// ignored.
+func WithWriteTimeout(timeout time.Duration) ForwarderOption {
+	return func(o *ForwarderOptions) {
+		if timeout > 0 {
+			o.writeTimeout = timeout
+		}
+	}
+}
+
+// WithHeaderInjector sets a function which is called once per request to
+// compute additional headers to set on the request sent to the upstream
+// server, such as tenant IDs derived from the authenticated cluster.
+// Injected headers replace any existing headers with the same name.
+func WithHeaderInjector(fn func(*fiber.Ctx) map[string]string) ForwarderOption {
+	return func(o *ForwarderOptions) {
+		o.headerInjector = fn
+	}
+}
+
+// WithForwardedHeaders configures the forwarder to preserve the
+// X-Forwarded-* headers set by proxies in front of it. The address of the
+// client connected to the forwarder is appended to any existing
+// X-Forwarded-For header, and existing X-Forwarded-Host and X-Forwarded-Proto
+// headers are kept, so that the upstream sees the address, host, and protocol
+// of the original request. By default, X-Forwarded-For is replaced with the
+// address of the client connected to the forwarder.
+//
+// This option should only be used if the forwarder is behind a trusted
+// proxy, as clients can otherwise spoof these headers.
+func WithForwardedHeaders() ForwarderOption {
+	return func(o *ForwarderOptions) {
+		o.forwardedHeaders = true
+	}
+}
+
+// UpstreamError is returned by the forwarder when the upstream server replies
+// with an error status and WithErrorOnNon2xx is set.
+type UpstreamError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *UpstreamError) Error() string {
+	return fmt.Sprintf("upstream server replied with status %d", e.StatusCode)
+}
+
+func (o *ForwarderOptions) shouldLogBody(path string) bool {
+	for _, prefix := range o.bodyLogPaths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func truncateBody(body []byte, maxBytes int) string {
+	if maxBytes <= 0 || len(body) <= maxBytes {
+		return string(body)
+	}
+	return fmt.Sprintf("%s... (%d bytes truncated)", body[:maxBytes], len(body)-maxBytes)
+}
+
 func To(addr string, opts ...ForwarderOption) func(*fiber.Ctx) error {
+	return ToBalanced([]string{addr}, opts...)
+}
+
+// ToBalanced returns a handler which forwards requests to the given upstream
+// addresses in round-robin order. If a health check is configured with
+// WithActiveHealthCheck, each upstream is probed separately, and unhealthy
+// upstreams are skipped until they recover. Requests are rejected with 503
+// Service Unavailable if no upstream is healthy.
+//
+// If a request fails to reach an upstream, it is sent to the next healthy
+// upstream instead. Requests which are not idempotent are only re-sent if the
+// connection to the failed upstream could not be established.
+func ToBalanced(addrs []string, opts ...ForwarderOption) func(*fiber.Ctx) error {
 	defaultLogger := logger.New(
 		logger.WithSampling(&zap.SamplingConfig{
 			Initial:    1,
@@ -53,33 +233,79 @@ func To(addr string, opts ...ForwarderOption) func(*fiber.Ctx) error {
 		}),
 	).Named("fwd")
 	options := &ForwarderOptions{
-		logger: defaultLogger,
+		logger:              defaultLogger,
+		maxConns:            1024 * 8,
+		readTimeout:         10 * time.Second,
+		writeTimeout:        10 * time.Second,
+		maxBufferedBodySize: DefaultMaxBufferedBodySize,
 	}
 	options.Apply(opts...)
 
+	if options.tlsServerName != "" {
+		if options.tlsConfig == nil {
+			options.tlsConfig = &tls.Config{}
+		} else {
+			options.tlsConfig = options.tlsConfig.Clone()
+		}
+		options.tlsConfig.ServerName = options.tlsServerName
+	}
+
 	if options.name != "" {
 		options.logger = options.logger.Named(options.name)
 	}
+	if len(options.bodyLogPaths) > 0 {
+		options.logger.With(
+			"paths", options.bodyLogPaths,
+		).Warn("body logging is enabled; request and response bodies may contain sensitive data")
+	}
 
-	hostClient := &fasthttp.HostClient{
-		MaxConns:                 1024 * 8,
-		ReadTimeout:              10 * time.Second,
-		WriteTimeout:             10 * time.Second,
-		NoDefaultUserAgentHeader: true,
-		DisablePathNormalizing:   true,
-		Addr:                     addr,
-		IsTLS:                    options.tlsConfig != nil,
-		TLSConfig:                options.tlsConfig,
+	lb := &balancer{}
+	for _, addr := range addrs {
+		lb.upstreams = append(lb.upstreams, newUpstream(addr, options))
+	}
+	isTLS := options.tlsConfig != nil
+
+	var metrics *forwarderMetrics
+	if options.metricsRegisterer != nil {
+		if err := registerMetrics(options.metricsRegisterer); err != nil {
+			options.logger.With(
+				zap.Error(err),
+			).Error("failed to register forwarder metrics")
+		} else {
+			metrics = newForwarderMetrics(options.name)
+		}
+	}
+
+	var flights *singleFlight
+	if options.singleFlightKey != nil && !options.streaming {
+		flights = newSingleFlight(options.singleFlightKey)
 	}
 
 	return func(c *fiber.Ctx) error {
 		forwardedFor := c.IP()
 		forwardedHost := c.Hostname()
 		forwardedProto := c.Protocol()
+		if options.forwardedHeaders {
+			forwardedFor = c.Context().RemoteIP().String()
+			if prior := c.Get(fiber.HeaderXForwardedFor); prior != "" {
+				forwardedFor = prior + ", " + forwardedFor
+			}
+			if host := c.Get(fiber.HeaderXForwardedHost); host != "" {
+				forwardedHost = host
+			}
+			if proto := c.Get(fiber.HeaderXForwardedProto); proto != "" {
+				forwardedProto = proto
+			}
+		}
+		candidates := lb.Candidates()
+		if len(candidates) == 0 {
+			return c.Status(fiber.StatusServiceUnavailable).
+				SendString("upstream is unavailable")
+		}
 		options.logger.With(
 			"method", c.Method(),
 			"path", c.Path(),
-			"to", addr,
+			"to", candidates[0].addr,
 			"for", forwardedFor,
 			"host", forwardedHost,
 		).Debugf("=>")
@@ -90,25 +316,111 @@ func To(addr string, opts ...ForwarderOption) func(*fiber.Ctx) error {
 		req.Header.Set(fiber.HeaderXForwardedFor, forwardedFor)
 		req.Header.Set(fiber.HeaderXForwardedHost, forwardedHost)
 		req.Header.Set(fiber.HeaderXForwardedProto, forwardedProto)
-		if hostClient.IsTLS {
+		if isTLS {
 			req.Header.Set(fiber.HeaderXForwardedSsl, "on")
 		}
+		if options.headerInjector != nil {
+			for k, v := range options.headerInjector(c) {
+				req.Header.Set(k, v)
+			}
+		}
+
+		logBody := !options.streaming && options.shouldLogBody(c.Path())
+		if logBody {
+			options.logger.With(
+				"req", c.Path(),
+				"body", truncateBody(req.Body(), options.bodyLogMaxBytes),
+			).Debug("request body")
+		}
 
+		if len(options.queryParamHeaders) > 0 {
+			moveQueryParamsToHeaders(req, options.queryParamHeaders)
+		}
 		req.SetRequestURI(utils.UnsafeString(req.RequestURI()))
-		if err := hostClient.Do(req, resp); err != nil {
+		// the scheme must match the upstream connection, regardless of how
+		// the request was received
+		scheme := "http"
+		if isTLS {
+			scheme = "https"
+		}
+		req.URI().SetScheme(scheme)
+		send := func(r *fasthttp.Response) (err error) {
+			for i, u := range candidates {
+				start := time.Now()
+				if options.streaming {
+					err = u.doStreaming(c.UserContext(), scheme, req, c.Context().RequestBodyStream(), r)
+				} else {
+					err = options.do(c.UserContext(), u.client, req, r)
+				}
+				if metrics != nil {
+					metrics.latency.Observe(time.Since(start).Seconds())
+				}
+				if err == nil || i == len(candidates)-1 {
+					break
+				}
+				if options.streaming {
+					// the request body may have been partially read
+					if !isDialError(err) {
+						break
+					}
+				} else if !canFallBack(req, err) {
+					break
+				}
+				options.logger.With(
+					zap.Error(err),
+					"error_class", ClassifyError(err),
+					"req", c.Path(),
+					"addr", u.addr,
+				).Warn("error forwarding request, trying next upstream")
+			}
+			return
+		}
+		var err error
+		if flights != nil {
+			err = flights.Do(c, resp, send)
+		} else {
+			err = send(resp)
+		}
+		if metrics != nil {
+			if options.streaming {
+				// reading the bodies would buffer them
+				metrics.ObserveRequest(resp.StatusCode(), contentLength(&req.Header),
+					contentLength(&resp.Header), err)
+			} else {
+				metrics.ObserveRequest(resp.StatusCode(), len(req.Body()), len(resp.Body()), err)
+			}
+		}
+		if err != nil {
+			forwardErr := classifiedError(err)
 			options.logger.With(
-				zap.Error(err),
+				zap.Error(forwardErr.Err),
+				"error_class", forwardErr.Class,
 				"req", c.Path(),
 			).Error("error forwarding request")
-			return fmt.Errorf("error forwarding request: %w", err)
+			return fmt.Errorf("error forwarding request: %w", forwardErr)
 		}
 		resp.Header.Del(fiber.HeaderConnection)
+		if logBody {
+			options.logger.With(
+				"req", c.Path(),
+				"status", resp.StatusCode(),
+				"body", truncateBody(resp.Body(), options.bodyLogMaxBytes),
+			).Debug("response body")
+		}
 		if resp.StatusCode()/100 >= 4 {
 			options.logger.With(
 				"req", c.Path(),
 				"status", resp.StatusCode(),
 			).Info("server replied with error")
 		}
+		if options.errorOnNon2xx {
+			if code := resp.StatusCode(); code < 200 || code >= 400 {
+				return &UpstreamError{
+					StatusCode: code,
+					Body:       append([]byte(nil), resp.Body()...),
+				}
+			}
+		}
 		return nil
 	}
 }
@@ -0,0 +1,1256 @@
+package fwd_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/rancher/opni-monitoring/pkg/logger"
+	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/pkg/util/fwd"
+)
+
+var _ = Describe("Forwarder", Label(test.Unit), func() {
+	var upstream *httptest.Server
+	BeforeEach(func() {
+		upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("response:" + string(body)))
+		}))
+		DeferCleanup(upstream.Close)
+	})
+
+	newApp := func(opts ...fwd.ForwarderOption) *fiber.App {
+		app := fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		app.All("/*", fwd.To(strings.TrimPrefix(upstream.URL, "http://"), opts...))
+		return app
+	}
+
+	It("should forward requests to the upstream server", func() {
+		app := newApp()
+		resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/foo", strings.NewReader("hello")))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		body, _ := io.ReadAll(resp.Body)
+		Expect(string(body)).To(Equal("response:hello"))
+	})
+
+	Context("body logging", func() {
+		var buf *gbytes.Buffer
+		var app *fiber.App
+		BeforeEach(func() {
+			buf = gbytes.NewBuffer()
+			lg := logger.New(logger.WithWriter(buf), logger.WithColor(false)).Named("test")
+			app = newApp(
+				fwd.WithLogger(lg),
+				fwd.WithBodyLogging([]string{"/api/v1/push"}, 10),
+			)
+		})
+		It("should warn that body logging is enabled", func() {
+			Expect(buf).To(gbytes.Say("body logging is enabled"))
+		})
+		It("should log bodies for matching paths", func() {
+			resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/api/v1/push", strings.NewReader("abc")))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(buf).To(gbytes.Say(`request body.*"body": "abc"`))
+			Expect(buf).To(gbytes.Say(`response body.*"body": "response:a`))
+		})
+		It("should not log bodies for other paths", func() {
+			resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/api/v1/query", strings.NewReader("abc")))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(string(buf.Contents())).NotTo(ContainSubstring("request body"))
+			Expect(string(buf.Contents())).NotTo(ContainSubstring("response body"))
+		})
+		It("should truncate bodies longer than the limit", func() {
+			resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/api/v1/push", strings.NewReader("0123456789abcdef")))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(buf).To(gbytes.Say(`request body.*"body": "0123456789\.\.\. \(6 bytes truncated\)"`))
+			Expect(buf).To(gbytes.Say(`response body.*"body": "response:0\.\.\. \(15 bytes truncated\)"`))
+		})
+	})
+})
+
+var _ = Describe("Active Health Checks", Label(test.Unit), func() {
+	type upstream struct {
+		server   *httptest.Server
+		healthy  uint32
+		requests uint32
+		probes   uint32
+	}
+	newUpstream := func(healthy bool) *upstream {
+		u := &upstream{}
+		if healthy {
+			u.healthy = 1
+		}
+		mux := http.NewServeMux()
+		mux.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddUint32(&u.probes, 1)
+			if atomic.LoadUint32(&u.healthy) == 1 {
+				w.WriteHeader(http.StatusOK)
+			} else {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		})
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddUint32(&u.requests, 1)
+			w.WriteHeader(http.StatusOK)
+		})
+		u.server = httptest.NewServer(mux)
+		DeferCleanup(u.server.Close)
+		return u
+	}
+	newAppWithContext := func(ctx context.Context, u *upstream) *fiber.App {
+		app := fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		app.All("/*", fwd.To(strings.TrimPrefix(u.server.URL, "http://"),
+			fwd.WithActiveHealthCheck(ctx, "/ready", 50*time.Millisecond)))
+		return app
+	}
+	newApp := func(u *upstream) *fiber.App {
+		ctx, ca := context.WithCancel(context.Background())
+		DeferCleanup(ca)
+		return newAppWithContext(ctx, u)
+	}
+	status := func(app *fiber.App) func() (int, error) {
+		return func() (int, error) {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/foo", nil))
+			if err != nil {
+				return 0, err
+			}
+			return resp.StatusCode, nil
+		}
+	}
+
+	It("should only forward requests to healthy upstreams", func() {
+		healthy := newUpstream(true)
+		unhealthy := newUpstream(false)
+		healthyApp := newApp(healthy)
+		unhealthyApp := newApp(unhealthy)
+
+		// upstreams are assumed to be healthy until the first probe completes
+		Eventually(status(unhealthyApp)).Should(Equal(http.StatusServiceUnavailable))
+		unhealthyRequests := atomic.LoadUint32(&unhealthy.requests)
+		for i := 0; i < 10; i++ {
+			Expect(status(healthyApp)()).To(Equal(http.StatusOK))
+			Expect(status(unhealthyApp)()).To(Equal(http.StatusServiceUnavailable))
+		}
+		Expect(atomic.LoadUint32(&healthy.requests)).To(BeEquivalentTo(10))
+		Expect(atomic.LoadUint32(&unhealthy.requests)).To(Equal(unhealthyRequests))
+	})
+	It("should resume forwarding once an upstream becomes healthy", func() {
+		u := newUpstream(false)
+		app := newApp(u)
+		Eventually(status(app)).Should(Equal(http.StatusServiceUnavailable))
+
+		atomic.StoreUint32(&u.healthy, 1)
+		Eventually(status(app)).Should(Equal(http.StatusOK))
+		Expect(atomic.LoadUint32(&u.requests)).To(BeNumerically(">", 0))
+
+		atomic.StoreUint32(&u.healthy, 0)
+		Eventually(status(app)).Should(Equal(http.StatusServiceUnavailable))
+	})
+	It("should stop probing once the context is done", func() {
+		u := newUpstream(false)
+		ctx, ca := context.WithCancel(context.Background())
+		app := newAppWithContext(ctx, u)
+		Eventually(status(app)).Should(Equal(http.StatusServiceUnavailable))
+
+		ca()
+		// the upstream is no longer considered unhealthy once probing stops
+		Eventually(status(app)).Should(Equal(http.StatusOK))
+		probes := atomic.LoadUint32(&u.probes)
+		Consistently(func() uint32 {
+			return atomic.LoadUint32(&u.probes)
+		}, 250*time.Millisecond, 50*time.Millisecond).Should(Equal(probes))
+	})
+	It("should probe upstreams if the context is nil", func() {
+		u := newUpstream(false)
+		var ctx context.Context
+		app := newAppWithContext(ctx, u)
+		Eventually(status(app)).Should(Equal(http.StatusServiceUnavailable))
+	})
+})
+
+var _ = Describe("Query Parameter Headers", Label(test.Unit), func() {
+	var app *fiber.App
+	var received chan *http.Request
+	BeforeEach(func() {
+		received = make(chan *http.Request, 1)
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received <- r
+			w.WriteHeader(http.StatusOK)
+		}))
+		DeferCleanup(upstream.Close)
+		app = fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		app.All("/*", fwd.To(strings.TrimPrefix(upstream.URL, "http://"),
+			fwd.WithQueryParamHeader("token", "Authorization", "Bearer "),
+			fwd.WithQueryParamHeader("secret", "", ""),
+		))
+	})
+
+	It("should move mapped query parameters into headers", func() {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/query?query=up&token=abc123", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var req *http.Request
+		Eventually(received).Should(Receive(&req))
+		Expect(req.Header.Get("Authorization")).To(Equal("Bearer abc123"))
+		Expect(req.URL.Path).To(Equal("/api/v1/query"))
+		Expect(req.URL.RawQuery).To(Equal("query=up"))
+		Expect(req.RequestURI).NotTo(ContainSubstring("abc123"))
+	})
+	It("should remove the query string if no parameters remain", func() {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/rules?token=abc123", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var req *http.Request
+		Eventually(received).Should(Receive(&req))
+		Expect(req.Header.Get("Authorization")).To(Equal("Bearer abc123"))
+		Expect(req.RequestURI).To(Equal("/api/v1/rules"))
+	})
+	It("should strip parameters which are not mapped to a header", func() {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/query?secret=foo&query=up", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var req *http.Request
+		Eventually(received).Should(Receive(&req))
+		Expect(req.URL.RawQuery).To(Equal("query=up"))
+		Expect(req.Header.Get("Authorization")).To(BeEmpty())
+	})
+	It("should not modify requests without mapped parameters", func() {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/query?query=up%7Bjob%3D%22a%22%7D", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var req *http.Request
+		Eventually(received).Should(Receive(&req))
+		Expect(req.RequestURI).To(Equal("/api/v1/query?query=up%7Bjob%3D%22a%22%7D"))
+	})
+})
+
+var _ = Describe("TLS Server Name", Label(test.Unit), func() {
+	var upstream *httptest.Server
+	var serverNames chan string
+	var rootCAs *x509.CertPool
+	BeforeEach(func() {
+		serverNames = make(chan string, 10)
+		upstream = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		upstream.TLS = &tls.Config{
+			GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+				serverNames <- hello.ServerName
+				return nil, nil
+			},
+		}
+		upstream.StartTLS()
+		DeferCleanup(upstream.Close)
+		rootCAs = x509.NewCertPool()
+		rootCAs.AddCert(upstream.Certificate())
+	})
+
+	newApp := func(opts ...fwd.ForwarderOption) *fiber.App {
+		app := fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		app.All("/*", fwd.To(strings.TrimPrefix(upstream.URL, "https://"), opts...))
+		return app
+	}
+
+	It("should use the configured server name when dialing by IP", func() {
+		// the test server's certificate is valid for example.com
+		app := newApp(
+			fwd.WithTLS(&tls.Config{RootCAs: rootCAs}),
+			fwd.WithTLSServerName("example.com"),
+		)
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/foo", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Eventually(serverNames).Should(Receive(Equal("example.com")))
+	})
+	It("should verify the certificate against the configured server name", func() {
+		app := newApp(
+			fwd.WithTLS(&tls.Config{RootCAs: rootCAs}),
+			fwd.WithTLSServerName("not-example.com"),
+		)
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/foo", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+		Eventually(serverNames).Should(Receive(Equal("not-example.com")))
+	})
+	It("should not modify the provided TLS config", func() {
+		tlsConfig := &tls.Config{RootCAs: rootCAs}
+		newApp(
+			fwd.WithTLS(tlsConfig),
+			fwd.WithTLSServerName("example.com"),
+		)
+		Expect(tlsConfig.ServerName).To(BeEmpty())
+	})
+	It("should enable TLS if no TLS config was provided", func() {
+		app := newApp(fwd.WithTLSServerName("example.com"))
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/foo", nil))
+		Expect(err).NotTo(HaveOccurred())
+		// the handshake is attempted using the server name, but the test
+		// server's CA is not trusted by default
+		Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+		Eventually(serverNames).Should(Receive(Equal("example.com")))
+	})
+})
+
+// flakyListener closes the first n accepted connections without reading
+// from them, simulating an upstream which is restarting.
+type flakyListener struct {
+	net.Listener
+	remaining int32
+}
+
+func (l *flakyListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		if atomic.AddInt32(&l.remaining, -1) < 0 {
+			return conn, nil
+		}
+		conn.Close()
+	}
+}
+
+var _ = Describe("Retry", Label(test.Unit), func() {
+	var listener *flakyListener
+	var requests chan string
+	BeforeEach(func() {
+		requests = make(chan string, 10)
+		upstream := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			requests <- string(body)
+			w.WriteHeader(http.StatusOK)
+		}))
+		listener = &flakyListener{Listener: upstream.Listener}
+		upstream.Listener = listener
+		upstream.Start()
+		DeferCleanup(upstream.Close)
+	})
+
+	newApp := func(opts ...fwd.ForwarderOption) *fiber.App {
+		app := fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		app.All("/*", fwd.To(listener.Addr().String(), opts...))
+		return app
+	}
+
+	It("should retry idempotent requests until they succeed", func() {
+		atomic.StoreInt32(&listener.remaining, 2)
+		app := newApp(fwd.WithRetry(3, 10*time.Millisecond))
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/foo", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(requests).To(HaveLen(1))
+	})
+	It("should re-send the request body on each attempt", func() {
+		atomic.StoreInt32(&listener.remaining, 1)
+		app := newApp(fwd.WithRetry(3, 10*time.Millisecond))
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/foo", strings.NewReader("hello")))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(requests).To(Receive(Equal("hello")))
+	})
+	It("should not retry requests whose bodies are too large to buffer", func() {
+		atomic.StoreInt32(&listener.remaining, 1)
+		app := newApp(fwd.WithRetry(3, 10*time.Millisecond), fwd.WithMaxBufferedBodySize(4))
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/foo", strings.NewReader("hello")))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+		Expect(requests).To(BeEmpty())
+
+		atomic.StoreInt32(&listener.remaining, 1)
+		app = newApp(fwd.WithRetry(3, 10*time.Millisecond), fwd.WithMaxBufferedBodySize(5))
+		resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/foo", strings.NewReader("hello")))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(requests).To(Receive(Equal("hello")))
+	})
+	It("should give up after the maximum number of attempts", func() {
+		atomic.StoreInt32(&listener.remaining, 3)
+		app := newApp(fwd.WithRetry(3, 10*time.Millisecond))
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/foo", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+		Expect(atomic.LoadInt32(&listener.remaining)).To(BeEquivalentTo(0))
+		Expect(requests).To(BeEmpty())
+	})
+	It("should not retry non-idempotent requests", func() {
+		atomic.StoreInt32(&listener.remaining, 1)
+		app := newApp(fwd.WithRetry(3, 10*time.Millisecond))
+		resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/foo", strings.NewReader("hello")))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+		Expect(requests).To(BeEmpty())
+	})
+	It("should stop retrying once the request deadline is exceeded", func() {
+		atomic.StoreInt32(&listener.remaining, 100)
+		app := fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		app.Use(func(c *fiber.Ctx) error {
+			ctx, ca := context.WithTimeout(c.UserContext(), 100*time.Millisecond)
+			defer ca()
+			c.SetUserContext(ctx)
+			return c.Next()
+		})
+		app.All("/*", fwd.To(listener.Addr().String(), fwd.WithRetry(100, 50*time.Millisecond)))
+		start := time.Now()
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/foo", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+		Expect(time.Since(start)).To(BeNumerically("<", 500*time.Millisecond))
+		Expect(atomic.LoadInt32(&listener.remaining)).To(BeNumerically(">", 90))
+	})
+})
+
+var _ = Describe("Retry On Status", Label(test.Unit), func() {
+	var addr string
+	var attempts int32
+	var failures int32
+	var retryAfter string
+	BeforeEach(func() {
+		atomic.StoreInt32(&attempts, 0)
+		atomic.StoreInt32(&failures, 1)
+		retryAfter = ""
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			if atomic.AddInt32(&failures, -1) >= 0 {
+				if retryAfter != "" {
+					w.Header().Set("Retry-After", retryAfter)
+				}
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("ok"))
+		}))
+		DeferCleanup(upstream.Close)
+		addr = strings.TrimPrefix(upstream.URL, "http://")
+	})
+
+	newApp := func(opts ...fwd.ForwarderOption) *fiber.App {
+		app := fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		app.All("/*", fwd.To(addr, opts...))
+		return app
+	}
+
+	It("should retry idempotent requests when the upstream returns a listed status", func() {
+		app := newApp(fwd.WithRetry(3, 10*time.Millisecond), fwd.WithRetryOnStatus(http.StatusServiceUnavailable))
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/foo", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		body, _ := io.ReadAll(resp.Body)
+		Expect(string(body)).To(Equal("ok"))
+		Expect(atomic.LoadInt32(&attempts)).To(BeEquivalentTo(2))
+	})
+	It("should retry using the default policy if WithRetry is not set", func() {
+		app := newApp(fwd.WithRetryOnStatus(http.StatusTooManyRequests, http.StatusServiceUnavailable))
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/foo", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(atomic.LoadInt32(&attempts)).To(BeEquivalentTo(2))
+	})
+	It("should not retry statuses which are not listed", func() {
+		app := newApp(fwd.WithRetry(3, 10*time.Millisecond), fwd.WithRetryOnStatus(http.StatusTooManyRequests))
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/foo", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusServiceUnavailable))
+		Expect(atomic.LoadInt32(&attempts)).To(BeEquivalentTo(1))
+	})
+	It("should not retry non-idempotent requests", func() {
+		app := newApp(fwd.WithRetry(3, 10*time.Millisecond), fwd.WithRetryOnStatus(http.StatusServiceUnavailable))
+		resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/foo", strings.NewReader("hello")))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusServiceUnavailable))
+		Expect(atomic.LoadInt32(&attempts)).To(BeEquivalentTo(1))
+	})
+	It("should return the last response once attempts are exhausted", func() {
+		atomic.StoreInt32(&failures, 5)
+		app := newApp(fwd.WithRetry(3, 10*time.Millisecond), fwd.WithRetryOnStatus(http.StatusServiceUnavailable))
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/foo", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusServiceUnavailable))
+		Expect(atomic.LoadInt32(&attempts)).To(BeEquivalentTo(3))
+	})
+	It("should honor the Retry-After header", func() {
+		retryAfter = "1"
+		app := newApp(fwd.WithRetry(3, 10*time.Millisecond), fwd.WithRetryOnStatus(http.StatusServiceUnavailable))
+		start := time.Now()
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/foo", nil), 5000)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(time.Since(start)).To(BeNumerically(">=", time.Second))
+	})
+	It("should not retry if Retry-After exceeds the request deadline", func() {
+		retryAfter = "10"
+		app := fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		app.Use(func(c *fiber.Ctx) error {
+			ctx, ca := context.WithTimeout(c.UserContext(), 500*time.Millisecond)
+			defer ca()
+			c.SetUserContext(ctx)
+			return c.Next()
+		})
+		app.All("/*", fwd.To(addr, fwd.WithRetry(3, 10*time.Millisecond),
+			fwd.WithRetryOnStatus(http.StatusServiceUnavailable)))
+		start := time.Now()
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/foo", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusServiceUnavailable))
+		Expect(time.Since(start)).To(BeNumerically("<", 500*time.Millisecond))
+		Expect(atomic.LoadInt32(&attempts)).To(BeEquivalentTo(1))
+	})
+})
+
+var _ = Describe("Upstream Errors", Label(test.Unit), func() {
+	var addr string
+	BeforeEach(func() {
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			code, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/"))
+			w.WriteHeader(code)
+			w.Write([]byte("status " + strconv.Itoa(code)))
+		}))
+		DeferCleanup(upstream.Close)
+		addr = strings.TrimPrefix(upstream.URL, "http://")
+	})
+
+	// newApp returns an app which relays upstream responses to the client,
+	// and records any upstream error returned by the forwarder.
+	newApp := func(upstreamErr **fwd.UpstreamError, opts ...fwd.ForwarderOption) *fiber.App {
+		app := fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		app.Use(func(c *fiber.Ctx) error {
+			err := c.Next()
+			if errors.As(err, upstreamErr) {
+				return nil
+			}
+			return err
+		})
+		app.All("/*", fwd.To(addr, opts...))
+		return app
+	}
+
+	DescribeTable("should return an error for statuses outside of 200-399",
+		func(code int, expectError bool) {
+			var upstreamErr *fwd.UpstreamError
+			app := newApp(&upstreamErr, fwd.WithErrorOnNon2xx())
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/"+strconv.Itoa(code), nil))
+			Expect(err).NotTo(HaveOccurred())
+			if expectError {
+				Expect(upstreamErr).NotTo(BeNil())
+				Expect(upstreamErr.StatusCode).To(Equal(code))
+				Expect(string(upstreamErr.Body)).To(Equal("status " + strconv.Itoa(code)))
+			} else {
+				Expect(upstreamErr).To(BeNil())
+			}
+
+			// the upstream response is still forwarded to the client
+			Expect(resp.StatusCode).To(Equal(code))
+			body, _ := io.ReadAll(resp.Body)
+			Expect(string(body)).To(Equal("status " + strconv.Itoa(code)))
+		},
+		Entry("200", http.StatusOK, false),
+		Entry("201", http.StatusCreated, false),
+		Entry("302", http.StatusFound, false),
+		Entry("400", http.StatusBadRequest, true),
+		Entry("404", http.StatusNotFound, true),
+		Entry("500", http.StatusInternalServerError, true),
+		Entry("503", http.StatusServiceUnavailable, true),
+	)
+	It("should not return an error unless the option is set", func() {
+		var upstreamErr *fwd.UpstreamError
+		app := newApp(&upstreamErr)
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/503", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(upstreamErr).To(BeNil())
+		Expect(resp.StatusCode).To(Equal(http.StatusServiceUnavailable))
+	})
+})
+
+var _ = Describe("Header Injection", Label(test.Unit), func() {
+	var app *fiber.App
+	var received chan *http.Request
+	var calls int32
+	BeforeEach(func() {
+		received = make(chan *http.Request, 1)
+		atomic.StoreInt32(&calls, 0)
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received <- r
+			w.WriteHeader(http.StatusOK)
+		}))
+		DeferCleanup(upstream.Close)
+		app = fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		app.All("/*", fwd.To(strings.TrimPrefix(upstream.URL, "http://"),
+			fwd.WithHeaderInjector(func(c *fiber.Ctx) map[string]string {
+				atomic.AddInt32(&calls, 1)
+				return map[string]string{
+					"X-Scope-OrgID": strings.TrimPrefix(c.Path(), "/"),
+				}
+			}),
+		))
+	})
+
+	It("should set injected headers on the upstream request", func() {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/cluster-1", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var req *http.Request
+		Eventually(received).Should(Receive(&req))
+		Expect(req.Header.Get("X-Scope-OrgID")).To(Equal("cluster-1"))
+		Expect(atomic.LoadInt32(&calls)).To(BeEquivalentTo(1))
+	})
+	It("should override existing headers with the same name", func() {
+		r := httptest.NewRequest(http.MethodGet, "/cluster-1", nil)
+		r.Header.Set("X-Scope-OrgID", "cluster-2")
+		resp, err := app.Test(r)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		var req *http.Request
+		Eventually(received).Should(Receive(&req))
+		Expect(req.Header.Values("X-Scope-OrgID")).To(Equal([]string{"cluster-1"}))
+	})
+	It("should call the injector once per request", func() {
+		for i := 0; i < 5; i++ {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/cluster-1", nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Eventually(received).Should(Receive())
+		}
+		Expect(atomic.LoadInt32(&calls)).To(BeEquivalentTo(5))
+	})
+})
+
+var _ = Describe("Connection Pool", Label(test.Unit), func() {
+	var addr string
+	var release chan struct{}
+	var started chan struct{}
+	BeforeEach(func() {
+		release = make(chan struct{})
+		started = make(chan struct{}, 10)
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/slow" {
+				started <- struct{}{}
+				<-release
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		DeferCleanup(upstream.Close)
+		DeferCleanup(func() {
+			select {
+			case <-release:
+			default:
+				close(release)
+			}
+		})
+		addr = strings.TrimPrefix(upstream.URL, "http://")
+	})
+
+	newApp := func(opts ...fwd.ForwarderOption) *fiber.App {
+		app := fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		app.All("/*", fwd.To(addr, opts...))
+		return app
+	}
+	// startSlowRequest sends a request which holds a connection open until
+	// release is closed, and waits until the upstream has received it.
+	startSlowRequest := func(app *fiber.App) <-chan int {
+		codes := make(chan int, 1)
+		go func() {
+			defer GinkgoRecover()
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/slow", nil), -1)
+			Expect(err).NotTo(HaveOccurred())
+			codes <- resp.StatusCode
+		}()
+		Eventually(started).Should(Receive())
+		return codes
+	}
+
+	It("should fail requests beyond the connection limit", func() {
+		app := newApp(fwd.WithMaxConns(1))
+		slow := startSlowRequest(app)
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/fast", nil), -1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+
+		close(release)
+		Eventually(slow).Should(Receive(Equal(http.StatusOK)))
+	})
+	It("should wait for a connection if a wait timeout is set", func() {
+		app := newApp(fwd.WithMaxConns(1), fwd.WithMaxConnWaitTimeout(5*time.Second))
+		slow := startSlowRequest(app)
+
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			close(release)
+		}()
+		start := time.Now()
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/fast", nil), -1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(time.Since(start)).To(BeNumerically(">=", 100*time.Millisecond))
+		Eventually(slow).Should(Receive(Equal(http.StatusOK)))
+	})
+	It("should fail requests which wait longer than the wait timeout", func() {
+		app := newApp(fwd.WithMaxConns(1), fwd.WithMaxConnWaitTimeout(100*time.Millisecond))
+		startSlowRequest(app)
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/fast", nil), -1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+	})
+	It("should fail requests which exceed the read timeout", func() {
+		app := newApp(fwd.WithReadTimeout(100 * time.Millisecond))
+		start := time.Now()
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/slow", nil), -1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+		Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+	})
+	It("should ignore non-positive values", func() {
+		app := newApp(
+			fwd.WithMaxConns(0),
+			fwd.WithMaxConnWaitTimeout(-1),
+			fwd.WithReadTimeout(0),
+			fwd.WithWriteTimeout(-1),
+		)
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/fast", nil), -1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+})
+
+var _ = Describe("Single Flight", Label(test.Unit), func() {
+	var addr string
+	var calls int32
+	var release chan struct{}
+	BeforeEach(func() {
+		calls = 0
+		release = make(chan struct{})
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&calls, 1)
+			<-release
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(r.URL.Path + " " + strconv.Itoa(int(n))))
+		}))
+		DeferCleanup(upstream.Close)
+		DeferCleanup(func() {
+			select {
+			case <-release:
+			default:
+				close(release)
+			}
+		})
+		addr = strings.TrimPrefix(upstream.URL, "http://")
+	})
+
+	type result struct {
+		code int
+		body string
+	}
+	// sendConcurrently sends one request per path at the same time, and
+	// releases the upstream once all of them are waiting on a response.
+	sendConcurrently := func(method string, paths ...string) []result {
+		var keyed int32
+		app := fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		app.All("/*", fwd.To(addr, fwd.WithSingleFlight(func(c *fiber.Ctx) string {
+			atomic.AddInt32(&keyed, 1)
+			return c.OriginalURL()
+		})))
+
+		results := make(chan result, len(paths))
+		for _, path := range paths {
+			path := path
+			go func() {
+				defer GinkgoRecover()
+				resp, err := app.Test(httptest.NewRequest(method, path, nil), -1)
+				Expect(err).NotTo(HaveOccurred())
+				body, err := io.ReadAll(resp.Body)
+				Expect(err).NotTo(HaveOccurred())
+				results <- result{resp.StatusCode, string(body)}
+			}()
+		}
+		if method == http.MethodGet {
+			Eventually(func() int32 {
+				return atomic.LoadInt32(&keyed)
+			}).Should(BeEquivalentTo(len(paths)))
+		} else {
+			Eventually(func() int32 {
+				return atomic.LoadInt32(&calls)
+			}).Should(BeEquivalentTo(len(paths)))
+		}
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+
+		var all []result
+		for range paths {
+			var r result
+			Eventually(results).Should(Receive(&r))
+			all = append(all, r)
+		}
+		return all
+	}
+
+	It("should coalesce identical concurrent GET requests", func() {
+		paths := make([]string, 50)
+		for i := range paths {
+			paths[i] = "/foo?bar=baz"
+		}
+		results := sendConcurrently(http.MethodGet, paths...)
+		Expect(atomic.LoadInt32(&calls)).To(BeEquivalentTo(1))
+		for _, r := range results {
+			Expect(r).To(Equal(result{http.StatusOK, "/foo 1"}))
+		}
+	})
+	It("should not coalesce requests with different keys", func() {
+		results := sendConcurrently(http.MethodGet, "/foo", "/foo", "/bar", "/bar")
+		Expect(atomic.LoadInt32(&calls)).To(BeEquivalentTo(2))
+		bodies := map[string]int{}
+		for _, r := range results {
+			Expect(r.code).To(Equal(http.StatusOK))
+			bodies[r.body]++
+		}
+		Expect(bodies).To(HaveLen(2))
+		for _, count := range bodies {
+			Expect(count).To(Equal(2))
+		}
+	})
+	It("should not coalesce non-idempotent requests", func() {
+		results := sendConcurrently(http.MethodPost, "/foo", "/foo", "/foo")
+		Expect(atomic.LoadInt32(&calls)).To(BeEquivalentTo(3))
+		for _, r := range results {
+			Expect(r.code).To(Equal(http.StatusOK))
+		}
+	})
+})
+
+var _ = Describe("Load Balancing", Label(test.Unit), func() {
+	type upstream struct {
+		server   *httptest.Server
+		addr     string
+		healthy  uint32
+		requests uint32
+	}
+	newUpstream := func() *upstream {
+		u := &upstream{
+			healthy: 1,
+		}
+		mux := http.NewServeMux()
+		mux.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+			if atomic.LoadUint32(&u.healthy) == 1 {
+				w.WriteHeader(http.StatusOK)
+			} else {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		})
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddUint32(&u.requests, 1)
+			w.WriteHeader(http.StatusOK)
+		})
+		u.server = httptest.NewServer(mux)
+		u.addr = strings.TrimPrefix(u.server.URL, "http://")
+		DeferCleanup(u.server.Close)
+		return u
+	}
+	newApp := func(upstreams []*upstream, opts ...fwd.ForwarderOption) *fiber.App {
+		var addrs []string
+		for _, u := range upstreams {
+			addrs = append(addrs, u.addr)
+		}
+		app := fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		app.All("/*", fwd.ToBalanced(addrs, opts...))
+		return app
+	}
+	send := func(app *fiber.App, method string) (int, error) {
+		resp, err := app.Test(httptest.NewRequest(method, "/foo", nil), -1)
+		if err != nil {
+			return 0, err
+		}
+		return resp.StatusCode, nil
+	}
+
+	It("should distribute requests across upstreams in round-robin order", func() {
+		upstreams := []*upstream{newUpstream(), newUpstream()}
+		app := newApp(upstreams)
+		for i := 0; i < 10; i++ {
+			Expect(send(app, http.MethodGet)).To(Equal(http.StatusOK))
+		}
+		Expect(atomic.LoadUint32(&upstreams[0].requests)).To(BeEquivalentTo(5))
+		Expect(atomic.LoadUint32(&upstreams[1].requests)).To(BeEquivalentTo(5))
+	})
+	It("should fall back to the next upstream if one goes down", func() {
+		upstreams := []*upstream{newUpstream(), newUpstream()}
+		app := newApp(upstreams)
+		for i := 0; i < 4; i++ {
+			Expect(send(app, http.MethodGet)).To(Equal(http.StatusOK))
+		}
+		Expect(atomic.LoadUint32(&upstreams[0].requests)).To(BeEquivalentTo(2))
+
+		upstreams[0].server.Close()
+		for i := 0; i < 10; i++ {
+			Expect(send(app, http.MethodGet)).To(Equal(http.StatusOK))
+		}
+		Expect(atomic.LoadUint32(&upstreams[0].requests)).To(BeEquivalentTo(2))
+		Expect(atomic.LoadUint32(&upstreams[1].requests)).To(BeEquivalentTo(12))
+	})
+	It("should only fall back for non-idempotent requests if the upstream is unreachable", func() {
+		upstreams := []*upstream{newUpstream(), newUpstream()}
+		upstreams[0].server.Close()
+		app := newApp(upstreams)
+		for i := 0; i < 4; i++ {
+			Expect(send(app, http.MethodPost)).To(Equal(http.StatusOK))
+		}
+		Expect(atomic.LoadUint32(&upstreams[1].requests)).To(BeEquivalentTo(4))
+	})
+	It("should skip upstreams which are failing health checks", func() {
+		upstreams := []*upstream{newUpstream(), newUpstream()}
+		atomic.StoreUint32(&upstreams[0].healthy, 0)
+		ctx, ca := context.WithCancel(context.Background())
+		DeferCleanup(ca)
+		app := newApp(upstreams, fwd.WithActiveHealthCheck(ctx, "/ready", 50*time.Millisecond))
+
+		// upstreams are assumed to be healthy until the first probe completes
+		Eventually(func() uint32 {
+			before := atomic.LoadUint32(&upstreams[0].requests)
+			for i := 0; i < 4; i++ {
+				send(app, http.MethodGet)
+			}
+			return atomic.LoadUint32(&upstreams[0].requests) - before
+		}).Should(BeZero())
+		before := atomic.LoadUint32(&upstreams[1].requests)
+		for i := 0; i < 10; i++ {
+			Expect(send(app, http.MethodGet)).To(Equal(http.StatusOK))
+		}
+		Expect(atomic.LoadUint32(&upstreams[1].requests) - before).To(BeEquivalentTo(10))
+
+		atomic.StoreUint32(&upstreams[1].healthy, 0)
+		Eventually(func() (int, error) {
+			return send(app, http.MethodGet)
+		}).Should(Equal(http.StatusServiceUnavailable))
+
+		atomic.StoreUint32(&upstreams[0].healthy, 1)
+		Eventually(func() (int, error) {
+			return send(app, http.MethodGet)
+		}).Should(Equal(http.StatusOK))
+	})
+})
+
+var _ = Describe("Metrics", Label(test.Unit), func() {
+	var addr string
+	BeforeEach(func() {
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.Copy(io.Discard, r.Body)
+			if r.URL.Path == "/missing" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("response"))
+		}))
+		DeferCleanup(upstream.Close)
+		addr = strings.TrimPrefix(upstream.URL, "http://")
+	})
+
+	// metricValue scrapes the registry and returns the value of the metric
+	// with the given name and labels. Histograms return their sample count.
+	metricValue := func(reg *prometheus.Registry, name string, labels map[string]string) float64 {
+		families, err := reg.Gather()
+		Expect(err).NotTo(HaveOccurred())
+		for _, family := range families {
+			if family.GetName() != name {
+				continue
+			}
+		METRICS:
+			for _, m := range family.GetMetric() {
+				for _, lp := range m.GetLabel() {
+					if v, ok := labels[lp.GetName()]; ok && v != lp.GetValue() {
+						continue METRICS
+					}
+				}
+				switch family.GetType() {
+				case dto.MetricType_COUNTER:
+					return m.GetCounter().GetValue()
+				case dto.MetricType_HISTOGRAM:
+					return float64(m.GetHistogram().GetSampleCount())
+				}
+			}
+		}
+		return 0
+	}
+
+	It("should record forwarded requests", func() {
+		reg := prometheus.NewRegistry()
+		app := fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		app.All("/*", fwd.To(addr, fwd.WithName("metrics-test"), fwd.WithMetrics(reg)))
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/foo", strings.NewReader("request body")), -1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/missing", nil), -1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+
+		name := map[string]string{"name": "metrics-test"}
+		Expect(metricValue(reg, "opni_fwd_requests_total",
+			map[string]string{"name": "metrics-test", "code": "200"})).To(Equal(1.0))
+		Expect(metricValue(reg, "opni_fwd_requests_total",
+			map[string]string{"name": "metrics-test", "code": "404"})).To(Equal(1.0))
+		Expect(metricValue(reg, "opni_fwd_request_bytes_total", name)).To(BeEquivalentTo(len("request body")))
+		Expect(metricValue(reg, "opni_fwd_response_bytes_total", name)).To(BeEquivalentTo(len("response")))
+		Expect(metricValue(reg, "opni_fwd_upstream_latency_seconds", name)).To(Equal(2.0))
+	})
+	It("should record failed requests", func() {
+		reg := prometheus.NewRegistry()
+		app := fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		l.Close()
+		app.All("/*", fwd.To(l.Addr().String(), fwd.WithName("metrics-error-test"), fwd.WithMetrics(reg)))
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/foo", nil), -1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+		Expect(metricValue(reg, "opni_fwd_requests_total",
+			map[string]string{"name": "metrics-error-test", "code": "error"})).To(Equal(1.0))
+	})
+	It("should allow multiple forwarders to register with the same registry", func() {
+		reg := prometheus.NewRegistry()
+		app := fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		app.All("/a/*", fwd.To(addr, fwd.WithName("metrics-shared"), fwd.WithMetrics(reg)))
+		app.All("/b/*", fwd.To(addr, fwd.WithName("metrics-shared"), fwd.WithMetrics(reg)))
+		app.All("/c/*", fwd.To(addr, fwd.WithName("metrics-other"), fwd.WithMetrics(reg)))
+
+		for _, path := range []string{"/a/foo", "/b/foo", "/c/foo"} {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil), -1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		}
+		Expect(metricValue(reg, "opni_fwd_requests_total",
+			map[string]string{"name": "metrics-shared", "code": "200"})).To(Equal(2.0))
+		Expect(metricValue(reg, "opni_fwd_requests_total",
+			map[string]string{"name": "metrics-other", "code": "200"})).To(Equal(1.0))
+	})
+})
+
+var _ = Describe("Forwarded Headers", Label(test.Unit), func() {
+	var headers chan http.Header
+	var addr string
+	BeforeEach(func() {
+		headers = make(chan http.Header, 1)
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			headers <- r.Header.Clone()
+			w.WriteHeader(http.StatusOK)
+		}))
+		DeferCleanup(upstream.Close)
+		addr = strings.TrimPrefix(upstream.URL, "http://")
+	})
+	send := func(req *http.Request, opts ...fwd.ForwarderOption) http.Header {
+		app := fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+		})
+		app.All("/*", fwd.To(addr, opts...))
+		resp, err := app.Test(req, -1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		var h http.Header
+		Eventually(headers).Should(Receive(&h))
+		return h
+	}
+	// app.Test connections have no real remote address
+	const clientIP = "0.0.0.0"
+
+	When("the request is the first hop", func() {
+		It("should set the forwarded headers from the request", func() {
+			req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+			req.Host = "example.com"
+			h := send(req, fwd.WithForwardedHeaders())
+			Expect(h.Get("X-Forwarded-For")).To(Equal(clientIP))
+			Expect(h.Get("X-Forwarded-Host")).To(Equal("example.com"))
+			Expect(h.Get("X-Forwarded-Proto")).To(Equal("http"))
+		})
+	})
+	When("the request has passed through other proxies", func() {
+		newRequest := func() *http.Request {
+			req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+			req.Host = "proxy.local"
+			req.Header.Set("X-Forwarded-For", "10.0.0.1, 10.0.0.2")
+			req.Header.Set("X-Forwarded-Host", "example.com")
+			req.Header.Set("X-Forwarded-Proto", "https")
+			return req
+		}
+		It("should append to the existing X-Forwarded-For chain", func() {
+			h := send(newRequest(), fwd.WithForwardedHeaders())
+			Expect(h.Get("X-Forwarded-For")).To(Equal("10.0.0.1, 10.0.0.2, " + clientIP))
+			Expect(h.Get("X-Forwarded-Host")).To(Equal("example.com"))
+			Expect(h.Get("X-Forwarded-Proto")).To(Equal("https"))
+		})
+		It("should replace the X-Forwarded-For chain by default", func() {
+			h := send(newRequest())
+			Expect(h.Get("X-Forwarded-For")).To(Equal(clientIP))
+		})
+	})
+})
+
+// patternReader generates size bytes of a repeating pattern without holding
+// them in memory.
+type patternReader struct {
+	offset, size int64
+}
+
+func (r *patternReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if remaining := r.size - r.offset; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	for i := range p {
+		p[i] = byte((r.offset + int64(i)) % 251)
+	}
+	r.offset += int64(len(p))
+	return len(p), nil
+}
+
+var _ = Describe("Streaming", Label(test.Unit, test.Slow), func() {
+	const size = 32 * 1024 * 1024
+	var addr string
+	var fwdAddr string
+	sum := func(r io.Reader) (string, int64) {
+		h := sha256.New()
+		n, err := io.Copy(h, r)
+		Expect(err).NotTo(HaveOccurred())
+		return hex.EncodeToString(h.Sum(nil)), n
+	}
+	BeforeEach(func() {
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h := sha256.New()
+			n, _ := io.Copy(h, r.Body)
+			w.Header().Set("X-Request-Sum", hex.EncodeToString(h.Sum(nil)))
+			w.Header().Set("X-Request-Bytes", strconv.FormatInt(n, 10))
+			w.WriteHeader(http.StatusAccepted)
+			io.Copy(w, &patternReader{size: size})
+		}))
+		DeferCleanup(upstream.Close)
+		addr = strings.TrimPrefix(upstream.URL, "http://")
+	})
+	listen := func(addrs []string, opts ...fwd.ForwarderOption) {
+		app := fiber.New(fiber.Config{
+			DisableStartupMessage: true,
+			StreamRequestBody:     true,
+		})
+		app.All("/*", fwd.ToBalanced(addrs, opts...))
+		listener, err := net.Listen("tcp4", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		go app.Listener(listener)
+		DeferCleanup(app.Shutdown)
+		fwdAddr = listener.Addr().String()
+	}
+	// sends a request with a body of the given size, and returns the response
+	// with its body fully read and checksummed
+	send := func(contentLength int64) (*http.Response, string, int64) {
+		client := &http.Client{
+			Transport: &http.Transport{},
+		}
+		defer client.CloseIdleConnections()
+		req, err := http.NewRequest(http.MethodPost, "http://"+fwdAddr+"/api/v1/push",
+			io.NopCloser(&patternReader{size: size}))
+		Expect(err).NotTo(HaveOccurred())
+		req.ContentLength = contentLength
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		respSum, n := sum(resp.Body)
+		return resp, respSum, n
+	}
+
+	It("should forward large bodies without buffering them", func() {
+		listen([]string{addr}, fwd.WithStreaming())
+		expectedSum, _ := sum(&patternReader{size: size})
+
+		for _, contentLength := range []int64{size, -1} {
+			runtime.GC()
+			var before, after runtime.MemStats
+			runtime.ReadMemStats(&before)
+			resp, respSum, n := send(contentLength)
+			runtime.ReadMemStats(&after)
+
+			Expect(resp.StatusCode).To(Equal(http.StatusAccepted))
+			Expect(resp.Header.Get("X-Request-Bytes")).To(Equal(strconv.Itoa(size)))
+			Expect(resp.Header.Get("X-Request-Sum")).To(Equal(expectedSum))
+			Expect(n).To(BeEquivalentTo(size))
+			Expect(respSum).To(Equal(expectedSum))
+			// both bodies together are 64MB; this includes allocations made by
+			// the client and upstream server
+			Expect(after.TotalAlloc - before.TotalAlloc).To(BeNumerically("<", 4*1024*1024))
+		}
+	})
+	It("should fall back to the next upstream if the first cannot be reached", func() {
+		closed, err := net.Listen("tcp4", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		closedAddr := closed.Addr().String()
+		closed.Close()
+		listen([]string{closedAddr, addr}, fwd.WithStreaming())
+
+		resp, _, n := send(size)
+		Expect(resp.StatusCode).To(Equal(http.StatusAccepted))
+		Expect(resp.Header.Get("X-Request-Bytes")).To(Equal(strconv.Itoa(size)))
+		Expect(n).To(BeEquivalentTo(size))
+	})
+})
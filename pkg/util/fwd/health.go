@@ -0,0 +1,119 @@
+package fwd
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"go.uber.org/zap"
+)
+
+const defaultHealthCheckInterval = 5 * time.Second
+
+// WithActiveHealthCheck enables periodic probing of the upstream's readiness
+// endpoint at the given path. While the upstream is unhealthy, requests are
+// rejected with 503 Service Unavailable instead of being forwarded. Once the
+// endpoint reports healthy again, requests are forwarded as normal. If the
+// interval is not positive, a default of 5 seconds is used.
+//
+// Probing stops once ctx is done, so ctx should be tied to the lifetime of the
+// handler returned by To or ToBalanced. After that, upstreams are considered
+// healthy again. If ctx is nil, context.Background() is used, and probing
+// never stops.
+func WithActiveHealthCheck(ctx context.Context, path string, interval time.Duration) ForwarderOption {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return func(o *ForwarderOptions) {
+		o.healthCheckCtx = ctx
+		o.healthCheckPath = path
+		o.healthCheckInterval = interval
+	}
+}
+
+// healthChecker probes an upstream's readiness endpoint in the background.
+// An upstream is considered healthy if the endpoint returns a 2xx status.
+type healthChecker struct {
+	client    *fasthttp.HostClient
+	path      string
+	interval  time.Duration
+	logger    *zap.SugaredLogger
+	unhealthy uint32
+}
+
+func newHealthChecker(
+	ctx context.Context,
+	client *fasthttp.HostClient,
+	path string,
+	interval time.Duration,
+	lg *zap.SugaredLogger,
+) *healthChecker {
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+	hc := &healthChecker{
+		client:   client,
+		path:     path,
+		interval: interval,
+		logger:   lg,
+	}
+	go hc.run(ctx)
+	return hc
+}
+
+func (hc *healthChecker) Healthy() bool {
+	return atomic.LoadUint32(&hc.unhealthy) == 0
+}
+
+func (hc *healthChecker) run(ctx context.Context) {
+	ticker := time.NewTicker(hc.interval)
+	defer ticker.Stop()
+	for {
+		hc.probe()
+		select {
+		case <-ctx.Done():
+			atomic.StoreUint32(&hc.unhealthy, 0)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (hc *healthChecker) probe() {
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	scheme := "http"
+	if hc.client.IsTLS {
+		scheme = "https"
+	}
+	req.SetRequestURI(scheme + "://" + hc.client.Addr + hc.path)
+	req.Header.SetMethod(fasthttp.MethodGet)
+
+	err := hc.client.DoTimeout(req, resp, hc.interval)
+	healthy := err == nil && resp.StatusCode()/100 == 2
+
+	if healthy {
+		if atomic.SwapUint32(&hc.unhealthy, 0) == 1 {
+			hc.logger.With(
+				"addr", hc.client.Addr,
+			).Info("upstream is healthy")
+		}
+		return
+	}
+	if atomic.SwapUint32(&hc.unhealthy, 1) == 0 {
+		lg := hc.logger.With(
+			"addr", hc.client.Addr,
+			"path", hc.path,
+		)
+		if err != nil {
+			lg = lg.With(zap.Error(err), "error_class", ClassifyError(err))
+		} else {
+			lg = lg.With("status", resp.StatusCode())
+		}
+		lg.Warn("upstream is unhealthy")
+	}
+}
@@ -0,0 +1,97 @@
+package fwd
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The collectors are shared by all forwarders, which are distinguished by
+// the "name" label, so that any number of forwarders can register them with
+// the same registry.
+var (
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "opni",
+		Subsystem: "fwd",
+		Name:      "requests_total",
+		Help:      "Total number of forwarded requests by upstream response status",
+	}, []string{"name", "code"})
+	requestBytesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "opni",
+		Subsystem: "fwd",
+		Name:      "request_bytes_total",
+		Help:      "Total number of request body bytes forwarded to the upstream",
+	}, []string{"name"})
+	responseBytesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "opni",
+		Subsystem: "fwd",
+		Name:      "response_bytes_total",
+		Help:      "Total number of response body bytes received from the upstream",
+	}, []string{"name"})
+	upstreamLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "opni",
+		Subsystem: "fwd",
+		Name:      "upstream_latency_seconds",
+		Help:      "Latency of requests to the upstream",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"name"})
+)
+
+// WithMetrics registers the forwarder metrics with the given registerer and
+// enables recording them. Metrics are labeled with the forwarder's name (see
+// WithName). Multiple forwarders can register with the same registerer.
+func WithMetrics(reg prometheus.Registerer) ForwarderOption {
+	return func(o *ForwarderOptions) {
+		o.metricsRegisterer = reg
+	}
+}
+
+func registerMetrics(reg prometheus.Registerer) error {
+	for _, c := range []prometheus.Collector{
+		requestsTotal,
+		requestBytesTotal,
+		responseBytesTotal,
+		upstreamLatency,
+	} {
+		if err := reg.Register(c); err != nil {
+			var are prometheus.AlreadyRegisteredError
+			if errors.As(err, &are) && are.ExistingCollector == c {
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// forwarderMetrics holds the metrics for a single named forwarder.
+type forwarderMetrics struct {
+	name          string
+	requestBytes  prometheus.Counter
+	responseBytes prometheus.Counter
+	latency       prometheus.Observer
+}
+
+func newForwarderMetrics(name string) *forwarderMetrics {
+	name = strings.TrimSpace(name)
+	return &forwarderMetrics{
+		name:          name,
+		requestBytes:  requestBytesTotal.WithLabelValues(name),
+		responseBytes: responseBytesTotal.WithLabelValues(name),
+		latency:       upstreamLatency.WithLabelValues(name),
+	}
+}
+
+// ObserveRequest records a forwarded request. If the request failed, err
+// should be non-nil, and the response is ignored.
+func (m *forwarderMetrics) ObserveRequest(code int, requestBytes, responseBytes int, err error) {
+	if err != nil {
+		requestsTotal.WithLabelValues(m.name, "error").Inc()
+		return
+	}
+	requestsTotal.WithLabelValues(m.name, strconv.Itoa(code)).Inc()
+	m.requestBytes.Add(float64(requestBytes))
+	m.responseBytes.Add(float64(responseBytes))
+}
@@ -0,0 +1,65 @@
+package fwd
+
+import (
+	"bytes"
+
+	"github.com/valyala/fasthttp"
+)
+
+type queryParamHeader struct {
+	param  string
+	header string
+	prefix string
+}
+
+// WithQueryParamHeader moves the value of the named query parameter into the
+// given request header before forwarding, prepending prefix to the value
+// (e.g. "Bearer "). The parameter is always removed from the forwarded URL,
+// so credentials passed in query parameters do not appear in upstream access
+// logs. If header is empty, the parameter is removed without setting a
+// header. This option can be specified multiple times.
+func WithQueryParamHeader(param, header, prefix string) ForwarderOption {
+	return func(o *ForwarderOptions) {
+		o.queryParamHeaders = append(o.queryParamHeaders, queryParamHeader{
+			param:  param,
+			header: header,
+			prefix: prefix,
+		})
+	}
+}
+
+// moveQueryParamsToHeaders applies the query parameter mappings to the
+// request. The request URI is rewritten directly, rather than through
+// req.URI(), so that the path is forwarded exactly as it was received.
+func moveQueryParamsToHeaders(req *fasthttp.Request, mappings []queryParamHeader) {
+	uri := req.RequestURI()
+	idx := bytes.IndexByte(uri, '?')
+	if idx < 0 {
+		return
+	}
+	path, query := uri[:idx], uri[idx+1:]
+	args := fasthttp.AcquireArgs()
+	defer fasthttp.ReleaseArgs(args)
+	args.ParseBytes(query)
+
+	modified := false
+	for _, m := range mappings {
+		if !args.Has(m.param) {
+			continue
+		}
+		if m.header != "" {
+			req.Header.Set(m.header, m.prefix+string(args.Peek(m.param)))
+		}
+		args.Del(m.param)
+		modified = true
+	}
+	if !modified {
+		return
+	}
+	newURI := append([]byte(nil), path...)
+	if args.Len() > 0 {
+		newURI = append(newURI, '?')
+		newURI = args.AppendBytes(newURI)
+	}
+	req.SetRequestURIBytes(newURI)
+}
@@ -0,0 +1,182 @@
+package fwd
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/rancher/opni-monitoring/pkg/util/backoff"
+	"github.com/valyala/fasthttp"
+	"go.uber.org/zap"
+)
+
+// WithRetry enables retrying requests which fail to reach the upstream
+// server, for example because the connection was reset or the upstream is
+// restarting. Each request is attempted at most maxAttempts times, waiting
+// with exponential backoff and jitter between attempts, starting at the given
+// backoff duration. Only idempotent requests (GET, HEAD, and OPTIONS) are
+// retried. Retries stop once the request's context is canceled or its
+// deadline is exceeded, and the last error is returned.
+//
+// Request bodies are buffered in memory before the first attempt so that
+// they can be re-sent, up to the limit set by WithMaxBufferedBodySize;
+// requests with larger bodies are not retried. Responses with an error
+// status are not retried, unless their status is listed using
+// WithRetryOnStatus.
+func WithRetry(maxAttempts int, backoff time.Duration) ForwarderOption {
+	return func(o *ForwarderOptions) {
+		o.retryMaxAttempts = maxAttempts
+		o.retryBackoff = backoff
+	}
+}
+
+// WithRetryOnStatus enables retrying idempotent requests (GET, HEAD, and
+// OPTIONS) when the upstream server replies with one of the given status
+// codes, such as 429 or 503. Requests using other methods are never retried.
+//
+// If the response has a Retry-After header, the next attempt is delayed by
+// at least the duration it specifies. If the request's deadline would be
+// exceeded before then, the response is returned without retrying.
+//
+// The number of attempts and the backoff between them are set by WithRetry.
+// If WithRetry is not used, requests are attempted at most
+// DefaultRetryMaxAttempts times, starting at DefaultRetryBackoff. Once
+// attempts are exhausted, the last upstream response is returned.
+func WithRetryOnStatus(codes ...int) ForwarderOption {
+	return func(o *ForwarderOptions) {
+		if o.retryStatusCodes == nil {
+			o.retryStatusCodes = map[int]struct{}{}
+		}
+		for _, code := range codes {
+			o.retryStatusCodes[code] = struct{}{}
+		}
+	}
+}
+
+const (
+	DefaultRetryMaxAttempts = 3
+	DefaultRetryBackoff     = 100 * time.Millisecond
+)
+
+func isRetryable(req *fasthttp.Request) bool {
+	return req.Header.IsGet() || req.Header.IsHead() || req.Header.IsOptions()
+}
+
+func (o *ForwarderOptions) retryEnabled() bool {
+	return o.retryMaxAttempts > 1 || len(o.retryStatusCodes) > 0
+}
+
+func (o *ForwarderOptions) retryPolicy() backoff.Policy {
+	policy := backoff.Policy{
+		Base:        o.retryBackoff,
+		Multiplier:  2,
+		Jitter:      0.2,
+		MaxAttempts: o.retryMaxAttempts,
+	}
+	if policy.MaxAttempts == 0 {
+		policy.MaxAttempts = DefaultRetryMaxAttempts
+	}
+	if policy.Base == 0 {
+		policy.Base = DefaultRetryBackoff
+	}
+	return policy
+}
+
+func (o *ForwarderOptions) shouldRetryStatus(code int) bool {
+	_, ok := o.retryStatusCodes[code]
+	return ok
+}
+
+// retryAfter parses the response's Retry-After header, which can either be
+// a number of seconds or an HTTP date. It returns 0 if the header is missing
+// or invalid.
+func retryAfter(resp *fasthttp.Response, now time.Time) time.Duration {
+	value := resp.Header.Peek(fasthttp.HeaderRetryAfter)
+	if len(value) == 0 {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(string(value)); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := fasthttp.ParseHTTPDate(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// do sends the request to the upstream server, retrying according to the
+// configured retry policy.
+func (o *ForwarderOptions) do(
+	ctx context.Context,
+	client *fasthttp.HostClient,
+	req *fasthttp.Request,
+	resp *fasthttp.Response,
+) error {
+	if !o.retryEnabled() || !isRetryable(req) {
+		return client.Do(req, resp)
+	}
+	clone, err := cloneRequest(req, o.maxBufferedBodySize)
+	if err != nil {
+		o.logger.With(
+			zap.Error(err),
+			"req", string(req.URI().Path()),
+		).Debug("request will not be retried")
+		return client.Do(req, resp)
+	}
+
+	policy := o.retryPolicy()
+	b := policy.Start()
+	for attempt := 1; ; attempt++ {
+		attemptReq := clone.Request()
+		if deadline, ok := ctx.Deadline(); ok {
+			err = client.DoDeadline(attemptReq, resp, deadline)
+		} else {
+			err = client.Do(attemptReq, resp)
+		}
+		fasthttp.ReleaseRequest(attemptReq)
+
+		var delay time.Duration
+		switch {
+		case err != nil:
+			if ctx.Err() != nil || o.retryMaxAttempts <= 1 {
+				return err
+			}
+			delay = b.Next()
+		case o.shouldRetryStatus(resp.StatusCode()):
+			delay = b.Next()
+			if after := retryAfter(resp, time.Now()); after > delay {
+				delay = after
+			}
+		default:
+			return nil
+		}
+		if attempt >= policy.MaxAttempts {
+			return err
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			return err
+		}
+		o.logger.With(
+			zap.Error(err),
+			"error_class", ClassifyError(err),
+			"status", resp.StatusCode(),
+			"req", string(req.URI().Path()),
+			"attempt", attempt+1,
+		).Debug("retrying request")
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if err == nil {
+				// keep the last upstream response
+				return nil
+			}
+			return err
+		case <-timer.C:
+		}
+	}
+}
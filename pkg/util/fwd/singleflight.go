@@ -0,0 +1,62 @@
+package fwd
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+	"golang.org/x/sync/singleflight"
+)
+
+// WithSingleFlight coalesces concurrent identical GET and HEAD requests into
+// a single request to the upstream server, whose response is shared by all
+// of the coalesced requests. Requests are identical if keyFunc returns the
+// same key for them. keyFunc is called after all headers have been set on the
+// outgoing request, and must include everything which affects the response,
+// such as the tenant ID header, in the key.
+//
+// Only requests which are in flight at the same time are coalesced; responses
+// are not cached.
+func WithSingleFlight(keyFunc func(*fiber.Ctx) string) ForwarderOption {
+	return func(o *ForwarderOptions) {
+		o.singleFlightKey = keyFunc
+	}
+}
+
+type singleFlight struct {
+	group   singleflight.Group
+	keyFunc func(*fiber.Ctx) string
+}
+
+func newSingleFlight(keyFunc func(*fiber.Ctx) string) *singleFlight {
+	return &singleFlight{
+		keyFunc: keyFunc,
+	}
+}
+
+// Do calls fn to send the request unless an identical request is already in
+// flight, and copies the response into resp. Requests with methods other than
+// GET and HEAD are always sent.
+func (s *singleFlight) Do(
+	c *fiber.Ctx,
+	resp *fasthttp.Response,
+	fn func(*fasthttp.Response) error,
+) error {
+	req := c.Request()
+	if !req.Header.IsGet() && !req.Header.IsHead() {
+		return fn(resp)
+	}
+	key := string(req.Header.Method()) + " " + s.keyFunc(c)
+	shared, err, _ := s.group.Do(key, func() (interface{}, error) {
+		// the response is shared between requests, so it cannot be
+		// returned to the pool
+		r := &fasthttp.Response{}
+		if err := fn(r); err != nil {
+			return nil, err
+		}
+		return r, nil
+	})
+	if err != nil {
+		return err
+	}
+	shared.(*fasthttp.Response).CopyTo(resp)
+	return nil
+}
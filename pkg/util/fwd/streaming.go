@@ -0,0 +1,108 @@
+package fwd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/valyala/fasthttp"
+)
+
+// WithStreaming enables streaming of request and response bodies, so that
+// large transfers such as remote-write payloads and streamed query results
+// are not buffered in memory. Request bodies are only streamed if the fiber
+// app was created with StreamRequestBody enabled; otherwise they have already
+// been read by the time the forwarder sees them.
+//
+// Streamed bodies can only be read once, so in streaming mode:
+//   - requests are not retried, and WithRetry is ignored. A request is only
+//     sent to another upstream if the connection to the first could not be
+//     established.
+//   - requests are not coalesced, and WithSingleFlight is ignored.
+//   - bodies are not logged, and WithBodyLogging is ignored.
+//   - the read timeout only limits how long to wait for the response headers.
+//   - request and response sizes are taken from the Content-Length headers,
+//     and are not recorded in metrics if they are unknown.
+func WithStreaming() ForwarderOption {
+	return func(o *ForwarderOptions) {
+		o.streaming = true
+	}
+}
+
+// The fasthttp client always reads the full response body into memory, so
+// streamed requests are sent using net/http instead.
+func newStreamingTransport(options *ForwarderOptions) *http.Transport {
+	return &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: fasthttp.DefaultDialTimeout,
+		}).DialContext,
+		TLSClientConfig:       options.tlsConfig,
+		MaxConnsPerHost:       options.maxConns,
+		MaxIdleConnsPerHost:   options.maxConns,
+		IdleConnTimeout:       fasthttp.DefaultMaxIdleConnDuration,
+		ResponseHeaderTimeout: options.readTimeout,
+		// responses must be relayed as-is
+		DisableCompression: true,
+	}
+}
+
+// doStreaming sends the request to the upstream, streaming the request body
+// from the incoming request (if it is a stream), and setting the response body
+// to a stream which reads from the upstream connection.
+func (u *upstream) doStreaming(
+	ctx context.Context,
+	scheme string,
+	req *fasthttp.Request,
+	bodyStream io.Reader,
+	resp *fasthttp.Response,
+) error {
+	if bodyStream == nil {
+		bodyStream = bytes.NewReader(req.Body())
+	}
+	url := scheme + "://" + u.addr + string(req.URI().RequestURI())
+	httpReq, err := http.NewRequestWithContext(ctx, string(req.Header.Method()), url, io.NopCloser(bodyStream))
+	if err != nil {
+		return err
+	}
+	httpReq.Host = string(req.Host())
+	httpReq.ContentLength = int64(req.Header.ContentLength())
+	if httpReq.ContentLength == 0 {
+		httpReq.Body = http.NoBody
+	}
+	req.Header.VisitAll(func(key, value []byte) {
+		httpReq.Header.Add(string(key), string(value))
+	})
+	if httpReq.Header.Get(fasthttp.HeaderUserAgent) == "" {
+		// prevent net/http from adding its own user agent
+		httpReq.Header.Set(fasthttp.HeaderUserAgent, "")
+	}
+
+	httpResp, err := u.streamingTransport.RoundTrip(httpReq)
+	if err != nil {
+		return err
+	}
+	resp.Reset()
+	resp.SetStatusCode(httpResp.StatusCode)
+	for key, values := range httpResp.Header {
+		if key == fasthttp.HeaderContentLength {
+			continue
+		}
+		for _, value := range values {
+			resp.Header.Add(key, value)
+		}
+	}
+	// the body is closed by fasthttp once it has been written to the client
+	resp.SetBodyStream(httpResp.Body, int(httpResp.ContentLength))
+	return nil
+}
+
+// contentLength returns the value of the Content-Length header, or 0 if the
+// length is unknown.
+func contentLength(h interface{ ContentLength() int }) int {
+	if n := h.ContentLength(); n > 0 {
+		return n
+	}
+	return 0
+}
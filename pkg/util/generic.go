@@ -90,6 +90,16 @@ func (f *Future[T]) Get() T {
 	return f.object
 }
 
+// IsSet reports whether the future's value has been set.
+func (f *Future[T]) IsSet() bool {
+	select {
+	case <-f.wait:
+		return true
+	default:
+		return false
+	}
+}
+
 func (f *Future[T]) GetContext(ctx context.Context) (_ T, err error) {
 	select {
 	case <-f.wait:
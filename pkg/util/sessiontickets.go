@@ -0,0 +1,72 @@
+package util
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"sync"
+	"time"
+)
+
+// SessionTicketKeyRotator manages the session ticket keys for a server's TLS
+// config. New sessions are always encrypted with the newest key, while
+// sessions encrypted with up to RetainedKeys previous keys can still be
+// resumed after a rotation.
+type SessionTicketKeyRotator struct {
+	config   *tls.Config
+	retained int
+	mu       sync.Mutex
+	keys     [][32]byte
+}
+
+// NewSessionTicketKeyRotator generates an initial session ticket key and
+// configures the given TLS config to use it. This disables the automatic key
+// rotation performed by crypto/tls.
+func NewSessionTicketKeyRotator(config *tls.Config, retainedKeys int) (*SessionTicketKeyRotator, error) {
+	if retainedKeys < 0 {
+		retainedKeys = 0
+	}
+	r := &SessionTicketKeyRotator{
+		config:   config,
+		retained: retainedKeys,
+	}
+	if err := r.Rotate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Rotate generates a new session ticket key and discards the oldest key if
+// more than RetainedKeys previous keys are held.
+func (r *SessionTicketKeyRotator) Rotate() error {
+	var key [32]byte
+	if _, err := rand.Read(key[:]); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	keys := append([][32]byte{key}, r.keys...)
+	if len(keys) > r.retained+1 {
+		keys = keys[:r.retained+1]
+	}
+	r.keys = keys
+	r.config.SetSessionTicketKeys(keys)
+	return nil
+}
+
+// Run rotates the session ticket keys at the given interval until the
+// context is canceled. Errors from Rotate are passed to onError, if set.
+func (r *SessionTicketKeyRotator) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Rotate(); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package util_test
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/pkg/util"
+)
+
+var _ = Describe("Session Ticket Key Rotation", Ordered, Label(test.Unit), func() {
+	var rotator *util.SessionTicketKeyRotator
+	var addr string
+	var clientConfig *tls.Config
+	BeforeAll(func() {
+		cert, err := tls.X509KeyPair(test.TestData("localhost.crt"), test.TestData("localhost.key"))
+		Expect(err).NotTo(HaveOccurred())
+		serverConfig := &tls.Config{
+			Certificates: []tls.Certificate{cert},
+		}
+		rotator, err = util.NewSessionTicketKeyRotator(serverConfig, 1)
+		Expect(err).NotTo(HaveOccurred())
+
+		listener, err := tls.Listen("tcp4", "127.0.0.1:0", serverConfig)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(listener.Close)
+		addr = listener.Addr().String()
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				conn.Write([]byte("x"))
+				conn.Close()
+			}
+		}()
+
+		clientConfig = &tls.Config{
+			InsecureSkipVerify: true,
+			ServerName:         "localhost",
+			ClientSessionCache: tls.NewLRUClientSessionCache(1),
+		}
+	})
+
+	connect := func() (resumed bool) {
+		conn, err := tls.DialWithDialer(&net.Dialer{}, "tcp4", addr, clientConfig)
+		Expect(err).NotTo(HaveOccurred())
+		defer conn.Close()
+		// reading ensures any session tickets sent by the server are processed
+		_, err = io.ReadAll(conn)
+		Expect(err).NotTo(HaveOccurred())
+		return conn.ConnectionState().DidResume
+	}
+
+	It("should resume sessions on reconnect", func() {
+		Expect(connect()).To(BeFalse())
+		Expect(connect()).To(BeTrue())
+	})
+	It("should resume sessions using a retained key after rotation", func() {
+		Expect(rotator.Rotate()).To(Succeed())
+		Expect(connect()).To(BeTrue())
+	})
+	It("should not resume sessions once the key is no longer retained", func() {
+		Expect(rotator.Rotate()).To(Succeed())
+		Expect(rotator.Rotate()).To(Succeed())
+		Expect(connect()).To(BeFalse())
+		Expect(connect()).To(BeTrue())
+	})
+})
@@ -20,6 +20,7 @@ var (
 	ErrInvalidRoleName      = Errorf("role names %s, and %s", nameConstraint, lengthConstraint(64))
 	ErrInvalidSubjectName   = Errorf("subject names %s and %s", lengthConstraint(256), annoyingCharactersConstraint)
 	ErrInvalidID            = Errorf("ids %s, and %s", nameConstraint, lengthConstraint(128))
+	ErrAnnotationsTooLarge  = Errorf("total size of annotations must not exceed %d bytes", MaxAnnotationsSize)
 
 	labelNameRegex   = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9-_./]{0,63}$`)
 	labelValueRegex  = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9-_.]{0,63}$`)
@@ -27,6 +28,10 @@ var (
 	subjectNameRegex = regexp.MustCompile(`^[^\\*"'\s]{1,256}$`)
 )
 
+// MaxAnnotationsSize is the maximum combined size of all annotation keys and
+// values on a single object.
+const MaxAnnotationsSize = 64 * 1024
+
 type Validator interface {
 	Validate() error
 }
@@ -43,6 +48,23 @@ func ValidateLabels(labels map[string]string) error {
 	return nil
 }
 
+// ValidateAnnotations checks that annotation keys are valid label names.
+// Annotation values are free-form, but the total size of all keys and values
+// is limited to MaxAnnotationsSize.
+func ValidateAnnotations(annotations map[string]string) error {
+	size := 0
+	for k, v := range annotations {
+		if err := ValidateLabelName(k); err != nil {
+			return err
+		}
+		size += len(k) + len(v)
+	}
+	if size > MaxAnnotationsSize {
+		return ErrAnnotationsTooLarge
+	}
+	return nil
+}
+
 func ValidateLabelName(name string) error {
 	if !labelNameRegex.MatchString(name) {
 		return ErrInvalidLabelName
@@ -157,6 +157,23 @@ var _ = Describe("Validation", Label(test.Unit), func() {
 		Entry(nil, map[string]string{"": "baz"}, validation.ErrInvalidLabelName),
 		Entry(nil, map[string]string{"foo": ""}, validation.ErrInvalidLabelValue),
 	)
+	DescribeTable("Annotation validation",
+		func(annotations map[string]string, err error) {
+			e := validation.ValidateAnnotations(annotations)
+			if err != nil {
+				Expect(e).To(MatchError(err))
+			} else {
+				Expect(e).To(BeNil())
+			}
+		},
+		Entry(nil, map[string]string{}, nil),
+		Entry(nil, map[string]string{"owner": "someone@example.com"}, nil),
+		Entry(nil, map[string]string{"example.com/ticket": "https://example.com/tickets/1234"}, nil),
+		Entry(nil, map[string]string{"description": "a free-form description, with spaces!"}, nil),
+		Entry(nil, map[string]string{"foo": ""}, nil),
+		Entry(nil, map[string]string{"foo.com!": "baz"}, validation.ErrInvalidLabelName),
+		Entry(nil, map[string]string{"foo": string(make([]byte, validation.MaxAnnotationsSize))}, validation.ErrAnnotationsTooLarge),
+	)
 	DescribeTable("Label name validation",
 		func(name string, err error) {
 			e := validation.ValidateLabelName(name)
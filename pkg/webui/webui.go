@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"sync"
 
+	"github.com/rancher/opni-monitoring/pkg/auth"
 	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
 	"github.com/rancher/opni-monitoring/pkg/logger"
 	"github.com/rancher/opni-monitoring/web"
@@ -35,10 +36,11 @@ func AddExtraHandler(path string, handler http.HandlerFunc) {
 }
 
 type WebUIServer struct {
-	config *v1beta1.GatewayConfig
-	server *http.Server
-	mu     sync.Mutex
-	logger *zap.SugaredLogger
+	config         *v1beta1.GatewayConfig
+	authMiddleware auth.NamedMiddleware
+	server         *http.Server
+	mu             sync.Mutex
+	logger         *zap.SugaredLogger
 }
 
 func NewWebUIServer(config *v1beta1.GatewayConfig) (*WebUIServer, error) {
@@ -48,10 +50,18 @@ func NewWebUIServer(config *v1beta1.GatewayConfig) (*WebUIServer, error) {
 	if config.Spec.Management.WebListenAddress == "" {
 		return nil, errors.New("management.webListenAddress not set in config")
 	}
-	return &WebUIServer{
+	ws := &WebUIServer{
 		config: config,
 		logger: logger.New().Named("webui"),
-	}, nil
+	}
+	if name := config.Spec.RouteAuthProviders.ManagementWeb; name != "" {
+		mw, err := auth.GetMiddleware(name)
+		if err != nil {
+			return nil, err
+		}
+		ws.authMiddleware = mw
+	}
+	return ws, nil
 }
 
 func (ws *WebUIServer) ListenAndServe() error {
@@ -67,8 +77,12 @@ func (ws *WebUIServer) ListenAndServe() error {
 	).Info("ui server starting")
 
 	mux := http.NewServeMux()
+	var handler http.Handler = mux
+	if ws.authMiddleware != nil {
+		handler = auth.HTTPMiddleware(ws.authMiddleware)(mux)
+	}
 	ws.server = &http.Server{
-		Handler: mux,
+		Handler: handler,
 	}
 
 	// 200.html (app entrypoint)
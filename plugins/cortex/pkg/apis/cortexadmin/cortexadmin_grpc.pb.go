@@ -27,6 +27,8 @@ type CortexAdminClient interface {
 	WriteMetrics(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*WriteResponse, error)
 	Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error)
 	QueryRange(ctx context.Context, in *QueryRangeRequest, opts ...grpc.CallOption) (*QueryResponse, error)
+	LoadRuleGroups(ctx context.Context, in *LoadRuleGroupsRequest, opts ...grpc.CallOption) (*LoadRuleGroupsResponse, error)
+	WatchThroughput(ctx context.Context, in *WatchThroughputRequest, opts ...grpc.CallOption) (CortexAdmin_WatchThroughputClient, error)
 }
 
 type cortexAdminClient struct {
@@ -73,6 +75,47 @@ func (c *cortexAdminClient) QueryRange(ctx context.Context, in *QueryRangeReques
 	return out, nil
 }
 
+func (c *cortexAdminClient) LoadRuleGroups(ctx context.Context, in *LoadRuleGroupsRequest, opts ...grpc.CallOption) (*LoadRuleGroupsResponse, error) {
+	out := new(LoadRuleGroupsResponse)
+	err := c.cc.Invoke(ctx, "/cortexadmin.CortexAdmin/LoadRuleGroups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cortexAdminClient) WatchThroughput(ctx context.Context, in *WatchThroughputRequest, opts ...grpc.CallOption) (CortexAdmin_WatchThroughputClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CortexAdmin_ServiceDesc.Streams[0], "/cortexadmin.CortexAdmin/WatchThroughput", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &cortexAdminWatchThroughputClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CortexAdmin_WatchThroughputClient interface {
+	Recv() (*ThroughputUpdate, error)
+	grpc.ClientStream
+}
+
+type cortexAdminWatchThroughputClient struct {
+	grpc.ClientStream
+}
+
+func (x *cortexAdminWatchThroughputClient) Recv() (*ThroughputUpdate, error) {
+	m := new(ThroughputUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // CortexAdminServer is the server API for CortexAdmin service.
 // All implementations must embed UnimplementedCortexAdminServer
 // for forward compatibility
@@ -81,6 +124,8 @@ type CortexAdminServer interface {
 	WriteMetrics(context.Context, *WriteRequest) (*WriteResponse, error)
 	Query(context.Context, *QueryRequest) (*QueryResponse, error)
 	QueryRange(context.Context, *QueryRangeRequest) (*QueryResponse, error)
+	LoadRuleGroups(context.Context, *LoadRuleGroupsRequest) (*LoadRuleGroupsResponse, error)
+	WatchThroughput(*WatchThroughputRequest, CortexAdmin_WatchThroughputServer) error
 	mustEmbedUnimplementedCortexAdminServer()
 }
 
@@ -100,6 +145,12 @@ func (UnimplementedCortexAdminServer) Query(context.Context, *QueryRequest) (*Qu
 func (UnimplementedCortexAdminServer) QueryRange(context.Context, *QueryRangeRequest) (*QueryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryRange not implemented")
 }
+func (UnimplementedCortexAdminServer) LoadRuleGroups(context.Context, *LoadRuleGroupsRequest) (*LoadRuleGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LoadRuleGroups not implemented")
+}
+func (UnimplementedCortexAdminServer) WatchThroughput(*WatchThroughputRequest, CortexAdmin_WatchThroughputServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchThroughput not implemented")
+}
 func (UnimplementedCortexAdminServer) mustEmbedUnimplementedCortexAdminServer() {}
 
 // UnsafeCortexAdminServer may be embedded to opt out of forward compatibility for this service.
@@ -185,6 +236,45 @@ func _CortexAdmin_QueryRange_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CortexAdmin_LoadRuleGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadRuleGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CortexAdminServer).LoadRuleGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortexadmin.CortexAdmin/LoadRuleGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CortexAdminServer).LoadRuleGroups(ctx, req.(*LoadRuleGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CortexAdmin_WatchThroughput_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchThroughputRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CortexAdminServer).WatchThroughput(m, &cortexAdminWatchThroughputServer{stream})
+}
+
+type CortexAdmin_WatchThroughputServer interface {
+	Send(*ThroughputUpdate) error
+	grpc.ServerStream
+}
+
+type cortexAdminWatchThroughputServer struct {
+	grpc.ServerStream
+}
+
+func (x *cortexAdminWatchThroughputServer) Send(m *ThroughputUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // CortexAdmin_ServiceDesc is the grpc.ServiceDesc for CortexAdmin service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -208,7 +298,17 @@ var CortexAdmin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "QueryRange",
 			Handler:    _CortexAdmin_QueryRange_Handler,
 		},
+		{
+			MethodName: "LoadRuleGroups",
+			Handler:    _CortexAdmin_LoadRuleGroups_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchThroughput",
+			Handler:       _CortexAdmin_WatchThroughput_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "plugins/cortex/pkg/apis/cortexadmin/cortexadmin.proto",
 }
@@ -12,11 +12,14 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cortexproject/cortex/pkg/cortexpb"
 	"github.com/cortexproject/cortex/pkg/distributor/distributorpb"
 	"github.com/cortexproject/cortex/pkg/ingester/client"
+	"github.com/prometheus/prometheus/model/rulefmt"
+	"github.com/rancher/opni-monitoring/pkg/management"
 	"github.com/rancher/opni-monitoring/plugins/cortex/pkg/apis/cortexadmin"
 	"github.com/samber/lo"
 	"google.golang.org/grpc"
@@ -25,6 +28,7 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"gopkg.in/yaml.v3"
 )
 
 // this is 'github.com/cortexproject/cortex/pkg/distributor.UserIDStats'
@@ -279,3 +283,97 @@ func (p *Plugin) QueryRange(
 func formatTime(t time.Time) string {
 	return strconv.FormatFloat(float64(t.Unix())+float64(t.Nanosecond())/1e9, 'f', -1, 64)
 }
+
+// maxConcurrentRuleGroupLoads is the maximum number of requests sent to the
+// ruler at once when loading a rule group for multiple clusters.
+const maxConcurrentRuleGroupLoads = 10
+
+func (p *Plugin) LoadRuleGroups(
+	ctx context.Context,
+	in *cortexadmin.LoadRuleGroupsRequest,
+) (*cortexadmin.LoadRuleGroupsResponse, error) {
+	lg := p.logger.With(
+		"namespace", in.Namespace,
+	)
+	if in.Namespace == "" {
+		return nil, status.Error(codes.InvalidArgument, "namespace is required")
+	}
+	ruleGroup := rulefmt.RuleGroup{}
+	if err := yaml.Unmarshal(in.YamlContent, &ruleGroup); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid rule group: %v", err)
+	}
+	if ruleGroup.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "rule group name is required")
+	}
+	clusters, err := p.mgmtApi.Get().ListClusters(ctx, &management.ListClustersRequest{
+		MatchLabels:  in.MatchLabels,
+		MatchOptions: in.MatchOptions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Failures are reported per-cluster, and do not prevent the rule group
+	// from being loaded for the remaining clusters.
+	results := make([]*cortexadmin.LoadRuleGroupsResult, len(clusters.Items))
+	sem := make(chan struct{}, maxConcurrentRuleGroupLoads)
+	var wg sync.WaitGroup
+	for i, cluster := range clusters.Items {
+		i, id := i, cluster.GetId()
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			result := &cortexadmin.LoadRuleGroupsResult{
+				ClusterID: id,
+			}
+			if err := p.loadRuleGroup(ctx, id, in.Namespace, in.YamlContent); err != nil {
+				lg.With(
+					"cluster", id,
+					"error", err,
+				).Error("failed to load rule group")
+				result.Error = err.Error()
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+	lg.With(
+		"group", ruleGroup.Name,
+		"clusters", len(results),
+	).Info("loaded rule group")
+	return &cortexadmin.LoadRuleGroupsResponse{
+		Results: results,
+	}, nil
+}
+
+func (p *Plugin) loadRuleGroup(
+	ctx context.Context,
+	clusterID string,
+	namespace string,
+	yamlContent []byte,
+) error {
+	client := p.cortexHttpClient.Get()
+	req, err := http.NewRequestWithContext(ctx, "POST",
+		fmt.Sprintf("https://%s/api/v1/rules/%s", p.config.Get().Spec.Cortex.Ruler.HTTPAddress,
+			url.PathEscape(namespace)),
+		bytes.NewReader(yamlContent))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/yaml")
+	req.Header.Set(orgIDCodec.Key(), orgIDCodec.Encode([]string{clusterID}))
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("cortex ruler returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
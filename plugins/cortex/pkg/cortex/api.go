@@ -1,15 +1,22 @@
 package cortex
 
 import (
+	"context"
 	"os"
 	"strconv"
+	"time"
 
+	"github.com/cortexproject/cortex/pkg/cortexpb"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/limiter"
 	"github.com/rancher/opni-monitoring/pkg/auth"
 	"github.com/rancher/opni-monitoring/pkg/auth/cluster"
+	"github.com/rancher/opni-monitoring/pkg/capabilities"
+	"github.com/rancher/opni-monitoring/pkg/capabilities/wellknown"
+	"github.com/rancher/opni-monitoring/pkg/core"
 	"github.com/rancher/opni-monitoring/pkg/rbac"
 	"github.com/rancher/opni-monitoring/pkg/storage"
+	"github.com/rancher/opni-monitoring/pkg/util/backoff"
 	"github.com/rancher/opni-monitoring/pkg/util/fwd"
 )
 
@@ -29,7 +36,7 @@ type middlewares struct {
 func (p *Plugin) ConfigureRoutes(app *fiber.App) {
 	config := p.config.Get()
 
-	cortexTLSConfig := p.loadCortexCerts()
+	cortexTLSConfig := p.cortexTLSConfig.Get()
 
 	storageBackend := p.storageBackend.Get()
 	rbacProvider := storage.NewRBACProvider(storageBackend)
@@ -85,6 +92,35 @@ func (p *Plugin) configureAgentAPI(app *fiber.App, f *forwarders, m *middlewares
 	}), m.Cluster)
 	g.Post("/push", func(c *fiber.Ctx) error {
 		clusterID := cluster.AuthorizedID(c)
+		cl, ok := p.cachedCluster(clusterID)
+		if !ok {
+			return c.Status(fiber.StatusServiceUnavailable).
+				SendString("cluster state is not yet available")
+		}
+		if capabilities.Paused(cl, wellknown.CapabilityMetrics) {
+			return c.Status(fiber.StatusServiceUnavailable).
+				SendString("metrics capability is paused for this cluster")
+		}
+		body := c.Body()
+		var samples uint64
+		if req, err := decodeWriteRequest(body); err != nil {
+			p.logger.With(
+				"err", err,
+				"id", clusterID,
+			).Debug("failed to decode remote-write request")
+		} else {
+			samples = countSamples(req)
+			err := CheckSampleAge(req, cl.GetLimits(), time.Now())
+			cortexpb.ReuseSlice(req.Timeseries)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).SendString(err.Error())
+			}
+		}
+		if !p.ingestion.Allow(clusterID, cl.GetLimits(), int(samples)) {
+			return c.Status(fiber.StatusTooManyRequests).
+				SendString("ingestion rate limit exceeded for this cluster")
+		}
+		p.throughput.Record(clusterID, samples, uint64(len(body)))
 		len := c.Get("Content-Length", "0")
 		if i, err := strconv.ParseInt(len, 10, 64); err == nil && i > 0 {
 			flen := float64(i)
@@ -99,6 +135,33 @@ func (p *Plugin) configureAgentAPI(app *fiber.App, f *forwarders, m *middlewares
 	g.Post("/sync_rules", p.preprocessRules, f.Ruler)
 }
 
+// syncClusters keeps the cluster cache up to date with the storage backend,
+// until the plugin's context is done.
+func (p *Plugin) syncClusters() {
+	backoff.Retry(p.ctx, func(ctx context.Context) error {
+		err := p.clusters.Sync(ctx, p.storageBackend.Get())
+		if err != nil {
+			p.logger.With(
+				"err", err,
+			).Warn("failed to sync cluster cache")
+		}
+		return err
+	}, backoff.DefaultPolicy)
+}
+
+// cachedCluster returns the cluster with the given ID from the cluster cache,
+// which is used on the remote-write path instead of reading from storage. ok
+// is false if the cache has not been synced yet. If the cluster has not been
+// received from the cluster watch yet, such as just after it was created, nil
+// is returned, and its capabilities are assumed to be active.
+func (p *Plugin) cachedCluster(clusterID string) (_ *core.Cluster, ok bool) {
+	if !p.clusters.Synced() {
+		return nil, false
+	}
+	cl, _ := p.clusters.Get(clusterID)
+	return cl, true
+}
+
 func (p *Plugin) configureAlertmanager(app *fiber.App, f *forwarders, m *middlewares) {
 	orgIdLimiter := func(c *fiber.Ctx) error {
 		ids := rbac.AuthorizedClusterIDs(c)
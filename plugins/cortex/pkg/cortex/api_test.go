@@ -0,0 +1,147 @@
+package cortex_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cortexproject/cortex/pkg/cortexpb"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/snappy"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/capabilities/wellknown"
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/storage"
+	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/plugins/cortex/pkg/cortex"
+)
+
+type noGetClusterStore struct {
+	storage.ClusterStore
+}
+
+func (noGetClusterStore) GetCluster(context.Context, *core.Reference) (*core.Cluster, error) {
+	return nil, errors.New("unexpected call to GetCluster")
+}
+
+// writeRequest returns an encoded remote-write request containing a single
+// series with the given number of samples, all at time t.
+func writeRequest(samples int, t time.Time) []byte {
+	series := cortexpb.TimeSeries{
+		Labels: []cortexpb.LabelAdapter{
+			{Name: "__name__", Value: "test_metric"},
+		},
+	}
+	for i := 0; i < samples; i++ {
+		series.Samples = append(series.Samples, cortexpb.Sample{
+			Value:       float64(i),
+			TimestampMs: t.UnixMilli(),
+		})
+	}
+	req := &cortexpb.WriteRequest{
+		Timeseries: []cortexpb.PreallocTimeseries{
+			{TimeSeries: &series},
+		},
+	}
+	data, err := req.Marshal()
+	Expect(err).NotTo(HaveOccurred())
+	return snappy.Encode(nil, data)
+}
+
+var _ = Describe("Agent API", Label(test.Unit), func() {
+	var ctx context.Context
+	var backend storage.Backend
+	var app *fiber.App
+	BeforeEach(func() {
+		var ca context.CancelFunc
+		ctx, ca = context.WithCancel(context.Background())
+		DeferCleanup(ca)
+		inner := test.NewTestStorageBackend(ctx, gomock.NewController(GinkgoT())).(*storage.CompositeBackend)
+		// the push path must only read clusters from the cluster cache
+		inner.ClusterStore = noGetClusterStore{
+			ClusterStore: inner.ClusterStore,
+		}
+		backend = inner
+		app = cortex.NewAgentAPI(ctx, backend, func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusOK)
+		})
+	})
+
+	push := func(clusterID string, body []byte) (int, string) {
+		req := httptest.NewRequest("POST", "/api/agent/push", bytes.NewReader(body))
+		req.Header.Set(cortex.ClusterIDHeader, clusterID)
+		resp, err := app.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(data)
+	}
+
+	It("should reject pushes from paused clusters using the cluster cache", func() {
+		Expect(backend.CreateCluster(ctx, &core.Cluster{
+			Id: "cluster-1",
+			Metadata: &core.ClusterMetadata{
+				Capabilities: []*core.ClusterCapability{
+					{
+						Name:   wellknown.CapabilityMetrics,
+						Paused: true,
+					},
+				},
+			},
+		})).To(Succeed())
+		Eventually(func() string {
+			_, body := push("cluster-1", nil)
+			return body
+		}).Should(ContainSubstring("paused"))
+
+		_, err := backend.UpdateCluster(ctx, &core.Reference{Id: "cluster-1"}, func(c *core.Cluster) {
+			c.Metadata.Capabilities[0].Paused = false
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() int {
+			code, _ := push("cluster-1", nil)
+			return code
+		}).Should(Equal(fiber.StatusOK))
+	})
+
+	It("should apply ingestion limits from the cluster cache", func() {
+		Expect(backend.CreateCluster(ctx, &core.Cluster{
+			Id: "cluster-1",
+			Metadata: &core.ClusterMetadata{
+				Limits: &core.ClusterLimits{
+					IngestionRate: 10,
+				},
+			},
+		})).To(Succeed())
+		Eventually(func() int {
+			code, _ := push("cluster-1", writeRequest(10, time.Now()))
+			return code
+		}).Should(Equal(fiber.StatusTooManyRequests))
+		// other clusters are not limited
+		code, _ := push("cluster-2", writeRequest(10, time.Now()))
+		Expect(code).To(Equal(fiber.StatusOK))
+	})
+
+	It("should reject samples outside the age limits from the cluster cache", func() {
+		Expect(backend.CreateCluster(ctx, &core.Cluster{
+			Id: "cluster-1",
+			Metadata: &core.ClusterMetadata{
+				Limits: &core.ClusterLimits{
+					MaxSampleAgeSeconds: 60,
+				},
+			},
+		})).To(Succeed())
+		Eventually(func() int {
+			code, _ := push("cluster-1", writeRequest(1, time.Now().Add(-time.Hour)))
+			return code
+		}).Should(Equal(fiber.StatusBadRequest))
+		code, _ := push("cluster-1", writeRequest(1, time.Now()))
+		Expect(code).To(Equal(fiber.StatusOK))
+	})
+})
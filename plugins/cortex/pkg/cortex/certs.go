@@ -1,9 +1,23 @@
 package cortex
 
 import (
+	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/rancher/opni-monitoring/pkg/storage"
+)
+
+const (
+	clientCertKey            = "tls.crt"
+	clientKeyKey             = "tls.key"
+	clientCertReloadInterval = 30 * time.Second
 )
 
 func (p *Plugin) loadCortexCerts() *tls.Config {
@@ -15,12 +29,37 @@ func (p *Plugin) loadCortexCerts() *tls.Config {
 	cortexClientCert := config.Spec.Cortex.Certs.ClientCert
 	cortexClientKey := config.Spec.Cortex.Certs.ClientKey
 
-	clientCert, err := tls.LoadX509KeyPair(cortexClientCert, cortexClientKey)
-	if err != nil {
-		lg.With(
-			"err", err,
-		).Error("fatal: failed to load cortex client keypair")
-		os.Exit(1)
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if store := config.Spec.Cortex.Certs.ClientCertStore; store != "" {
+		kvStore, err := p.storageBackend.Get().KeyValueStore(store)
+		if err != nil {
+			lg.With(
+				"err", err,
+				"store", store,
+			).Error("fatal: failed to open cortex client cert store")
+			os.Exit(1)
+		}
+		reloader := NewClientCertReloader(kvStore)
+		if err := reloader.Reload(p.ctx); err != nil {
+			lg.With(
+				"err", err,
+				"store", store,
+			).Error("fatal: failed to load cortex client keypair")
+			os.Exit(1)
+		}
+		go reloader.Run(p.ctx, clientCertReloadInterval, lg)
+		tlsConfig.GetClientCertificate = reloader.GetClientCertificate
+	} else {
+		clientCert, err := tls.LoadX509KeyPair(cortexClientCert, cortexClientKey)
+		if err != nil {
+			lg.With(
+				"err", err,
+			).Error("fatal: failed to load cortex client keypair")
+			os.Exit(1)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
 	}
 	serverCAPool := x509.NewCertPool()
 	serverCAData, err := os.ReadFile(cortexServerCA)
@@ -46,10 +85,86 @@ func (p *Plugin) loadCortexCerts() *tls.Config {
 		lg.Error("fatal: failed to load cortex client CA")
 		os.Exit(1)
 	}
-	return &tls.Config{
-		MinVersion:   tls.VersionTLS12,
-		Certificates: []tls.Certificate{clientCert},
-		ClientCAs:    clientCAPool,
-		RootCAs:      serverCAPool,
+	tlsConfig.ClientCAs = clientCAPool
+	tlsConfig.RootCAs = serverCAPool
+	return tlsConfig
+}
+
+// ClientCertReloader loads a client certificate and private key from a
+// key-value store, and reloads them when they are rotated. Its
+// GetClientCertificate method can be used in a tls.Config, so that new
+// connections always use the most recently loaded certificate.
+type ClientCertReloader struct {
+	store storage.KeyValueStore
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	certData []byte
+	keyData  []byte
+}
+
+func NewClientCertReloader(store storage.KeyValueStore) *ClientCertReloader {
+	return &ClientCertReloader{
+		store: store,
+	}
+}
+
+// Reload reads the certificate and private key from the store. If they
+// have changed, they replace the current certificate. If an error is
+// returned, the current certificate is kept.
+func (r *ClientCertReloader) Reload(ctx context.Context) error {
+	certData, err := r.store.Get(ctx, clientCertKey)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", clientCertKey, err)
+	}
+	keyData, err := r.store.Get(ctx, clientKeyKey)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", clientKeyKey, err)
+	}
+
+	r.mu.RLock()
+	unchanged := bytes.Equal(certData, r.certData) && bytes.Equal(keyData, r.keyData)
+	r.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	cert, err := tls.X509KeyPair(certData, keyData)
+	if err != nil {
+		return fmt.Errorf("failed to load keypair: %w", err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.certData = certData
+	r.keyData = keyData
+	return nil
+}
+
+// Run reloads the certificate at the given interval until the context is
+// done. Errors are logged, and the current certificate is kept.
+func (r *ClientCertReloader) Run(ctx context.Context, interval time.Duration, lg hclog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := r.Reload(ctx); err != nil {
+			lg.With(
+				"err", err,
+			).Warn("failed to reload cortex client keypair")
+		}
+	}
+}
+
+func (r *ClientCertReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.cert == nil {
+		return nil, fmt.Errorf("client keypair has not been loaded")
 	}
+	return r.cert, nil
 }
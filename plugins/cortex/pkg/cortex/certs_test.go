@@ -0,0 +1,141 @@
+package cortex_test
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/storage"
+	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/plugins/cortex/pkg/cortex"
+)
+
+// kvStore is a minimal key-value store which is safe for concurrent use.
+type kvStore struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func (s *kvStore) Put(_ context.Context, key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = value
+	return nil
+}
+
+func (s *kvStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.data[key]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return v, nil
+}
+
+func (s *kvStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, key)
+	return nil
+}
+
+func (s *kvStore) ListKeys(_ context.Context, prefix string) ([]string, error) {
+	return nil, nil
+}
+
+var _ = Describe("Client Cert Reloader", Label(test.Unit), func() {
+	var store *kvStore
+	var client *http.Client
+	var serverURL string
+	putKeypair := func(name string) {
+		store.Put(context.Background(), "tls.crt", test.TestData(name+".crt"))
+		store.Put(context.Background(), "tls.key", test.TestData(name+".key"))
+	}
+	// peerName sends a request over a new connection and returns the common
+	// name of the client certificate the server received.
+	peerName := func() (string, error) {
+		resp, err := client.Get(serverURL)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		return string(body), err
+	}
+	newClient := func(reloader *cortex.ClientCertReloader) {
+		client = &http.Client{
+			Transport: &http.Transport{
+				DisableKeepAlives: true,
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify:   true,
+					GetClientCertificate: reloader.GetClientCertificate,
+				},
+			},
+		}
+	}
+	BeforeEach(func() {
+		store = &kvStore{
+			data: map[string][]byte{},
+		}
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+		}))
+		server.TLS = &tls.Config{
+			ClientAuth: tls.RequireAnyClientCert,
+		}
+		server.StartTLS()
+		DeferCleanup(server.Close)
+		serverURL = server.URL
+	})
+
+	It("should fail to load if the store has no keypair", func() {
+		reloader := cortex.NewClientCertReloader(store)
+		Expect(reloader.Reload(context.Background())).To(MatchError(storage.ErrNotFound))
+		newClient(reloader)
+		_, err := peerName()
+		Expect(err).To(HaveOccurred())
+	})
+	It("should use the rotated keypair for new connections", func() {
+		putKeypair("example.com")
+		reloader := cortex.NewClientCertReloader(store)
+		Expect(reloader.Reload(context.Background())).To(Succeed())
+		newClient(reloader)
+		Expect(peerName()).To(Equal("example.com"))
+
+		putKeypair("localhost")
+		Expect(peerName()).To(Equal("example.com"))
+		Expect(reloader.Reload(context.Background())).To(Succeed())
+		Expect(peerName()).To(Equal("leaf"))
+	})
+	It("should keep the current keypair if the new one is invalid", func() {
+		putKeypair("example.com")
+		reloader := cortex.NewClientCertReloader(store)
+		Expect(reloader.Reload(context.Background())).To(Succeed())
+		newClient(reloader)
+
+		store.Put(context.Background(), "tls.key", test.TestData("localhost.key"))
+		Expect(reloader.Reload(context.Background())).NotTo(Succeed())
+		Expect(peerName()).To(Equal("example.com"))
+	})
+	It("should reload the keypair periodically", func() {
+		putKeypair("example.com")
+		reloader := cortex.NewClientCertReloader(store)
+		Expect(reloader.Reload(context.Background())).To(Succeed())
+		newClient(reloader)
+		ctx, ca := context.WithCancel(context.Background())
+		DeferCleanup(ca)
+		go reloader.Run(ctx, 10*time.Millisecond, hclog.NewNullLogger())
+
+		putKeypair("localhost")
+		Eventually(peerName).Should(Equal("leaf"))
+	})
+})
@@ -0,0 +1,40 @@
+package cortex
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rancher/opni-monitoring/pkg/auth/cluster"
+	"github.com/rancher/opni-monitoring/pkg/storage"
+)
+
+// SyncRuntimeConfig runs syncRuntimeConfig for a plugin using the given
+// storage backend and ingestion limiter, until ctx is done.
+func SyncRuntimeConfig(ctx context.Context, backend storage.Backend, ingestion *IngestionLimiter, path string) {
+	p := NewPlugin(ctx)
+	p.storageBackend.Set(backend)
+	p.ingestion = ingestion
+	p.syncRuntimeConfig(path)
+}
+
+// NewAgentAPI returns an app serving the agent API of a plugin using the
+// given storage backend, until ctx is done. Requests are authenticated as the
+// cluster named in the ClusterIDHeader header, and accepted remote-write
+// requests are passed to distributor.
+func NewAgentAPI(ctx context.Context, backend storage.Backend, distributor fiber.Handler) *fiber.App {
+	p := NewPlugin(ctx)
+	p.storageBackend.Set(backend)
+	go p.syncClusters()
+	app := fiber.New()
+	p.configureAgentAPI(app, &forwarders{
+		Distributor: distributor,
+	}, &middlewares{
+		Cluster: func(c *fiber.Ctx) error {
+			c.Locals(cluster.ClusterIDKey, c.Get(ClusterIDHeader))
+			return c.Next()
+		},
+	})
+	return app
+}
+
+const ClusterIDHeader = "X-Test-Cluster-Id"
@@ -0,0 +1,354 @@
+package cortex
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cortexproject/cortex/pkg/cortexpb"
+	"github.com/prometheus/common/model"
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/storage"
+	"github.com/rancher/opni-monitoring/pkg/util/atomic"
+	"github.com/rancher/opni-monitoring/pkg/util/backoff"
+	"gopkg.in/yaml.v3"
+)
+
+// runtimeConfigRetryInterval is how long to wait before trying again to
+// write the runtime configuration file, if writing it failed.
+const runtimeConfigRetryInterval = 5 * time.Second
+
+// TenantLimits holds the Cortex limits which can be set per cluster. Unset
+// fields are omitted, so that Cortex uses its defaults for them.
+type TenantLimits struct {
+	IngestionRate                  float64 `yaml:"ingestion_rate,omitempty"`
+	IngestionBurstSize             int64   `yaml:"ingestion_burst_size,omitempty"`
+	MaxGlobalSeriesPerUser         int64   `yaml:"max_global_series_per_user,omitempty"`
+	CompactorBlocksRetentionPeriod string  `yaml:"compactor_blocks_retention_period,omitempty"`
+}
+
+// managedTenantLimits are the keys of the tenant overrides which are set
+// from cluster limits. Any other overrides are left unchanged.
+var managedTenantLimits = []string{
+	"ingestion_rate",
+	"ingestion_burst_size",
+	"max_global_series_per_user",
+	"compactor_blocks_retention_period",
+}
+
+// NewTenantLimits returns the Cortex limits for the cluster.
+func NewTenantLimits(cluster *core.Cluster) TenantLimits {
+	limits := cluster.GetLimits()
+	tl := TenantLimits{
+		IngestionRate:          limits.GetIngestionRate(),
+		IngestionBurstSize:     limits.GetIngestionBurstSize(),
+		MaxGlobalSeriesPerUser: limits.GetMaxSeries(),
+	}
+	if limits.GetRetentionSeconds() > 0 {
+		tl.CompactorBlocksRetentionPeriod =
+			model.Duration(time.Duration(limits.GetRetentionSeconds()) * time.Second).String()
+	}
+	return tl
+}
+
+// UpdateRuntimeConfig merges the limits of the updated clusters into the
+// tenant overrides of an existing Cortex runtime configuration file, and
+// returns the new contents of the file. The limits of deleted clusters are
+// removed. All other settings, including overrides for other tenants and
+// overrides for the same tenants which are not set from cluster limits, are
+// kept as-is.
+func UpdateRuntimeConfig(data []byte, updated []*core.Cluster, deleted []string) ([]byte, error) {
+	config := map[string]any{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse runtime config: %w", err)
+	}
+	if config == nil {
+		config = map[string]any{}
+	}
+	overrides := map[string]any{}
+	if existing, ok := config["overrides"]; ok && existing != nil {
+		if overrides, ok = existing.(map[string]any); !ok {
+			return nil, fmt.Errorf("failed to parse runtime config: overrides must be a map")
+		}
+	}
+	update := func(id string, tl TenantLimits) error {
+		tenant := map[string]any{}
+		if existing, ok := overrides[id]; ok && existing != nil {
+			if tenant, ok = existing.(map[string]any); !ok {
+				return fmt.Errorf("failed to parse runtime config: overrides for %q must be a map", id)
+			}
+		}
+		for _, key := range managedTenantLimits {
+			delete(tenant, key)
+		}
+		limits := map[string]any{}
+		data, err := yaml.Marshal(tl)
+		if err != nil {
+			return err
+		}
+		if err := yaml.Unmarshal(data, &limits); err != nil {
+			return err
+		}
+		for k, v := range limits {
+			tenant[k] = v
+		}
+		if len(tenant) == 0 {
+			delete(overrides, id)
+		} else {
+			overrides[id] = tenant
+		}
+		return nil
+	}
+	for _, cluster := range updated {
+		if err := update(cluster.GetId(), NewTenantLimits(cluster)); err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range deleted {
+		if err := update(id, TenantLimits{}); err != nil {
+			return nil, err
+		}
+	}
+	config["overrides"] = overrides
+	return yaml.Marshal(config)
+}
+
+// syncRuntimeConfig keeps the limits of all clusters in the Cortex runtime
+// configuration file up to date, until the plugin's context is done. Changes
+// are received from a cluster watch and merged into the existing file. The
+// ingestion limits of deleted clusters are also dropped.
+func (p *Plugin) syncRuntimeConfig(path string) {
+	var eventC <-chan storage.ClusterWatchEvent
+	err := backoff.Retry(p.ctx, func(ctx context.Context) error {
+		var err error
+		eventC, err = p.storageBackend.Get().WatchClusters(ctx, nil)
+		if err != nil {
+			p.logger.With(
+				"err", err,
+			).Warn("failed to watch clusters")
+		}
+		return err
+	}, backoff.DefaultPolicy)
+	if err != nil {
+		return
+	}
+
+	// clusters which have changed since the file was last written, with nil
+	// values for deleted clusters
+	pending := map[string]*core.Cluster{}
+	var retry <-chan time.Time
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case event, ok := <-eventC:
+			if !ok {
+				return
+			}
+			if event.EventType == storage.WatchEventDelete {
+				pending[event.Previous.GetId()] = nil
+				p.ingestion.Delete(event.Previous.GetId())
+			} else {
+				pending[event.Current.GetId()] = event.Current
+			}
+			if len(eventC) > 0 {
+				// write all queued changes at once
+				continue
+			}
+		case <-retry:
+		}
+		if err := writeRuntimeConfig(path, pending); err != nil {
+			p.logger.With(
+				"err", err,
+				"path", path,
+			).Error("failed to write cortex runtime config")
+			retry = time.After(runtimeConfigRetryInterval)
+			continue
+		}
+		pending = map[string]*core.Cluster{}
+		retry = nil
+	}
+}
+
+// writeRuntimeConfig merges the limits of the changed clusters into the
+// runtime configuration file at path. The file is created if it does not
+// exist, and is only rewritten if its contents have changed.
+func writeRuntimeConfig(path string, changed map[string]*core.Cluster) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	var updated []*core.Cluster
+	var deleted []string
+	for id, cluster := range changed {
+		if cluster == nil {
+			deleted = append(deleted, id)
+		} else {
+			updated = append(updated, cluster)
+		}
+	}
+	newData, err := UpdateRuntimeConfig(data, updated, deleted)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(data, newData) {
+		return nil
+	}
+	return atomic.WriteFile(path, newData, 0644)
+}
+
+// IngestionLimiter enforces per-cluster ingestion rate limits in the
+// gateway, so that writes over the limit are rejected before reaching Cortex.
+type IngestionLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+func NewIngestionLimiter() *IngestionLimiter {
+	return &IngestionLimiter{
+		buckets: map[string]*tokenBucket{},
+	}
+}
+
+// Allow reports whether the given number of samples can be ingested from
+// the cluster now, according to the cluster's limits. Clusters without an
+// ingestion rate limit are not limited. If no burst size is set, up to one
+// second's worth of samples can be ingested at once.
+//
+// A write larger than the burst size is allowed if no samples have been
+// ingested for long enough to fill the burst, since remote-write batches can
+// be larger than the configured rate. The samples over the burst size are
+// then paid back before any further writes are allowed, so the sustained rate
+// is still limited.
+func (l *IngestionLimiter) Allow(clusterID string, limits *core.ClusterLimits, samples int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if limits.GetIngestionRate() <= 0 {
+		delete(l.buckets, clusterID)
+		return true
+	}
+	limit := limits.GetIngestionRate()
+	burst := float64(limits.GetIngestionBurstSize())
+	if burst <= 0 {
+		burst = math.Ceil(limit)
+	}
+	now := time.Now()
+	// if the limits have changed, start over with a full bucket
+	bucket, ok := l.buckets[clusterID]
+	if !ok || bucket.limit != limit || bucket.burst != burst {
+		bucket = &tokenBucket{
+			limit:  limit,
+			burst:  burst,
+			tokens: burst,
+			last:   now,
+		}
+		l.buckets[clusterID] = bucket
+	}
+	return bucket.take(now, float64(samples))
+}
+
+// Delete drops the state kept for the cluster, such as when it is deleted.
+func (l *IngestionLimiter) Delete(clusterID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.buckets, clusterID)
+}
+
+// tokenBucket is a token bucket which allows taking more tokens than its
+// burst size when it is full, leaving it with a negative balance.
+type tokenBucket struct {
+	limit  float64 // tokens added per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) take(now time.Time, n float64) bool {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed.Seconds()*b.limit)
+		b.last = now
+	}
+	if b.tokens < math.Min(n, b.burst) {
+		return false
+	}
+	b.tokens -= n
+	return true
+}
+
+// maxReportedSeries is the maximum number of series listed in a
+// SampleAgeError.
+const maxReportedSeries = 10
+
+// SampleAgeError is returned by CheckSampleAge if a remote-write request
+// contains samples outside of the allowed time window.
+type SampleAgeError struct {
+	// The series containing rejected samples. At most maxReportedSeries
+	// series are listed.
+	Series []string
+	// The total number of series containing rejected samples.
+	Count int
+	// The allowed window, relative to the time of the check. A zero value
+	// means that side of the window is unbounded.
+	MaxAge, MaxFuture time.Duration
+}
+
+func (e *SampleAgeError) Error() string {
+	var window []string
+	if e.MaxAge > 0 {
+		window = append(window, "older than "+e.MaxAge.String())
+	}
+	if e.MaxFuture > 0 {
+		window = append(window, "more than "+e.MaxFuture.String()+" in the future")
+	}
+	msg := fmt.Sprintf("%d series contain samples %s: %s",
+		e.Count, strings.Join(window, " or "), strings.Join(e.Series, ", "))
+	if e.Count > len(e.Series) {
+		msg += fmt.Sprintf(" (and %d more)", e.Count-len(e.Series))
+	}
+	return msg
+}
+
+// CheckSampleAge checks that all samples in the remote-write request are
+// within the time window allowed by the cluster's limits, relative to now.
+// If any samples are too old or too far in the future, a *SampleAgeError
+// listing the offending series is returned. Clusters without sample age
+// limits are not checked.
+func CheckSampleAge(req *cortexpb.WriteRequest, limits *core.ClusterLimits, now time.Time) error {
+	maxAge := time.Duration(limits.GetMaxSampleAgeSeconds()) * time.Second
+	maxFuture := time.Duration(limits.GetMaxSampleFutureSeconds()) * time.Second
+	if maxAge <= 0 && maxFuture <= 0 {
+		return nil
+	}
+	var err *SampleAgeError
+	for _, ts := range req.Timeseries {
+		for _, sample := range ts.Samples {
+			t := time.UnixMilli(sample.TimestampMs)
+			if (maxAge > 0 && t.Before(now.Add(-maxAge))) ||
+				(maxFuture > 0 && t.After(now.Add(maxFuture))) {
+				if err == nil {
+					err = &SampleAgeError{
+						MaxAge:    maxAge,
+						MaxFuture: maxFuture,
+					}
+				}
+				if len(err.Series) < maxReportedSeries {
+					err.Series = append(err.Series,
+						cortexpb.FromLabelAdaptersToLabels(ts.Labels).String())
+				}
+				err.Count++
+				break
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,332 @@
+package cortex_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cortexproject/cortex/pkg/cortexpb"
+	"github.com/golang/mock/gomock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/plugins/cortex/pkg/cortex"
+)
+
+var _ = Describe("Cluster Limits", Label(test.Unit), func() {
+	Context("runtime config", func() {
+		clusters := []*core.Cluster{
+			{
+				Id: "cluster-1",
+				Metadata: &core.ClusterMetadata{
+					Limits: &core.ClusterLimits{
+						IngestionRate:      1000,
+						IngestionBurstSize: 5000,
+						MaxSeries:          10000,
+						RetentionSeconds:   int64((30 * 24 * time.Hour).Seconds()),
+					},
+				},
+			},
+			{
+				Id: "cluster-2",
+				Metadata: &core.ClusterMetadata{
+					Limits: &core.ClusterLimits{
+						MaxSeries: 500,
+					},
+				},
+			},
+			{
+				Id: "cluster-3",
+			},
+			{
+				Id: "cluster-4",
+				Metadata: &core.ClusterMetadata{
+					Limits: &core.ClusterLimits{},
+				},
+			},
+		}
+		It("should contain overrides for clusters with limits", func() {
+			data, err := cortex.UpdateRuntimeConfig(nil, clusters, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(MatchYAML(`
+overrides:
+  cluster-1:
+    ingestion_rate: 1000
+    ingestion_burst_size: 5000
+    max_global_series_per_user: 10000
+    compactor_blocks_retention_period: 30d
+  cluster-2:
+    max_global_series_per_user: 500
+`))
+		})
+		It("should contain an empty set of overrides if no clusters have limits", func() {
+			data, err := cortex.UpdateRuntimeConfig(nil, clusters[2:], nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(MatchYAML(`overrides: {}`))
+		})
+		It("should keep existing settings", func() {
+			existing := `
+multi_kv_config:
+  primary: consul
+overrides:
+  other-tenant:
+    ingestion_rate: 5
+  cluster-1:
+    ingestion_rate: 1
+    max_label_names_per_series: 20
+  cluster-2:
+    max_global_series_per_user: 1
+  cluster-3:
+    max_global_series_per_user: 1
+`
+			data, err := cortex.UpdateRuntimeConfig([]byte(existing), clusters[:1], []string{"cluster-2", "cluster-5"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(MatchYAML(`
+multi_kv_config:
+  primary: consul
+overrides:
+  other-tenant:
+    ingestion_rate: 5
+  cluster-1:
+    ingestion_rate: 1000
+    ingestion_burst_size: 5000
+    max_global_series_per_user: 10000
+    compactor_blocks_retention_period: 30d
+    max_label_names_per_series: 20
+  cluster-3:
+    max_global_series_per_user: 1
+`))
+		})
+		It("should remove limits which are no longer set", func() {
+			existing := `
+overrides:
+  cluster-1:
+    ingestion_rate: 1000
+    max_label_names_per_series: 20
+  cluster-4:
+    max_global_series_per_user: 1
+`
+			data, err := cortex.UpdateRuntimeConfig([]byte(existing), clusters[2:], []string{"cluster-1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(MatchYAML(`
+overrides:
+  cluster-1:
+    max_label_names_per_series: 20
+`))
+		})
+		It("should keep the runtime config file up to date", func() {
+			ctx, ca := context.WithCancel(context.Background())
+			defer ca()
+			backend := test.NewTestStorageBackend(ctx, gomock.NewController(GinkgoT()))
+			Expect(backend.CreateCluster(ctx, clusters[1])).To(Succeed())
+
+			path := filepath.Join(GinkgoT().TempDir(), "runtime-config.yaml")
+			Expect(os.WriteFile(path, []byte(`
+multi_kv_config:
+  primary: consul
+`), 0644)).To(Succeed())
+
+			ingestion := cortex.NewIngestionLimiter()
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				cortex.SyncRuntimeConfig(ctx, backend, ingestion, path)
+			}()
+			readFile := func() string {
+				data, _ := os.ReadFile(path)
+				return string(data)
+			}
+			Eventually(readFile).Should(MatchYAML(`
+multi_kv_config:
+  primary: consul
+overrides:
+  cluster-2:
+    max_global_series_per_user: 500
+`))
+
+			Expect(backend.CreateCluster(ctx, clusters[0])).To(Succeed())
+			Eventually(readFile).Should(MatchYAML(`
+multi_kv_config:
+  primary: consul
+overrides:
+  cluster-1:
+    ingestion_rate: 1000
+    ingestion_burst_size: 5000
+    max_global_series_per_user: 10000
+    compactor_blocks_retention_period: 30d
+  cluster-2:
+    max_global_series_per_user: 500
+`))
+
+			limits := clusters[0].GetLimits()
+			Expect(ingestion.Allow("cluster-1", limits, 5000)).To(BeTrue())
+			Expect(ingestion.Allow("cluster-1", limits, 5000)).To(BeFalse())
+
+			Expect(backend.DeleteCluster(ctx, clusters[0].Reference())).To(Succeed())
+			Eventually(readFile).Should(MatchYAML(`
+multi_kv_config:
+  primary: consul
+overrides:
+  cluster-2:
+    max_global_series_per_user: 500
+`))
+			// the ingestion limits of the deleted cluster are dropped
+			Expect(ingestion.Allow("cluster-1", limits, 5000)).To(BeTrue())
+
+			ca()
+			Eventually(done).Should(BeClosed())
+		})
+		It("should reject invalid runtime configs", func() {
+			_, err := cortex.UpdateRuntimeConfig([]byte(`overrides: []`), clusters, nil)
+			Expect(err).To(HaveOccurred())
+			_, err = cortex.UpdateRuntimeConfig([]byte(`overrides: {cluster-1: 5}`), clusters, nil)
+			Expect(err).To(HaveOccurred())
+			_, err = cortex.UpdateRuntimeConfig([]byte(`{`), clusters, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+	Context("ingestion rate", func() {
+		It("should reject writes over the limit", func() {
+			limiter := cortex.NewIngestionLimiter()
+			limits := &core.ClusterLimits{
+				IngestionRate:      10,
+				IngestionBurstSize: 100,
+			}
+			Expect(limiter.Allow("cluster-1", limits, 60)).To(BeTrue())
+			Expect(limiter.Allow("cluster-1", limits, 60)).To(BeFalse())
+			// the limit is tracked separately for each cluster
+			Expect(limiter.Allow("cluster-2", limits, 60)).To(BeTrue())
+		})
+		It("should allow writes larger than the burst size if no samples were recently ingested", func() {
+			limiter := cortex.NewIngestionLimiter()
+			limits := &core.ClusterLimits{
+				IngestionRate:      10,
+				IngestionBurstSize: 100,
+			}
+			Expect(limiter.Allow("cluster-1", limits, 101)).To(BeTrue())
+			// the extra samples must be paid back first
+			Expect(limiter.Allow("cluster-1", limits, 1)).To(BeFalse())
+
+			Expect(limiter.Allow("cluster-2", limits, 1)).To(BeTrue())
+			Expect(limiter.Allow("cluster-2", limits, 101)).To(BeFalse())
+		})
+		It("should allow remote-write batches larger than the rate", func() {
+			limiter := cortex.NewIngestionLimiter()
+			limits := &core.ClusterLimits{
+				IngestionRate: 10,
+			}
+			Expect(limiter.Allow("cluster-1", limits, 500)).To(BeTrue())
+			Expect(limiter.Allow("cluster-1", limits, 500)).To(BeFalse())
+		})
+		It("should default the burst size to one second of samples", func() {
+			limiter := cortex.NewIngestionLimiter()
+			limits := &core.ClusterLimits{
+				IngestionRate: 50,
+			}
+			Expect(limiter.Allow("cluster-1", limits, 50)).To(BeTrue())
+			Expect(limiter.Allow("cluster-1", limits, 1)).To(BeFalse())
+		})
+		It("should apply updated limits", func() {
+			limiter := cortex.NewIngestionLimiter()
+			Expect(limiter.Allow("cluster-1", &core.ClusterLimits{IngestionRate: 10}, 10)).To(BeTrue())
+			Expect(limiter.Allow("cluster-1", &core.ClusterLimits{IngestionRate: 10}, 10)).To(BeFalse())
+			Expect(limiter.Allow("cluster-1", &core.ClusterLimits{IngestionRate: 1000}, 500)).To(BeTrue())
+		})
+		It("should start over for deleted clusters", func() {
+			limiter := cortex.NewIngestionLimiter()
+			limits := &core.ClusterLimits{IngestionRate: 10}
+			Expect(limiter.Allow("cluster-1", limits, 10)).To(BeTrue())
+			Expect(limiter.Allow("cluster-1", limits, 10)).To(BeFalse())
+			limiter.Delete("cluster-1")
+			Expect(limiter.Allow("cluster-1", limits, 10)).To(BeTrue())
+		})
+		It("should not limit clusters without an ingestion rate", func() {
+			limiter := cortex.NewIngestionLimiter()
+			for i := 0; i < 10; i++ {
+				Expect(limiter.Allow("cluster-1", nil, 1e6)).To(BeTrue())
+				Expect(limiter.Allow("cluster-1", &core.ClusterLimits{MaxSeries: 10}, 1e6)).To(BeTrue())
+			}
+		})
+	})
+	Context("sample age", func() {
+		now := time.Unix(1700000000, 0)
+		limits := &core.ClusterLimits{
+			MaxSampleAgeSeconds:    3600,
+			MaxSampleFutureSeconds: 600,
+		}
+		series := func(name string, timestamps ...time.Time) cortexpb.PreallocTimeseries {
+			ts := &cortexpb.TimeSeries{
+				Labels: []cortexpb.LabelAdapter{
+					{Name: "__name__", Value: name},
+					{Name: "job", Value: "test"},
+				},
+			}
+			for _, t := range timestamps {
+				ts.Samples = append(ts.Samples, cortexpb.Sample{
+					TimestampMs: t.UnixMilli(),
+					Value:       1,
+				})
+			}
+			return cortexpb.PreallocTimeseries{TimeSeries: ts}
+		}
+		request := func(series ...cortexpb.PreallocTimeseries) *cortexpb.WriteRequest {
+			return &cortexpb.WriteRequest{
+				Timeseries: series,
+			}
+		}
+		It("should accept samples just inside the allowed window", func() {
+			Expect(cortex.CheckSampleAge(request(
+				series("a", now.Add(-time.Hour)),
+				series("b", now.Add(-time.Hour+time.Millisecond), now, now.Add(10*time.Minute)),
+			), limits, now)).To(Succeed())
+		})
+		It("should reject samples just outside the allowed window", func() {
+			err := cortex.CheckSampleAge(request(
+				series("a", now),
+				series("old", now, now.Add(-time.Hour-time.Millisecond)),
+				series("new", now.Add(10*time.Minute+time.Millisecond)),
+			), limits, now)
+			Expect(err).To(HaveOccurred())
+			sampleAgeErr := &cortex.SampleAgeError{}
+			Expect(errors.As(err, &sampleAgeErr)).To(BeTrue())
+			Expect(sampleAgeErr.Count).To(Equal(2))
+			Expect(sampleAgeErr.Series).To(Equal([]string{
+				`{__name__="old", job="test"}`,
+				`{__name__="new", job="test"}`,
+			}))
+			Expect(err.Error()).To(Equal(`2 series contain samples older than 1h0m0s or more than 10m0s in the future: ` +
+				`{__name__="old", job="test"}, {__name__="new", job="test"}`))
+		})
+		It("should only check the configured side of the window", func() {
+			req := request(
+				series("old", now.Add(-48*time.Hour)),
+				series("new", now.Add(48*time.Hour)),
+			)
+			Expect(cortex.CheckSampleAge(req, &core.ClusterLimits{MaxSampleAgeSeconds: 3600}, now)).To(MatchError(
+				`1 series contain samples older than 1h0m0s: {__name__="old", job="test"}`))
+			Expect(cortex.CheckSampleAge(req, &core.ClusterLimits{MaxSampleFutureSeconds: 600}, now)).To(MatchError(
+				`1 series contain samples more than 10m0s in the future: {__name__="new", job="test"}`))
+		})
+		It("should limit the number of series listed", func() {
+			req := request()
+			for i := 0; i < 15; i++ {
+				req.Timeseries = append(req.Timeseries, series(fmt.Sprintf("s%d", i), now.Add(-2*time.Hour)))
+			}
+			err := cortex.CheckSampleAge(req, limits, now)
+			Expect(err).To(HaveOccurred())
+			Expect(err.(*cortex.SampleAgeError).Series).To(HaveLen(10))
+			Expect(err.Error()).To(HaveSuffix("(and 5 more)"))
+		})
+		It("should not check clusters without sample age limits", func() {
+			req := request(series("old", time.Unix(0, 0)))
+			Expect(cortex.CheckSampleAge(req, nil, now)).To(Succeed())
+			Expect(cortex.CheckSampleAge(req, &core.ClusterLimits{MaxSeries: 10}, now)).To(Succeed())
+		})
+	})
+})
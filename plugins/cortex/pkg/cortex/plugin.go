@@ -2,6 +2,7 @@ package cortex
 
 import (
 	"context"
+	"crypto/tls"
 	"net/http"
 
 	"github.com/cortexproject/cortex/pkg/distributor/distributorpb"
@@ -30,9 +31,13 @@ type Plugin struct {
 	config            *util.Future[*v1beta1.GatewayConfig]
 	mgmtApi           *util.Future[management.ManagementClient]
 	storageBackend    *util.Future[storage.Backend]
+	clusters          *storage.ClusterIndex
 	distributorClient *util.Future[distributorpb.DistributorClient]
 	ingesterClient    *util.Future[ingesterclient.IngesterClient]
 	cortexHttpClient  *util.Future[http.Client]
+	cortexTLSConfig   *util.Future[*tls.Config]
+	throughput        *throughputTracker
+	ingestion         *IngestionLimiter
 	logger            hclog.Logger
 }
 
@@ -45,9 +50,13 @@ func NewPlugin(ctx context.Context) *Plugin {
 		config:            util.NewFuture[*v1beta1.GatewayConfig](),
 		mgmtApi:           util.NewFuture[management.ManagementClient](),
 		storageBackend:    util.NewFuture[storage.Backend](),
+		clusters:          storage.NewClusterIndex(),
 		distributorClient: util.NewFuture[distributorpb.DistributorClient](),
 		ingesterClient:    util.NewFuture[ingesterclient.IngesterClient](),
 		cortexHttpClient:  util.NewFuture[http.Client](),
+		cortexTLSConfig:   util.NewFuture[*tls.Config](),
+		throughput:        newThroughputTracker(),
+		ingestion:         NewIngestionLimiter(),
 		logger:            lg,
 	}
 }
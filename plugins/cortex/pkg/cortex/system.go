@@ -38,8 +38,14 @@ func (p *Plugin) UseManagementAPI(client management.ManagementClient) {
 			os.Exit(1)
 		}
 		p.storageBackend.Set(backend)
+		go p.syncClusters()
 		p.config.Set(config)
-		p.configureAdminClients(p.loadCortexCerts())
+		tlsConfig := p.loadCortexCerts()
+		p.cortexTLSConfig.Set(tlsConfig)
+		p.configureAdminClients(tlsConfig)
+		if path := config.Spec.Cortex.RuntimeConfigFile; path != "" {
+			go p.syncRuntimeConfig(path)
+		}
 	})
 	<-p.ctx.Done()
 }
@@ -0,0 +1,183 @@
+package cortex
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cortexproject/cortex/pkg/cortexpb"
+	"github.com/golang/snappy"
+	"github.com/rancher/opni-monitoring/plugins/cortex/pkg/apis/cortexadmin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultThroughputInterval = 1 * time.Second
+	// Counters for clusters which have not sent any remote-write requests for
+	// this long are removed. This is also the longest allowed update interval
+	// for WatchThroughput, so that requests are always included in an update
+	// before their cluster can be evicted.
+	throughputIdleTimeout = 5 * time.Minute
+)
+
+type throughputCounters struct {
+	samples uint64
+	bytes   uint64
+	// when the counters were created, so that counters recreated after the
+	// cluster was evicted are not compared against the evicted ones
+	created time.Time
+	updated time.Time
+}
+
+// throughputTracker keeps running totals of the number of samples and bytes
+// received from remote-write requests, keyed by cluster ID. Clusters are
+// evicted once they have been idle for longer than throughputIdleTimeout,
+// so that deleted clusters are not tracked forever.
+type throughputTracker struct {
+	mu       sync.Mutex
+	counters map[string]throughputCounters
+}
+
+func newThroughputTracker() *throughputTracker {
+	return &throughputTracker{
+		counters: map[string]throughputCounters{},
+	}
+}
+
+func (t *throughputTracker) Record(clusterID string, samples, bytes uint64) {
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	c, ok := t.counters[clusterID]
+	if !ok {
+		// only check for idle clusters when a new one is added, which keeps
+		// the map bounded without scanning it on every request
+		t.evictIdle(now)
+		c.created = now
+	}
+	c.samples += samples
+	c.bytes += bytes
+	c.updated = now
+	t.counters[clusterID] = c
+}
+
+func (t *throughputTracker) Snapshot() map[string]throughputCounters {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.evictIdle(time.Now())
+	snapshot := make(map[string]throughputCounters, len(t.counters))
+	for id, c := range t.counters {
+		snapshot[id] = c
+	}
+	return snapshot
+}
+
+// evictIdle removes the counters of clusters which have been idle for longer
+// than throughputIdleTimeout. t.mu must be held.
+func (t *throughputTracker) evictIdle(now time.Time) {
+	for id, c := range t.counters {
+		if now.Sub(c.updated) > throughputIdleTimeout {
+			delete(t.counters, id)
+		}
+	}
+}
+
+// decodeWriteRequest decodes a snappy-compressed remote-write request body.
+// The request's time series should be released using cortexpb.ReuseSlice
+// once they are no longer needed.
+func decodeWriteRequest(body []byte) (*cortexpb.WriteRequest, error) {
+	decoded, err := snappy.Decode(nil, body)
+	if err != nil {
+		return nil, err
+	}
+	req := &cortexpb.WriteRequest{}
+	if err := req.Unmarshal(decoded); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// countSamples returns the number of samples in a remote-write request.
+func countSamples(req *cortexpb.WriteRequest) uint64 {
+	var count uint64
+	for _, ts := range req.Timeseries {
+		count += uint64(len(ts.Samples))
+	}
+	return count
+}
+
+// throughputRates computes per-cluster rates from two snapshots taken
+// elapsed apart. Clusters are sorted by ID. Clusters which were evicted
+// before the current snapshot was taken are not included.
+func throughputRates(
+	prev, cur map[string]throughputCounters,
+	elapsed time.Duration,
+) (clusters []*cortexadmin.ClusterThroughput, total *cortexadmin.ClusterThroughput) {
+	total = &cortexadmin.ClusterThroughput{}
+	seconds := elapsed.Seconds()
+	if seconds <= 0 {
+		return nil, total
+	}
+	for id, c := range cur {
+		p, ok := prev[id]
+		if !ok || !p.created.Equal(c.created) {
+			// new or recreated since the previous snapshot
+			p = throughputCounters{}
+		}
+		ct := &cortexadmin.ClusterThroughput{
+			ClusterID:        id,
+			SamplesPerSecond: float64(c.samples-p.samples) / seconds,
+			BytesPerSecond:   float64(c.bytes-p.bytes) / seconds,
+		}
+		total.SamplesPerSecond += ct.SamplesPerSecond
+		total.BytesPerSecond += ct.BytesPerSecond
+		clusters = append(clusters, ct)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].ClusterID < clusters[j].ClusterID
+	})
+	return clusters, total
+}
+
+func (p *Plugin) WatchThroughput(
+	in *cortexadmin.WatchThroughputRequest,
+	stream cortexadmin.CortexAdmin_WatchThroughputServer,
+) error {
+	interval := defaultThroughputInterval
+	if in.Interval != nil {
+		if err := in.Interval.CheckValid(); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		if in.Interval.AsDuration() <= 0 {
+			return status.Error(codes.InvalidArgument, "interval must be positive")
+		}
+		if in.Interval.AsDuration() > throughputIdleTimeout {
+			return status.Errorf(codes.InvalidArgument, "interval must not be longer than %s", throughputIdleTimeout)
+		}
+		interval = in.Interval.AsDuration()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	prev := p.throughput.Snapshot()
+	prevTime := time.Now()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case now := <-ticker.C:
+			cur := p.throughput.Snapshot()
+			clusters, total := throughputRates(prev, cur, now.Sub(prevTime))
+			prev, prevTime = cur, now
+			if err := stream.Send(&cortexadmin.ThroughputUpdate{
+				Timestamp: timestamppb.New(now),
+				Clusters:  clusters,
+				Total:     total,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -72,7 +72,8 @@ var _ = Describe("Agent - Agent and Gateway Bootstrap Tests", Ordered, Label(tes
 
 			port, errC := environment.StartAgent(name, token, []string{fingerprint})
 			Consistently(errC).ShouldNot(Receive())
-			promAgentPort := environment.StartPrometheus(port)
+			promAgentPort, err := environment.StartPrometheus(port)
+			Expect(err).NotTo(HaveOccurred())
 			Expect(promAgentPort).NotTo(BeZero())
 
 			for i := 0; i < 5; i++ {
@@ -3,6 +3,8 @@ package integration_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"net/http"
 	"sync"
 	"time"
 
@@ -67,7 +69,8 @@ var _ = Describe("Agent - Agent and Gateway Bootstrap Tests", Ordered, test.Enab
 			Expect(err).NotTo(HaveOccurred())
 
 			port, errC := environment.StartAgent("test-cluster-id", token, []string{fingerprint})
-			promAgentPort := environment.StartPrometheus(port)
+			promAgentPort, err := environment.StartPrometheus(port)
+			Expect(err).NotTo(HaveOccurred())
 			Expect(promAgentPort).NotTo(BeZero())
 			Consistently(errC).ShouldNot(Receive(HaveOccurred()))
 
@@ -114,7 +117,12 @@ var _ = Describe("Agent - Agent and Gateway Bootstrap Tests", Ordered, test.Enab
 			clusterName := "test-cluster-id-" + uuid.New().String()
 
 			_, errC := environment.StartAgent(clusterName, token, []string{fingerprint})
-			Eventually(errC).Should(Receive(MatchError("bootstrap error: bootstrap failed: 405 Method Not Allowed")))
+			var bootstrapErr error
+			Eventually(errC).Should(Receive(&bootstrapErr))
+			var serverErr *bootstrap.ServerError
+			Expect(errors.As(bootstrapErr, &serverErr)).To(BeTrue())
+			Expect(serverErr.StatusCode).To(Equal(http.StatusMethodNotAllowed))
+			Expect(serverErr.Code).To(Equal(bootstrap.ErrorCodeNoActiveTokens))
 		})
 	})
 
@@ -195,8 +203,8 @@ var _ = Describe("Agent - Agent and Gateway Bootstrap Tests", Ordered, test.Enab
 
 			_, errC := environment.StartAgent("multiple-token-cluster-1", tokens[0], []string{fingerprint})
 			Consistently(errC).ShouldNot(Receive())
+			Expect(environment.WaitForAgentConnected("multiple-token-cluster-1", 30*time.Second)).To(Succeed())
 
-			time.Sleep(time.Second)
 			_, errC = environment.StartAgent("multiple-token-cluster-2", tokens[1], []string{fingerprint})
 			Consistently(errC).ShouldNot(Receive())
 		})
@@ -327,7 +335,7 @@ var _ = Describe("Agent - Agent and Gateway Bootstrap Tests", Ordered, test.Enab
 			Expect(resp.Deleted).To(BeEquivalentTo(1))
 
 			_, errC = environment.StartAgent("test-cluster-3", token, []string{fingerprint})
-			Eventually(errC).Should(Receive(MatchError("bootstrap error: bootstrap failed: bootstrap failed: 409 Conflict")))
+			Eventually(errC).Should(Receive(MatchError(bootstrap.ErrClusterIDConflict)))
 		})
 
 		It("should not increment the usage count of the token ", func() {
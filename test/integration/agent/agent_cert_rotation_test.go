@@ -0,0 +1,75 @@
+package integration_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/management"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Agent - Gateway Certificate Rotation Tests", Ordered, Label(test.Integration, test.Slow), func() {
+	var environment *test.Environment
+	var client management.ManagementClient
+	var token *core.BootstrapToken
+
+	fingerprint := func() string {
+		certsInfo, err := client.CertsInfo(context.Background(), &emptypb.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		fp := certsInfo.Chain[len(certsInfo.Chain)-1].Fingerprint
+		Expect(fp).NotTo(BeEmpty())
+		return fp
+	}
+
+	BeforeAll(func() {
+		environment = &test.Environment{
+			TestBin: "../../../testbin/bin",
+		}
+		Expect(environment.Start()).To(Succeed())
+		DeferCleanup(environment.Stop)
+		client = environment.NewManagementClient()
+
+		var err error
+		token, err = client.CreateBootstrapToken(context.Background(), &management.CreateBootstrapTokenRequest{
+			Ttl: durationpb.New(time.Minute),
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should require agents to re-pin after the gateway certificate is rotated", func() {
+		oldFingerprint := fingerprint()
+		_, errC := environment.StartAgent("rotation-agent-1", token, []string{oldFingerprint},
+			test.WithWaitForConnected(30*time.Second))
+		Expect(errC).NotTo(Receive())
+		agent1 := environment.GetAgent("rotation-agent-1")
+		Expect(agent1.SetGatewayAddress(context.Background(), environment.GatewayAddress(), nil)).To(Succeed())
+
+		Expect(environment.RotateGatewayCert(
+			test.TestData("self_signed_leaf.crt"),
+			test.TestData("self_signed_leaf.key"),
+		)).To(Succeed())
+		newFingerprint := fingerprint()
+		Expect(newFingerprint).NotTo(Equal(oldFingerprint))
+
+		// the agent's keyring only contains the old pin
+		Expect(agent1.SetGatewayAddress(context.Background(), environment.GatewayAddress(), nil)).NotTo(Succeed())
+
+		_, errC = environment.StartAgent("rotation-agent-2", token, []string{oldFingerprint},
+			test.WithWaitForConnected(30*time.Second))
+		Expect(errC).To(Receive())
+
+		_, errC = environment.StartAgent("rotation-agent-3", token, []string{newFingerprint},
+			test.WithWaitForConnected(30*time.Second))
+		Expect(errC).NotTo(Receive())
+		_, err := client.GetCluster(context.Background(), &core.Reference{
+			Id: "rotation-agent-3",
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
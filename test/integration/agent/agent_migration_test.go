@@ -0,0 +1,91 @@
+package integration_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/management"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Agent - Gateway Migration Tests", Ordered, Label(test.Integration, test.Slow), func() {
+	var source, target *test.Environment
+	var sourceClient, targetClient management.ManagementClient
+	var sourceFingerprint, targetFingerprint string
+
+	fingerprintOf := func(client management.ManagementClient) string {
+		certsInfo, err := client.CertsInfo(context.Background(), &emptypb.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		fp := certsInfo.Chain[len(certsInfo.Chain)-1].Fingerprint
+		Expect(fp).NotTo(BeEmpty())
+		return fp
+	}
+	clusterIDs := func(client management.ManagementClient) []string {
+		clusters, err := client.ListClusters(context.Background(), &management.ListClustersRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		ids := []string{}
+		for _, c := range clusters.Items {
+			ids = append(ids, c.Id)
+		}
+		return ids
+	}
+	newToken := func(client management.ManagementClient) *core.BootstrapToken {
+		token, err := client.CreateBootstrapToken(context.Background(), &management.CreateBootstrapTokenRequest{
+			Ttl: durationpb.New(time.Minute),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return token
+	}
+
+	BeforeAll(func() {
+		source = &test.Environment{
+			TestBin: "../../../testbin/bin",
+		}
+		Expect(source.Start()).To(Succeed())
+		DeferCleanup(source.Stop)
+		target = &test.Environment{
+			TestBin: "../../../testbin/bin",
+		}
+		Expect(target.Start()).To(Succeed())
+		DeferCleanup(target.Stop)
+
+		sourceClient = source.NewManagementClient()
+		targetClient = target.NewManagementClient()
+		sourceFingerprint = fingerprintOf(sourceClient)
+		targetFingerprint = fingerprintOf(targetClient)
+	})
+
+	It("should move a running agent to a new gateway", func() {
+		_, errC := source.StartAgent("migrating-agent", newToken(sourceClient), []string{sourceFingerprint})
+		Eventually(func() []string {
+			return clusterIDs(sourceClient)
+		}).Should(ContainElement("migrating-agent"))
+		Consistently(errC).ShouldNot(Receive(HaveOccurred()))
+		Expect(clusterIDs(targetClient)).NotTo(ContainElement("migrating-agent"))
+
+		Expect(source.MigrateAgent("migrating-agent", target,
+			newToken(targetClient), []string{targetFingerprint})).To(Succeed())
+
+		Expect(clusterIDs(targetClient)).To(ContainElement("migrating-agent"))
+		Expect(source.GetAgent("migrating-agent").GatewayAddress).To(Equal(target.GatewayAddress()))
+	})
+
+	It("should keep the current gateway if the new gateway's pins do not match", func() {
+		_, errC := source.StartAgent("pinned-agent", newToken(sourceClient), []string{sourceFingerprint})
+		Eventually(func() []string {
+			return clusterIDs(sourceClient)
+		}).Should(ContainElement("pinned-agent"))
+		Consistently(errC).ShouldNot(Receive(HaveOccurred()))
+
+		Expect(source.MigrateAgent("pinned-agent", target,
+			newToken(targetClient), []string{"sha256:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"})).NotTo(Succeed())
+		Expect(clusterIDs(targetClient)).NotTo(ContainElement("pinned-agent"))
+		Expect(source.GetAgent("pinned-agent").GatewayAddress).To(Equal(source.GatewayAddress()))
+	})
+})
@@ -0,0 +1,84 @@
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/rancher/opni-monitoring/pkg/config/v1beta1"
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/management"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Agent - Profiling Endpoints", Ordered, Label(test.Integration, test.Slow), func() {
+	var environment *test.Environment
+	var client management.ManagementClient
+	var fingerprint string
+	var token *core.BootstrapToken
+	BeforeAll(func() {
+		environment = &test.Environment{
+			TestBin: "../../../testbin/bin",
+		}
+		Expect(environment.Start()).To(Succeed())
+		client = environment.NewManagementClient()
+		Expect(json.Unmarshal(test.TestData("fingerprints.json"), &testFingerprints)).To(Succeed())
+
+		certsInfo, err := client.CertsInfo(context.Background(), &emptypb.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		fingerprint = certsInfo.Chain[len(certsInfo.Chain)-1].Fingerprint
+		Expect(fingerprint).NotTo(BeEmpty())
+
+		token, err = client.CreateBootstrapToken(context.Background(), &management.CreateBootstrapTokenRequest{
+			Ttl: durationpb.New(time.Minute),
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterAll(func() {
+		Expect(environment.Stop()).To(Succeed())
+	})
+
+	pprofStatus := func(port int) func() (int, error) {
+		return func() (int, error) {
+			resp, err := http.Get(fmt.Sprintf("http://localhost:%d/debug/pprof/", port))
+			if err != nil {
+				return 0, err
+			}
+			defer resp.Body.Close()
+			return resp.StatusCode, nil
+		}
+	}
+
+	When("profiling is enabled", func() {
+		It("should serve the pprof endpoints", func() {
+			port, errC := environment.StartAgent("pprof-enabled", token, []string{fingerprint},
+				test.WithAgentProfiling(&v1beta1.ProfilingSpec{
+					Enabled: true,
+				}),
+			)
+			Consistently(errC).ShouldNot(Receive())
+			Eventually(pprofStatus(port)).Should(Equal(http.StatusOK))
+
+			resp, err := http.Get(fmt.Sprintf("http://localhost:%d/debug/pprof/goroutine?debug=1", port))
+			Expect(err).NotTo(HaveOccurred())
+			defer resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+	})
+
+	When("profiling is disabled", func() {
+		It("should not serve the pprof endpoints", func() {
+			port, errC := environment.StartAgent("pprof-disabled", token, []string{fingerprint})
+			Consistently(errC).ShouldNot(Receive())
+			Eventually(pprofStatus(port)).Should(Equal(http.StatusNotFound))
+		})
+	})
+})
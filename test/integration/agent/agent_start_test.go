@@ -0,0 +1,153 @@
+package integration_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/rancher/opni-monitoring/pkg/agent"
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/management"
+	"github.com/rancher/opni-monitoring/pkg/pkp"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Agent - Blocking Start Tests", Ordered, Label(test.Integration, test.Slow), func() {
+	var environment *test.Environment
+	var client management.ManagementClient
+	var fingerprint string
+	var token *core.BootstrapToken
+
+	BeforeAll(func() {
+		environment = &test.Environment{
+			TestBin: "../../../testbin/bin",
+		}
+		Expect(environment.Start()).To(Succeed())
+		DeferCleanup(environment.Stop)
+		client = environment.NewManagementClient()
+
+		certsInfo, err := client.CertsInfo(context.Background(), &emptypb.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		fingerprint = certsInfo.Chain[len(certsInfo.Chain)-1].Fingerprint
+		Expect(fingerprint).NotTo(BeEmpty())
+
+		token, err = client.CreateBootstrapToken(context.Background(), &management.CreateBootstrapTokenRequest{
+			Ttl: durationpb.New(time.Minute),
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should return once the agent is connected", func() {
+		_, errC := environment.StartAgent("blocking-agent", token, []string{fingerprint},
+			test.WithWaitForConnected(30*time.Second))
+		Expect(errC).NotTo(Receive())
+		Expect(environment.GetAgent("blocking-agent").Agent).NotTo(BeNil())
+
+		cluster, err := client.GetCluster(context.Background(), &core.Reference{
+			Id: "blocking-agent",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.GetId()).To(Equal("blocking-agent"))
+	})
+
+	It("should return the error if the agent fails to connect", func() {
+		_, errC := environment.StartAgent("blocking-agent-bad-pin", token,
+			[]string{"sha256:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"},
+			test.WithWaitForConnected(30*time.Second))
+		Expect(errC).To(Receive(MatchError(pkp.ErrCertValidationFailed)))
+	})
+
+	It("should wait until a started agent is connected", func() {
+		_, errC := environment.StartAgent("waiting-agent", token, []string{fingerprint})
+		start := time.Now()
+		Expect(environment.WaitForAgentConnected("waiting-agent", 30*time.Second)).To(Succeed())
+		Expect(time.Since(start)).To(BeNumerically("<", 10*time.Second))
+		Expect(errC).NotTo(Receive())
+
+		_, err := client.GetCluster(context.Background(), &core.Reference{
+			Id: "waiting-agent",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		start = time.Now()
+		Expect(environment.WaitForAgentConnected("waiting-agent", 30*time.Second)).To(Succeed())
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+	})
+
+	It("should time out waiting for an agent that is not running", func() {
+		err := environment.WaitForAgentConnected("nonexistent-agent", 500*time.Millisecond)
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(err.Error()).To(ContainSubstring("nonexistent-agent"))
+	})
+
+	It("should return an error if the agent does not connect in time", func() {
+		_, errC := environment.StartAgent("blocking-agent-timeout", token, []string{fingerprint},
+			test.WithWaitForConnected(time.Nanosecond))
+		Expect(errC).To(Receive(MatchError(context.DeadlineExceeded)))
+	})
+})
+
+var _ = Describe("Agent - Restart Tests", Ordered, Label(test.Integration, test.Slow), func() {
+	var environment *test.Environment
+	var client management.ManagementClient
+	var fingerprint string
+	var token *core.BootstrapToken
+
+	BeforeAll(func() {
+		environment = &test.Environment{
+			TestBin: "../../../testbin/bin",
+		}
+		Expect(environment.Start()).To(Succeed())
+		DeferCleanup(environment.Stop)
+		client = environment.NewManagementClient()
+
+		certsInfo, err := client.CertsInfo(context.Background(), &emptypb.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		fingerprint = certsInfo.Chain[len(certsInfo.Chain)-1].Fingerprint
+		Expect(fingerprint).NotTo(BeEmpty())
+
+		token, err = client.CreateBootstrapToken(context.Background(), &management.CreateBootstrapTokenRequest{
+			Ttl: durationpb.New(time.Minute),
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should re-attach to the same cluster when restarted with the same id", func() {
+		ctx, ca := context.WithCancel(context.Background())
+		_, errC := environment.StartAgent("restarted-agent", token, []string{fingerprint},
+			test.WithContext(ctx), test.WithWaitForConnected(30*time.Second))
+		Expect(errC).NotTo(Receive())
+		first := environment.GetAgent("restarted-agent").Agent
+		Expect(first).NotTo(BeNil())
+
+		before, err := client.GetBootstrapToken(context.Background(), token.Reference())
+		Expect(err).NotTo(HaveOccurred())
+
+		ca()
+		Eventually(func() *agent.Agent {
+			return environment.GetAgent("restarted-agent").Agent
+		}).Should(BeNil())
+
+		_, errC = environment.StartAgent("restarted-agent", token, []string{fingerprint},
+			test.WithWaitForConnected(30*time.Second))
+		Expect(errC).NotTo(Receive())
+		second := environment.GetAgent("restarted-agent").Agent
+		Expect(second).NotTo(BeNil())
+		Expect(second).NotTo(BeIdenticalTo(first))
+
+		// the restarted agent should reuse its keyring instead of bootstrapping
+		// again, so the token should not have been used
+		after, err := client.GetBootstrapToken(context.Background(), token.Reference())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(after.GetMetadata().GetUsageCount()).To(Equal(before.GetMetadata().GetUsageCount()))
+
+		clusters, err := client.ListClusters(context.Background(), &management.ListClustersRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusters.GetItems()).To(HaveLen(1))
+		Expect(clusters.GetItems()[0].GetId()).To(Equal("restarted-agent"))
+	})
+})
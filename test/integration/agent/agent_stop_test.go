@@ -0,0 +1,87 @@
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/management"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Agent - Stop Tests", Ordered, Label(test.Integration, test.Slow), func() {
+	var environment *test.Environment
+	var client management.ManagementClient
+	var fingerprint string
+	var token *core.BootstrapToken
+
+	BeforeAll(func() {
+		environment = &test.Environment{
+			TestBin: "../../../testbin/bin",
+		}
+		Expect(environment.Start()).To(Succeed())
+		DeferCleanup(environment.Stop)
+		client = environment.NewManagementClient()
+
+		certsInfo, err := client.CertsInfo(context.Background(), &emptypb.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		fingerprint = certsInfo.Chain[len(certsInfo.Chain)-1].Fingerprint
+		Expect(fingerprint).NotTo(BeEmpty())
+
+		token, err = client.CreateBootstrapToken(context.Background(), &management.CreateBootstrapTokenRequest{
+			Ttl: durationpb.New(time.Minute),
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	healthz := func(port int) func() error {
+		return func() error {
+			resp, err := http.Get(fmt.Sprintf("http://localhost:%d/healthz", port))
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+			}
+			return nil
+		}
+	}
+
+	It("should stop one agent and leave the others running", func() {
+		port1, errC := environment.StartAgent("stop-agent-1", token, []string{fingerprint},
+			test.WithWaitForConnected(30*time.Second))
+		Expect(errC).NotTo(Receive())
+		port2, errC := environment.StartAgent("stop-agent-2", token, []string{fingerprint},
+			test.WithWaitForConnected(30*time.Second))
+		Expect(errC).NotTo(Receive())
+
+		Expect(environment.StopAgent("stop-agent-1")).To(Succeed())
+		Expect(environment.GetAgent("stop-agent-1").Agent).To(BeNil())
+		Eventually(healthz(port1)).Should(HaveOccurred())
+
+		Expect(environment.GetAgent("stop-agent-2").Agent).NotTo(BeNil())
+		Consistently(healthz(port2), 2*time.Second).Should(Succeed())
+
+		// the gateway should still be serving, and should still know about
+		// the stopped agent's cluster
+		clusters, err := client.ListClusters(context.Background(), &management.ListClustersRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusters.GetItems()).To(ConsistOf(
+			HaveField("Id", "stop-agent-1"),
+			HaveField("Id", "stop-agent-2"),
+		))
+	})
+
+	It("should return an error if the agent is not running", func() {
+		Expect(environment.StopAgent("stop-agent-1")).To(HaveOccurred())
+		Expect(environment.StopAgent("unknown-agent")).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,34 @@
+package integration_test
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Cortex Readiness", Label(test.Integration, test.Slow), func() {
+	It("should detect cortex readiness when the gateway is disabled", func() {
+		environment := &test.Environment{
+			TestBin: "../../testbin/bin",
+		}
+		DeferCleanup(environment.Stop)
+		Expect(environment.Start(
+			test.WithEnableGateway(false),
+		)).To(Succeed())
+
+		// Start only logs a warning if cortex is not ready, so wait for it
+		// explicitly
+		start := time.Now()
+		Expect(environment.WaitForCortexReady(10 * time.Second)).To(Succeed())
+		Expect(time.Since(start)).To(BeNumerically("<", 10*time.Second))
+
+		resp, err := environment.CortexHTTPClient().Get("https://" + environment.CortexHTTPAddress() + "/ready")
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+})
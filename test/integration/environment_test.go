@@ -0,0 +1,66 @@
+package integration_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Environment", Label(test.Unit), func() {
+	var environment *test.Environment
+	BeforeEach(func() {
+		environment = &test.Environment{
+			TestBin: "../../testbin/bin",
+		}
+		DeferCleanup(environment.Stop)
+	})
+	disabled := []test.EnvironmentOption{
+		test.WithEnableEtcd(false),
+		test.WithEnableGateway(false),
+		test.WithEnableCortex(false),
+	}
+
+	When("there is not enough free disk space", func() {
+		It("should fail to start", func() {
+			err := environment.Start(append(disabled,
+				test.WithMinFreeDiskSpace(1<<30),
+				test.WithDiskStatFunc(func(string) (uint64, error) {
+					return 1 << 20, nil
+				}),
+			)...)
+			Expect(err).To(MatchError(test.ErrLowDiskSpace))
+		})
+	})
+	When("the disk space check fails", func() {
+		It("should fail to start", func() {
+			err := environment.Start(append(disabled,
+				test.WithMinFreeDiskSpace(1<<30),
+				test.WithDiskStatFunc(func(string) (uint64, error) {
+					return 0, errors.New("test error")
+				}),
+			)...)
+			Expect(err).To(MatchError(ContainSubstring("test error")))
+		})
+	})
+	When("free disk space drops below the threshold while running", func() {
+		It("should stop the environment", func() {
+			available := uint64(1 << 31)
+			Expect(environment.Start(append(disabled,
+				test.WithMinFreeDiskSpace(1<<30),
+				test.WithDiskSpaceMonitor(10*time.Millisecond),
+				test.WithDiskStatFunc(func(string) (uint64, error) {
+					return atomic.LoadUint64(&available), nil
+				}),
+			)...)).To(Succeed())
+			Consistently(environment.Err, 100*time.Millisecond).Should(BeNil())
+
+			atomic.StoreUint64(&available, 1<<20)
+			Eventually(environment.Err).Should(MatchError(test.ErrLowDiskSpace))
+		})
+	})
+})
@@ -0,0 +1,72 @@
+package integration_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/rancher/opni-monitoring/pkg/management"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("External Services", Ordered, Label(test.Integration), func() {
+	var etcdEnv *test.Environment
+	var environment *test.Environment
+	BeforeAll(func() {
+		etcdEnv = &test.Environment{
+			TestBin: "../../testbin/bin",
+		}
+		Expect(etcdEnv.Start(
+			test.WithEnableGateway(false),
+			test.WithEnableCortex(false),
+		)).To(Succeed())
+		DeferCleanup(etcdEnv.Stop)
+
+		environment = &test.Environment{
+			TestBin: "../../testbin/bin",
+		}
+		Expect(environment.Start(
+			test.WithExternalEtcd(etcdEnv.EtcdConfig().Endpoints...),
+			test.WithEnableCortex(false),
+		)).To(Succeed())
+		DeferCleanup(environment.Stop)
+	})
+
+	It("should configure the gateway to use the external etcd", func() {
+		Expect(environment.GatewayConfig().Spec.Storage.Etcd.Endpoints).
+			To(Equal(etcdEnv.EtcdConfig().Endpoints))
+		Expect(environment.EtcdConfig().Endpoints).
+			To(Equal(etcdEnv.EtcdConfig().Endpoints))
+	})
+
+	It("should store gateway data in the external etcd", func() {
+		client := environment.NewManagementClient()
+		_, err := client.CreateBootstrapToken(context.Background(), &management.CreateBootstrapTokenRequest{
+			Ttl: durationpb.New(time.Minute),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		etcdClient, err := etcdEnv.EtcdClient()
+		Expect(err).NotTo(HaveOccurred())
+		defer etcdClient.Close()
+		resp, err := etcdClient.Get(context.Background(), "", clientv3.WithPrefix(), clientv3.WithKeysOnly())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Kvs).NotTo(BeEmpty())
+	})
+
+	It("should fail to start if the external etcd is unreachable", func() {
+		env := &test.Environment{
+			TestBin: "../../testbin/bin",
+		}
+		defer env.Stop()
+		Expect(env.Start(
+			test.WithExternalEtcd("http://localhost:1"),
+			test.WithEnableGateway(false),
+			test.WithEnableCortex(false),
+		)).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,92 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/rancher/opni-monitoring/pkg/capabilities/wellknown"
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/management"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Gateway - Paused Capabilities", Ordered, Label(test.Integration, test.Slow), func() {
+	var environment *test.Environment
+	var client management.ManagementClient
+	agentPorts := map[string]int{}
+	BeforeAll(func() {
+		environment = &test.Environment{
+			TestBin: "../../../testbin/bin",
+		}
+		Expect(environment.Start()).To(Succeed())
+		DeferCleanup(environment.Stop)
+		client = environment.NewManagementClient()
+
+		certsInfo, err := client.CertsInfo(context.Background(), &emptypb.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		fingerprint := certsInfo.Chain[len(certsInfo.Chain)-1].Fingerprint
+
+		for _, id := range []string{"paused-cluster", "active-cluster"} {
+			token, err := client.CreateBootstrapToken(context.Background(), &management.CreateBootstrapTokenRequest{
+				Ttl:    durationpb.New(time.Minute),
+				Labels: map[string]string{"name": id},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			port, errC := environment.StartAgent(id, token, []string{fingerprint})
+			Consistently(errC).ShouldNot(Receive(HaveOccurred()))
+			agentPorts[id] = port
+		}
+	})
+
+	push := func(id string) int {
+		req, err := http.NewRequest(http.MethodPost,
+			fmt.Sprintf("http://localhost:%d/api/agent/push", agentPorts[id]),
+			bytes.NewReader(newRemoteWriteBody()))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Content-Type", "application/x-protobuf")
+		req.Header.Set("Content-Encoding", "snappy")
+		req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	It("should reject remote-write requests only from clusters with paused metrics", func() {
+		Eventually(func() int {
+			return push("paused-cluster")
+		}, 30*time.Second).Should(Equal(http.StatusOK))
+
+		updated, err := client.SetCapabilityEnabled(context.Background(), &management.SetCapabilityEnabledRequest{
+			Capability: wellknown.CapabilityMetrics,
+			Enabled:    false,
+			MatchLabels: &core.LabelSelector{
+				MatchLabels: map[string]string{"name": "paused-cluster"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updated.Items).To(HaveLen(1))
+		Expect(updated.Items[0].Id).To(Equal("paused-cluster"))
+
+		Expect(push("paused-cluster")).To(Equal(http.StatusServiceUnavailable))
+		Expect(push("active-cluster")).To(Equal(http.StatusOK))
+	})
+	It("should accept remote-write requests again once resumed", func() {
+		_, err := client.SetCapabilityEnabled(context.Background(), &management.SetCapabilityEnabledRequest{
+			Capability: wellknown.CapabilityMetrics,
+			Enabled:    true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(push("paused-cluster")).To(Equal(http.StatusOK))
+		Expect(push("active-cluster")).To(Equal(http.StatusOK))
+	})
+})
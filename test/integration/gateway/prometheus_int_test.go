@@ -70,7 +70,8 @@ var _ = Describe("Gateway - Prometheus Communication Tests", Ordered, Label(test
 			Expect(fingerprint).NotTo(BeEmpty())
 
 			port, errC := environment.StartAgent("test-cluster-id", token, []string{fingerprint})
-			promAgentPort := environment.StartPrometheus(port)
+			promAgentPort, err := environment.StartPrometheus(port)
+			Expect(err).NotTo(HaveOccurred())
 			Expect(promAgentPort).NotTo(BeZero())
 			Consistently(errC).ShouldNot(Receive(HaveOccurred()))
 
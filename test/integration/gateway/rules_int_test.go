@@ -0,0 +1,139 @@
+package integration_test
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/management"
+	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/plugins/cortex/pkg/apis/cortexadmin"
+)
+
+const testRuleGroup = `
+name: opni-test-group
+rules:
+- record: opni_test_rule
+  expr: vector(1)
+`
+
+var _ = Describe("Gateway - Cortex Rule Groups", Ordered, Label(test.Integration, test.Slow), func() {
+	var environment *test.Environment
+	var client management.ManagementClient
+	var adminClient cortexadmin.CortexAdminClient
+	BeforeAll(func() {
+		environment = &test.Environment{
+			TestBin: "../../../testbin/bin",
+		}
+		Expect(environment.Start()).To(Succeed())
+		DeferCleanup(environment.Stop)
+		client = environment.NewManagementClient()
+
+		var err error
+		adminClient, err = cortexadmin.NewClient(context.Background(),
+			cortexadmin.WithListenAddress(strings.TrimPrefix(
+				environment.GatewayConfig().Spec.Management.GRPCListenAddress, "tcp://")),
+			cortexadmin.WithDialOptions(grpc.WithDefaultCallOptions(grpc.WaitForReady(true))),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		certsInfo, err := client.CertsInfo(context.Background(), &emptypb.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		fingerprint := certsInfo.Chain[len(certsInfo.Chain)-1].Fingerprint
+
+		for group, ids := range map[string][]string{
+			"a": {"rules-cluster-1", "rules-cluster-2"},
+			"b": {"rules-cluster-3"},
+		} {
+			token, err := client.CreateBootstrapToken(context.Background(), &management.CreateBootstrapTokenRequest{
+				Ttl:    durationpb.New(time.Minute),
+				Labels: map[string]string{"group": group},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			for _, id := range ids {
+				_, errC := environment.StartAgent(id, token, []string{fingerprint})
+				Consistently(errC).ShouldNot(Receive(HaveOccurred()))
+
+				_, err = client.CreateRole(context.Background(), &core.Role{
+					Id:         id,
+					ClusterIDs: []string{id},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				_, err = client.CreateRoleBinding(context.Background(), &core.RoleBinding{
+					Id:       id,
+					RoleId:   id,
+					Subjects: []string{id + "@example.com"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		}
+	})
+
+	listRules := func(clusterID string) string {
+		httpClient := &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: true,
+				},
+			},
+		}
+		req, err := http.NewRequest("GET", environment.PrometheusAPIEndpoint()+"/rules", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Add("Authorization", clusterID+"@example.com")
+		resp, err := httpClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return string(body)
+	}
+
+	It("should load a rule group for each cluster in the group", func() {
+		resp, err := adminClient.LoadRuleGroups(context.Background(), &cortexadmin.LoadRuleGroupsRequest{
+			Namespace:   "opni-test",
+			YamlContent: []byte(testRuleGroup),
+			MatchLabels: &core.LabelSelector{
+				MatchLabels: map[string]string{"group": "a"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Results).To(ConsistOf(
+			And(HaveField("ClusterID", "rules-cluster-1"), HaveField("Error", BeEmpty())),
+			And(HaveField("ClusterID", "rules-cluster-2"), HaveField("Error", BeEmpty())),
+		))
+
+		Eventually(func() string {
+			return listRules("rules-cluster-1")
+		}).Should(ContainSubstring("opni-test-group"))
+		Eventually(func() string {
+			return listRules("rules-cluster-2")
+		}).Should(ContainSubstring("opni-test-group"))
+		Consistently(func() string {
+			return listRules("rules-cluster-3")
+		}, 2*time.Second).ShouldNot(ContainSubstring("opni-test-group"))
+	})
+
+	It("should reject invalid rule groups", func() {
+		_, err := adminClient.LoadRuleGroups(context.Background(), &cortexadmin.LoadRuleGroupsRequest{
+			Namespace:   "opni-test",
+			YamlContent: []byte("rules: [}"),
+		})
+		Expect(err).To(HaveOccurred())
+
+		_, err = adminClient.LoadRuleGroups(context.Background(), &cortexadmin.LoadRuleGroupsRequest{
+			YamlContent: []byte(testRuleGroup),
+		})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,151 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cortexproject/cortex/pkg/cortexpb"
+	"github.com/golang/snappy"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/rancher/opni-monitoring/pkg/management"
+	"github.com/rancher/opni-monitoring/pkg/test"
+	"github.com/rancher/opni-monitoring/plugins/cortex/pkg/apis/cortexadmin"
+)
+
+const (
+	throughputClusterID   = "throughput-cluster"
+	samplesPerRequest     = 100
+	requestsPerSecond     = 10
+	expectedSamplesPerSec = samplesPerRequest * requestsPerSecond
+)
+
+func newRemoteWriteBody() []byte {
+	now := time.Now().UnixMilli()
+	req := &cortexpb.WriteRequest{}
+	for i := 0; i < samplesPerRequest; i++ {
+		req.Timeseries = append(req.Timeseries, cortexpb.PreallocTimeseries{
+			TimeSeries: &cortexpb.TimeSeries{
+				Labels: []cortexpb.LabelAdapter{
+					{Name: "__name__", Value: "opni_throughput_test"},
+					{Name: "series", Value: fmt.Sprint(i)},
+				},
+				Samples: []cortexpb.Sample{
+					{TimestampMs: now, Value: float64(i)},
+				},
+			},
+		})
+	}
+	data, err := req.Marshal()
+	Expect(err).NotTo(HaveOccurred())
+	return snappy.Encode(nil, data)
+}
+
+var _ = Describe("Gateway - Remote Write Throughput", Ordered, Label(test.Integration, test.Slow, test.TimeSensitive), func() {
+	var environment *test.Environment
+	var adminClient cortexadmin.CortexAdminClient
+	var agentPort int
+	BeforeAll(func() {
+		environment = &test.Environment{
+			TestBin: "../../../testbin/bin",
+		}
+		Expect(environment.Start()).To(Succeed())
+		DeferCleanup(environment.Stop)
+		client := environment.NewManagementClient()
+
+		var err error
+		adminClient, err = cortexadmin.NewClient(context.Background(),
+			cortexadmin.WithListenAddress(strings.TrimPrefix(
+				environment.GatewayConfig().Spec.Management.GRPCListenAddress, "tcp://")),
+			cortexadmin.WithDialOptions(grpc.WithDefaultCallOptions(grpc.WaitForReady(true))),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		certsInfo, err := client.CertsInfo(context.Background(), &emptypb.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		fingerprint := certsInfo.Chain[len(certsInfo.Chain)-1].Fingerprint
+
+		token, err := client.CreateBootstrapToken(context.Background(), &management.CreateBootstrapTokenRequest{
+			Ttl: durationpb.New(time.Minute),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		var errC <-chan error
+		agentPort, errC = environment.StartAgent(throughputClusterID, token, []string{fingerprint})
+		Consistently(errC).ShouldNot(Receive(HaveOccurred()))
+	})
+
+	It("should report the remote-write rate for each cluster", func() {
+		ctx, ca := context.WithCancel(context.Background())
+		defer ca()
+
+		go func() {
+			defer GinkgoRecover()
+			ticker := time.NewTicker(time.Second / requestsPerSecond)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+				req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+					fmt.Sprintf("http://localhost:%d/api/agent/push", agentPort),
+					bytes.NewReader(newRemoteWriteBody()))
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Content-Type", "application/x-protobuf")
+				req.Header.Set("Content-Encoding", "snappy")
+				req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+				resp, err := http.DefaultClient.Do(req)
+				if err != nil {
+					continue
+				}
+				resp.Body.Close()
+			}
+		}()
+
+		stream, err := adminClient.WatchThroughput(ctx, &cortexadmin.WatchThroughputRequest{
+			Interval: durationpb.New(2 * time.Second),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		// skip the first update, which may include a partial interval
+		_, err = stream.Recv()
+		Expect(err).NotTo(HaveOccurred())
+
+		for i := 0; i < 3; i++ {
+			update, err := stream.Recv()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(update.Clusters).To(HaveLen(1))
+			cluster := update.Clusters[0]
+			Expect(cluster.ClusterID).To(Equal(throughputClusterID))
+			Expect(cluster.SamplesPerSecond).To(BeNumerically("~", expectedSamplesPerSec, expectedSamplesPerSec*0.3))
+			Expect(cluster.BytesPerSecond).To(BeNumerically(">", 0))
+			Expect(update.Total.SamplesPerSecond).To(Equal(cluster.SamplesPerSecond))
+			Expect(update.Total.BytesPerSecond).To(Equal(cluster.BytesPerSecond))
+		}
+	})
+
+	It("should reject invalid intervals", func() {
+		stream, err := adminClient.WatchThroughput(context.Background(), &cortexadmin.WatchThroughputRequest{
+			Interval: durationpb.New(-time.Second),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = stream.Recv()
+		Expect(err).To(HaveOccurred())
+
+		stream, err = adminClient.WatchThroughput(context.Background(), &cortexadmin.WatchThroughputRequest{
+			Interval: durationpb.New(time.Hour),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = stream.Recv()
+		Expect(err).To(HaveOccurred())
+	})
+})
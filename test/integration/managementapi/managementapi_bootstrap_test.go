@@ -3,12 +3,15 @@ package integration_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"net/http"
 	"strconv"
 	"time"
 	"unicode/utf8"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/rancher/opni-monitoring/pkg/bootstrap"
 	"github.com/rancher/opni-monitoring/pkg/core"
 	"github.com/rancher/opni-monitoring/pkg/management"
 	"github.com/rancher/opni-monitoring/pkg/pkp"
@@ -115,7 +118,12 @@ var _ = Describe("Management API Boostrap Token Management Tests", Ordered, Labe
 			Expect(fingerprint).NotTo(BeEmpty())
 
 			_, errC := environment.StartAgent("foo", token, []string{fingerprint})
-			Eventually(errC).Should(Receive(MatchError("bootstrap error: bootstrap failed: 405 Method Not Allowed")))
+			var bootstrapErr error
+			Eventually(errC).Should(Receive(&bootstrapErr))
+			var serverErr *bootstrap.ServerError
+			Expect(errors.As(bootstrapErr, &serverErr)).To(BeTrue())
+			Expect(serverErr.StatusCode).To(Equal(http.StatusMethodNotAllowed))
+			Expect(serverErr.Code).To(Equal(bootstrap.ErrorCodeNoActiveTokens))
 		})
 	})
 
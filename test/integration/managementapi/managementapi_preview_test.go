@@ -0,0 +1,78 @@
+package integration_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/rancher/opni-monitoring/pkg/core"
+	"github.com/rancher/opni-monitoring/pkg/management"
+	"github.com/rancher/opni-monitoring/pkg/test"
+)
+
+var _ = Describe("Management API Token Effect Preview Tests", Ordered, Label(test.Integration), func() {
+	var environment *test.Environment
+	var client management.ManagementClient
+	var fingerprint string
+	BeforeAll(func() {
+		environment = &test.Environment{
+			TestBin: "../../../testbin/bin",
+		}
+		Expect(environment.Start()).To(Succeed())
+		client = environment.NewManagementClient()
+
+		certsInfo, err := client.CertsInfo(context.Background(), &emptypb.Empty{})
+		Expect(err).NotTo(HaveOccurred())
+		fingerprint = certsInfo.Chain[len(certsInfo.Chain)-1].Fingerprint
+		Expect(fingerprint).NotTo(BeEmpty())
+	})
+
+	AfterAll(func() {
+		Expect(environment.Stop()).To(Succeed())
+	})
+
+	It("should preview the cluster created by bootstrapping with a token", func() {
+		tokenLabels := map[string]string{
+			"env":  "test",
+			"team": "preview",
+		}
+		preview, err := client.PreviewTokenEffect(context.Background(), &management.PreviewTokenEffectRequest{
+			Labels:     tokenLabels,
+			ClusterID:  "preview-cluster",
+			Capability: "metrics",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.GetCluster(context.Background(), &core.Reference{
+			Id: "preview-cluster",
+		})
+		Expect(err).To(HaveOccurred())
+
+		token, err := client.CreateBootstrapToken(context.Background(), &management.CreateBootstrapTokenRequest{
+			Ttl:    durationpb.New(time.Minute),
+			Labels: tokenLabels,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, errC := environment.StartAgent("preview-cluster", token, []string{fingerprint})
+		Consistently(errC).ShouldNot(Receive(HaveOccurred()))
+
+		var cluster *core.Cluster
+		Eventually(func() error {
+			cluster, err = client.GetCluster(context.Background(), &core.Reference{
+				Id: "preview-cluster",
+			})
+			return err
+		}).Should(Succeed())
+		Expect(cluster.GetLabels()).To(Equal(preview.GetLabels()))
+		Expect(cluster.GetMetadata().GetCapabilities()).To(HaveLen(len(preview.GetMetadata().GetCapabilities())))
+		for i, cap := range preview.GetMetadata().GetCapabilities() {
+			Expect(proto.Equal(cap, cluster.GetMetadata().GetCapabilities()[i])).To(BeTrue())
+		}
+	})
+})
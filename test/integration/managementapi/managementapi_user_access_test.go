@@ -40,7 +40,8 @@ var _ = Describe("Management API User/Subject Access Management Tests", Ordered,
 		Expect(fingerprint).NotTo(BeEmpty())
 
 		port, errC := environment.StartAgent("test-cluster-id", token, []string{fingerprint})
-		promAgentPort := environment.StartPrometheus(port)
+		promAgentPort, err := environment.StartPrometheus(port)
+		Expect(err).NotTo(HaveOccurred())
 		Expect(promAgentPort).NotTo(BeZero())
 		Consistently(errC).ShouldNot(Receive())
 	})
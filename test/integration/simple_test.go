@@ -44,7 +44,8 @@ var _ = Describe("Simple Test", Ordered, func() {
 	When("an agent is added", func() {
 		It("should become ready", func() {
 			port, errC := environment.StartAgent("foo", token, []string{fingerprint})
-			promAgentPort := environment.StartPrometheus(port)
+			promAgentPort, err := environment.StartPrometheus(port)
+			Expect(err).NotTo(HaveOccurred())
 			Expect(promAgentPort).NotTo(BeZero())
 			Consistently(errC).ShouldNot(Receive(HaveOccurred()))
 		})